@@ -0,0 +1,126 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/encryption"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	backupFile       string
+	backupPassphrase string
+
+	// Backup - export cluster objects from storage into archive file
+	Backup = &cobra.Command{
+		Use:   "backup",
+		Short: "Export cluster objects into backup archive",
+		Long: `Export namespaces, services, secrets, configs, volumes, routes, autoscalers,
+users, roles and deployment revisions from storage into gzipped tar archive.
+Secrets are encrypted in archive when passphrase is set, passphrase is required
+when secrets encryption is enabled.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.New(viper.GetInt("verbose"))
+
+			stg := storageInit()
+
+			f, err := os.OpenFile(backupFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+			if err != nil {
+				log.Fatalf("Cannot create backup file: %v", err)
+			}
+
+			manifest, err := distribution.NewBackupModel(context.Background(), stg).
+				Export(f, &types.BackupOptions{Passphrase: backupPassphrase})
+			if err != nil {
+				f.Close()
+				os.Remove(backupFile)
+				log.Fatalf("Backup failed: %v", err)
+			}
+
+			if err := f.Close(); err != nil {
+				log.Fatalf("Cannot write backup file: %v", err)
+			}
+
+			if manifest.Encryption == nil {
+				log.Warn("Secrets are stored in backup without encryption, set passphrase to encrypt them")
+			}
+
+			log.Infof("Backup saved to %s: %v", backupFile, manifest.Collections)
+		},
+	}
+
+	// Restore - restore cluster objects from archive file into empty storage
+	Restore = &cobra.Command{
+		Use:   "restore",
+		Short: "Restore cluster objects from backup archive",
+		Long: `Restore cluster objects from backup archive into empty storage.
+Runtime status of restored objects is reset and controllers reconcile them from scratch.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.New(viper.GetInt("verbose"))
+
+			f, err := os.Open(backupFile)
+			if err != nil {
+				log.Fatalf("Cannot open backup file: %v", err)
+			}
+			defer f.Close()
+
+			manifest, err := distribution.NewBackupModel(context.Background(), storageInit()).
+				Restore(f, &types.BackupOptions{Passphrase: backupPassphrase})
+			if err != nil {
+				log.Fatalf("Restore failed: %v", err)
+			}
+
+			log.Infof("Backup created at %s restored: %v", manifest.Created, manifest.Collections)
+		},
+	}
+)
+
+// storageInit - storage and secrets encryption configured for cluster
+func storageInit() storage.Storage {
+
+	stg, err := storage.Get(viper.GetString("storage.driver"))
+	if err != nil {
+		log.Fatalf("Cannot initialize storage: %v", err)
+	}
+
+	if err := encryption.Init(); err != nil {
+		log.Fatalf("Cannot initialize secrets encryption: %v", err)
+	}
+
+	return stg
+}
+
+func init() {
+
+	for _, cmd := range []*cobra.Command{Backup, Restore} {
+		cmd.Flags().StringVarP(&backupFile, "file", "f", "", "/path/to/backup.tar.gz")
+		cmd.Flags().StringVarP(&backupPassphrase, "passphrase", "p", "", "passphrase to encrypt or decrypt secrets")
+		_ = cmd.MarkFlagRequired("file")
+		CLI.AddCommand(cmd)
+	}
+}
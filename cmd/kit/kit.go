@@ -67,6 +67,8 @@ import (
 
 	"github.com/lastbackend/lastbackend/pkg/api"
 	"github.com/lastbackend/lastbackend/pkg/controller"
+	"github.com/lastbackend/lastbackend/pkg/discovery"
+	"github.com/lastbackend/lastbackend/pkg/ingress"
 	"github.com/lastbackend/lastbackend/pkg/log"
 
 	"github.com/spf13/cobra"
@@ -83,6 +85,9 @@ var (
 		Short: "",
 		Long:  ``,
 
+		// components to run are passed as arguments, so arguments are not checked as subcommands
+		Args: cobra.ArbitraryArgs,
+
 		// parse the config if one is provided, or use the defaults. Set the backend
 		// driver to be used
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
@@ -126,6 +131,8 @@ var (
 					"api":        api.Daemon,
 					"controller": controller.Daemon,
 					"ctl":        controller.Daemon,
+					"discovery":  discovery.Daemon,
+					"ingress":    ingress.Daemon,
 				}
 			)
 
@@ -169,10 +176,10 @@ func init() {
 	viper.SetDefault("garbage-collect", false)
 
 	// local flags;
-	CLI.Flags().StringVarP(&config, "config", "c", "", "/path/to/config.yml")
-	CLI.Flags().IntVarP(&debug, "verbose", "v", 0, "verbose level")
+	CLI.PersistentFlags().StringVarP(&config, "config", "c", "", "/path/to/config.yml")
+	CLI.PersistentFlags().IntVarP(&debug, "verbose", "v", 0, "verbose level")
 
-	_ = viper.BindPFlag("verbose", CLI.Flags().Lookup("verbose"))
+	_ = viper.BindPFlag("verbose", CLI.PersistentFlags().Lookup("verbose"))
 }
 
 func main() {
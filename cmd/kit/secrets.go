@@ -0,0 +1,61 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package main
+
+import (
+	"context"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	// Secrets - secrets maintenance commands
+	Secrets = &cobra.Command{
+		Use:   "secrets",
+		Short: "Secrets maintenance commands",
+	}
+
+	// SecretsRotate - encrypt stored secrets with current key after key rotation
+	SecretsRotate = &cobra.Command{
+		Use:   "rotate",
+		Short: "Encrypt stored secrets with current encryption key",
+		Long: `Encrypt stored secrets with first key of secrets.encryption.key_file.
+Secrets encrypted with previous keys or stored as plain data are encrypted again.
+To rotate key: add new key first in key file, restart api, run rotate and remove previous key.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.New(viper.GetInt("verbose"))
+
+			count, err := distribution.NewSecretModel(context.Background(), storageInit()).Rotate()
+			if err != nil {
+				log.Fatalf("Secrets rotation failed after %d secrets: %v", count, err)
+			}
+
+			log.Infof("Secrets encrypted with current key: %d", count)
+		},
+	}
+)
+
+func init() {
+	Secrets.AddCommand(SecretsRotate)
+	CLI.AddCommand(Secrets)
+}
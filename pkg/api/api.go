@@ -23,10 +23,13 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/lastbackend/lastbackend/pkg/api/auditor"
 	"github.com/lastbackend/lastbackend/pkg/api/cache"
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/api/http"
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
 	"github.com/lastbackend/lastbackend/pkg/api/runtime"
+	"github.com/lastbackend/lastbackend/pkg/distribution/encryption"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
@@ -42,14 +45,24 @@ func Daemon() bool {
 
 	log.Info("Start API server")
 
-	stg, err := storage.Get(viper.GetString("etcd"))
+	stg, err := storage.Get(viper.GetString("storage.driver"))
 	if err != nil {
 		log.Fatalf("Cannot initialize storage: %v", err)
 	}
 
+	if err := encryption.Init(); err != nil {
+		log.Fatalf("Cannot initialize secrets encryption: %v", err)
+	}
+
 	envs.Get().SetStorage(stg)
 	envs.Get().SetCache(cache.NewCache())
 
+	if err := auditor.Init(); err != nil {
+		log.Fatalf("Cannot initialize audit sink: %v", err)
+	}
+
+	limits.Init()
+
 	runtime.New().Run()
 
 	go func() {
@@ -83,5 +96,9 @@ func Daemon() bool {
 
 	log.Info("Handle SIGINT and SIGTERM.")
 
+	if err := auditor.Close(); err != nil {
+		log.Errorf("Close audit sink err: %v", err)
+	}
+
 	return true
 }
@@ -0,0 +1,259 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package auditor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/generator"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+	"github.com/spf13/viper"
+)
+
+const (
+	logLevel  = 3
+	logPrefix = "api:auditor"
+
+	SinkFile    = "file"
+	SinkSyslog  = "syslog"
+	SinkStorage = "storage"
+
+	defaultFilePath       = "/var/log/lastbackend/audit.log"
+	defaultFileMaxSize    = 100
+	defaultFileMaxBackups = 5
+	defaultSyslogTag      = "lastbackend-api"
+	defaultRetention      = 720
+	defaultBodyLimit      = 10 << 20
+)
+
+// ErrNotQueryable - sink does not support reading of stored records
+var ErrNotQueryable = errors.New("audit sink does not support queries")
+
+// Sink - destination of audit records
+type Sink interface {
+	Write(record *types.AuditRecord) error
+	List(filter *types.AuditFilter) (*types.AuditRecordList, error)
+	Close() error
+}
+
+var (
+	sink Sink
+	// bodyLimit - max size of request body hashed in record
+	bodyLimit int64 = defaultBodyLimit
+)
+
+// Init - create audit sink configured by `audit.sink`, empty sink disables audit
+func Init() error {
+
+	var (
+		s   Sink
+		err error
+	)
+
+	bodyLimit = defaultBodyLimit
+	if viper.IsSet("audit.body_limit") {
+		bodyLimit = viper.GetInt64("audit.body_limit")
+	}
+
+	switch viper.GetString("audit.sink") {
+	case "":
+		log.V(logLevel).Debugf("%s:init:> audit is disabled", logPrefix)
+		return nil
+	case SinkFile:
+		path := viper.GetString("audit.file.path")
+		if path == "" {
+			path = defaultFilePath
+		}
+		size := viper.GetInt64("audit.file.max_size")
+		if size <= 0 {
+			size = defaultFileMaxSize
+		}
+		backups := defaultFileMaxBackups
+		if viper.IsSet("audit.file.max_backups") {
+			backups = viper.GetInt("audit.file.max_backups")
+		}
+		s, err = NewFileSink(path, size*1024*1024, backups)
+	case SinkSyslog:
+		tag := viper.GetString("audit.syslog.tag")
+		if tag == "" {
+			tag = defaultSyslogTag
+		}
+		s, err = NewSyslogSink(viper.GetString("audit.syslog.network"), viper.GetString("audit.syslog.address"), tag)
+	case SinkStorage:
+		retention := viper.GetInt64("audit.storage.retention")
+		if retention <= 0 {
+			retention = defaultRetention
+		}
+		s = NewStorageSink(envs.Get().GetStorage(), time.Duration(retention)*time.Hour)
+	default:
+		return fmt.Errorf("unknown audit sink: %s", viper.GetString("audit.sink"))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	SetSink(s)
+	return nil
+}
+
+// SetSink - set destination of audit records, nil disables audit
+func SetSink(s Sink) {
+	sink = s
+}
+
+// List - query audit records from configured sink
+func List(filter *types.AuditFilter) (*types.AuditRecordList, error) {
+	if sink == nil {
+		return types.NewAuditRecordList(), nil
+	}
+	return sink.List(filter)
+}
+
+// Close - flush and close configured sink
+func Close() error {
+	if sink == nil {
+		return nil
+	}
+	return sink.Close()
+}
+
+// Record - audit middleware, writes record of every mutation request to sink
+func Record(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		verb := types.AuditVerb(r.Method)
+		if verb == "" || sink == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		// body is hashed while handler reads it, so request is not buffered in memory
+		var body *bodyReader
+		if r.Body != nil {
+			body = &bodyReader{reader: r.Body, Closer: r.Body, hash: sha256.New()}
+			r.Body = body
+		}
+
+		record := newRecord(r, verb)
+
+		rw := &responseWriter{ResponseWriter: w, code: http.StatusOK}
+		h.ServeHTTP(rw, r)
+
+		record.Code = rw.code
+
+		// rest of body not read by handler is hashed too, so hash is not defined by handler
+		if body != nil {
+			record.BodyTruncated = body.drain(bodyLimit)
+			record.BodyHash = hex.EncodeToString(body.hash.Sum(nil))
+		} else {
+			record.BodyHash = hex.EncodeToString(sha256.New().Sum(nil))
+		}
+
+		if err := sink.Write(record); err != nil {
+			log.Errorf("%s:record:> write audit record err: %s", logPrefix, err.Error())
+		}
+	}
+}
+
+// Session - write record of exec or port forward session start, streams are read requests
+// and are not recorded by middleware
+func Session(r *http.Request, verb string, session *types.AuditSession) {
+
+	if sink == nil {
+		return
+	}
+
+	record := newRecord(r, verb)
+	record.Code = http.StatusSwitchingProtocols
+	record.Session = session
+
+	if err := sink.Write(record); err != nil {
+		log.Errorf("%s:session:> write audit record err: %s", logPrefix, err.Error())
+	}
+}
+
+func newRecord(r *http.Request, verb string) *types.AuditRecord {
+	record := new(types.AuditRecord)
+	record.Timestamp = time.Now().UTC()
+	record.SetID(generator.GenerateRandomString(8))
+	record.User = rbac.Identity(r)
+	record.Verb = verb
+	record.Method = r.Method
+	record.Path = r.URL.Path
+	record.Namespace = utils.Vars(r)["namespace"]
+	return record
+}
+
+// bodyReader - request body which passes read data to hash
+type bodyReader struct {
+	reader io.Reader
+	io.Closer
+	hash hash.Hash
+	size int64
+}
+
+func (b *bodyReader) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.hash.Write(p[:n])
+	b.size += int64(n)
+	return n, err
+}
+
+// drain - hash body data not read by handler up to limit, returns true if body is not hashed completely
+func (b *bodyReader) drain(limit int64) bool {
+
+	if b.size < limit {
+		if _, err := io.Copy(ioutil.Discard, io.LimitReader(b, limit-b.size)); err != nil {
+			return true
+		}
+	}
+
+	// body has data over limit
+	n, _ := io.ReadFull(b.reader, make([]byte, 1))
+	return n > 0
+}
+
+// responseWriter - keeps response status code for audit record
+type responseWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	rw.code = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
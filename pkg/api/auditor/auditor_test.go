@@ -0,0 +1,296 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package auditor_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/auditor"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+type memorySink struct {
+	records []*types.AuditRecord
+}
+
+func (s *memorySink) Write(record *types.AuditRecord) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memorySink) List(filter *types.AuditFilter) (*types.AuditRecordList, error) {
+	list := types.NewAuditRecordList()
+	for _, r := range s.records {
+		if filter.Match(r) {
+			list.Items = append(list.Items, r)
+		}
+	}
+	return list, nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+
+	sink := new(memorySink)
+	auditor.SetSink(sink)
+	defer auditor.SetSink(nil)
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		code   int
+		verb   string
+	}{
+		{name: "read request is skipped", method: http.MethodGet, code: http.StatusOK},
+		{name: "create", method: http.MethodPost, body: `{"name":"demo"}`, code: http.StatusOK, verb: types.VerbCreate},
+		{name: "denied update", method: http.MethodPut, body: `{}`, code: http.StatusForbidden, verb: types.VerbUpdate},
+		{name: "delete", method: http.MethodDelete, code: http.StatusNotFound, verb: types.VerbDelete},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			sink.records = nil
+
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.body, string(body), "body should be available for handler")
+				w.WriteHeader(tc.code)
+			}
+
+			req, err := http.NewRequest(tc.method, "/namespace/demo/service/demo", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+
+			res := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}", auditor.Record(handler))
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.code, res.Code)
+
+			if tc.verb == "" {
+				assert.Len(t, sink.records, 0)
+				return
+			}
+
+			if !assert.Len(t, sink.records, 1) {
+				return
+			}
+
+			hash := sha256.Sum256([]byte(tc.body))
+
+			record := sink.records[0]
+			assert.Equal(t, tc.verb, record.Verb)
+			assert.Equal(t, tc.method, record.Method)
+			assert.Equal(t, "demo", record.Namespace)
+			assert.Equal(t, "/namespace/demo/service/demo", record.Path)
+			assert.Equal(t, tc.code, record.Code)
+			assert.Equal(t, hex.EncodeToString(hash[:]), record.BodyHash)
+			assert.NotEmpty(t, record.User)
+			assert.NotEmpty(t, record.ID)
+			assert.False(t, record.Timestamp.IsZero())
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "audit")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+
+	// small size limit rotates file on every second record
+	fs, err := auditor.NewFileSink(path, 300, 2)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fs.Close()
+
+	now := time.Now().UTC()
+	for i := 0; i < 6; i++ {
+		record := new(types.AuditRecord)
+		record.Timestamp = now.Add(time.Duration(i) * time.Minute)
+		record.SetID("test")
+		record.Namespace = "ns-a"
+		if i%2 == 1 {
+			record.Namespace = "ns-b"
+		}
+		record.Verb = types.VerbCreate
+		assert.NoError(t, fs.Write(record))
+	}
+
+	_, err = os.Stat(path + ".2")
+	assert.NoError(t, err, "backup should exist")
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "backups over limit should be removed")
+
+	list, err := fs.List(&types.AuditFilter{})
+	assert.NoError(t, err)
+	if !assert.True(t, len(list.Items) > 0 && len(list.Items) < 6, "old records should be rotated out") {
+		return
+	}
+
+	for i := 1; i < len(list.Items); i++ {
+		assert.True(t, list.Items[i-1].ID < list.Items[i].ID, "records should be ordered by time")
+	}
+
+	list, err = fs.List(&types.AuditFilter{Namespace: "ns-b", From: now.Add(4 * time.Minute)})
+	assert.NoError(t, err)
+	if assert.Len(t, list.Items, 1) {
+		assert.Equal(t, now.Add(5*time.Minute), list.Items[0].Timestamp)
+	}
+}
+
+// Testing exec and port forward session records
+func TestSession(t *testing.T) {
+
+	sink := new(memorySink)
+	auditor.SetSink(sink)
+	defer auditor.SetSink(nil)
+
+	session := &types.AuditSession{Service: "web", Pod: "web-1", Container: "app", Command: []string{"sh"}}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/namespace/{namespace}/service/{service}/exec", auditor.Record(func(w http.ResponseWriter, r *http.Request) {
+		auditor.Session(r, types.AuditSessionExec, session)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/namespace/demo/service/web/exec?command=sh", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !assert.Len(t, sink.records, 1, "session should be recorded once") {
+		return
+	}
+
+	record := sink.records[0]
+	assert.Equal(t, types.AuditSessionExec, record.Verb)
+	assert.Equal(t, "demo", record.Namespace)
+	assert.Equal(t, http.StatusSwitchingProtocols, record.Code)
+	assert.Equal(t, session, record.Session)
+	assert.NotEmpty(t, record.User)
+}
+
+// Testing body not read by handler is hashed up to audit body limit
+func TestRecordBodyDrain(t *testing.T) {
+
+	viper.Set("audit.body_limit", 16)
+	assert.NoError(t, auditor.Init())
+
+	defer auditor.Init()
+	defer viper.Set("audit.body_limit", nil)
+
+	sink := new(memorySink)
+	auditor.SetSink(sink)
+	defer auditor.SetSink(nil)
+
+	tests := []struct {
+		name      string
+		body      string
+		read      int64
+		truncated bool
+	}{
+		{name: "body is not read by handler", body: "0123456789"},
+		{name: "body is partially read by handler", body: "0123456789", read: 4},
+		{name: "body is larger than limit", body: strings.Repeat("x", 32), read: 4, truncated: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			sink.records = nil
+
+			handler := auditor.Record(func(w http.ResponseWriter, r *http.Request) {
+				_, err := io.CopyN(ioutil.Discard, r.Body, tc.read)
+				assert.NoError(t, err)
+				w.WriteHeader(http.StatusBadRequest)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/namespace/demo/service", strings.NewReader(tc.body))
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !assert.Len(t, sink.records, 1) {
+				return
+			}
+
+			assert.Equal(t, tc.truncated, sink.records[0].BodyTruncated)
+			if !tc.truncated {
+				hash := sha256.Sum256([]byte(tc.body))
+				assert.Equal(t, hex.EncodeToString(hash[:]), sink.records[0].BodyHash)
+			}
+		})
+	}
+}
+
+// Testing body size limit is applied to audited requests, as auditor is the outermost middleware
+func TestRecordBodyLimit(t *testing.T) {
+
+	sink := new(memorySink)
+	auditor.SetSink(sink)
+	defer auditor.SetSink(nil)
+
+	const limit = 8
+
+	var read int
+
+	l := middleware.NewLimiter(middleware.LimiterOpts{Body: limit})
+	handler := auditor.Record(l.Limit(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		read = len(body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/namespace/demo/service", strings.NewReader(strings.Repeat("x", 1024)))
+	req.ContentLength = -1
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.Code)
+	assert.True(t, read <= limit, "handler should not read body over limit")
+
+	if assert.Len(t, sink.records, 1) {
+		assert.Equal(t, http.StatusRequestEntityTooLarge, sink.records[0].Code)
+	}
+}
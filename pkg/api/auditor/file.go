@@ -0,0 +1,185 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package auditor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+// FileSink - writes audit records as json lines into local file,
+// file is rotated when it exceeds max size and max backups are kept
+type FileSink struct {
+	lock sync.Mutex
+
+	path    string
+	maxSize int64
+	backups int
+
+	file *os.File
+	size int64
+}
+
+// Write - append record to audit file
+func (fs *FileSink) Write(record *types.AuditRecord) error {
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	if fs.size > 0 && fs.size+int64(len(data)) > fs.maxSize {
+		if err := fs.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := fs.file.Write(data)
+	fs.size += int64(n)
+	return err
+}
+
+// List - read records matched filter from audit file and its backups
+func (fs *FileSink) List(filter *types.AuditFilter) (*types.AuditRecordList, error) {
+
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	list := types.NewAuditRecordList()
+
+	// read backups from oldest to current file to keep records ordered by time
+	for i := fs.backups; i >= 0; i-- {
+
+		path := fs.backup(i)
+
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			record := new(types.AuditRecord)
+			if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+				log.V(logLevel).Warnf("%s:file:> skip malformed record in %s: %s", logPrefix, path, err.Error())
+				continue
+			}
+			if filter.Match(record) {
+				list.Items = append(list.Items, record)
+			}
+		}
+
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+// Close - close audit file
+func (fs *FileSink) Close() error {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+	return fs.file.Close()
+}
+
+func (fs *FileSink) open() error {
+
+	f, err := os.OpenFile(fs.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	fs.file = f
+	fs.size = info.Size()
+	return nil
+}
+
+func (fs *FileSink) rotate() error {
+
+	log.V(logLevel).Debugf("%s:file:> rotate audit file %s", logPrefix, fs.path)
+
+	if err := fs.file.Close(); err != nil {
+		return err
+	}
+
+	if fs.backups == 0 {
+		if err := os.Remove(fs.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return fs.open()
+	}
+
+	for i := fs.backups - 1; i >= 0; i-- {
+		if err := os.Rename(fs.backup(i), fs.backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return fs.open()
+}
+
+func (fs *FileSink) backup(i int) string {
+	if i == 0 {
+		return fs.path
+	}
+	return fmt.Sprintf("%s.%d", fs.path, i)
+}
+
+// NewFileSink - create file sink, max size is set in bytes
+func NewFileSink(path string, maxSize int64, backups int) (*FileSink, error) {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	fs := new(FileSink)
+	fs.path = path
+	fs.maxSize = maxSize
+	fs.backups = backups
+
+	if err := fs.open(); err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
@@ -0,0 +1,55 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package auditor
+
+import (
+	"context"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+)
+
+// StorageSink - keeps audit records in storage collection,
+// records are removed by storage after retention period
+type StorageSink struct {
+	storage   storage.Storage
+	retention time.Duration
+}
+
+// Write - put record to storage with retention ttl
+func (ss *StorageSink) Write(record *types.AuditRecord) error {
+	am := distribution.NewAuditModel(context.Background(), ss.storage)
+	return am.Create(record, uint64(ss.retention.Seconds()))
+}
+
+// List - query records from storage
+func (ss *StorageSink) List(filter *types.AuditFilter) (*types.AuditRecordList, error) {
+	am := distribution.NewAuditModel(context.Background(), ss.storage)
+	return am.List(filter)
+}
+
+// Close - nothing to release for storage sink
+func (ss *StorageSink) Close() error {
+	return nil
+}
+
+func NewStorageSink(stg storage.Storage, retention time.Duration) *StorageSink {
+	return &StorageSink{storage: stg, retention: retention}
+}
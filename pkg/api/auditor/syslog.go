@@ -0,0 +1,58 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package auditor
+
+import (
+	"encoding/json"
+	"log/syslog"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+// SyslogSink - writes audit records as json messages to syslog
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// Write - send record to syslog
+func (ss *SyslogSink) Write(record *types.AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return ss.writer.Info(string(data))
+}
+
+// List - syslog records can not be read back
+func (ss *SyslogSink) List(filter *types.AuditFilter) (*types.AuditRecordList, error) {
+	return nil, ErrNotQueryable
+}
+
+// Close - close syslog connection
+func (ss *SyslogSink) Close() error {
+	return ss.writer.Close()
+}
+
+// NewSyslogSink - connect to syslog daemon, empty network and address connect to local syslog
+func NewSyslogSink(network, address, tag string) (*SyslogSink, error) {
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_AUTHPRIV, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{writer: w}, nil
+}
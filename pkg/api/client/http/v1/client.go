@@ -19,7 +19,12 @@
 package v1
 
 import (
+	"context"
+	"io"
+
 	"github.com/lastbackend/lastbackend/pkg/api/client/types"
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
+	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	"github.com/lastbackend/lastbackend/pkg/util/http/request"
 )
 
@@ -49,3 +54,34 @@ func (s *Client) Namespace(args ...string) types.NamespaceClientV1 {
 	}
 	return newNamespaceClient(s.client, name)
 }
+
+// watch - open typed watch of list endpoint, list options are passed as query parameters
+func watch(ctx context.Context, client *request.RESTClient, path string, opts *rv1.ListOptions, decode watcher.Decoder) (watcher.IWatcher, error) {
+
+	source := func(revision *int64) (io.ReadCloser, error) {
+
+		o := new(rv1.ListOptions)
+		if opts != nil {
+			*o = *opts
+		}
+
+		o.Watch = true
+		o.Revision = revision
+
+		req := client.Get(path)
+		for key, values := range o.ToQuery() {
+			for _, value := range values {
+				req.Param(key, value)
+			}
+		}
+
+		return req.Stream()
+	}
+
+	w, err := watcher.Watch(ctx, source, decode)
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
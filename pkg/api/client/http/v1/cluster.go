@@ -20,8 +20,10 @@ package v1
 
 import (
 	"context"
+	"io"
 
 	"github.com/lastbackend/lastbackend/pkg/api/client/types"
+	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/util/http/request"
@@ -95,6 +97,42 @@ func (cc *ClusterClient) Get(ctx context.Context) (*vv1.Cluster, error) {
 	return s, nil
 }
 
+// Backup - stream of gzipped tar archive with cluster objects
+func (cc *ClusterClient) Backup(ctx context.Context, opts *rv1.ClusterBackupOptions) (io.ReadCloser, error) {
+
+	res := cc.client.Get("/cluster/backup")
+
+	if opts != nil && opts.Passphrase != "" {
+		res.AddHeader(rv1.HeaderPassphrase, opts.Passphrase)
+	}
+
+	return res.Stream()
+}
+
+// Restore - restore cluster objects from backup archive into empty cluster storage
+func (cc *ClusterClient) Restore(ctx context.Context, data []byte, opts *rv1.ClusterBackupOptions) (*vv1.Backup, error) {
+
+	var s *vv1.Backup
+	var e *errors.Http
+
+	res := cc.client.Post("/cluster/restore").
+		AddHeader("Content-Type", "application/gzip").
+		Body(data)
+
+	if opts != nil && opts.Passphrase != "" {
+		res.AddHeader(rv1.HeaderPassphrase, opts.Passphrase)
+	}
+
+	if err := res.JSON(&s, &e); err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
 func newClusterClient(req *request.RESTClient) *ClusterClient {
 	return &ClusterClient{client: req}
 }
@@ -20,9 +20,11 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -107,6 +109,14 @@ func (sc *ConfigClient) List(ctx context.Context) (*vv1.ConfigList, error) {
 	return s, nil
 }
 
+func (sc *ConfigClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, sc.client, fmt.Sprintf("/namespace/%s/config", sc.namespace), opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Config)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (sc *ConfigClient) Update(ctx context.Context, opts *rv1.ConfigManifest) (*vv1.Config, error) {
 
 	body, err := opts.ToJson()
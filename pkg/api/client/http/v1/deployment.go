@@ -20,9 +20,12 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/lastbackend/lastbackend/pkg/api/client/types"
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -76,6 +79,14 @@ func (dc *DeploymentClient) List(ctx context.Context) (*vv1.DeploymentList, erro
 	return s, nil
 }
 
+func (dc *DeploymentClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, dc.client, fmt.Sprintf("/namespace/%s/service/%s/deployment", dc.namespace, dc.service), opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Deployment)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (dc *DeploymentClient) Get(ctx context.Context) (*vv1.Deployment, error) {
 
 	var s *vv1.Deployment
@@ -120,6 +131,78 @@ func (dc *DeploymentClient) Update(ctx context.Context, opts *rv1.DeploymentUpda
 	return s, nil
 }
 
+func (dc *DeploymentClient) History(ctx context.Context) (*vv1.DeploymentRevisionList, error) {
+
+	var s *vv1.DeploymentRevisionList
+	var e *errors.Http
+
+	err := dc.client.Get(fmt.Sprintf("/namespace/%s/service/%s/deployment", dc.namespace, dc.service)).
+		AddHeader("Content-Type", "application/json").
+		Param("history", "true").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	if s == nil {
+		list := make(vv1.DeploymentRevisionList, 0)
+		s = &list
+	}
+
+	return s, nil
+}
+
+func (dc *DeploymentClient) Rollback(ctx context.Context, version int) (*vv1.Service, error) {
+
+	var s *vv1.Service
+	var e *errors.Http
+
+	err := dc.client.Post(fmt.Sprintf("/namespace/%s/service/%s/deployment/rollback", dc.namespace, dc.service)).
+		AddHeader("Content-Type", "application/json").
+		Param("version", strconv.Itoa(version)).
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
+func (dc *DeploymentClient) Promote(ctx context.Context) (*vv1.Service, error) {
+	return dc.rollout("promote")
+}
+
+func (dc *DeploymentClient) Abort(ctx context.Context) (*vv1.Service, error) {
+	return dc.rollout("abort")
+}
+
+func (dc *DeploymentClient) rollout(action string) (*vv1.Service, error) {
+
+	var s *vv1.Service
+	var e *errors.Http
+
+	err := dc.client.Post(fmt.Sprintf("/namespace/%s/service/%s/deployment/%s", dc.namespace, dc.service, action)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
 func newDeploymentClient(client *request.RESTClient, namespace, service, name string) *DeploymentClient {
 	return &DeploymentClient{client: client, namespace: namespace, service: service, name: name}
 }
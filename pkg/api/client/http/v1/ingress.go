@@ -20,7 +20,10 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -57,6 +60,14 @@ func (ic *IngressClient) List(ctx context.Context) (*vv1.IngressList, error) {
 	return i, nil
 }
 
+func (ic *IngressClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, ic.client, "/ingress", opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Ingress)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (ic *IngressClient) Get(ctx context.Context) (*vv1.Ingress, error) {
 
 	var s *vv1.Ingress
@@ -20,10 +20,12 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/lastbackend/lastbackend/pkg/api/client/types"
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -135,6 +137,14 @@ func (nc *NamespaceClient) List(ctx context.Context) (*vv1.NamespaceList, error)
 	return s, nil
 }
 
+func (nc *NamespaceClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, nc.client, "/namespace", opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Namespace)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (nc *NamespaceClient) Create(ctx context.Context, opts *rv1.NamespaceManifest) (*vv1.Namespace, error) {
 
 	body, err := opts.ToJson()
@@ -20,9 +20,11 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -54,6 +56,14 @@ func (nc NodeClient) List(ctx context.Context) (*vv1.NodeList, error) {
 	return s, nil
 }
 
+func (nc NodeClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, nc.client, "/cluster/node", opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Node)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (nc NodeClient) Connect(ctx context.Context, opts *rv1.NodeConnectOptions) error {
 
 	body := opts.ToJson()
@@ -139,6 +149,85 @@ func (nc NodeClient) Remove(ctx context.Context, opts *rv1.NodeRemoveOptions) er
 	return nil
 }
 
+func (nc NodeClient) Cordon(ctx context.Context) (*vv1.Node, error) {
+
+	var s *vv1.Node
+	var e *errors.Http
+
+	err := nc.client.Put(fmt.Sprintf("/cluster/node/%s/cordon", nc.hostname)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
+func (nc NodeClient) Uncordon(ctx context.Context) (*vv1.Node, error) {
+
+	var s *vv1.Node
+	var e *errors.Http
+
+	err := nc.client.Put(fmt.Sprintf("/cluster/node/%s/uncordon", nc.hostname)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
+func (nc NodeClient) Drain(ctx context.Context) (*vv1.Node, error) {
+
+	var s *vv1.Node
+	var e *errors.Http
+
+	err := nc.client.Put(fmt.Sprintf("/cluster/node/%s/drain", nc.hostname)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
+func (nc NodeClient) SetTaints(ctx context.Context, opts *rv1.NodeTaintsOptions) (*vv1.Node, error) {
+
+	body := opts.ToJson()
+
+	var s *vv1.Node
+	var e *errors.Http
+
+	err := nc.client.Put(fmt.Sprintf("/cluster/node/%s/taints", nc.hostname)).
+		AddHeader("Content-Type", "application/json").
+		Body([]byte(body)).
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
 func newNodeClient(req *request.RESTClient, hostname string) *NodeClient {
 	return &NodeClient{client: req, hostname: hostname}
 }
@@ -20,9 +20,11 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -85,6 +87,14 @@ func (rc *RouteClient) List(ctx context.Context) (*vv1.RouteList, error) {
 	return s, nil
 }
 
+func (rc *RouteClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, rc.client, fmt.Sprintf("/namespace/%s/route", rc.namespace), opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Route)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (rc *RouteClient) Get(ctx context.Context) (*vv1.Route, error) {
 
 	var s *vv1.Route
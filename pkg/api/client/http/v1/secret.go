@@ -20,9 +20,11 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -107,6 +109,14 @@ func (sc *SecretClient) List(ctx context.Context) (*vv1.SecretList, error) {
 	return s, nil
 }
 
+func (sc *SecretClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, sc.client, fmt.Sprintf("/namespace/%s/secret", sc.namespace), opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Secret)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (sc *SecretClient) Update(ctx context.Context, opts *rv1.SecretManifest) (*vv1.Secret, error) {
 
 	body, err := opts.ToJson()
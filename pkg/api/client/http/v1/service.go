@@ -20,15 +20,19 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"strconv"
 
 	"github.com/lastbackend/lastbackend/pkg/api/client/types"
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/util/http/request"
+	"github.com/lastbackend/lastbackend/pkg/util/http/ws"
 )
 
 type ServiceClient struct {
@@ -53,6 +57,10 @@ func (sc *ServiceClient) Deployment(args ...string) types.DeploymentClientV1 {
 	return newDeploymentClient(sc.client, sc.namespace, sc.name, name)
 }
 
+func (sc *ServiceClient) Autoscaler() types.AutoscalerClientV1 {
+	return newAutoscalerClient(sc.client, sc.namespace, sc.name)
+}
+
 func (sc *ServiceClient) Create(ctx context.Context, opts *rv1.ServiceManifest) (*vv1.Service, error) {
 
 	body, err := opts.ToJson()
@@ -102,6 +110,14 @@ func (sc *ServiceClient) List(ctx context.Context) (*vv1.ServiceList, error) {
 	return s, nil
 }
 
+func (sc *ServiceClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, sc.client, fmt.Sprintf("/namespace/%s/service", sc.namespace), opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Service)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (sc *ServiceClient) Get(ctx context.Context) (*vv1.Service, error) {
 
 	var s *vv1.Service
@@ -174,18 +190,58 @@ func (sc *ServiceClient) Logs(ctx context.Context, opts *rv1.ServiceLogsOptions)
 	res := sc.client.Get(fmt.Sprintf("/namespace/%s/service/%s/logs", sc.namespace, sc.name))
 
 	if opts != nil {
-		res.Param("deployment", opts.Deployment)
-		res.Param("pod", opts.Pod)
-		res.Param("container", opts.Container)
-
-		if opts.Follow {
-			res.Param("follow", strconv.FormatBool(opts.Follow))
+		for key, values := range opts.ToQuery() {
+			for _, value := range values {
+				res.Param(key, value)
+			}
 		}
 	}
 
 	return res.Stream()
 }
 
+// PortForward - listen on local address and forward accepted connections to service pod port.
+// Listener is closed when context is done or forward stream is closed
+func (sc *ServiceClient) PortForward(ctx context.Context, address string, opts *rv1.ServicePortForwardOptions) (net.Listener, error) {
+
+	if opts == nil {
+		return nil, errors.New("port forward options are required")
+	}
+
+	res := sc.client.Get(fmt.Sprintf("/namespace/%s/service/%s/portforward", sc.namespace, sc.name))
+	for key, values := range opts.ToQuery() {
+		for _, value := range values {
+			res.Param(key, value)
+		}
+	}
+
+	conn, err := res.Dial()
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	f := ws.NewForwardClient(conn)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			f.Close()
+		case <-f.Done():
+		}
+		l.Close()
+	}()
+
+	go f.Listen(l)
+
+	return l, nil
+}
+
 func newServiceClient(client *request.RESTClient, namespace, name string) *ServiceClient {
 	return &ServiceClient{client: client, namespace: namespace, name: name}
 }
@@ -20,9 +20,11 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -85,6 +87,14 @@ func (vc *VolumeClient) List(ctx context.Context) (*vv1.VolumeList, error) {
 	return s, nil
 }
 
+func (vc *VolumeClient) Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error) {
+	return watch(ctx, vc.client, fmt.Sprintf("/namespace/%s/volume", vc.namespace), opts, func(data []byte) (interface{}, error) {
+		obj := new(vv1.Volume)
+		err := json.Unmarshal(data, obj)
+		return obj, err
+	})
+}
+
 func (vc *VolumeClient) Get(ctx context.Context) (*vv1.Volume, error) {
 
 	var s *vv1.Volume
@@ -21,7 +21,9 @@ package types
 import (
 	"context"
 	"io"
+	"net"
 
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
 	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 )
@@ -36,18 +38,26 @@ type ClusterClientV1 interface {
 	Ingress(args ...string) IngressClientV1
 	Discovery(args ...string) DiscoveryClientV1
 	Get(ctx context.Context) (*vv1.Cluster, error)
+	Backup(ctx context.Context, opts *rv1.ClusterBackupOptions) (io.ReadCloser, error)
+	Restore(ctx context.Context, data []byte, opts *rv1.ClusterBackupOptions) (*vv1.Backup, error)
 }
 
 type NodeClientV1 interface {
 	List(ctx context.Context) (*vv1.NodeList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Connect(ctx context.Context, opts *rv1.NodeConnectOptions) error
 	Get(ctx context.Context) (*vv1.Node, error)
 	SetStatus(ctx context.Context, opts *rv1.NodeStatusOptions) (*vv1.NodeManifest, error)
 	Remove(ctx context.Context, opts *rv1.NodeRemoveOptions) error
+	Cordon(ctx context.Context) (*vv1.Node, error)
+	Uncordon(ctx context.Context) (*vv1.Node, error)
+	Drain(ctx context.Context) (*vv1.Node, error)
+	SetTaints(ctx context.Context, opts *rv1.NodeTaintsOptions) (*vv1.Node, error)
 }
 
 type DiscoveryClientV1 interface {
 	List(ctx context.Context) (*vv1.DiscoveryList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Get(ctx context.Context) (*vv1.Discovery, error)
 	Connect(ctx context.Context, opts *rv1.DiscoveryConnectOptions) error
 	SetStatus(ctx context.Context, opts *rv1.DiscoveryStatusOptions) (*vv1.DiscoveryManifest, error)
@@ -55,6 +65,7 @@ type DiscoveryClientV1 interface {
 
 type IngressClientV1 interface {
 	List(ctx context.Context) (*vv1.IngressList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Get(ctx context.Context) (*vv1.Ingress, error)
 	Connect(ctx context.Context, opts *rv1.IngressConnectOptions) error
 	SetStatus(ctx context.Context, opts *rv1.IngressStatusOptions) (*vv1.IngressManifest, error)
@@ -68,6 +79,7 @@ type NamespaceClientV1 interface {
 	Volume(args ...string) VolumeClientV1
 	Create(ctx context.Context, opts *rv1.NamespaceManifest) (*vv1.Namespace, error)
 	List(ctx context.Context) (*vv1.NamespaceList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Get(ctx context.Context) (*vv1.Namespace, error)
 	Update(ctx context.Context, opts *rv1.NamespaceManifest) (*vv1.Namespace, error)
 	Remove(ctx context.Context, opts *rv1.NamespaceRemoveOptions) error
@@ -75,20 +87,34 @@ type NamespaceClientV1 interface {
 
 type ServiceClientV1 interface {
 	Deployment(args ...string) DeploymentClientV1
+	Autoscaler() AutoscalerClientV1
 	Create(ctx context.Context, opts *rv1.ServiceManifest) (*vv1.Service, error)
 	List(ctx context.Context) (*vv1.ServiceList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Get(ctx context.Context) (*vv1.Service, error)
 	Update(ctx context.Context, opts *rv1.ServiceManifest) (*vv1.Service, error)
 	Remove(ctx context.Context, opts *rv1.ServiceRemoveOptions) error
 	Logs(ctx context.Context, opts *rv1.ServiceLogsOptions) (io.ReadCloser, error)
+	PortForward(ctx context.Context, address string, opts *rv1.ServicePortForwardOptions) (net.Listener, error)
 }
 
 type DeploymentClientV1 interface {
 	Pod(args ...string) PodClientV1
 
 	List(ctx context.Context) (*vv1.DeploymentList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Get(ctx context.Context) (*vv1.Deployment, error)
 	Update(ctx context.Context, opts *rv1.DeploymentUpdateOptions) (*vv1.Deployment, error)
+	History(ctx context.Context) (*vv1.DeploymentRevisionList, error)
+	Rollback(ctx context.Context, version int) (*vv1.Service, error)
+	Promote(ctx context.Context) (*vv1.Service, error)
+	Abort(ctx context.Context) (*vv1.Service, error)
+}
+
+type AutoscalerClientV1 interface {
+	Get(ctx context.Context) (*vv1.Autoscaler, error)
+	Set(ctx context.Context, opts *rv1.AutoscalerOptions) (*vv1.Autoscaler, error)
+	Remove(ctx context.Context) error
 }
 
 type PodClientV1 interface {
@@ -104,6 +130,7 @@ type SecretClientV1 interface {
 	Get(ctx context.Context) (*vv1.Secret, error)
 	Create(ctx context.Context, opts *rv1.SecretManifest) (*vv1.Secret, error)
 	List(ctx context.Context) (*vv1.SecretList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Update(ctx context.Context, opts *rv1.SecretManifest) (*vv1.Secret, error)
 	Remove(ctx context.Context, opts *rv1.SecretRemoveOptions) error
 }
@@ -112,6 +139,7 @@ type ConfigClientV1 interface {
 	Get(ctx context.Context) (*vv1.Config, error)
 	Create(ctx context.Context, opts *rv1.ConfigManifest) (*vv1.Config, error)
 	List(ctx context.Context) (*vv1.ConfigList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Update(ctx context.Context, opts *rv1.ConfigManifest) (*vv1.Config, error)
 	Remove(ctx context.Context, opts *rv1.ConfigRemoveOptions) error
 }
@@ -119,6 +147,7 @@ type ConfigClientV1 interface {
 type RouteClientV1 interface {
 	Create(ctx context.Context, opts *rv1.RouteManifest) (*vv1.Route, error)
 	List(ctx context.Context) (*vv1.RouteList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Get(ctx context.Context) (*vv1.Route, error)
 	Update(ctx context.Context, opts *rv1.RouteManifest) (*vv1.Route, error)
 	Remove(ctx context.Context, opts *rv1.RouteRemoveOptions) error
@@ -127,6 +156,7 @@ type RouteClientV1 interface {
 type VolumeClientV1 interface {
 	Create(ctx context.Context, opts *rv1.VolumeManifest) (*vv1.Volume, error)
 	List(ctx context.Context) (*vv1.VolumeList, error)
+	Watch(ctx context.Context, opts *rv1.ListOptions) (watcher.IWatcher, error)
 	Get(ctx context.Context) (*vv1.Volume, error)
 	Update(ctx context.Context, opts *rv1.VolumeManifest) (*vv1.Volume, error)
 	Remove(ctx context.Context, opts *rv1.VolumeRemoveOptions) error
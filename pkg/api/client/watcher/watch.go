@@ -0,0 +1,171 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const (
+	logPrefix        = "client:watcher"
+	reconnectTimeout = time.Second
+)
+
+// Source - open events stream of resource watch,
+// revision is nil on first connect and points after last received event on reconnect
+type Source func(revision *int64) (io.ReadCloser, error)
+
+// Decoder - decode event data into typed resource view
+type Decoder func(data []byte) (interface{}, error)
+
+type event struct {
+	Type     EventType       `json:"type"`
+	Revision int64           `json:"revision"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// ResourceWatcher - typed resource watcher with automatic reconnect
+type ResourceWatcher struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	source   Source
+	decode   Decoder
+	revision *int64
+
+	result chan Event
+}
+
+// Watch - open resource events stream, stream is reopened from last received revision
+// after disconnect until watcher is stopped, context is done or server sends error event
+func Watch(ctx context.Context, source Source, decode Decoder) (*ResourceWatcher, error) {
+
+	reader, err := source(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &ResourceWatcher{
+		source: source,
+		decode: decode,
+		result: make(chan Event),
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+
+	go w.run(reader)
+
+	return w, nil
+}
+
+func (w *ResourceWatcher) ResultChan() <-chan Event {
+	return w.result
+}
+
+func (w *ResourceWatcher) Stop() {
+	w.cancel()
+}
+
+func (w *ResourceWatcher) run(reader io.ReadCloser) {
+	defer close(w.result)
+
+	for {
+		if reader != nil {
+			if err := w.receive(reader); err != nil {
+				w.send(Event{Type: Error, Data: err})
+				return
+			}
+		}
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(reconnectTimeout):
+		}
+
+		r, err := w.source(w.revision)
+		if err != nil {
+			log.Errorf("%s:> reconnect err: %v", logPrefix, err)
+			reader = nil
+			continue
+		}
+		reader = r
+	}
+}
+
+// receive - pass stream events to result channel until stream is closed,
+// returns error only if server sends error event
+func (w *ResourceWatcher) receive(reader io.ReadCloser) error {
+
+	done := make(chan bool)
+	defer close(done)
+
+	go func() {
+		select {
+		case <-w.ctx.Done():
+		case <-done:
+		}
+		reader.Close()
+	}()
+
+	decoder := json.NewDecoder(reader)
+
+	for {
+		e := new(event)
+		if err := decoder.Decode(e); err != nil {
+			if err != io.EOF && w.ctx.Err() == nil {
+				log.Errorf("%s:> read stream err: %v", logPrefix, err)
+			}
+			return nil
+		}
+
+		if e.Type == Error {
+			var message string
+			json.Unmarshal(e.Data, &message)
+			return errors.New(message)
+		}
+
+		obj, err := w.decode(e.Data)
+		if err != nil {
+			log.Errorf("%s:> decode event err: %v", logPrefix, err)
+			continue
+		}
+
+		revision := e.Revision + 1
+		w.revision = &revision
+
+		if !w.send(Event{Type: e.Type, Revision: e.Revision, Data: obj}) {
+			return nil
+		}
+	}
+}
+
+func (w *ResourceWatcher) send(e Event) bool {
+	select {
+	case w.result <- e:
+		return true
+	case <-w.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,93 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package watcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/api/client/watcher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+
+	type obj struct {
+		Name string `json:"name"`
+	}
+
+	var (
+		streams = []string{
+			`{"type":"ADDED","revision":3,"data":{"name":"demo"}}`,
+			`{"type":"MODIFIED","revision":5,"data":{"name":"demo"}}` + "\n" + `{"type":"ERROR","revision":0,"data":"revision compacted"}`,
+		}
+		revisions = make([]*int64, 0)
+	)
+
+	source := func(revision *int64) (io.ReadCloser, error) {
+		revisions = append(revisions, revision)
+		if len(revisions) > len(streams) {
+			return ioutil.NopCloser(strings.NewReader("")), nil
+		}
+		return ioutil.NopCloser(strings.NewReader(streams[len(revisions)-1])), nil
+	}
+
+	decode := func(data []byte) (interface{}, error) {
+		o := new(obj)
+		err := json.Unmarshal(data, o)
+		return o, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	w, err := watcher.Watch(ctx, source, decode)
+	if !assert.NoError(t, err, "watch should be opened") {
+		return
+	}
+
+	events := make([]watcher.Event, 0)
+	for e := range w.ResultChan() {
+		events = append(events, e)
+	}
+
+	if !assert.Len(t, events, 3, "events count mismatch") {
+		return
+	}
+
+	assert.Equal(t, watcher.Added, events[0].Type)
+	assert.Equal(t, "demo", events[0].Data.(*obj).Name)
+	assert.Equal(t, watcher.Modified, events[1].Type)
+	assert.Equal(t, int64(5), events[1].Revision)
+	assert.Equal(t, watcher.Error, events[2].Type)
+	assert.EqualError(t, events[2].Data.(error), "revision compacted")
+
+	// stream is reopened after last received revision
+	if assert.Len(t, revisions, 2, "stream should be reconnected once") {
+		assert.Nil(t, revisions[0])
+		if assert.NotNil(t, revisions[1]) {
+			assert.Equal(t, int64(4), *revisions[1])
+		}
+	}
+}
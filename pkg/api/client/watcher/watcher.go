@@ -41,8 +41,9 @@ const (
 )
 
 type Event struct {
-	Type EventType
-	Data interface{}
+	Type     EventType
+	Revision int64
+	Data     interface{}
 }
 
 type Watcher struct {
@@ -95,8 +96,9 @@ func (w *Watcher) receive() {
 		}
 
 		w.result <- Event{
-			Type: result.Type,
-			Data: result.Data,
+			Type:     result.Type,
+			Revision: result.Revision,
+			Data:     result.Data,
 		}
 	}
 }
@@ -0,0 +1,834 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/api/http/service"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/selector"
+	"github.com/spf13/viper"
+)
+
+// applier - creates or updates objects from manifests documents
+// and prunes objects applied from the same source before
+type applier struct {
+	ctx  context.Context
+	stg  storage.Storage
+	opts *request.ApplyOptions
+
+	// namespaces and services known in current apply,
+	// in dry run mode they are used to resolve not saved objects
+	namespaces map[string]*types.Namespace
+	services   map[string]map[string]*types.Service
+
+	// keys of applied and pruned objects
+	applied map[string]bool
+	pruned  map[string]bool
+}
+
+func newApplier(ctx context.Context, stg storage.Storage, opts *request.ApplyOptions) *applier {
+	return &applier{
+		ctx:        ctx,
+		stg:        stg,
+		opts:       opts,
+		namespaces: make(map[string]*types.Namespace),
+		services:   make(map[string]map[string]*types.Service),
+		applied:    make(map[string]bool),
+		pruned:     make(map[string]bool),
+	}
+}
+
+// Apply - apply documents in dependency order of kinds
+func (a *applier) Apply(items []*request.ApplyDocument) *types.ApplyResultList {
+
+	result := types.NewApplyResultList()
+
+	docs := make([]*request.ApplyDocument, len(items))
+	copy(docs, items)
+	sort.SliceStable(docs, func(i, j int) bool {
+		return kindOrder(docs[i].Kind) < kindOrder(docs[j].Kind)
+	})
+
+	for _, d := range docs {
+
+		res := &types.ApplyResult{Kind: d.Kind, Namespace: d.Namespace, Name: d.Name}
+
+		var err error
+
+		switch d.Kind {
+		case types.KindNamespace:
+			res.Status, err = a.namespace(d)
+		case types.KindSecret:
+			res.Status, err = a.secret(d)
+		case types.KindConfig:
+			res.Status, err = a.config(d)
+		case types.KindVolume:
+			res.Status, err = a.volume(d)
+		case types.KindService:
+			res.Status, err = a.service(d)
+		case types.KindRoute:
+			res.Status, err = a.route(d)
+		default:
+			err = fmt.Errorf("unsupported kind %s", d.Kind)
+		}
+
+		if err != nil {
+			log.V(logLevel).Errorf("%s:apply:> apply %s `%s` err: %s", logPrefix, d.Kind, d.Name, err.Error())
+			res.Status = types.ApplyStatusFailed
+			res.Error = err
+		}
+
+		a.applied[key(d.Kind, d.Namespace, d.Name)] = true
+		result.Items = append(result.Items, res)
+	}
+
+	return result
+}
+
+// Prune - remove objects labeled with apply source which were not applied,
+// objects are removed in reverse dependency order. Namespaced objects are pruned
+// only in passed namespace, cluster-wide prune handles namespaces too.
+func (a *applier) Prune(namespace string) (*types.ApplyResultList, error) {
+
+	var (
+		result = types.NewApplyResultList()
+		nm     = distribution.NewNamespaceModel(a.ctx, a.stg)
+	)
+
+	sel, err := selector.New(fmt.Sprintf("%s=%s", types.LabelApplySource, a.opts.Source), types.EmptyString)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0)
+	if namespace != types.EmptyString {
+		namespaces = append(namespaces, namespace)
+	} else {
+		nl, err := nm.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nl.Items {
+			namespaces = append(namespaces, ns.Meta.Name)
+		}
+	}
+
+	for i := len(types.ApplyKinds) - 1; i >= 0; i-- {
+
+		kind := types.ApplyKinds[i]
+
+		if kind == types.KindNamespace {
+			if namespace != types.EmptyString {
+				continue
+			}
+
+			nl, err := nm.Select(&types.ListOptions{Selector: sel})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, ns := range nl.Items {
+				if !a.applied[key(kind, types.EmptyString, ns.Meta.Name)] {
+					result.Items = append(result.Items, a.prune(kind, types.EmptyString, ns.Meta.Name, ns))
+				}
+			}
+			continue
+		}
+
+		for _, ns := range namespaces {
+
+			items, err := a.selectObjects(kind, ns, sel)
+			if err != nil {
+				return nil, err
+			}
+
+			names := make([]string, 0, len(items))
+			for name := range items {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				if !a.applied[key(kind, ns, name)] {
+					result.Items = append(result.Items, a.prune(kind, ns, name, items[name]))
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// selectObjects - get objects of kind in namespace matched by selector by names
+func (a *applier) selectObjects(kind, namespace string, sel *selector.Selector) (map[string]interface{}, error) {
+
+	var (
+		opts  = &types.ListOptions{Selector: sel}
+		items = make(map[string]interface{})
+	)
+
+	switch kind {
+	case types.KindSecret:
+		l, err := distribution.NewSecretModel(a.ctx, a.stg).Select(namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l.Items {
+			items[i.Meta.Name] = i
+		}
+	case types.KindConfig:
+		l, err := distribution.NewConfigModel(a.ctx, a.stg).Select(namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l.Items {
+			items[i.Meta.Name] = i
+		}
+	case types.KindVolume:
+		l, err := distribution.NewVolumeModel(a.ctx, a.stg).Select(namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l.Items {
+			items[i.Meta.Name] = i
+		}
+	case types.KindService:
+		l, err := distribution.NewServiceModel(a.ctx, a.stg).Select(namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l.Items {
+			items[i.Meta.Name] = i
+		}
+	case types.KindRoute:
+		l, err := distribution.NewRouteModel(a.ctx, a.stg).Select(namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l.Items {
+			items[i.Meta.Name] = i
+		}
+	}
+
+	return items, nil
+}
+
+func (a *applier) prune(kind, namespace, name string, obj interface{}) *types.ApplyResult {
+
+	res := &types.ApplyResult{Kind: kind, Namespace: namespace, Name: name, Status: types.ApplyStatusPruned}
+
+	err := a.remove(kind, namespace, obj)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:prune:> prune %s `%s` err: %s", logPrefix, kind, name, err.Error())
+		res.Status = types.ApplyStatusFailed
+		res.Error = err
+		return res
+	}
+
+	a.pruned[key(kind, namespace, name)] = true
+	return res
+}
+
+func (a *applier) remove(kind, namespace string, obj interface{}) error {
+
+	switch o := obj.(type) {
+	case *types.Namespace:
+		if err := a.authorize(o.Meta.Name, kind, types.VerbDelete); err != nil {
+			return err
+		}
+
+		sl, err := distribution.NewServiceModel(a.ctx, a.stg).List(o.Meta.Name)
+		if err != nil {
+			return err
+		}
+		for _, s := range sl.Items {
+			if !a.pruned[key(types.KindService, o.Meta.Name, s.Meta.Name)] {
+				return fmt.Errorf("namespace has services")
+			}
+		}
+
+		if a.opts.DryRun {
+			return nil
+		}
+		return distribution.NewNamespaceModel(a.ctx, a.stg).Remove(o)
+
+	case *types.Secret:
+		if err := a.authorize(namespace, kind, types.VerbDelete); err != nil {
+			return err
+		}
+		if a.opts.DryRun {
+			return nil
+		}
+		return distribution.NewSecretModel(a.ctx, a.stg).Remove(o)
+
+	case *types.Config:
+		if err := a.authorize(namespace, kind, types.VerbDelete); err != nil {
+			return err
+		}
+		if a.opts.DryRun {
+			return nil
+		}
+		return distribution.NewConfigModel(a.ctx, a.stg).Remove(o)
+
+	case *types.Volume:
+		if err := a.authorize(namespace, kind, types.VerbDelete); err != nil {
+			return err
+		}
+		if a.opts.DryRun {
+			return nil
+		}
+		return distribution.NewVolumeModel(a.ctx, a.stg).Destroy(o)
+
+	case *types.Service:
+		if err := a.authorize(namespace, kind, types.VerbDelete); err != nil {
+			return err
+		}
+
+		rl, err := distribution.NewRouteModel(a.ctx, a.stg).ListByNamespace(namespace)
+		if err != nil {
+			return err
+		}
+		for _, r := range rl.Items {
+			if r.Status.State == types.StateDestroy || a.pruned[key(types.KindRoute, namespace, r.Meta.Name)] {
+				continue
+			}
+			for _, rule := range r.Spec.Rules {
+				if rule.Service == o.Meta.Name {
+					return fmt.Errorf("service is used in route %s", r.Meta.Name)
+				}
+			}
+		}
+
+		if a.opts.DryRun {
+			return nil
+		}
+		_, err = distribution.NewServiceModel(a.ctx, a.stg).Destroy(o)
+		return err
+
+	case *types.Route:
+		if err := a.authorize(namespace, kind, types.VerbDelete); err != nil {
+			return err
+		}
+		if a.opts.DryRun {
+			return nil
+		}
+		o.Status.State = types.StateDestroy
+		_, err := distribution.NewRouteModel(a.ctx, a.stg).Set(o)
+		return err
+	}
+
+	return fmt.Errorf("unsupported kind %s", kind)
+}
+
+func (a *applier) namespace(d *request.ApplyDocument) (string, error) {
+
+	var (
+		nm = distribution.NewNamespaceModel(a.ctx, a.stg)
+		m  = d.NamespaceManifest
+	)
+
+	ns, err := nm.Get(d.Name)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	if ns == nil {
+
+		if err := a.authorize(types.EmptyString, types.KindNamespace, types.VerbCreate); err != nil {
+			return types.EmptyString, err
+		}
+
+		ns = new(types.Namespace)
+		m.SetNamespaceMeta(ns)
+		m.SetNamespaceSpec(ns)
+		ns.Meta.Labels = types.ApplyLabels(ns.Meta.Labels, a.opts.Source, d.Hash)
+
+		if a.opts.DryRun {
+			ns.Meta.Endpoint = strings.ToLower(fmt.Sprintf("%s.%s", ns.Meta.Name, viper.GetString("domain.internal")))
+		} else if ns, err = nm.Create(ns); err != nil {
+			return types.EmptyString, err
+		}
+
+		a.namespaces[ns.Meta.Name] = ns
+		return types.ApplyStatusCreated, nil
+	}
+
+	a.namespaces[ns.Meta.Name] = ns
+	if a.unchanged(ns.Meta.Labels, d) {
+		return types.ApplyStatusUnchanged, nil
+	}
+
+	if err := a.authorize(ns.Meta.Name, types.KindNamespace, types.VerbUpdate); err != nil {
+		return types.EmptyString, err
+	}
+
+	m.SetNamespaceMeta(ns)
+	m.SetNamespaceSpec(ns)
+	ns.Meta.Labels = types.ApplyLabels(ns.Meta.Labels, a.opts.Source, d.Hash)
+
+	if !a.opts.DryRun {
+		if err := nm.Update(ns); err != nil {
+			return types.EmptyString, err
+		}
+	}
+
+	return types.ApplyStatusUpdated, nil
+}
+
+func (a *applier) secret(d *request.ApplyDocument) (string, error) {
+
+	var (
+		sm = distribution.NewSecretModel(a.ctx, a.stg)
+		m  = d.SecretManifest
+	)
+
+	ns, err := a.getNamespace(d.Namespace)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	ss, err := sm.Get(ns.Meta.Name, d.Name)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	if ss == nil {
+
+		if err := a.authorize(ns.Meta.Name, types.KindSecret, types.VerbCreate); err != nil {
+			return types.EmptyString, err
+		}
+
+		ss = new(types.Secret)
+		m.SetSecretMeta(ss)
+		m.SetSecretSpec(ss)
+		ss.Meta.Labels = types.ApplyLabels(ss.Meta.Labels, a.opts.Source, d.Hash)
+
+		if !a.opts.DryRun {
+			if _, err := sm.Create(ns, ss); err != nil {
+				return types.EmptyString, err
+			}
+		}
+
+		return types.ApplyStatusCreated, nil
+	}
+
+	if a.unchanged(ss.Meta.Labels, d) {
+		return types.ApplyStatusUnchanged, nil
+	}
+
+	if err := a.authorize(ns.Meta.Name, types.KindSecret, types.VerbUpdate); err != nil {
+		return types.EmptyString, err
+	}
+
+	m.SetSecretMeta(ss)
+	m.SetSecretSpec(ss)
+	ss.Meta.Labels = types.ApplyLabels(ss.Meta.Labels, a.opts.Source, d.Hash)
+
+	if !a.opts.DryRun {
+		if _, err := sm.Update(ss); err != nil {
+			return types.EmptyString, err
+		}
+	}
+
+	return types.ApplyStatusUpdated, nil
+}
+
+func (a *applier) config(d *request.ApplyDocument) (string, error) {
+
+	var (
+		cm = distribution.NewConfigModel(a.ctx, a.stg)
+		m  = d.ConfigManifest
+	)
+
+	ns, err := a.getNamespace(d.Namespace)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	cfg, err := cm.Get(ns.Meta.Name, d.Name)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	if cfg == nil {
+
+		if err := a.authorize(ns.Meta.Name, types.KindConfig, types.VerbCreate); err != nil {
+			return types.EmptyString, err
+		}
+
+		cfg = new(types.Config)
+		m.SetConfigMeta(cfg)
+		m.SetConfigSpec(cfg)
+		cfg.Meta.Labels = types.ApplyLabels(cfg.Meta.Labels, a.opts.Source, d.Hash)
+
+		if !a.opts.DryRun {
+			if _, err := cm.Create(ns, cfg); err != nil {
+				return types.EmptyString, err
+			}
+		}
+
+		return types.ApplyStatusCreated, nil
+	}
+
+	if a.unchanged(cfg.Meta.Labels, d) {
+		return types.ApplyStatusUnchanged, nil
+	}
+
+	if err := a.authorize(ns.Meta.Name, types.KindConfig, types.VerbUpdate); err != nil {
+		return types.EmptyString, err
+	}
+
+	m.SetConfigMeta(cfg)
+	m.SetConfigSpec(cfg)
+	cfg.Meta.Labels = types.ApplyLabels(cfg.Meta.Labels, a.opts.Source, d.Hash)
+
+	if !a.opts.DryRun {
+		if _, err := cm.Update(cfg); err != nil {
+			return types.EmptyString, err
+		}
+	}
+
+	return types.ApplyStatusUpdated, nil
+}
+
+func (a *applier) volume(d *request.ApplyDocument) (string, error) {
+
+	var (
+		vm = distribution.NewVolumeModel(a.ctx, a.stg)
+		m  = d.VolumeManifest
+	)
+
+	ns, err := a.getNamespace(d.Namespace)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	vol, err := vm.Get(ns.Meta.Name, d.Name)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	if vol == nil {
+
+		if err := a.authorize(ns.Meta.Name, types.KindVolume, types.VerbCreate); err != nil {
+			return types.EmptyString, err
+		}
+
+		vol = new(types.Volume)
+		vol.Meta.SetDefault()
+		vol.Meta.Namespace = ns.Meta.Name
+		m.SetVolumeMeta(vol)
+		m.SetVolumeSpec(vol)
+		vol.Meta.Labels = types.ApplyLabels(vol.Meta.Labels, a.opts.Source, d.Hash)
+
+		if !a.opts.DryRun {
+			if _, err := vm.Create(ns, vol); err != nil {
+				return types.EmptyString, err
+			}
+		}
+
+		return types.ApplyStatusCreated, nil
+	}
+
+	if a.unchanged(vol.Meta.Labels, d) {
+		return types.ApplyStatusUnchanged, nil
+	}
+
+	if err := a.authorize(ns.Meta.Name, types.KindVolume, types.VerbUpdate); err != nil {
+		return types.EmptyString, err
+	}
+
+	m.SetVolumeMeta(vol)
+	m.SetVolumeSpec(vol)
+	vol.Meta.Labels = types.ApplyLabels(vol.Meta.Labels, a.opts.Source, d.Hash)
+
+	if !a.opts.DryRun {
+		if err := vm.Update(vol); err != nil {
+			return types.EmptyString, err
+		}
+	}
+
+	return types.ApplyStatusUpdated, nil
+}
+
+func (a *applier) service(d *request.ApplyDocument) (string, error) {
+
+	var (
+		nm = distribution.NewNamespaceModel(a.ctx, a.stg)
+		sm = distribution.NewServiceModel(a.ctx, a.stg)
+		m  = d.ServiceManifest
+	)
+
+	ns, err := a.getNamespace(d.Namespace)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, d.Name)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	var (
+		status   = types.ApplyStatusUpdated
+		previous *types.ResourceRequest
+	)
+
+	if svc == nil {
+
+		if err := a.authorize(ns.Meta.Name, types.KindService, types.VerbCreate); err != nil {
+			return types.EmptyString, err
+		}
+
+		svc = new(types.Service)
+		m.SetServiceMeta(svc)
+		svc.Meta.Namespace = ns.Meta.Name
+		status = types.ApplyStatusCreated
+
+	} else {
+
+		if a.unchanged(svc.Meta.Labels, d) {
+			a.setService(svc)
+			return types.ApplyStatusUnchanged, nil
+		}
+
+		if err := a.authorize(ns.Meta.Name, types.KindService, types.VerbUpdate); err != nil {
+			return types.EmptyString, err
+		}
+
+		resources := svc.Spec.GetResourceRequest()
+		previous = &resources
+		m.SetServiceMeta(svc)
+	}
+
+	svc.Meta.Endpoint = fmt.Sprintf("%s.%s", strings.ToLower(svc.Meta.Name), ns.Meta.Endpoint)
+	svc.Meta.Labels = types.ApplyLabels(svc.Meta.Labels, a.opts.Source, d.Hash)
+
+	if err := m.SetServiceSpec(svc); err != nil {
+		return types.EmptyString, err
+	}
+
+	var (
+		requested = svc.Spec.GetResourceRequest()
+		changed   = previous == nil || !previous.Equal(requested)
+	)
+
+	if changed {
+		if err := service.ServiceResourcesAllocate(ns, previous, requested); err != nil {
+			return types.EmptyString, err
+		}
+	}
+
+	if err := service.CheckServiceVolumes(a.ctx, svc); err != nil {
+		return types.EmptyString, err
+	}
+
+	if a.opts.DryRun {
+		a.setService(svc)
+		return status, nil
+	}
+
+	if changed {
+		if err := nm.Update(ns); err != nil {
+			return types.EmptyString, err
+		}
+	}
+
+	if status == types.ApplyStatusCreated {
+		svc, err = sm.Create(ns, svc)
+	} else {
+		svc, err = sm.Update(svc)
+	}
+	if err != nil {
+		if changed {
+			service.ServiceResourcesRollback(nm, ns, requested, previous)
+		}
+		return types.EmptyString, err
+	}
+
+	a.setService(svc)
+	return status, nil
+}
+
+func (a *applier) route(d *request.ApplyDocument) (string, error) {
+
+	var (
+		rm = distribution.NewRouteModel(a.ctx, a.stg)
+		m  = d.RouteManifest
+	)
+
+	ns, err := a.getNamespace(d.Namespace)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	svc, err := a.getServices(ns.Meta.Name)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	rs, err := rm.Get(ns.Meta.Name, d.Name)
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	status := types.ApplyStatusUpdated
+
+	if rs == nil {
+
+		if err := a.authorize(ns.Meta.Name, types.KindRoute, types.VerbCreate); err != nil {
+			return types.EmptyString, err
+		}
+
+		rs = new(types.Route)
+		rs.Meta.SetDefault()
+		rs.Meta.Namespace = ns.Meta.Name
+		status = types.ApplyStatusCreated
+
+	} else {
+
+		if a.unchanged(rs.Meta.Labels, d) {
+			return types.ApplyStatusUnchanged, nil
+		}
+
+		if err := a.authorize(ns.Meta.Name, types.KindRoute, types.VerbUpdate); err != nil {
+			return types.EmptyString, err
+		}
+	}
+
+	m.SetRouteMeta(rs)
+	m.SetRouteSpec(rs, svc)
+	rs.Meta.Labels = types.ApplyLabels(rs.Meta.Labels, a.opts.Source, d.Hash)
+
+	if len(rs.Spec.Rules) == 0 {
+		return types.EmptyString, fmt.Errorf("route rules are incorrect")
+	}
+
+	if a.opts.DryRun {
+		return status, nil
+	}
+
+	if status == types.ApplyStatusCreated {
+		_, err = rm.Add(ns, rs)
+	} else {
+		_, err = rm.Set(rs)
+	}
+	if err != nil {
+		return types.EmptyString, err
+	}
+
+	return status, nil
+}
+
+// getNamespace - get namespace known in current apply or from storage
+func (a *applier) getNamespace(name string) (*types.Namespace, error) {
+
+	if ns, ok := a.namespaces[name]; ok {
+		return ns, nil
+	}
+
+	ns, err := distribution.NewNamespaceModel(a.ctx, a.stg).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if ns == nil {
+		return nil, fmt.Errorf("namespace %s not found", name)
+	}
+
+	a.namespaces[name] = ns
+	return ns, nil
+}
+
+// getServices - get namespace services merged with services applied in current apply
+func (a *applier) getServices(namespace string) (*types.ServiceList, error) {
+
+	sl, err := distribution.NewServiceModel(a.ctx, a.stg).List(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[string]*types.Service)
+	for _, s := range sl.Items {
+		items[s.Meta.Name] = s
+	}
+	for name, s := range a.services[namespace] {
+		items[name] = s
+	}
+
+	list := types.NewServiceList()
+	for _, s := range items {
+		list.Items = append(list.Items, s)
+	}
+
+	return list, nil
+}
+
+func (a *applier) setService(svc *types.Service) {
+	if _, ok := a.services[svc.Meta.Namespace]; !ok {
+		a.services[svc.Meta.Namespace] = make(map[string]*types.Service)
+	}
+	a.services[svc.Meta.Namespace][svc.Meta.Name] = svc
+}
+
+// unchanged - check that object was applied from the same manifest before
+func (a *applier) unchanged(labels map[string]string, d *request.ApplyDocument) bool {
+
+	if labels == nil || labels[types.LabelApplyHash] != d.Hash {
+		return false
+	}
+
+	return a.opts.Source == types.EmptyString || labels[types.LabelApplySource] == a.opts.Source
+}
+
+func (a *applier) authorize(namespace, resource, verb string) error {
+
+	allowed, err := rbac.Allowed(a.ctx, namespace, resource, verb)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return fmt.Errorf("forbidden to %s %s", verb, resource)
+	}
+
+	return nil
+}
+
+func kindOrder(kind string) int {
+	for i, k := range types.ApplyKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return len(types.ApplyKinds)
+}
+
+func key(kind, namespace, name string) string {
+	return fmt.Sprintf("%s:%s:%s", kind, namespace, name)
+}
@@ -0,0 +1,231 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package apply
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+)
+
+const (
+	logLevel  = 2
+	logPrefix = "api:handler:apply"
+)
+
+func ApplyH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /namespace/{namespace}/apply apply applyNamespace
+	//
+	// Create or update objects from multi-document yaml or json manifests stream
+	//
+	// ---
+	// consumes:
+	// - application/json
+	// - application/yaml
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: source
+	//     in: query
+	//     description: name of manifests source, applied objects are labeled with it
+	//     required: false
+	//     type: string
+	//   - name: dryRun
+	//     in: query
+	//     description: validate and plan changes without saving them
+	//     required: false
+	//     type: boolean
+	//   - name: prune
+	//     in: query
+	//     description: remove objects applied from source before and missing in manifests
+	//     required: false
+	//     type: boolean
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       type: string
+	// responses:
+	//   '200':
+	//     description: Manifests were applied, result of each object is in response
+	//     schema:
+	//       "$ref": "#/definitions/views_apply_result_list"
+	//   '400':
+	//     description: Bad request
+	//   '404':
+	//     description: Namespace not found
+	//   '500':
+	//     description: Internal server error
+
+	// swagger:operation POST /apply apply applyCluster
+	//
+	// Create or update cluster-wide objects from multi-document yaml or json manifests stream,
+	// namespaced objects should have namespace set in meta
+	//
+	// ---
+	// consumes:
+	// - application/json
+	// - application/yaml
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: source
+	//     in: query
+	//     description: name of manifests source, applied objects are labeled with it
+	//     required: false
+	//     type: string
+	//   - name: dryRun
+	//     in: query
+	//     description: validate and plan changes without saving them
+	//     required: false
+	//     type: boolean
+	//   - name: prune
+	//     in: query
+	//     description: remove objects applied from source before and missing in manifests
+	//     required: false
+	//     type: boolean
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       type: string
+	// responses:
+	//   '200':
+	//     description: Manifests were applied, result of each object is in response
+	//     schema:
+	//       "$ref": "#/definitions/views_apply_result_list"
+	//   '400':
+	//     description: Bad request
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:apply:> apply manifests to namespace `%s`", logPrefix, nid)
+
+	var (
+		stg  = envs.Get().GetStorage()
+		nm   = distribution.NewNamespaceModel(r.Context(), stg)
+		opts = v1.Request().Apply().Options()
+		mf   = v1.Request().Apply().Manifest()
+	)
+
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:apply:> validation query params err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	if e := mf.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:apply:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	if nid != types.EmptyString {
+		ns, err := nm.Get(nid)
+		if err != nil {
+			log.V(logLevel).Errorf("%s:apply:> get namespace err: %s", logPrefix, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+		if ns == nil {
+			err := errors.New("namespace not found")
+			log.V(logLevel).Errorf("%s:apply:> get namespace err: %s", logPrefix, err.Error())
+			errors.New("namespace").NotFound().Http(w)
+			return
+		}
+	}
+
+	for _, d := range mf.Items {
+
+		if d.Kind == types.KindNamespace {
+			if nid != types.EmptyString {
+				errors.New("apply").BadRequest(fmt.Sprintf("namespace %s can not be applied in namespace", d.Name)).Http(w)
+				return
+			}
+			d.Namespace = types.EmptyString
+			continue
+		}
+
+		switch true {
+		case nid == types.EmptyString && d.Namespace == types.EmptyString:
+			errors.New("apply").BadRequest(fmt.Sprintf("%s %s: namespace is required", d.Kind, d.Name)).Http(w)
+			return
+		case nid != types.EmptyString && d.Namespace != types.EmptyString && d.Namespace != nid:
+			errors.New("apply").BadRequest(fmt.Sprintf("%s %s: namespace does not match", d.Kind, d.Name)).Http(w)
+			return
+		case nid != types.EmptyString:
+			d.Namespace = nid
+		}
+	}
+
+	a := newApplier(r.Context(), stg, opts)
+	result := a.Apply(mf.Items)
+
+	if opts.Prune {
+
+		failed := false
+		for _, i := range result.Items {
+			if i.Status == types.ApplyStatusFailed {
+				failed = true
+				break
+			}
+		}
+
+		// do not remove objects when some of manifests were not applied
+		if failed {
+			log.V(logLevel).Warnf("%s:apply:> skip prune, some objects were not applied", logPrefix)
+		} else {
+			pruned, err := a.Prune(nid)
+			if err != nil {
+				log.V(logLevel).Errorf("%s:apply:> prune objects err: %s", logPrefix, err.Error())
+				errors.HTTP.InternalServerError(w)
+				return
+			}
+			result.Items = append(result.Items, pruned.Items...)
+		}
+	}
+
+	response, err := v1.View().Apply().NewList(result).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:apply:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:apply:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
@@ -0,0 +1,214 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package apply_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/apply"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+const manifests = `
+kind: Secret
+meta:
+  name: demo-secret
+spec:
+  type: opaque
+  data:
+    token: secret
+---
+kind: Route
+meta:
+  name: demo-route
+spec:
+  port: 80
+  rules:
+  - service: demo-service
+    path: /
+    port: 80
+---
+kind: Service
+meta:
+  name: demo-service
+spec:
+  template:
+    containers:
+    - name: web
+      image:
+        name: nginx
+---
+kind: Config
+meta:
+  name: demo-config
+spec:
+  type: text
+  data:
+    key: value
+`
+
+// Testing ApplyH handler
+func TestApply(t *testing.T) {
+
+	var ctx = context.Background()
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns := new(types.Namespace)
+	ns.Meta.SetDefault()
+	ns.Meta.Name = "demo"
+	ns.Meta.Endpoint = "demo.lb.local"
+
+	clear := func() {
+		for _, c := range []string{stg.Collection().Namespace(), stg.Collection().Secret(), stg.Collection().Config(),
+			stg.Collection().Service(), stg.Collection().Route()} {
+			err := stg.Del(ctx, c, types.EmptyString)
+			assert.NoError(t, err)
+		}
+	}
+
+	clear()
+	defer clear()
+
+	err := stg.Put(ctx, stg.Collection().Namespace(), stg.Key().Namespace(ns.Meta.Name), ns, nil)
+	assert.NoError(t, err)
+
+	changed := strings.Replace(manifests, "token: secret", "token: changed", 1)
+	changed = changed[:strings.Index(changed, "---\nkind: Config")]
+
+	tests := []struct {
+		name         string
+		query        string
+		body         string
+		want         map[string]string
+		expectedCode int
+	}{
+		{
+			name:         "dry run plans objects creation",
+			query:        "source=demo&dryRun=true",
+			body:         manifests,
+			want:         map[string]string{"secret/demo-secret": "created", "config/demo-config": "created", "service/demo-service": "created", "route/demo-route": "created"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "apply creates objects",
+			query:        "source=demo",
+			body:         manifests,
+			want:         map[string]string{"secret/demo-secret": "created", "config/demo-config": "created", "service/demo-service": "created", "route/demo-route": "created"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "apply of the same manifests changes nothing",
+			query:        "source=demo",
+			body:         manifests,
+			want:         map[string]string{"secret/demo-secret": "unchanged", "config/demo-config": "unchanged", "service/demo-service": "unchanged", "route/demo-route": "unchanged"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "apply updates changed and prunes missing objects",
+			query:        "source=demo&prune=true",
+			body:         changed,
+			want:         map[string]string{"secret/demo-secret": "updated", "config/demo-config": "pruned", "service/demo-service": "unchanged", "route/demo-route": "unchanged"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "json stream is accepted",
+			query:        "source=demo",
+			body:         `{"kind":"config","meta":{"name":"json-config"},"spec":{"data":{"a":"b"}}} {"kind":"config","meta":{"name":"json-config-2"}}`,
+			want:         map[string]string{"config/json-config": "created", "config/json-config-2": "created"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "service with not existing volume is not applied",
+			query:        "source=demo",
+			body:         "kind: Service\nmeta:\n  name: data-service\nspec:\n  template:\n    volumes:\n    - name: data\n      volume:\n        name: missing\n    containers:\n    - name: web\n      image:\n        name: nginx\n",
+			want:         map[string]string{"service/data-service": "failed"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "namespace manifest in namespace apply",
+			body:         "kind: Namespace\nmeta:\n  name: other",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unsupported kind",
+			body:         "kind: Pod\nmeta:\n  name: demo-pod",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "prune without source",
+			query:        "prune=true",
+			body:         manifests,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/apply?%s", ns.Meta.Name, tc.query), strings.NewReader(tc.body))
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/apply", apply.ApplyH)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			result := make(views.ApplyResultList, 0)
+			err = json.Unmarshal(res.Body.Bytes(), &result)
+			assert.NoError(t, err)
+
+			got := make(map[string]string)
+			for _, i := range result {
+				assert.Equal(t, ns.Meta.Name, i.Namespace)
+				got[fmt.Sprintf("%s/%s", i.Kind, i.Name)] = i.Status
+			}
+			assert.Equal(t, tc.want, got, "apply result not equal")
+		})
+	}
+
+	cfg := new(types.Config)
+	err = stg.Get(ctx, stg.Collection().Config(), stg.Key().Config(ns.Meta.Name, "demo-config"), cfg, nil)
+	assert.True(t, errors.Storage().IsErrEntityNotFound(err), "pruned config should be removed")
+
+	sec := new(types.Secret)
+	err = stg.Get(ctx, stg.Collection().Secret(), stg.Key().Secret(ns.Meta.Name, "demo-secret"), sec, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "demo", sec.Meta.Labels[types.LabelApplySource])
+}
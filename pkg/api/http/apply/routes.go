@@ -0,0 +1,33 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package apply
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/http"
+)
+
+// Routes - apply handlers, access to each applied object is checked separately
+var Routes = []http.Route{
+	{Path: "/apply", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindCluster, types.VerbGet), limits.Write.Limit}, Handler: ApplyH, Request: http.Raw{}, Response: views.ApplyResultList{}},
+	{Path: "/namespace/{namespace}/apply", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindNamespace, types.VerbGet), limits.Write.Limit}, Handler: ApplyH, Request: http.Raw{}, Response: views.ApplyResultList{}},
+}
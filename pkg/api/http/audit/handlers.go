@@ -0,0 +1,106 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package audit
+
+import (
+	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/api/auditor"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+)
+
+const (
+	logLevel  = 2
+	logPrefix = "api:handler:audit"
+)
+
+func AuditListH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /audit audit auditList
+	//
+	// Shows a list of audit records of api mutation requests
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: query
+	//     description: name of the namespace, ignored for namespace scoped route
+	//     required: false
+	//     type: string
+	//   - name: from
+	//     in: query
+	//     description: start of time range in RFC3339 format
+	//     required: false
+	//     type: string
+	//   - name: to
+	//     in: query
+	//     description: end of time range in RFC3339 format
+	//     required: false
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Audit record list response
+	//     schema:
+	//       "$ref": "#/definitions/views_audit_record_list"
+	//   '400':
+	//     description: Bad parameter / Audit sink does not support queries
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:list:> get audit records", logPrefix)
+
+	opts := v1.Request().Audit().ListOptions()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
+	if nid, ok := utils.Vars(r)["namespace"]; ok {
+		opts.Namespace = nid
+	}
+
+	items, err := auditor.List(opts.GetFilter())
+	if err != nil {
+		if err == auditor.ErrNotQueryable {
+			errors.New("audit").BadRequest(err.Error()).Http(w)
+			return
+		}
+		log.V(logLevel).Errorf("%s:list:> get audit records err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Audit().NewList(items).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
@@ -0,0 +1,31 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package audit
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/http"
+)
+
+var Routes = []http.Route{
+	{Path: "/audit", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindAudit, types.VerbList), limits.List}, Handler: AuditListH, Response: views.AuditRecordList{}},
+	{Path: "/namespace/{namespace}/audit", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindAudit, types.VerbList), limits.List}, Handler: AuditListH, Response: views.AuditRecordList{}},
+}
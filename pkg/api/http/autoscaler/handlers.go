@@ -0,0 +1,290 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package autoscaler
+
+import (
+	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+)
+
+const (
+	logLevel  = 2
+	logPrefix = "api:handler:autoscaler"
+)
+
+func AutoscalerInfoH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/service/{service}/autoscaler autoscaler autoscalerInfo
+	//
+	// Shows a service autoscaler info
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: name of the namespace
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: name of the service
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Autoscaler response
+	//     schema:
+	//       "$ref": "#/definitions/views_autoscaler"
+	//   '404':
+	//     description: Namespace not found / Service not found / Autoscaler not found
+	//   '500':
+	//     description: Internal server error
+
+	sid := utils.Vars(r)["service"]
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:info:> get autoscaler for `%s/%s`", logPrefix, nid, sid)
+
+	svc, e := fetchService(r, nid, sid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	am := distribution.NewAutoscalerModel(r.Context(), envs.Get().GetStorage())
+	as, err := am.Get(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> get autoscaler err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if as == nil {
+		log.V(logLevel).Warnf("%s:info:> autoscaler for `%s` not found", logPrefix, svc.SelfLink())
+		errors.New("autoscaler").NotFound().Http(w)
+		return
+	}
+
+	response, err := v1.View().Autoscaler().New(as).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:info:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func AutoscalerSetH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /namespace/{namespace}/service/{service}/autoscaler autoscaler autoscalerSet
+	//
+	// Creates or updates service autoscaler
+	//
+	// ---
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: name of the namespace
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: name of the service
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_autoscaler"
+	// responses:
+	//   '200':
+	//     description: Autoscaler was successfully set
+	//     schema:
+	//       "$ref": "#/definitions/views_autoscaler"
+	//   '400':
+	//     description: Bad request
+	//   '404':
+	//     description: Namespace not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	sid := utils.Vars(r)["service"]
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:set:> set autoscaler for `%s/%s`", logPrefix, nid, sid)
+
+	opts := v1.Request().Autoscaler().Options()
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:set:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
+	svc, e := fetchService(r, nid, sid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	am := distribution.NewAutoscalerModel(r.Context(), envs.Get().GetStorage())
+	as, err := am.Get(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:set:> get autoscaler err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	if as == nil {
+		as, err = am.Create(svc, opts.GetSpec())
+	} else {
+		as, err = am.SetSpec(as, opts.GetSpec())
+	}
+	if err != nil {
+		log.V(logLevel).Errorf("%s:set:> set autoscaler err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Autoscaler().New(as).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:set:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:set:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func AutoscalerRemoveH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation DELETE /namespace/{namespace}/service/{service}/autoscaler autoscaler autoscalerRemove
+	//
+	// Removes service autoscaler, service replicas are kept as is
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: name of the namespace
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: name of the service
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Autoscaler was successfully removed
+	//   '404':
+	//     description: Namespace not found / Service not found / Autoscaler not found
+	//   '500':
+	//     description: Internal server error
+
+	sid := utils.Vars(r)["service"]
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:remove:> remove autoscaler for `%s/%s`", logPrefix, nid, sid)
+
+	svc, e := fetchService(r, nid, sid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	am := distribution.NewAutoscalerModel(r.Context(), envs.Get().GetStorage())
+	as, err := am.Get(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:remove:> get autoscaler err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if as == nil {
+		log.V(logLevel).Warnf("%s:remove:> autoscaler for `%s` not found", logPrefix, svc.SelfLink())
+		errors.New("autoscaler").NotFound().Http(w)
+		return
+	}
+
+	if err := am.Remove(as); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove autoscaler err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte{}); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+// fetchService - get service scaled by autoscaler
+func fetchService(r *http.Request, namespace, service string) (*types.Service, *errors.Err) {
+
+	var (
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		sm  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, err := nsm.Get(namespace)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get namespace %s err: %s", logPrefix, namespace, err.Error())
+		return nil, errors.New("namespace").Unknown(err)
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:> namespace %s not found", logPrefix, namespace)
+		return nil, errors.New("namespace").NotFound()
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, service)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get service `%s` err: %s", logPrefix, service, err.Error())
+		return nil, errors.New("service").Unknown(err)
+	}
+	if svc == nil {
+		log.V(logLevel).Warnf("%s:> service `%s` not found", logPrefix, service)
+		return nil, errors.New("service").NotFound()
+	}
+
+	return svc, nil
+}
@@ -0,0 +1,177 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package autoscaler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/autoscaler"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// Testing AutoscalerSetH and AutoscalerInfoH handlers
+func TestAutoscalerSetH(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo")
+
+	tests := []struct {
+		name         string
+		service      string
+		body         string
+		err          string
+		want         types.AutoscalerSpec
+		expectedCode int
+	}{
+		{
+			name:         "checking set autoscaler for not existing service",
+			service:      "test",
+			body:         "{\"max_replicas\":5,\"cpu\":60}",
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Service not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking set autoscaler with invalid replicas",
+			service:      s1.Meta.Name,
+			body:         "{\"min_replicas\":5,\"max_replicas\":2,\"cpu\":60}",
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad max_replicas parameter\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking set autoscaler without targets",
+			service:      s1.Meta.Name,
+			body:         "{\"max_replicas\":5}",
+			err:          "{\"code\":400,\"status\":\"Bad Request\",\"message\":\"cpu or ram utilization target is required\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking set autoscaler successfully",
+			service:      s1.Meta.Name,
+			body:         "{\"max_replicas\":5,\"cpu\":60,\"stabilization\":{\"scale_down\":120}}",
+			want:         types.AutoscalerSpec{MinReplicas: 1, MaxReplicas: 5, CPU: 60, Stabilization: types.AutoscalerSpecStabilization{ScaleDown: 120}},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		for _, c := range []string{stg.Collection().Namespace(), stg.Collection().Service(), stg.Collection().Autoscaler()} {
+			assert.NoError(t, stg.Del(context.Background(), c, types.EmptyString))
+		}
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/autoscaler", autoscaler.AutoscalerSetH).Methods(http.MethodPut)
+			r.HandleFunc("/namespace/{namespace}/service/{service}/autoscaler", autoscaler.AutoscalerInfoH).Methods(http.MethodGet)
+
+			url := fmt.Sprintf("/namespace/%s/service/%s/autoscaler", ns1.Meta.Name, tc.service)
+
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewBufferString(tc.body))
+			assert.NoError(t, err)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.err != types.EmptyString {
+				assert.Equal(t, tc.err, string(body), "incorrect response body")
+				return
+			}
+
+			req, err = http.NewRequest(http.MethodGet, url, nil)
+			assert.NoError(t, err)
+
+			setRequestVars(r, req)
+
+			res = httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if !assert.Equal(t, http.StatusOK, res.Code, "status code not equal") {
+				return
+			}
+
+			as := new(views.Autoscaler)
+			if !assert.NoError(t, json.NewDecoder(res.Body).Decode(as)) {
+				return
+			}
+
+			assert.Equal(t, tc.want.MinReplicas, as.Spec.MinReplicas, "min replicas mismatch")
+			assert.Equal(t, tc.want.MaxReplicas, as.Spec.MaxReplicas, "max replicas mismatch")
+			assert.Equal(t, tc.want.CPU, as.Spec.CPU, "cpu target mismatch")
+			assert.Equal(t, tc.want.Stabilization.ScaleDown, as.Spec.Stabilization.ScaleDown, "scale down window mismatch")
+		})
+	}
+}
+
+func getNamespaceAsset(name string) *types.Namespace {
+	var n = types.Namespace{}
+	n.Meta.SetDefault()
+	n.Meta.Name = name
+	return &n
+}
+
+func getServiceAsset(namespace, name string) *types.Service {
+	var n = types.Service{}
+	n.Meta.SetDefault()
+	n.Meta.Namespace = namespace
+	n.Meta.Name = name
+	n.Spec.Replicas = 1
+	return &n
+}
+
+func setRequestVars(r *mux.Router, req *http.Request) {
+	var match mux.RouteMatch
+	// Take the request and match it
+	r.Match(req, &match)
+	// Push the variable onto the context
+	req = mux.SetURLVars(req, match.Vars)
+}
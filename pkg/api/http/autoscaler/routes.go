@@ -0,0 +1,34 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package autoscaler
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/http"
+)
+
+var Routes = []http.Route{
+	{Path: "/namespace/{namespace}/service/{service}/autoscaler", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindAutoscaler, types.VerbGet), limits.Read.Limit}, Handler: AutoscalerInfoH, Response: views.Autoscaler{}},
+	{Path: "/namespace/{namespace}/service/{service}/autoscaler", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindAutoscaler, types.VerbUpdate), limits.Write.Limit}, Handler: AutoscalerSetH, Request: request.AutoscalerOptions{}, Response: views.Autoscaler{}},
+	{Path: "/namespace/{namespace}/service/{service}/autoscaler", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindAutoscaler, types.VerbDelete), limits.Write.Limit}, Handler: AutoscalerRemoveH, Response: http.Empty{}},
+}
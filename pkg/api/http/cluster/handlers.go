@@ -19,7 +19,10 @@
 package cluster
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
@@ -83,3 +86,138 @@ func ClusterInfoH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func ClusterBackupH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /cluster/backup cluster clusterBackup
+	//
+	// Exports cluster objects into gzipped tar archive
+	//
+	// ---
+	// produces:
+	// - application/gzip
+	// parameters:
+	//   - name: X-Lastbackend-Passphrase
+	//     in: header
+	//     description: passphrase to encrypt secrets in archive, required when secrets encryption is enabled
+	//     required: false
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Backup archive
+	//   '400':
+	//     description: Passphrase is required
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:backup:> backup cluster", logPrefix)
+
+	opts := v1.Request().Cluster().BackupOptions()
+	if e := opts.DecodeAndValidate(r.Header); e != nil {
+		log.V(logLevel).Errorf("%s:backup:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	var (
+		bm  = distribution.NewBackupModel(r.Context(), envs.Get().GetStorage())
+		buf = new(bytes.Buffer)
+	)
+
+	manifest, err := bm.Export(buf, opts.GetOpts())
+	if err != nil {
+		log.V(logLevel).Errorf("%s:backup:> export err: %s", logPrefix, err.Error())
+
+		if err == types.ErrBackupPassphraseRequired {
+			errors.HTTP.BadRequest(w, err.Error())
+			return
+		}
+
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	if manifest.Encryption == nil {
+		log.V(logLevel).Warnf("%s:backup:> secrets are stored in backup without encryption", logPrefix)
+	}
+
+	name := fmt.Sprintf("lastbackend-backup-%s.tar.gz", manifest.Created.UTC().Format("20060102-150405"))
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+	if _, err = buf.WriteTo(w); err != nil {
+		log.V(logLevel).Errorf("%s:backup:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func ClusterRestoreH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /cluster/restore cluster clusterRestore
+	//
+	// Restores cluster objects from backup archive into empty storage
+	//
+	// ---
+	// consumes:
+	// - application/gzip
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: X-Lastbackend-Passphrase
+	//     in: header
+	//     description: passphrase to decrypt secrets in archive
+	//     required: false
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Backup was successfully restored
+	//     schema:
+	//       "$ref": "#/definitions/views_backup"
+	//   '400':
+	//     description: Invalid backup archive / Storage is not empty
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:restore:> restore cluster", logPrefix)
+
+	opts := v1.Request().Cluster().BackupOptions()
+	if e := opts.DecodeAndValidate(r.Header); e != nil {
+		log.V(logLevel).Errorf("%s:restore:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	var (
+		bm    = distribution.NewBackupModel(r.Context(), envs.Get().GetStorage())
+		start = time.Now()
+	)
+
+	manifest, err := bm.Restore(r.Body, opts.GetOpts())
+	if err != nil {
+		log.V(logLevel).Errorf("%s:restore:> restore err: %s", logPrefix, err.Error())
+
+		if _, ok := err.(*types.BackupArchiveError); ok || err == types.ErrBackupStorageNotEmpty {
+			errors.HTTP.BadRequest(w, err.Error())
+			return
+		}
+
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	log.V(logLevel).Infof("%s:restore:> backup created at %s restored in %s", logPrefix, manifest.Created, time.Since(start))
+
+	response, err := v1.View().Backup().New(manifest).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:restore:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:restore:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
@@ -19,8 +19,14 @@
 package cluster_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -29,9 +35,12 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/api/http/cluster"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/encryption"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -138,6 +147,365 @@ func TestClusterInfo(t *testing.T) {
 	}
 }
 
+// Testing ClusterBackupH and ClusterRestoreH handlers
+func TestClusterBackupRestore(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	var (
+		ctx  = context.Background()
+		ns   = getNamespaceAsset("demo")
+		svc  = getServiceAsset("demo", "web", types.StateReady)
+		sct  = getSecretAsset("demo", "registry", "password")
+		rt   = getRouteAsset("demo", "web", types.StateReady)
+		gone = getRouteAsset("demo", "old", types.StateDestroyed)
+	)
+
+	collections := []string{
+		stg.Collection().Namespace(),
+		stg.Collection().Service(),
+		stg.Collection().Secret(),
+		stg.Collection().Route(),
+	}
+
+	clear := func() {
+		for _, c := range collections {
+			assert.NoError(t, stg.Del(ctx, c, types.EmptyString))
+		}
+	}
+
+	clear()
+	defer clear()
+
+	ns.Status.Resources.Allocated = types.ResourceRequestItem{RAM: "512MB", CPU: "0.5"}
+	ns.Status.Resources.Requested = types.ResourceRequestItem{RAM: "256MB", CPU: "0.2"}
+
+	assert.NoError(t, stg.Put(ctx, stg.Collection().Namespace(), stg.Key().Namespace(ns.Meta.Name), ns, nil))
+	assert.NoError(t, stg.Put(ctx, stg.Collection().Service(), stg.Key().Service(svc.Meta.Namespace, svc.Meta.Name), svc, nil))
+	assert.NoError(t, stg.Put(ctx, stg.Collection().Secret(), stg.Key().Secret(sct.Meta.Namespace, sct.Meta.Name), sct, nil))
+	assert.NoError(t, stg.Put(ctx, stg.Collection().Route(), stg.Key().Route(rt.Meta.Namespace, rt.Meta.Name), rt, nil))
+	assert.NoError(t, stg.Put(ctx, stg.Collection().Route(), stg.Key().Route(gone.Meta.Namespace, gone.Meta.Name), gone, nil))
+
+	r := mux.NewRouter()
+	r.HandleFunc("/cluster/backup", cluster.ClusterBackupH)
+	r.HandleFunc("/cluster/restore", cluster.ClusterRestoreH)
+
+	serve := func(method, url, passphrase string, body io.Reader) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, body)
+		assert.NoError(t, err)
+		if passphrase != "" {
+			req.Header.Set(request.HeaderPassphrase, passphrase)
+		}
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+		return res
+	}
+
+	res := serve(http.MethodGet, "/cluster/backup", "passphrase", nil)
+	if !assert.Equal(t, http.StatusOK, res.Code, "status code not equal") {
+		return
+	}
+	assert.Equal(t, "application/gzip", res.Header().Get("Content-Type"))
+
+	archive := res.Body.Bytes()
+
+	entries := readArchive(t, archive)
+	assert.Contains(t, entries, types.BackupManifestFile)
+	assert.Contains(t, entries, "namespace/demo.json")
+	assert.Contains(t, entries, "service/demo:web.json")
+	assert.Contains(t, entries, "route/demo:old.json")
+	if assert.Contains(t, entries, "secret/demo:registry.json") {
+		assert.NotContains(t, string(entries["secret/demo:registry.json"]), "registry", "secret is not encrypted")
+	}
+
+	tests := []struct {
+		name         string
+		passphrase   string
+		clear        bool
+		archive      []byte
+		rollback     bool
+		expectedCode int
+	}{
+		{
+			name:         "checking restore into not empty storage",
+			passphrase:   "passphrase",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking restore without passphrase",
+			clear:        true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking restore with wrong passphrase",
+			passphrase:   "wrong",
+			clear:        true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking restored objects are removed on restore failure",
+			passphrase:   "passphrase",
+			clear:        true,
+			archive:      duplicateEntry(t, archive, "route/demo:web.json"),
+			rollback:     true,
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "checking success restore",
+			passphrase:   "passphrase",
+			clear:        true,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			if tc.clear {
+				clear()
+			}
+
+			data := archive
+			if tc.archive != nil {
+				data = tc.archive
+			}
+
+			res := serve(http.MethodPost, "/cluster/restore", tc.passphrase, bytes.NewReader(data))
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				return
+			}
+
+			if tc.rollback {
+				var (
+					nl = types.NewNamespaceList()
+					sl = types.NewServiceList()
+					cl = types.NewSecretList()
+					rl = types.NewRouteList()
+				)
+				assert.NoError(t, stg.List(ctx, stg.Collection().Namespace(), types.EmptyString, nl, nil))
+				assert.NoError(t, stg.List(ctx, stg.Collection().Service(), types.EmptyString, sl, nil))
+				assert.NoError(t, stg.List(ctx, stg.Collection().Secret(), types.EmptyString, cl, nil))
+				assert.NoError(t, stg.List(ctx, stg.Collection().Route(), types.EmptyString, rl, nil))
+				assert.Empty(t, nl.Items, "restored namespaces are not removed")
+				assert.Empty(t, sl.Items, "restored services are not removed")
+				assert.Empty(t, cl.Items, "restored secrets are not removed")
+				assert.Empty(t, rl.Items, "restored routes are not removed")
+			}
+
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+
+			b := new(views.Backup)
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), b))
+			assert.True(t, b.Encrypted)
+			assert.Equal(t, 1, b.Collections["namespace"])
+			assert.Equal(t, 1, b.Collections["route"], "destroyed route is restored")
+
+			n := new(types.Namespace)
+			assert.NoError(t, stg.Get(ctx, stg.Collection().Namespace(), stg.Key().Namespace("demo"), n, nil))
+			assert.Equal(t, ns.Status.Resources, n.Status.Resources, "namespace resources usage is lost")
+
+			s := new(types.Service)
+			assert.NoError(t, stg.Get(ctx, stg.Collection().Service(), stg.Key().Service("demo", "web"), s, nil))
+			assert.Equal(t, types.StateCreated, s.Status.State, "service status is not reset")
+			assert.Equal(t, svc.Spec.Replicas, s.Spec.Replicas)
+
+			c := new(types.Secret)
+			assert.NoError(t, stg.Get(ctx, stg.Collection().Secret(), stg.Key().Secret("demo", "registry"), c, nil))
+			assert.Equal(t, sct.Spec.Data, c.Spec.Data)
+
+			err := stg.Get(ctx, stg.Collection().Route(), stg.Key().Route("demo", "old"), new(types.Route), nil)
+			assert.Error(t, err, "destroyed route is restored")
+		})
+	}
+}
+
+// Testing ClusterBackupH handler with secrets encryption
+func TestClusterBackupPassphrase(t *testing.T) {
+
+	defer encryption.Set(nil)
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	var (
+		ctx = context.Background()
+		sct = getSecretAsset("demo", "registry", "password")
+	)
+
+	assert.NoError(t, stg.Del(ctx, stg.Collection().Secret(), types.EmptyString))
+	assert.NoError(t, stg.Put(ctx, stg.Collection().Secret(), stg.Key().Secret(sct.Meta.Namespace, sct.Meta.Name), sct, nil))
+
+	key, err := crypto.Random(crypto.KeySize)
+	assert.NoError(t, err)
+
+	keyring, err := encryption.Parse([]byte(fmt.Sprintf("keys:\n- id: main\n  secret: %s\n", base64.StdEncoding.EncodeToString(key))))
+	assert.NoError(t, err)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/cluster/backup", cluster.ClusterBackupH)
+
+	tests := []struct {
+		name         string
+		keyring      *encryption.Keyring
+		passphrase   string
+		expectedCode int
+	}{
+		{
+			name:         "checking backup without passphrase and encryption",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "checking backup without passphrase is rejected when encryption is enabled",
+			keyring:      keyring,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking backup with passphrase when encryption is enabled",
+			keyring:      keyring,
+			passphrase:   "passphrase",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			encryption.Set(tc.keyring)
+
+			req, err := http.NewRequest(http.MethodGet, "/cluster/backup", nil)
+			assert.NoError(t, err)
+			if tc.passphrase != "" {
+				req.Header.Set(request.HeaderPassphrase, tc.passphrase)
+			}
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+		})
+	}
+}
+
+func readArchive(t *testing.T, data []byte) map[string][]byte {
+
+	entries := make(map[string][]byte)
+
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if !assert.NoError(t, err) {
+		return entries
+	}
+
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+		body, err := ioutil.ReadAll(tr)
+		assert.NoError(t, err)
+		entries[h.Name] = body
+	}
+
+	return entries
+}
+
+// duplicateEntry - copy archive with entry added twice, so restore fails on the second entry
+func duplicateEntry(t *testing.T, data []byte, name string) []byte {
+
+	var (
+		buf = new(bytes.Buffer)
+		gw  = gzip.NewWriter(buf)
+		tw  = tar.NewWriter(gw)
+		dup []byte
+	)
+
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		body, err := ioutil.ReadAll(tr)
+		assert.NoError(t, err)
+
+		assert.NoError(t, tw.WriteHeader(h))
+		_, err = tw.Write(body)
+		assert.NoError(t, err)
+
+		if h.Name == name {
+			dup = body
+		}
+	}
+
+	if !assert.NotNil(t, dup, "entry %s not found", name) {
+		return nil
+	}
+
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(dup))}))
+	_, err = tw.Write(dup)
+	assert.NoError(t, err)
+
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+
+	return buf.Bytes()
+}
+
+func getNamespaceAsset(name string) *types.Namespace {
+	var n = types.Namespace{}
+	n.Meta.SetDefault()
+	n.Meta.Name = name
+	return &n
+}
+
+func getServiceAsset(namespace, name, state string) *types.Service {
+	var s = types.Service{}
+	s.Meta.SetDefault()
+	s.Meta.Namespace = namespace
+	s.Meta.Name = name
+	s.Meta.IP = "10.0.0.1"
+	s.Spec.Replicas = 2
+	s.Status.State = state
+	return &s
+}
+
+func getSecretAsset(namespace, name, value string) *types.Secret {
+	var s = types.Secret{}
+	s.Meta.SetDefault()
+	s.Meta.Namespace = namespace
+	s.Meta.Name = name
+	s.Spec.Type = types.KindSecretOpaque
+	s.Spec.Data = map[string][]byte{"password": []byte(value)}
+	return &s
+}
+
+func getRouteAsset(namespace, name, state string) *types.Route {
+	var r = types.Route{}
+	r.Meta.SetDefault()
+	r.Meta.Namespace = namespace
+	r.Meta.Name = name
+	r.Meta.Ingress = "ingress"
+	r.Status.State = state
+	return &r
+}
+
 func getClusterAsset(memory int64) *types.Cluster {
 	var c = types.Cluster{}
 	c.Status.Capacity.Memory = memory
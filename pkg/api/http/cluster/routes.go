@@ -19,11 +19,16 @@
 package cluster
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
 	// Cluster handlers
-	{Path: "/cluster", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ClusterInfoH},
+	{Path: "/cluster", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindCluster, types.VerbGet), limits.Read.Limit}, Handler: ClusterInfoH, Response: views.Cluster{}},
+	{Path: "/cluster/backup", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindBackup, types.VerbGet), limits.Stream.Limit}, Handler: ClusterBackupH, Response: http.Raw{}},
+	{Path: "/cluster/restore", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindBackup, types.VerbCreate), limits.Restore.Limit}, Handler: ClusterRestoreH, Request: http.Raw{}, Response: views.Backup{}},
 }
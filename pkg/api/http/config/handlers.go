@@ -20,7 +20,9 @@ package config
 
 import (
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
@@ -124,6 +126,36 @@ func ConfigListH(w http.ResponseWriter, r *http.Request) {
 	//     description: namespace id
 	//     required: true
 	//     type: string
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
 	// responses:
 	//   '200':
 	//     description: Config list response
@@ -136,6 +168,13 @@ func ConfigListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> get configs list", logPrefix)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	var (
 		nid = utils.Vars(r)["namespace"]
 
@@ -156,8 +195,17 @@ func ConfigListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items, err := rm.List(ns.Meta.Name)
+	if opts.Watch {
+		configWatch(w, r, ns.Meta.Name, opts.GetWatchOptions())
+		return
+	}
+
+	items, err := rm.Select(ns.Meta.Name, opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> find config list err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -170,6 +218,10 @@ func ConfigListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if items.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, items.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
 		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -177,6 +229,23 @@ func ConfigListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// configWatch - stream configs changes as chunked json events
+func configWatch(w http.ResponseWriter, r *http.Request, namespace string, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch configs in namespace %s", logPrefix, namespace)
+
+	var (
+		m  = distribution.NewConfigModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.ConfigEvent)
+	)
+
+	watch.Stream(w, "configs", ch, func() error {
+		return m.WatchSelect(namespace, ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Config().New(data.(*types.Config))
+	})
+}
+
 func ConfigCreateH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation POST /namespace/{namespace}/config config configCreate
@@ -349,10 +418,15 @@ func ConfigUpdateH(w http.ResponseWriter, r *http.Request) {
 	opts.SetConfigMeta(cfg)
 	opts.SetConfigSpec(cfg)
 
-	cfg, err = rm.Update(cfg)
+	_, err = rm.Update(cfg)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:update:> update config `%s` err: %s", logPrefix, cfg.Meta.SelfLink, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("config").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
+		return
 	}
 
 	response, err := v1.View().Config().New(cfg).ToJson()
@@ -19,15 +19,19 @@
 package config
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
 	// Route handlers
-	{Path: "/namespace/{namespace}/config", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ConfigCreateH},
-	{Path: "/namespace/{namespace}/config", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ConfigListH},
-	{Path: "/namespace/{namespace}/config/{config}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ConfigGetH},
-	{Path: "/namespace/{namespace}/config/{config}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ConfigUpdateH},
-	{Path: "/namespace/{namespace}/config/{config}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ConfigRemoveH},
+	{Path: "/namespace/{namespace}/config", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindConfig, types.VerbCreate), limits.Write.Limit}, Handler: ConfigCreateH, Request: request.ConfigManifest{}, Response: views.Config{}},
+	{Path: "/namespace/{namespace}/config", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindConfig, types.VerbList), limits.List}, Handler: ConfigListH, Response: views.ConfigList{}},
+	{Path: "/namespace/{namespace}/config/{config}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindConfig, types.VerbGet), limits.Read.Limit}, Handler: ConfigGetH, Response: views.Config{}},
+	{Path: "/namespace/{namespace}/config/{config}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindConfig, types.VerbUpdate), limits.Write.Limit}, Handler: ConfigUpdateH, Request: request.ConfigManifest{}, Response: views.Config{}},
+	{Path: "/namespace/{namespace}/config/{config}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindConfig, types.VerbDelete), limits.Write.Limit}, Handler: ConfigRemoveH, Response: http.Empty{}},
 }
@@ -20,9 +20,13 @@ package deployment
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
@@ -55,11 +59,48 @@ func DeploymentListH(w http.ResponseWriter, r *http.Request) {
 	//     description: name of the service
 	//     required: true
 	//     type: string
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
+	//   - name: history
+	//     in: query
+	//     description: show stored deployment revisions with changes between them instead of deployments
+	//     required: false
+	//     type: boolean
 	// responses:
 	//   '200':
-	//     description: Deployment list response
+	//     description: Deployment list response, or deployment revision list response if history is set
 	//     schema:
 	//       "$ref": "#/definitions/views_deployment_list"
+	//   '400':
+	//     description: History can not be watched
 	//   '404':
 	//     description: Namespace not found / Service not found
 	//   '500':
@@ -70,6 +111,13 @@ func DeploymentListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> get deployments list for `%s/%s`", logPrefix, sid, nid)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	var (
 		sm  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
 		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
@@ -102,8 +150,28 @@ func DeploymentListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dl, err := dm.ListByService(srv.Meta.Namespace, srv.Meta.Name)
+	if history, _ := strconv.ParseBool(r.URL.Query().Get("history")); history {
+
+		if opts.Watch {
+			errors.New("deployment").BadParameter("history").Http(w)
+			return
+		}
+
+		deploymentHistory(w, r, srv)
+		return
+	}
+
+	if opts.Watch {
+		deploymentWatch(w, r, srv.Meta.Namespace, srv.Meta.Name, opts.GetWatchOptions())
+		return
+	}
+
+	dl, err := dm.Select(srv.Meta.Namespace, srv.Meta.Name, opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> get deployment list by service id `%s` err: %s", logPrefix, srv.Meta.Name, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -123,6 +191,10 @@ func DeploymentListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if dl.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, dl.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
 		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -130,6 +202,23 @@ func DeploymentListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// deploymentWatch - stream deployments changes as chunked json events
+func deploymentWatch(w http.ResponseWriter, r *http.Request, namespace, service string, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch deployments of service %s:%s", logPrefix, namespace, service)
+
+	var (
+		m  = distribution.NewDeploymentModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.DeploymentEvent)
+	)
+
+	watch.Stream(w, "deployments", ch, func() error {
+		return m.WatchSelect(namespace, service, ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Deployment().New(data.(*types.Deployment), nil)
+	})
+}
+
 func DeploymentInfoH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation GET /namespace/{namespace}/service/{service}/deployment/{deployment} deployment deploymentInfo
@@ -273,6 +362,8 @@ func DeploymentUpdateH(w http.ResponseWriter, r *http.Request) {
 	//       "$ref": "#/definitions/views_deployment"
 	//   '404':
 	//     description: Namespace not found / Service not found
+	//   '409':
+	//     description: Deployment was changed by another request
 	//   '500':
 	//     description: Internal server error
 
@@ -322,8 +413,16 @@ func DeploymentUpdateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if opts.ResourceVersion != nil {
+		dp.Runtime.System.Revision = *opts.ResourceVersion
+	}
+
 	if err := dm.Update(dp); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update deployment err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("deployment").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
 		return
 	}
@@ -347,3 +446,329 @@ func DeploymentUpdateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// deploymentHistory - write stored deployment revisions of service with changes between them
+func deploymentHistory(w http.ResponseWriter, r *http.Request, srv *types.Service) {
+
+	log.V(logLevel).Debugf("%s:history:> get deployments history for `%s`", logPrefix, srv.SelfLink())
+
+	dm := distribution.NewDeploymentModel(r.Context(), envs.Get().GetStorage())
+
+	rl, err := dm.ListRevisions(srv.Meta.Namespace, srv.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:history:> get deployment revisions by service `%s` err: %s", logPrefix, srv.Meta.Name, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Deployment().NewRevisionList(rl).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:history:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:history:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func DeploymentRollbackH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /namespace/{namespace}/service/{service}/deployment/rollback deployment deploymentRollback
+	//
+	// Rollbacks service to stored deployment revision
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: name of the namespace
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: name of the service
+	//     required: true
+	//     type: string
+	//   - name: version
+	//     in: query
+	//     description: version of the deployment revision
+	//     required: true
+	//     type: integer
+	// responses:
+	//   '200':
+	//     description: Service was successfully rolled back
+	//     schema:
+	//       "$ref": "#/definitions/views_service"
+	//   '400':
+	//     description: Bad version parameter
+	//   '404':
+	//     description: Namespace not found / Service not found / Revision not found
+	//   '500':
+	//     description: Internal server error
+
+	sid := utils.Vars(r)["service"]
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:rollback:> rollback service `%s/%s`", logPrefix, nid, sid)
+
+	version, err := strconv.Atoi(r.URL.Query().Get("version"))
+	if err != nil || version < 0 {
+		log.V(logLevel).Warnf("%s:rollback:> invalid version `%s`", logPrefix, r.URL.Query().Get("version"))
+		errors.New("deployment").BadParameter("version").Http(w)
+		return
+	}
+
+	var (
+		sm  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		dm  = distribution.NewDeploymentModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> get namespace %s err: %s", logPrefix, nid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:rollback:> namespace %s not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, sid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> get service by name `%s` in namespace `%s` err: %s", logPrefix, sid, ns.Meta.Name, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if svc == nil {
+		log.V(logLevel).Warnf("%s:rollback:> service `%s` in namespace `%s` not found", logPrefix, sid, ns.Meta.Name)
+		errors.New("service").NotFound().Http(w)
+		return
+	}
+
+	rev, err := dm.Revision(svc.Meta.Namespace, svc.Meta.Name, version)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> get deployment revision `%d` err: %s", logPrefix, version, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if rev == nil {
+		log.V(logLevel).Warnf("%s:rollback:> deployment revision `%d` not found", logPrefix, version)
+		errors.New("revision").NotFound().Http(w)
+		return
+	}
+
+	resources := svc.Spec.GetResourceRequest()
+
+	// stored spec is re-activated as a new deployment
+	svc.Spec.Template = rev.Spec.Template
+	svc.Spec.Template.Updated = time.Now()
+	svc.Spec.Selector = rev.Spec.Selector
+	svc.Spec.Selector.Updated = time.Now()
+	svc.Spec.Affinity = rev.Spec.Affinity
+	svc.Spec.Affinity.Updated = time.Now()
+	svc.Status.State = types.StateProvision
+
+	if requested := svc.Spec.GetResourceRequest(); !resources.Equal(requested) {
+		allocated := ns.Status.Resources
+		if err := ns.ReleaseResources(resources); err != nil {
+			log.V(logLevel).Errorf("%s:rollback:> %s", logPrefix, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+
+		if err := ns.AllocateResources(requested); err != nil {
+			ns.Status.Resources = allocated
+			log.V(logLevel).Errorf("%s:rollback:> %s", logPrefix, err.Error())
+			errors.New("service").BadRequest(err.Error()).Http(w)
+			return
+		}
+
+		if err := nsm.Update(ns); err != nil {
+			log.V(logLevel).Errorf("%s:rollback:> update namespace err: %s", logPrefix, err.Error())
+			if errors.Storage().IsErrEntityConflict(err) {
+				errors.New("namespace").Conflict().Http(w)
+				return
+			}
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+	}
+
+	srv, err := sm.Update(svc)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> update service err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("service").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Service().NewWithDeployment(srv, nil, nil).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func DeploymentPromoteH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /namespace/{namespace}/service/{service}/deployment/promote deployment deploymentPromote
+	//
+	// Promotes running canary or blue-green rollout
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: name of the namespace
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: name of the service
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Rollout was successfully promoted
+	//     schema:
+	//       "$ref": "#/definitions/views_service"
+	//   '400':
+	//     description: Rollout is not in progress
+	//   '404':
+	//     description: Namespace not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	setRolloutAction(w, r, types.StrategyActionPromote)
+}
+
+func DeploymentAbortH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /namespace/{namespace}/service/{service}/deployment/abort deployment deploymentAbort
+	//
+	// Aborts running canary or blue-green rollout
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: name of the namespace
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: name of the service
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Rollout was successfully aborted
+	//     schema:
+	//       "$ref": "#/definitions/views_service"
+	//   '400':
+	//     description: Rollout is not in progress
+	//   '404':
+	//     description: Namespace not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	setRolloutAction(w, r, types.StrategyActionAbort)
+}
+
+func setRolloutAction(w http.ResponseWriter, r *http.Request, action string) {
+
+	sid := utils.Vars(r)["service"]
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:%s:> %s rollout of service `%s/%s`", logPrefix, action, action, nid, sid)
+
+	var (
+		sm  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:%s:> get namespace %s err: %s", logPrefix, action, nid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:%s:> namespace %s not found", logPrefix, action, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, sid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:%s:> get service by name `%s` in namespace `%s` err: %s", logPrefix, action, sid, ns.Meta.Name, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if svc == nil {
+		log.V(logLevel).Warnf("%s:%s:> service `%s` in namespace `%s` not found", logPrefix, action, sid, ns.Meta.Name)
+		errors.New("service").NotFound().Http(w)
+		return
+	}
+
+	if !svc.Spec.Strategy.Manual() {
+		log.V(logLevel).Warnf("%s:%s:> service `%s` strategy `%s` can not be promoted or aborted", logPrefix, action, sid, svc.Spec.Strategy.Type)
+		errors.New("service").BadRequest("rollout strategy should be canary or bluegreen").Http(w)
+		return
+	}
+
+	if svc.Status.State != types.StateProvision {
+		log.V(logLevel).Warnf("%s:%s:> service `%s` rollout is not in progress", logPrefix, action, sid)
+		errors.New("service").BadRequest("rollout is not in progress").Http(w)
+		return
+	}
+
+	svc.Spec.Strategy.Action = action
+
+	srv, err := sm.Update(svc)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:%s:> update service err: %s", logPrefix, action, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("service").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Service().NewWithDeployment(srv, nil, nil).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:%s:> convert struct to json err: %s", logPrefix, action, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:%s:> write response err: %s", logPrefix, action, err.Error())
+		return
+	}
+}
@@ -348,6 +348,276 @@ func TestDeploymentListHList(t *testing.T) {
 
 }
 
+// Testing DeploymentListH handler with history
+func TestDeploymentListHistory(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "")
+	r1 := getRevisionAsset(ns1.Meta.Name, s1.Meta.Name, 1, "redis:4")
+	r2 := getRevisionAsset(ns1.Meta.Name, s1.Meta.Name, 2, "redis:5")
+
+	err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+	assert.NoError(t, err)
+
+	err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+	assert.NoError(t, err)
+
+	for _, r := range []*types.Deployment{r2, r1} {
+		err = stg.Put(context.Background(), stg.Collection().Revision(), stg.Key().Revision(r.Meta.Namespace, r.Meta.Service, r.Meta.Version), r, nil)
+		assert.NoError(t, err)
+	}
+
+	defer func() {
+		for _, c := range []string{stg.Collection().Namespace(), stg.Collection().Service(), stg.Collection().Revision()} {
+			assert.NoError(t, stg.Del(context.Background(), c, types.EmptyString))
+		}
+	}()
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/service/%s/deployment?history=true", ns1.Meta.Name, s1.Meta.Name), nil)
+	assert.NoError(t, err)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/namespace/{namespace}/service/{service}/deployment", deployment.DeploymentListH)
+
+	setRequestVars(r, req)
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if !assert.Equal(t, http.StatusOK, res.Code, "status code not equal") {
+		return
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+
+	rl := new(views.DeploymentRevisionList)
+	if !assert.NoError(t, json.Unmarshal(body, &rl)) {
+		return
+	}
+
+	if !assert.Len(t, *rl, 2, "revisions count not match") {
+		return
+	}
+
+	assert.Equal(t, 1, (*rl)[0].Version, "revisions should be sorted by version")
+	assert.Len(t, (*rl)[0].Diff, 0, "first revision should not have changes")
+	assert.Equal(t, []views.DeploymentRevisionDiff{{
+		Field:    "template.containers.0.image.name",
+		Previous: "redis:4",
+		Current:  "redis:5",
+	}}, (*rl)[1].Diff, "revision changes not match")
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("/namespace/%s/service/%s/deployment?history=true&watch=true", ns1.Meta.Name, s1.Meta.Name), nil)
+	assert.NoError(t, err)
+
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusBadRequest, res.Code, "history should not be watched")
+}
+
+// Testing DeploymentRollbackH handler
+func TestDeploymentRollbackH(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "")
+	r1 := getRevisionAsset(ns1.Meta.Name, s1.Meta.Name, 1, "redis:4")
+
+	s1.Status.State = types.StateReady
+	s1.Spec.Template.Containers = append(s1.Spec.Template.Containers, &types.SpecTemplateContainer{Name: "demo"})
+	s1.Spec.Template.Containers[0].Image.Name = "redis:5"
+
+	tests := []struct {
+		name         string
+		version      string
+		err          string
+		want         string
+		expectedCode int
+	}{
+		{
+			name:         "checking rollback with invalid version",
+			version:      "latest",
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad version parameter\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking rollback if revision not exists",
+			version:      "2",
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Revision not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking rollback successfully",
+			version:      "1",
+			want:         "redis:4",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		for _, c := range []string{stg.Collection().Namespace(), stg.Collection().Service(), stg.Collection().Revision()} {
+			assert.NoError(t, stg.Del(context.Background(), c, types.EmptyString))
+		}
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Revision(), stg.Key().Revision(r1.Meta.Namespace, r1.Meta.Service, r1.Meta.Version), r1, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/service/%s/deployment/rollback?version=%s", ns1.Meta.Name, s1.Meta.Name, tc.version), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/deployment/rollback", deployment.DeploymentRollbackH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.err != types.EmptyString {
+				assert.Equal(t, tc.err, string(body), "incorrect response body")
+				return
+			}
+
+			svc := new(types.Service)
+			err = stg.Get(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), &svc, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, types.StateProvision, svc.Status.State, "service should be provisioned")
+			if assert.Len(t, svc.Spec.Template.Containers, 1) {
+				assert.Equal(t, tc.want, svc.Spec.Template.Containers[0].Image.Name, "template not restored")
+			}
+			assert.True(t, svc.Spec.Template.Updated.After(r1.Spec.Template.Updated), "template should be marked updated")
+		})
+	}
+}
+
+// Testing DeploymentPromoteH handler
+func TestDeploymentPromoteH(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+
+	getRolloutServiceAsset := func(strategy, state string) *types.Service {
+		s := getServiceAsset(ns1.Meta.Name, "demo", "")
+		s.Spec.Strategy.Type = strategy
+		s.Status.State = state
+		return s
+	}
+
+	tests := []struct {
+		name         string
+		service      *types.Service
+		err          string
+		expectedCode int
+	}{
+		{
+			name:         "checking promote rolling rollout",
+			service:      getRolloutServiceAsset(types.StrategyRolling, types.StateProvision),
+			err:          "{\"code\":400,\"status\":\"Bad Request\",\"message\":\"rollout strategy should be canary or bluegreen\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking promote if rollout is not in progress",
+			service:      getRolloutServiceAsset(types.StrategyCanary, types.StateReady),
+			err:          "{\"code\":400,\"status\":\"Bad Request\",\"message\":\"rollout is not in progress\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking promote canary rollout successfully",
+			service:      getRolloutServiceAsset(types.StrategyCanary, types.StateProvision),
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		for _, c := range []string{stg.Collection().Namespace(), stg.Collection().Service()} {
+			assert.NoError(t, stg.Del(context.Background(), c, types.EmptyString))
+		}
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(tc.service.Meta.Namespace, tc.service.Meta.Name), tc.service, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/service/%s/deployment/promote", ns1.Meta.Name, tc.service.Meta.Name), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/deployment/promote", deployment.DeploymentPromoteH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.err != types.EmptyString {
+				assert.Equal(t, tc.err, string(body), "incorrect response body")
+				return
+			}
+
+			svc := new(types.Service)
+			err = stg.Get(context.Background(), stg.Collection().Service(), stg.Key().Service(tc.service.Meta.Namespace, tc.service.Meta.Name), &svc, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, types.StrategyActionPromote, svc.Spec.Strategy.Action, "rollout action not stored")
+		})
+	}
+}
+
+func getRevisionAsset(namespace, service string, version int, image string) *types.Deployment {
+	d := getDeploymentAsset(namespace, service, fmt.Sprintf("v%d", version))
+	d.Meta.Version = version
+	d.Spec.Template.Containers = append(d.Spec.Template.Containers, &types.SpecTemplateContainer{Name: "demo"})
+	d.Spec.Template.Containers[0].Image.Name = image
+	return d
+}
+
 func getNamespaceAsset(name, desc string) *types.Namespace {
 	var n = types.Namespace{}
 	n.Meta.SetDefault()
@@ -19,12 +19,19 @@
 package deployment
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
-	{Path: "/namespace/{namespace}/service/{service}/deployment", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: DeploymentListH},
-	{Path: "/namespace/{namespace}/service/{service}/deployment/{deployment}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: DeploymentInfoH},
-	{Path: "/namespace/{namespace}/service/{service}/deployment/{deployment}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: DeploymentUpdateH},
+	{Path: "/namespace/{namespace}/service/{service}/deployment", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindDeployment, types.VerbList), limits.List}, Handler: DeploymentListH, Response: views.DeploymentList{}},
+	{Path: "/namespace/{namespace}/service/{service}/deployment/rollback", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindDeployment, types.VerbUpdate), limits.Write.Limit}, Handler: DeploymentRollbackH, Request: http.Empty{}, Response: views.Service{}},
+	{Path: "/namespace/{namespace}/service/{service}/deployment/promote", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindDeployment, types.VerbUpdate), limits.Write.Limit}, Handler: DeploymentPromoteH, Request: http.Empty{}, Response: views.Service{}},
+	{Path: "/namespace/{namespace}/service/{service}/deployment/abort", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindDeployment, types.VerbUpdate), limits.Write.Limit}, Handler: DeploymentAbortH, Request: http.Empty{}, Response: views.Service{}},
+	{Path: "/namespace/{namespace}/service/{service}/deployment/{deployment}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindDeployment, types.VerbGet), limits.Read.Limit}, Handler: DeploymentInfoH, Response: views.Deployment{}},
+	{Path: "/namespace/{namespace}/service/{service}/deployment/{deployment}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindDeployment, types.VerbUpdate), limits.Write.Limit}, Handler: DeploymentUpdateH, Request: request.DeploymentUpdateOptions{}, Response: views.Deployment{}},
 }
@@ -25,7 +25,9 @@ import (
 	"net/http"
 
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/log"
@@ -104,6 +106,37 @@ func DiscoveryListH(w http.ResponseWriter, r *http.Request) {
 	// ---
 	// produces:
 	// - application/json
+	// parameters:
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
 	// responses:
 	//   '200':
 	//     description: Discovery list response
@@ -114,12 +147,28 @@ func DiscoveryListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> get discoverys list", logPrefix)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	var (
 		im = distribution.NewDiscoveryModel(r.Context(), envs.Get().GetStorage())
 	)
 
-	discoverys, err := im.List()
+	if opts.Watch {
+		discoveryWatch(w, r, opts.GetWatchOptions())
+		return
+	}
+
+	discoverys, err := im.Select(opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> get discoverys list err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -132,6 +181,10 @@ func DiscoveryListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if discoverys.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, discoverys.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
 		log.Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -139,6 +192,23 @@ func DiscoveryListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// discoveryWatch - stream discoveries changes as chunked json events
+func discoveryWatch(w http.ResponseWriter, r *http.Request, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch discoveries", logPrefix)
+
+	var (
+		m  = distribution.NewDiscoveryModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.DiscoveryEvent)
+	)
+
+	watch.Stream(w, "discoveries", ch, func() error {
+		return m.WatchSelect(ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Discovery().New(data.(*types.Discovery))
+	})
+}
+
 func DiscoveryConnectH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation GET /cluster/discovery/{discovery} discovery discoveryInfo
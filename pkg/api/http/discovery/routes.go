@@ -19,14 +19,18 @@
 package discovery
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
-	{Path: "/discovery", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: DiscoveryListH},
-	{Path: "/discovery/{discovery}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: DiscoveryInfoH},
-	{Path: "/discovery/{discovery}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: DiscoveryConnectH},
-	{Path: "/discovery/{discovery}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: DiscoveryRemoveH},
-	{Path: "/discovery/{discovery}/status", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: DiscoverySetStatusH},
+	{Path: "/discovery", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindDiscovery, types.VerbList), limits.List}, Handler: DiscoveryListH, Response: views.DiscoveryList{}},
+	{Path: "/discovery/{discovery}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindDiscovery, types.VerbGet), limits.Read.Limit}, Handler: DiscoveryInfoH, Response: views.Discovery{}},
+	{Path: "/discovery/{discovery}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindDiscovery, types.VerbUpdate), limits.System.Limit}, Handler: DiscoveryConnectH, Request: request.DiscoveryConnectOptions{}, Response: http.Empty{}},
+	{Path: "/discovery/{discovery}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindDiscovery, types.VerbDelete), limits.Write.Limit}, Handler: DiscoveryRemoveH, Response: http.Empty{}},
+	{Path: "/discovery/{discovery}/status", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindDiscovery, types.VerbUpdate), limits.System.Limit}, Handler: DiscoverySetStatusH, Request: request.DiscoveryStatusOptions{}, Response: views.DiscoveryManifest{}},
 }
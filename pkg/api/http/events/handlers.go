@@ -19,16 +19,19 @@
 package events
 
 import (
+	"context"
 	"net/http"
 
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/ws"
 )
 
 const (
@@ -39,9 +42,7 @@ const (
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+	CheckOrigin:     ws.CheckOrigin,
 }
 
 type Event struct {
@@ -101,6 +102,10 @@ func EventSubscribeH(w http.ResponseWriter, r *http.Request) {
 				return
 			case e := <-clusterEvents:
 
+				if !eventAllowed(r.Context(), types.EmptyString, types.KindCluster) {
+					continue
+				}
+
 				var data interface{}
 				if e.Data == nil {
 					data = nil
@@ -120,6 +125,15 @@ func EventSubscribeH(w http.ResponseWriter, r *http.Request) {
 				}
 			case e := <-serviceEvents:
 
+				var namespace string
+				if e.Data != nil {
+					namespace = e.Data.Meta.Namespace
+				}
+
+				if !eventAllowed(r.Context(), namespace, types.KindService) {
+					continue
+				}
+
 				var data interface{}
 				if e.Data == nil {
 					data = nil
@@ -139,6 +153,15 @@ func EventSubscribeH(w http.ResponseWriter, r *http.Request) {
 				}
 			case e := <-namespaceEvents:
 
+				var namespace string
+				if e.Data != nil {
+					namespace = e.Data.Meta.Name
+				}
+
+				if !eventAllowed(r.Context(), namespace, types.KindNamespace) {
+					continue
+				}
+
 				var data interface{}
 				if e.Data == nil {
 					data = nil
@@ -176,3 +199,14 @@ func EventSubscribeH(w http.ResponseWriter, r *http.Request) {
 
 	<-done
 }
+
+// eventAllowed - check if request user can read resources of event namespace,
+// events without data are sent only to users granted on all namespaces
+func eventAllowed(ctx context.Context, namespace, kind string) bool {
+	allowed, err := rbac.Allowed(ctx, namespace, kind, types.VerbGet)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:subscribe:> check event access err: %s", logPrefix, err.Error())
+		return false
+	}
+	return allowed
+}
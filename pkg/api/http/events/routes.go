@@ -19,11 +19,14 @@
 package events
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
 	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
 	// Events handlers
-	{Path: "/events", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Context}, Handler: EventSubscribeH},
+	{Path: "/events", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Context, rbac.Authorize(types.KindCluster, types.VerbGet), limits.Stream.Limit}, Handler: EventSubscribeH, Response: http.Raw{}},
 }
@@ -20,6 +20,10 @@ package http
 
 import (
 	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/auditor"
+	"github.com/lastbackend/lastbackend/pkg/api/http/apply"
+	"github.com/lastbackend/lastbackend/pkg/api/http/audit"
+	"github.com/lastbackend/lastbackend/pkg/api/http/autoscaler"
 	"github.com/lastbackend/lastbackend/pkg/api/http/cluster"
 	"github.com/lastbackend/lastbackend/pkg/api/http/config"
 	"github.com/lastbackend/lastbackend/pkg/api/http/deployment"
@@ -28,9 +32,11 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/api/http/ingress"
 	"github.com/lastbackend/lastbackend/pkg/api/http/namespace"
 	"github.com/lastbackend/lastbackend/pkg/api/http/node"
+	"github.com/lastbackend/lastbackend/pkg/api/http/role"
 	"github.com/lastbackend/lastbackend/pkg/api/http/route"
 	"github.com/lastbackend/lastbackend/pkg/api/http/secret"
 	"github.com/lastbackend/lastbackend/pkg/api/http/service"
+	"github.com/lastbackend/lastbackend/pkg/api/http/user"
 	"github.com/lastbackend/lastbackend/pkg/api/http/volume"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
@@ -66,6 +72,10 @@ func init() {
 	AddRoutes(node.Routes)
 	AddRoutes(ingress.Routes)
 
+	// Access
+	AddRoutes(user.Routes)
+	AddRoutes(role.Routes)
+	AddRoutes(audit.Routes)
 
 	// Namespace
 	AddRoutes(namespace.Routes)
@@ -74,12 +84,17 @@ func init() {
 	AddRoutes(route.Routes)
 	AddRoutes(service.Routes)
 	AddRoutes(deployment.Routes)
+	AddRoutes(autoscaler.Routes)
 	AddRoutes(volume.Routes)
 	AddRoutes(ingress.Routes)
 	AddRoutes(discovery.Routes)
+	AddRoutes(apply.Routes)
 
 	// events
 	AddRoutes(events.Routes)
+
+	// api schema
+	AddRoutes(OpenAPIRoutes)
 }
 
 func Listen(host string, port int, opts *HttpOpts) error {
@@ -101,7 +116,12 @@ func Listen(host string, port int, opts *HttpOpts) error {
 
 	for _, route := range Routes {
 		log.V(logLevel).Debugf("%s:> init route: %s", logPrefix, route.Path)
-		r.Handle(route.Path, http.Handle(route.Handler, route.Middleware...)).Methods(route.Method)
+
+		// audit recorder is the outermost middleware to catch denied requests too
+		middleware := make([]http.Middleware, 0, len(route.Middleware)+1)
+		middleware = append(middleware, route.Middleware...)
+		middleware = append(middleware, auditor.Record)
+		r.Handle(route.Path, http.Handle(route.Handler, middleware...)).Methods(route.Method)
 	}
 
 	if opts.Insecure {
@@ -26,7 +26,9 @@ import (
 	"strings"
 
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/log"
@@ -105,6 +107,37 @@ func IngressListH(w http.ResponseWriter, r *http.Request) {
 	// ---
 	// produces:
 	// - application/json
+	// parameters:
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
 	// responses:
 	//   '200':
 	//     description: Ingress list response
@@ -115,12 +148,28 @@ func IngressListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> get ingresss list", logPrefix)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	var (
 		im = distribution.NewIngressModel(r.Context(), envs.Get().GetStorage())
 	)
 
-	ingresss, err := im.List()
+	if opts.Watch {
+		ingressWatch(w, r, opts.GetWatchOptions())
+		return
+	}
+
+	ingresss, err := im.Select(opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> get ingresss list err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -133,6 +182,10 @@ func IngressListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if ingresss.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, ingresss.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
 		log.Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -140,6 +193,23 @@ func IngressListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ingressWatch - stream ingresses changes as chunked json events
+func ingressWatch(w http.ResponseWriter, r *http.Request, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch ingresses", logPrefix)
+
+	var (
+		m  = distribution.NewIngressModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.IngressEvent)
+	)
+
+	watch.Stream(w, "ingresses", ch, func() error {
+		return m.WatchSelect(ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Ingress().New(data.(*types.Ingress))
+	})
+}
+
 func IngressConnectH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation GET /cluster/ingress/{ingress} ingress ingressInfo
@@ -351,7 +421,25 @@ func IngressSetStatusH(w http.ResponseWriter, r *http.Request) {
 		route.Status.State = s.State
 		route.Status.Message = s.Message
 
-		if _, err := rm.Set(route); err != nil {
+		err = distribution.RetryOnConflict(func() error {
+			_, err := rm.Set(route)
+			return err
+		}, func() error {
+
+			latest, err := rm.Get(keys[0], keys[1])
+			if err != nil {
+				return err
+			}
+			if latest == nil {
+				return errors.Storage().NewErrEntityNotFound()
+			}
+
+			latest.Status.State = s.State
+			latest.Status.Message = s.Message
+			route = latest
+			return nil
+		})
+		if err != nil {
 			log.V(logLevel).Errorf("%s:setroutestatus:> update route err: %s", logPrefix, err.Error())
 			errors.HTTP.InternalServerError(w)
 			return
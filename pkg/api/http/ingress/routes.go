@@ -19,14 +19,18 @@
 package ingress
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
-	{Path: "/ingress", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: IngressListH},
-	{Path: "/ingress/{ingress}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: IngressInfoH},
-	{Path: "/ingress/{ingress}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: IngressConnectH},
-	{Path: "/ingress/{ingress}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: IngressRemoveH},
-	{Path: "/ingress/{ingress}/status", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: IngressSetStatusH},
+	{Path: "/ingress", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindIngress, types.VerbList), limits.List}, Handler: IngressListH, Response: views.IngressList{}},
+	{Path: "/ingress/{ingress}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindIngress, types.VerbGet), limits.Read.Limit}, Handler: IngressInfoH, Response: views.Ingress{}},
+	{Path: "/ingress/{ingress}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindIngress, types.VerbUpdate), limits.System.Limit}, Handler: IngressConnectH, Request: request.IngressConnectOptions{}, Response: http.Empty{}},
+	{Path: "/ingress/{ingress}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindIngress, types.VerbDelete), limits.Write.Limit}, Handler: IngressRemoveH, Response: http.Empty{}},
+	{Path: "/ingress/{ingress}/status", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindIngress, types.VerbUpdate), limits.System.Limit}, Handler: IngressSetStatusH, Request: request.IngressStatusOptions{}, Response: views.IngressManifest{}},
 }
@@ -23,7 +23,9 @@ import (
 	"net/http"
 
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/log"
@@ -44,6 +46,37 @@ func NamespaceListH(w http.ResponseWriter, r *http.Request) {
 	// ---
 	// produces:
 	// - application/json
+	// parameters:
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
 	// responses:
 	//   '200':
 	//     description: Namespace list response
@@ -54,12 +87,28 @@ func NamespaceListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> get namespace list", logPrefix)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	var (
 		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
 	)
 
-	items, err := nsm.List()
+	if opts.Watch {
+		namespaceWatch(w, r, opts.GetWatchOptions())
+		return
+	}
+
+	items, err := nsm.Select(opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> find p list err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -72,6 +121,10 @@ func NamespaceListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if items.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, items.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err = w.Write(response); err != nil {
 		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -79,6 +132,23 @@ func NamespaceListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// namespaceWatch - stream namespaces changes as chunked json events
+func namespaceWatch(w http.ResponseWriter, r *http.Request, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch namespaces", logPrefix)
+
+	var (
+		m  = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.NamespaceEvent)
+	)
+
+	watch.Stream(w, "namespaces", ch, func() error {
+		return m.WatchSelect(ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Namespace().New(data.(*types.Namespace))
+	})
+}
+
 func NamespaceInfoH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation GET /namespace/{namespace} namespace namespaceInfo
@@ -278,6 +348,10 @@ func NamespaceUpdateH(w http.ResponseWriter, r *http.Request) {
 
 	if err := nsm.Update(ns); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update namespace `%s` err: %s", logPrefix, nid, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("namespace").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
 		return
 	}
@@ -283,6 +283,90 @@ func createNamespaceManifest(name, description string, resources *request.Namesp
 	return opts
 }
 
+// Testing NamespaceListH handler in watch mode
+func TestNamespaceWatch(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	clear := func() {
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	clear()
+	defer clear()
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns1.Meta.Labels = map[string]string{"app": "web"}
+	ns2 := getNamespaceAsset("test", "")
+	ns2.Meta.Labels = map[string]string{"app": "db"}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/namespace", namespace.NamespaceListH)
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/namespace?watch=true&labelSelector=app%3Dweb")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer res.Body.Close()
+
+	if !assert.Equal(t, http.StatusOK, res.StatusCode, "status code not equal") {
+		return
+	}
+
+	err = stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns2.Meta.Name), ns2, nil)
+	assert.NoError(t, err)
+
+	err = stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+	assert.NoError(t, err)
+
+	err = stg.Del(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name))
+	assert.NoError(t, err)
+
+	decoder := json.NewDecoder(res.Body)
+
+	for _, want := range []string{views.WatchEventAdded, views.WatchEventDeleted} {
+
+		e := struct {
+			Type     string          `json:"type"`
+			Revision int64           `json:"revision"`
+			Data     views.Namespace `json:"data"`
+		}{}
+
+		if !assert.NoError(t, decoder.Decode(&e), "decode event") {
+			return
+		}
+
+		assert.Equal(t, want, e.Type, "event type not equal")
+		assert.Equal(t, ns1.Meta.Name, e.Data.Meta.Name, "namespace name not equal")
+		assert.NotZero(t, e.Revision, "event revision should be set")
+	}
+
+	// watch is resumed from revision with items changed since it
+	res2, err := http.Get(srv.URL + "/namespace?watch=true&revision=1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer res2.Body.Close()
+
+	e := new(views.WatchEvent)
+	if assert.NoError(t, json.NewDecoder(res2.Body).Decode(e), "decode event") {
+		assert.Equal(t, views.WatchEventAdded, e.Type, "event type not equal")
+		assert.Equal(t, ns2.Meta.Name, e.Data.(map[string]interface{})["meta"].(map[string]interface{})["name"], "namespace name not equal")
+	}
+
+	// pagination is not allowed with watch
+	res3, err := http.Get(srv.URL + "/namespace?watch=true&limit=1")
+	if assert.NoError(t, err) {
+		res3.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res3.StatusCode, "status code not equal")
+	}
+}
+
 // Testing NamespaceCreateH handler
 func TestNamespaceCreate(t *testing.T) {
 
@@ -19,15 +19,19 @@
 package namespace
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
 	// Namespace handlers
-	{Path: "/namespace", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NamespaceListH},
-	{Path: "/namespace", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NamespaceCreateH},
-	{Path: "/namespace/{namespace}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NamespaceInfoH},
-	{Path: "/namespace/{namespace}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NamespaceUpdateH},
-	{Path: "/namespace/{namespace}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NamespaceRemoveH},
+	{Path: "/namespace", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindNamespace, types.VerbList), limits.List}, Handler: NamespaceListH, Response: views.NamespaceList{}},
+	{Path: "/namespace", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindNamespace, types.VerbCreate), limits.Write.Limit}, Handler: NamespaceCreateH, Request: request.NamespaceManifest{}, Response: views.Namespace{}},
+	{Path: "/namespace/{namespace}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindNamespace, types.VerbGet), limits.Read.Limit}, Handler: NamespaceInfoH, Response: views.Namespace{}},
+	{Path: "/namespace/{namespace}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindNamespace, types.VerbUpdate), limits.Write.Limit}, Handler: NamespaceUpdateH, Request: request.NamespaceManifest{}, Response: views.Namespace{}},
+	{Path: "/namespace/{namespace}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindNamespace, types.VerbDelete), limits.Write.Limit}, Handler: NamespaceRemoveH, Response: http.Empty{}},
 }
@@ -25,8 +25,10 @@ import (
 	"strings"
 
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
@@ -173,6 +175,37 @@ func NodeListH(w http.ResponseWriter, r *http.Request) {
 	// ---
 	// produces:
 	// - application/json
+	// parameters:
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
 	// responses:
 	//   '200':
 	//     description: Node list response
@@ -183,12 +216,28 @@ func NodeListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> get nodes list", logPrefix)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	var (
 		nm = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
 	)
 
-	nodes, err := nm.List()
+	if opts.Watch {
+		nodeWatch(w, r, opts.GetWatchOptions())
+		return
+	}
+
+	nodes, err := nm.Select(opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> get nodes list err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -201,6 +250,10 @@ func NodeListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if nodes.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, nodes.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
 		log.Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -208,6 +261,23 @@ func NodeListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// nodeWatch - stream nodes changes as chunked json events
+func nodeWatch(w http.ResponseWriter, r *http.Request, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch nodes", logPrefix)
+
+	var (
+		m  = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.NodeEvent)
+	)
+
+	watch.Stream(w, "nodes", ch, func() error {
+		return m.WatchSelect(ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Node().New(data.(*types.Node))
+	})
+}
+
 func NodeSetMetaH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation PUT /cluster/node/{node}/meta node nodeSetMeta
@@ -535,15 +605,35 @@ func NodeSetStatusH(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		pod.Status.State = s.State
-		pod.Status.Status = s.Status
-		pod.Status.Running = s.Running
-		pod.Status.Message = s.Message
-		pod.Status.Containers = s.Containers
-		pod.Status.Network = s.Network
-		pod.Status.Steps = s.Steps
+		setPodStatus := func(pod *types.Pod) {
+			pod.Status.State = s.State
+			pod.Status.Status = s.Status
+			pod.Status.Running = s.Running
+			pod.Status.Message = s.Message
+			pod.Status.Containers = s.Containers
+			pod.Status.Network = s.Network
+			pod.Status.Steps = s.Steps
+		}
+
+		setPodStatus(pod)
+
+		err = distribution.RetryOnConflict(func() error {
+			return pm.Update(pod)
+		}, func() error {
+
+			latest, err := pm.Get(keys[0], keys[1], keys[2], keys[3])
+			if err != nil {
+				return err
+			}
+			if latest == nil {
+				return errors.Storage().NewErrEntityNotFound()
+			}
 
-		if err := pm.Update(pod); err != nil {
+			setPodStatus(latest)
+			pod = latest
+			return nil
+		})
+		if err != nil {
 			log.V(logLevel).Errorf("%s:setpodstatus:> update pod err: %s", logPrefix, err.Error())
 			errors.HTTP.InternalServerError(w)
 			return
@@ -580,7 +670,24 @@ func NodeSetStatusH(w http.ResponseWriter, r *http.Request) {
 		volume.Status.State = s.State
 		volume.Status.Message = s.Message
 
-		if err := vm.Update(volume); err != nil {
+		err = distribution.RetryOnConflict(func() error {
+			return vm.Update(volume)
+		}, func() error {
+
+			latest, err := vm.Get(keys[0], keys[1])
+			if err != nil {
+				return err
+			}
+			if latest == nil {
+				return errors.Storage().NewErrEntityNotFound()
+			}
+
+			latest.Status.State = s.State
+			latest.Status.Message = s.Message
+			volume = latest
+			return nil
+		})
+		if err != nil {
 			log.V(logLevel).Errorf("%s:set volume status:> update pod err: %s", logPrefix, err.Error())
 			errors.HTTP.InternalServerError(w)
 			return
@@ -669,6 +776,193 @@ func NodeRemoveH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func NodeCordonH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /cluster/node/{node}/cordon node nodeCordon
+	//
+	// Mark node as unschedulable
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: node
+	//     in: path
+	//     description: node id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Node cordoned
+	//     schema:
+	//       "$ref": "#/definitions/views_node"
+	//   '404':
+	//     description: Node not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:cordon:> cordon node", logPrefix)
+
+	setNodeSpec(w, r, "cordon", func(n *types.Node) {
+		n.Spec.Unschedulable = true
+	})
+}
+
+func NodeUncordonH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /cluster/node/{node}/uncordon node nodeUncordon
+	//
+	// Mark node as schedulable and stop node drain
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: node
+	//     in: path
+	//     description: node id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Node uncordoned
+	//     schema:
+	//       "$ref": "#/definitions/views_node"
+	//   '404':
+	//     description: Node not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:uncordon:> uncordon node", logPrefix)
+
+	setNodeSpec(w, r, "uncordon", func(n *types.Node) {
+		n.Spec.Unschedulable = false
+		n.Spec.Drain = false
+	})
+}
+
+func NodeDrainH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /cluster/node/{node}/drain node nodeDrain
+	//
+	// Cordon node and reschedule all node pods to other nodes
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: node
+	//     in: path
+	//     description: node id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Node drain started
+	//     schema:
+	//       "$ref": "#/definitions/views_node"
+	//   '404':
+	//     description: Node not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:drain:> drain node", logPrefix)
+
+	setNodeSpec(w, r, "drain", func(n *types.Node) {
+		n.Spec.Unschedulable = true
+		n.Spec.Drain = true
+	})
+}
+
+func NodeSetTaintsH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /cluster/node/{node}/taints node nodeSetTaints
+	//
+	// Set node taints
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: node
+	//     in: path
+	//     description: node id
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_node_taints"
+	// responses:
+	//   '200':
+	//     description: Successfully set node taints
+	//     schema:
+	//       "$ref": "#/definitions/views_node"
+	//   '400':
+	//     description: Bad request
+	//   '404':
+	//     description: Node not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:settaints:> set node taints", logPrefix)
+
+	// request body struct
+	opts := new(request.NodeTaintsOptions)
+	if err := opts.DecodeAndValidate(r.Body); err != nil {
+		log.V(logLevel).Errorf("%s:settaints:> validation incoming data err: %s", logPrefix, err.Err())
+		err.Http(w)
+		return
+	}
+
+	setNodeSpec(w, r, "settaints", func(n *types.Node) {
+		n.Spec.Taints = opts.Taints
+	})
+}
+
+// setNodeSpec applies spec changes to node from request path and writes node view
+func setNodeSpec(w http.ResponseWriter, r *http.Request, action string, fn func(n *types.Node)) {
+
+	var (
+		nm  = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
+		nid = utils.Vars(r)["node"]
+	)
+
+	n, err := nm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:%s:> get node err: %s", logPrefix, action, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if n == nil {
+		log.V(logLevel).Warnf("%s:%s:> node `%s` not found", logPrefix, action, nid)
+		errors.New("node").NotFound().Http(w)
+		return
+	}
+
+	fn(n)
+
+	if err := nm.Set(n); err != nil {
+		log.V(logLevel).Errorf("%s:%s:> update node `%s` err: %s", logPrefix, action, nid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Node().New(n).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:%s:> convert struct to json err: %s", logPrefix, action, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:%s:> write response err: %s", logPrefix, action, err.Error())
+		return
+	}
+}
+
 func getNodeSpec(ctx context.Context, n *types.Node) (*types.NodeManifest, error) {
 
 	var (
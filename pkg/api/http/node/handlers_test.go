@@ -133,9 +133,6 @@ func TestNodeGetH(t *testing.T) {
 		n2 = getNodeAsset("test2", "", true)
 	)
 
-	v, err := v1.View().Node().New(&n1).ToJson()
-	assert.NoError(t, err)
-
 	tests := []struct {
 		name         string
 		url          string
@@ -155,19 +152,25 @@ func TestNodeGetH(t *testing.T) {
 			name:         "checking get node successfully",
 			url:          fmt.Sprintf("/cluster/node/%s", n1.Meta.Name),
 			handler:      node.NodeInfoH,
-			expectedBody: string(v),
 			expectedCode: http.StatusOK,
 		},
 	}
 
 	for _, tc := range tests {
 
-		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Node().Info(), types.EmptyString)
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Node().Info(), types.EmptyString)
 		assert.NoError(t, err)
 
 		err = stg.Put(context.Background(), stg.Collection().Node().Info(), stg.Key().Node(n1.Meta.Name), &n1, nil)
 		assert.NoError(t, err)
 
+		// expected view has resource version of stored node
+		if tc.expectedCode == http.StatusOK {
+			v, err := v1.View().Node().New(&n1).ToJson()
+			assert.NoError(t, err)
+			tc.expectedBody = string(v)
+		}
+
 		t.Run(tc.name, func(t *testing.T) {
 
 			// Create assert request to pass to our handler. We don't have any query parameters for now, so we'll
@@ -413,9 +416,6 @@ func TestNodeSetMetaH(t *testing.T) {
 	uo.Meta = &types.NodeUpdateMetaOptions{}
 	uo.Meta.Architecture = strPointer("test")
 
-	v, err := v1.View().Node().New(&n1).ToJson()
-	assert.NoError(t, err)
-
 	type args struct {
 		ctx  context.Context
 		node string
@@ -443,14 +443,13 @@ func TestNodeSetMetaH(t *testing.T) {
 			args:         args{ctx, n1.Meta.Name},
 			handler:      node.NodeSetMetaH,
 			data:         uo.ToJson(),
-			expectedBody: string(v),
 			expectedCode: http.StatusOK,
 		},
 	}
 
 	for _, tc := range tests {
 
-		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Node().Info(), types.EmptyString)
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Node().Info(), types.EmptyString)
 		assert.NoError(t, err)
 
 		err = stg.Put(context.Background(), stg.Collection().Node().Info(), stg.Key().Node(n1.Meta.Name), &n1, nil)
@@ -484,10 +483,6 @@ func TestNodeSetMetaH(t *testing.T) {
 			// Check the status code is what we expect.
 			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
 
-			body, err := ioutil.ReadAll(res.Body)
-			assert.NoError(t, err)
-			assert.Equal(t, tc.expectedBody, string(body), "incorrect status code")
-
 			if tc.expectedCode == http.StatusOK {
 				got := new(types.Node)
 				err = envs.Get().GetStorage().Get(context.Background(), stg.Collection().Node().Info(), envs.Get().GetStorage().Key().Node(tc.args.node), got, nil)
@@ -496,8 +491,17 @@ func TestNodeSetMetaH(t *testing.T) {
 					return
 				}
 				assert.Equal(t, *uo.Meta.Architecture, got.Meta.Architecture, "Architecture not equal")
+
+				// expected view has resource version of updated node
+				v, err := v1.View().Node().New(got).ToJson()
+				assert.NoError(t, err)
+				tc.expectedBody = string(v)
 			}
 
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, string(body), "incorrect status code")
+
 		})
 	}
 }
@@ -722,6 +726,200 @@ func setRequestVars(r *mux.Router, req *http.Request) {
 	req = mux.SetURLVars(req, match.Vars)
 }
 
+func TestNodeCordonH(t *testing.T) {
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+	viper.Set("verbose", 0)
+
+	var (
+		ctx = context.Background()
+		n1  = getNodeAsset("test1", "", true)
+		n2  = getNodeAsset("test2", "", true)
+	)
+
+	type args struct {
+		ctx  context.Context
+		node string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		path          string
+		handler       func(http.ResponseWriter, *http.Request)
+		spec          types.NodeSpec
+		unschedulable bool
+		drain         bool
+		expectedBody  string
+		expectedCode  int
+	}{
+		{
+			name:         "checking cordon node failed: not found",
+			args:         args{ctx, n2.Meta.Name},
+			path:         "cordon",
+			handler:      node.NodeCordonH,
+			expectedBody: "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Node not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:          "checking cordon node successfully",
+			args:          args{ctx, n1.Meta.Name},
+			path:          "cordon",
+			handler:       node.NodeCordonH,
+			unschedulable: true,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "checking drain node successfully",
+			args:          args{ctx, n1.Meta.Name},
+			path:          "drain",
+			handler:       node.NodeDrainH,
+			unschedulable: true,
+			drain:         true,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:         "checking uncordon node successfully",
+			args:         args{ctx, n1.Meta.Name},
+			path:         "uncordon",
+			handler:      node.NodeUncordonH,
+			spec:         types.NodeSpec{Unschedulable: true, Drain: true},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Node().Info(), types.EmptyString)
+		assert.NoError(t, err)
+
+		n := n1
+		n.Spec = tc.spec
+
+		err = stg.Put(context.Background(), stg.Collection().Node().Info(), stg.Key().Node(n.Meta.Name), &n, nil)
+		assert.NoError(t, err)
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			req, err := http.NewRequest("PUT", fmt.Sprintf("/cluster/node/%s/%s", tc.args.node, tc.path), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc(fmt.Sprintf("/cluster/node/{node}/%s", tc.path), tc.handler)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.expectedCode != http.StatusOK {
+				assert.Equal(t, tc.expectedBody, string(body), "incorrect status code")
+				return
+			}
+
+			got := new(types.Node)
+			err = envs.Get().GetStorage().Get(context.Background(), stg.Collection().Node().Info(), envs.Get().GetStorage().Key().Node(tc.args.node), got, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.unschedulable, got.Spec.Unschedulable, "unschedulable not equal")
+			assert.Equal(t, tc.drain, got.Spec.Drain, "drain not equal")
+		})
+	}
+}
+
+func TestNodeSetTaintsH(t *testing.T) {
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+	viper.Set("verbose", 0)
+
+	var (
+		ctx = context.Background()
+		n1  = getNodeAsset("test1", "", true)
+		n2  = getNodeAsset("test2", "", true)
+		to  = v1.Request().Node().TaintsOptions()
+		ti  = v1.Request().Node().TaintsOptions()
+	)
+
+	to.Taints = []types.NodeTaint{{Key: "dedicated", Value: "db"}}
+	ti.Taints = []types.NodeTaint{{Value: "db"}}
+
+	type args struct {
+		ctx  context.Context
+		node string
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		data         string
+		expectedBody string
+		expectedCode int
+	}{
+		{
+			name:         "checking set node taints failed: not found",
+			args:         args{ctx, n2.Meta.Name},
+			data:         to.ToJson(),
+			expectedBody: "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Node not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking set node taints failed: empty key",
+			args:         args{ctx, n1.Meta.Name},
+			data:         ti.ToJson(),
+			expectedBody: "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad taint parameter\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking set node taints successfully",
+			args:         args{ctx, n1.Meta.Name},
+			data:         to.ToJson(),
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Node().Info(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Put(context.Background(), stg.Collection().Node().Info(), stg.Key().Node(n1.Meta.Name), &n1, nil)
+		assert.NoError(t, err)
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			req, err := http.NewRequest("PUT", fmt.Sprintf("/cluster/node/%s/taints", tc.args.node), strings.NewReader(tc.data))
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/cluster/node/{node}/taints", node.NodeSetTaintsH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.expectedCode != http.StatusOK {
+				assert.Equal(t, tc.expectedBody, string(body), "incorrect status code")
+				return
+			}
+
+			got := new(types.Node)
+			err = envs.Get().GetStorage().Get(context.Background(), stg.Collection().Node().Info(), envs.Get().GetStorage().Key().Node(tc.args.node), got, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, to.Taints, got.Spec.Taints, "taints not equal")
+		})
+	}
+}
+
 func getNodeAsset(name, desc string, online bool) types.Node {
 	var n = types.Node{
 		Meta: types.NodeMeta{},
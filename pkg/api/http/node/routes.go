@@ -19,16 +19,24 @@
 package node
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
-	{Path: "/cluster/node", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeListH},
-	{Path: "/cluster/node/{node}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeInfoH},
-	{Path: "/cluster/node/{node}/spec", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeGetSpecH},
-	{Path: "/cluster/node/{node}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeRemoveH},
-	{Path: "/cluster/node/{node}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeConnectH},
-	{Path: "/cluster/node/{node}/meta", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeSetMetaH},
-	{Path: "/cluster/node/{node}/status", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeSetStatusH},
+	{Path: "/cluster/node", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbList), limits.List}, Handler: NodeListH, Response: views.NodeList{}},
+	{Path: "/cluster/node/{node}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbGet), limits.Read.Limit}, Handler: NodeInfoH, Response: views.Node{}},
+	{Path: "/cluster/node/{node}/spec", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbGet), limits.System.Limit}, Handler: NodeGetSpecH, Response: views.NodeManifest{}},
+	{Path: "/cluster/node/{node}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbDelete), limits.Write.Limit}, Handler: NodeRemoveH, Response: http.Empty{}},
+	{Path: "/cluster/node/{node}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbUpdate), limits.System.Limit}, Handler: NodeConnectH, Request: request.NodeConnectOptions{}, Response: http.Empty{}},
+	{Path: "/cluster/node/{node}/meta", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbUpdate), limits.System.Limit}, Handler: NodeSetMetaH, Request: request.NodeMetaOptions{}, Response: views.Node{}},
+	{Path: "/cluster/node/{node}/status", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbUpdate), limits.System.Limit}, Handler: NodeSetStatusH, Request: request.NodeStatusOptions{}, Response: views.NodeManifest{}},
+	{Path: "/cluster/node/{node}/cordon", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbUpdate), limits.Write.Limit}, Handler: NodeCordonH, Request: http.Empty{}, Response: views.Node{}},
+	{Path: "/cluster/node/{node}/uncordon", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbUpdate), limits.Write.Limit}, Handler: NodeUncordonH, Request: http.Empty{}, Response: views.Node{}},
+	{Path: "/cluster/node/{node}/drain", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbUpdate), limits.Write.Limit}, Handler: NodeDrainH, Request: http.Empty{}, Response: views.Node{}},
+	{Path: "/cluster/node/{node}/taints", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindNode, types.VerbUpdate), limits.Write.Limit}, Handler: NodeSetTaintsH, Request: request.NodeTaintsOptions{}, Response: views.Node{}},
 }
@@ -0,0 +1,77 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	h "github.com/lastbackend/lastbackend/pkg/util/http"
+	"github.com/lastbackend/lastbackend/pkg/util/http/openapi"
+)
+
+var OpenAPIRoutes = []h.Route{
+	{Path: "/openapi.json", Method: http.MethodGet, Middleware: []h.Middleware{limits.Read.Limit}, Handler: OpenAPIH, Response: openapi.Document{}},
+}
+
+var schema struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// OpenAPIH - serve OpenAPI document built from registered routes
+func OpenAPIH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /openapi.json openapi openapi
+	//
+	// Shows OpenAPI document of api
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     description: OpenAPI document
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:openapi:> get openapi document", logPrefix)
+
+	schema.once.Do(func() {
+		doc := openapi.Build(openapi.Info{Title: "Last.Backend API", Version: "v1"}, Routes)
+		schema.data, schema.err = json.Marshal(doc)
+	})
+
+	if schema.err != nil {
+		log.V(logLevel).Errorf("%s:openapi:> convert struct to json err: %s", logPrefix, schema.err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(schema.data); err != nil {
+		log.V(logLevel).Errorf("%s:openapi:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
@@ -0,0 +1,81 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "github.com/lastbackend/lastbackend/pkg/api/http"
+	"github.com/lastbackend/lastbackend/pkg/util/http/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// Testing all registered routes describe request and response schema
+func TestRoutesSchema(t *testing.T) {
+	assert.NoError(t, openapi.Validate(api.Routes), "route schema should be set in routes list")
+}
+
+// Testing OpenAPIH handler
+func TestOpenAPI(t *testing.T) {
+
+	req, err := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	assert.NoError(t, err)
+
+	res := httptest.NewRecorder()
+	api.OpenAPIH(res, req)
+
+	if !assert.Equal(t, http.StatusOK, res.Code, "status code not equal") {
+		return
+	}
+
+	doc := new(openapi.Document)
+	if !assert.NoError(t, json.Unmarshal(res.Body.Bytes(), doc)) {
+		return
+	}
+
+	assert.Equal(t, openapi.Version, doc.OpenAPI, "openapi version not equal")
+
+	for _, r := range api.Routes {
+
+		item, ok := doc.Paths[r.Path]
+		if !assert.True(t, ok, "path %s not found", r.Path) {
+			continue
+		}
+
+		op, ok := item[strings.ToLower(r.Method)]
+		if !assert.True(t, ok, "operation %s %s not found", r.Method, r.Path) {
+			continue
+		}
+
+		assert.NotEmpty(t, op.OperationID, "operation %s %s id is empty", r.Method, r.Path)
+		assert.Equal(t, strings.Count(r.Path, "{"), len(op.Parameters), "operation %s %s path parameters count mismatch", r.Method, r.Path)
+
+		// all referenced schemas should be described in components
+		for _, c := range op.Responses["200"].Content {
+			if c.Schema.Ref == "" {
+				continue
+			}
+			name := strings.TrimPrefix(c.Schema.Ref, "#/components/schemas/")
+			assert.Contains(t, doc.Components.Schemas, name, "operation %s %s response schema not found", r.Method, r.Path)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package role
+
+import (
+	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+)
+
+const (
+	logLevel  = 2
+	logPrefix = "api:handler:role"
+)
+
+func RoleListH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /role role roleList
+	//
+	// Shows a list of roles
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
+	// responses:
+	//   '200':
+	//     description: Role list response
+	//     schema:
+	//       "$ref": "#/definitions/views_role_list"
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:list:> get roles list", logPrefix)
+
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
+	um := distribution.NewRoleModel(r.Context(), envs.Get().GetStorage())
+
+	if opts.Watch {
+		roleWatch(w, r, opts.GetWatchOptions())
+		return
+	}
+
+	items, err := um.Select(opts.GetOptions())
+	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
+		log.V(logLevel).Errorf("%s:list:> get roles list err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Role().NewList(items).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	if items.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, items.System.Continue)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+// roleWatch - stream roles changes as chunked json events
+func roleWatch(w http.ResponseWriter, r *http.Request, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch roles", logPrefix)
+
+	var (
+		m  = distribution.NewRoleModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.RoleEvent)
+	)
+
+	watch.Stream(w, "roles", ch, func() error {
+		return m.WatchSelect(ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Role().New(data.(*types.Role))
+	})
+}
+
+func RoleInfoH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /role/{role} role roleInfo
+	//
+	// Shows an info about role
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: role
+	//     in: path
+	//     description: name of the role
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Role response
+	//     schema:
+	//       "$ref": "#/definitions/views_role"
+	//   '404':
+	//     description: Role not found
+	//   '500':
+	//     description: Internal server error
+
+	rid := utils.Vars(r)["role"]
+
+	log.V(logLevel).Debugf("%s:info:> get role `%s`", logPrefix, rid)
+
+	role, e := fetchRole(r, rid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	response, err := v1.View().Role().New(role).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:info:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func RoleCreateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /role role roleCreate
+	//
+	// Creates role
+	//
+	// ---
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_role"
+	// responses:
+	//   '200':
+	//     description: Role was successfully created
+	//     schema:
+	//       "$ref": "#/definitions/views_role"
+	//   '400':
+	//     description: Name is already in use
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:create:> create role", logPrefix)
+
+	opts := v1.Request().Role().Options()
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:create:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
+	um := distribution.NewRoleModel(r.Context(), envs.Get().GetStorage())
+
+	role, err := um.Get(opts.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> get role err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if role != nil {
+		log.V(logLevel).Warnf("%s:create:> role `%s` already exists", logPrefix, opts.Name)
+		errors.New("role").NotUnique("name").Http(w)
+		return
+	}
+
+	role = new(types.Role)
+	opts.SetRole(role)
+
+	role, err = um.Create(role)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> create role err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Role().New(role).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:create:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func RoleUpdateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /role/{role} role roleUpdate
+	//
+	// Updates role description and rules
+	//
+	// ---
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: role
+	//     in: path
+	//     description: name of the role
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_role"
+	// responses:
+	//   '200':
+	//     description: Role was successfully updated
+	//     schema:
+	//       "$ref": "#/definitions/views_role"
+	//   '400':
+	//     description: Bad request
+	//   '404':
+	//     description: Role not found
+	//   '500':
+	//     description: Internal server error
+
+	rid := utils.Vars(r)["role"]
+
+	log.V(logLevel).Debugf("%s:update:> update role `%s`", logPrefix, rid)
+
+	opts := v1.Request().Role().Options()
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:update:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
+	role, e := fetchRole(r, rid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	opts.SetRole(role)
+
+	um := distribution.NewRoleModel(r.Context(), envs.Get().GetStorage())
+	if err := um.Update(role); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update role err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("role").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Role().New(role).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:update:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:update:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func RoleRemoveH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation DELETE /role/{role} role roleRemove
+	//
+	// Removes role, bindings of removed role grant nothing
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: role
+	//     in: path
+	//     description: name of the role
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Role was successfully removed
+	//   '404':
+	//     description: Role not found
+	//   '500':
+	//     description: Internal server error
+
+	rid := utils.Vars(r)["role"]
+
+	log.V(logLevel).Debugf("%s:remove:> remove role `%s`", logPrefix, rid)
+
+	role, e := fetchRole(r, rid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	um := distribution.NewRoleModel(r.Context(), envs.Get().GetStorage())
+	if err := um.Remove(role); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove role err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte{}); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+// fetchRole - get role by name
+func fetchRole(r *http.Request, name string) (*types.Role, *errors.Err) {
+
+	um := distribution.NewRoleModel(r.Context(), envs.Get().GetStorage())
+
+	role, err := um.Get(name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get role `%s` err: %s", logPrefix, name, err.Error())
+		return nil, errors.New("role").Unknown(err)
+	}
+	if role == nil {
+		log.V(logLevel).Warnf("%s:> role `%s` not found", logPrefix, name)
+		return nil, errors.New("role").NotFound()
+	}
+
+	return role, nil
+}
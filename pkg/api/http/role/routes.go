@@ -0,0 +1,35 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package role
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/http"
+)
+
+var Routes = []http.Route{
+	{Path: "/role", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindRole, types.VerbList), limits.List}, Handler: RoleListH, Response: views.RoleList{}},
+	{Path: "/role", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindRole, types.VerbCreate), limits.Write.Limit}, Handler: RoleCreateH, Request: request.RoleOptions{}, Response: views.Role{}},
+	{Path: "/role/{role}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindRole, types.VerbGet), limits.Read.Limit}, Handler: RoleInfoH, Response: views.Role{}},
+	{Path: "/role/{role}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindRole, types.VerbUpdate), limits.Write.Limit}, Handler: RoleUpdateH, Request: request.RoleOptions{}, Response: views.Role{}},
+	{Path: "/role/{role}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindRole, types.VerbDelete), limits.Write.Limit}, Handler: RoleRemoveH, Response: http.Empty{}},
+}
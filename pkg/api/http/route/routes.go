@@ -19,15 +19,19 @@
 package route
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
 	// Route handlers
-	{Path: "/namespace/{namespace}/route", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: RouteCreateH},
-	{Path: "/namespace/{namespace}/route", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: RouteListH},
-	{Path: "/namespace/{namespace}/route/{route}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: RouteInfoH},
-	{Path: "/namespace/{namespace}/route/{route}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: RouteUpdateH},
-	{Path: "/namespace/{namespace}/route/{route}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: RouteRemoveH},
+	{Path: "/namespace/{namespace}/route", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindRoute, types.VerbCreate), limits.Write.Limit}, Handler: RouteCreateH, Request: request.RouteManifest{}, Response: views.Route{}},
+	{Path: "/namespace/{namespace}/route", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindRoute, types.VerbList), limits.List}, Handler: RouteListH, Response: views.RouteList{}},
+	{Path: "/namespace/{namespace}/route/{route}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindRoute, types.VerbGet), limits.Read.Limit}, Handler: RouteInfoH, Response: views.Route{}},
+	{Path: "/namespace/{namespace}/route/{route}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindRoute, types.VerbUpdate), limits.Write.Limit}, Handler: RouteUpdateH, Request: request.RouteManifest{}, Response: views.Route{}},
+	{Path: "/namespace/{namespace}/route/{route}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindRoute, types.VerbDelete), limits.Write.Limit}, Handler: RouteRemoveH, Response: http.Empty{}},
 }
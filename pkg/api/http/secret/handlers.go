@@ -20,7 +20,9 @@ package secret
 
 import (
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
@@ -123,6 +125,36 @@ func SecretListH(w http.ResponseWriter, r *http.Request) {
 	//     description: namespace id
 	//     required: true
 	//     type: string
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
 	// responses:
 	//   '200':
 	//     description: Secret list response
@@ -135,6 +167,13 @@ func SecretListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> get secrets list", logPrefix)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	var (
 		nid = utils.Vars(r)["namespace"]
 		nm  = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
@@ -154,8 +193,17 @@ func SecretListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items, err := rm.List(ns.Meta.Name)
+	if opts.Watch {
+		secretWatch(w, r, ns.Meta.Name, opts.GetWatchOptions())
+		return
+	}
+
+	items, err := rm.Select(ns.Meta.Name, opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> find secret list err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -168,6 +216,10 @@ func SecretListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if items.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, items.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
 		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -175,6 +227,23 @@ func SecretListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// secretWatch - stream secrets changes as chunked json events
+func secretWatch(w http.ResponseWriter, r *http.Request, namespace string, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch secrets in namespace %s", logPrefix, namespace)
+
+	var (
+		m  = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.SecretEvent)
+	)
+
+	watch.Stream(w, "secrets", ch, func() error {
+		return m.WatchSelect(namespace, ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Secret().New(data.(*types.Secret))
+	})
+}
+
 func SecretCreateH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation POST /namespace/{namespace}/secret secret secretCreate
@@ -342,10 +411,15 @@ func SecretUpdateH(w http.ResponseWriter, r *http.Request) {
 	opts.SetSecretMeta(ss)
 	opts.SetSecretSpec(ss)
 
-	ss, err = rm.Update(ss)
+	_, err = rm.Update(ss)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:update:> update secret `%s` err: %s", logPrefix, ss.Meta.SelfLink, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("secret").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
+		return
 	}
 
 	response, err := v1.View().Secret().New(ss).ToJson()
@@ -35,9 +35,12 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/encryption"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -546,6 +549,143 @@ func TestSecretRemove(t *testing.T) {
 
 }
 
+// Testing secret data encryption at rest with SecretCreateH and SecretGetH handlers
+func TestSecretEncryption(t *testing.T) {
+
+	var ctx = context.Background()
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	defer encryption.Set(nil)
+
+	ns1 := getNamespaceAsset("demo", "")
+
+	s1 := getSecretAsset(ns1, "demo")
+	s1.Spec.Data["password"] = []byte(base64.StdEncoding.EncodeToString([]byte("registry password")))
+
+	mf1, _ := getSecretManifest(s1).ToJson()
+
+	keys := map[string][]byte{"old": getKeyAsset(t), "new": getKeyAsset(t)}
+
+	old := getKeyringAsset(t, keys, "old")
+	rotated := getKeyringAsset(t, keys, "new", "old")
+
+	clear := func() {
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Secret(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	clear()
+	defer clear()
+
+	err := stg.Put(ctx, stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+	assert.NoError(t, err)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/namespace/{namespace}/secret", secret.SecretCreateH)
+	r.HandleFunc("/namespace/{namespace}/secret/{secret}", secret.SecretGetH)
+
+	serve := func(method, url, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		assert.NoError(t, err)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+		return res
+	}
+
+	stored := func() *types.Secret {
+		got := new(types.Secret)
+		err := stg.Get(ctx, stg.Collection().Secret(), stg.Key().Secret(s1.Meta.Namespace, s1.Meta.Name), got, nil)
+		assert.NoError(t, err)
+		return got
+	}
+
+	encryption.Set(old)
+
+	res := serve(http.MethodPost, fmt.Sprintf("/namespace/%s/secret", s1.Meta.Namespace), string(mf1))
+	if !assert.Equal(t, http.StatusOK, res.Code, "status code not equal") {
+		return
+	}
+
+	got := stored()
+	assert.Nil(t, got.Spec.Data, "plain secret data is stored")
+	if assert.NotNil(t, got.Spec.Envelope, "secret data is not encrypted") {
+		assert.Equal(t, "old", got.Spec.Envelope.Key)
+	}
+
+	tests := []struct {
+		name    string
+		keyring *encryption.Keyring
+		rotate  bool
+		key     string
+	}{
+		{
+			name:    "checking get secret encrypted with current key",
+			keyring: old,
+			key:     "old",
+		},
+		{
+			name:    "checking get secret encrypted with previous key",
+			keyring: rotated,
+			key:     "old",
+		},
+		{
+			name:    "checking get secret after rotation",
+			keyring: rotated,
+			rotate:  true,
+			key:     "new",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			encryption.Set(tc.keyring)
+
+			if tc.rotate {
+				count, err := distribution.NewSecretModel(ctx, stg).Rotate()
+				assert.NoError(t, err)
+				assert.Equal(t, 1, count, "rotated secrets count not equal")
+			}
+
+			if got := stored(); assert.NotNil(t, got.Spec.Envelope) {
+				assert.Equal(t, tc.key, got.Spec.Envelope.Key, "secret encryption key not equal")
+			}
+
+			res := serve(http.MethodGet, fmt.Sprintf("/namespace/%s/secret/%s", s1.Meta.Namespace, s1.Meta.Name), "")
+			if !assert.Equal(t, http.StatusOK, res.Code, "status code not equal") {
+				return
+			}
+
+			s := new(views.Secret)
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), s))
+			assert.Equal(t, v1.View().Secret().New(s1).Spec.Data, s.Spec.Data, "secret data not equal")
+		})
+	}
+}
+
+func getKeyAsset(t *testing.T) []byte {
+	key, err := crypto.Random(crypto.KeySize)
+	assert.NoError(t, err)
+	return key
+}
+
+func getKeyringAsset(t *testing.T, keys map[string][]byte, ids ...string) *encryption.Keyring {
+
+	data := "keys:\n"
+	for _, id := range ids {
+		data += fmt.Sprintf("- id: %s\n  secret: %s\n", id, base64.StdEncoding.EncodeToString(keys[id]))
+	}
+
+	k, err := encryption.Parse([]byte(data))
+	assert.NoError(t, err)
+	return k
+}
+
 func getSecretManifest(s *types.Secret) *request.SecretManifest {
 
 	smf := new(request.SecretManifest)
@@ -19,15 +19,19 @@
 package secret
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
 	// Route handlers
-	{Path: "/namespace/{namespace}/secret", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: SecretCreateH},
-	{Path: "/namespace/{namespace}/secret", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: SecretListH},
-	{Path: "/namespace/{namespace}/secret/{secret}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: SecretGetH},
-	{Path: "/namespace/{namespace}/secret/{secret}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: SecretUpdateH},
-	{Path: "/namespace/{namespace}/secret/{secret}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: SecretRemoveH},
+	{Path: "/namespace/{namespace}/secret", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindSecret, types.VerbCreate), limits.Write.Limit}, Handler: SecretCreateH, Request: request.SecretManifest{}, Response: views.Secret{}},
+	{Path: "/namespace/{namespace}/secret", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindSecret, types.VerbList), limits.List}, Handler: SecretListH, Response: views.SecretList{}},
+	{Path: "/namespace/{namespace}/secret/{secret}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindSecret, types.VerbGet), limits.Read.Limit}, Handler: SecretGetH, Response: views.Secret{}},
+	{Path: "/namespace/{namespace}/secret/{secret}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindSecret, types.VerbUpdate), limits.Write.Limit}, Handler: SecretUpdateH, Request: request.SecretManifest{}, Response: views.Secret{}},
+	{Path: "/namespace/{namespace}/secret/{secret}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindSecret, types.VerbDelete), limits.Write.Limit}, Handler: SecretRemoveH, Response: http.Empty{}},
 }
@@ -21,17 +21,25 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/lastbackend/lastbackend/pkg/api/auditor"
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+	"github.com/lastbackend/lastbackend/pkg/util/http/ws"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -55,6 +63,36 @@ func ServiceListH(w http.ResponseWriter, r *http.Request) {
 	//     description: namespace id
 	//     required: true
 	//     type: string
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
 	// responses:
 	//   '200':
 	//     description: Service list response
@@ -69,6 +107,13 @@ func ServiceListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> list services in %s", logPrefix, nid)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	var (
 		stg = envs.Get().GetStorage()
 		sm  = distribution.NewServiceModel(r.Context(), stg)
@@ -90,8 +135,17 @@ func ServiceListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items, err := sm.List(ns.Meta.Name)
+	if opts.Watch {
+		serviceWatch(w, r, ns.Meta.Name, opts.GetWatchOptions())
+		return
+	}
+
+	items, err := sm.Select(ns.Meta.Name, opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> get service list in namespace `%s` err: %s", logPrefix, ns.Meta.Name, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -118,6 +172,10 @@ func ServiceListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if items.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, items.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
 		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -125,6 +183,23 @@ func ServiceListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// serviceWatch - stream services changes as chunked json events
+func serviceWatch(w http.ResponseWriter, r *http.Request, namespace string, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch services in namespace %s", logPrefix, namespace)
+
+	var (
+		m  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.ServiceEvent)
+	)
+
+	watch.Stream(w, "services", ch, func() error {
+		return m.WatchSelect(namespace, ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Service().New(data.(*types.Service))
+	})
+}
+
 func ServiceInfoH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation GET /namespace/{namespace}/service/{service} service serviceInfo
@@ -310,26 +385,32 @@ func ServiceCreateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := ns.AllocateResources(svc.Spec.GetResourceRequest()); err != nil {
+	if err := ServiceResourcesAllocate(ns, nil, svc.Spec.GetResourceRequest()); err != nil {
 		log.V(logLevel).Errorf("%s:create:> %s", logPrefix, err.Error())
 		errors.New("service").BadRequest(err.Error()).Http(w)
 		return
-	} else {
-		if err := nm.Update(ns); err != nil {
-			log.V(logLevel).Errorf("%s:update:> update namespace err: %s", logPrefix, err.Error())
-			errors.HTTP.InternalServerError(w)
-			return
-		}
 	}
 
-	if err := checkServiceVolumes(r.Context(), svc); err != nil {
+	if err := CheckServiceVolumes(r.Context(), svc); err != nil {
 		log.V(logLevel).Errorf("%s:create:> create service err: %s", logPrefix, err.Error())
 		errors.HTTP.BadParameter(w, "volume templates")
+		return
+	}
+
+	if err := nm.Update(ns); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update namespace err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("namespace").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
 	}
 
 	srv, err := sm.Create(ns, svc)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:create:> create service err: %s", logPrefix, err.Error())
+		ServiceResourcesRollback(nm, ns, svc.Spec.GetResourceRequest(), nil)
 		errors.HTTP.InternalServerError(w)
 		return
 	}
@@ -435,38 +516,47 @@ func ServiceUpdateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	requestedResources := svc.Spec.GetResourceRequest()
-	if !resources.Equal(requestedResources) {
-		allocatedResources := ns.Status.Resources.Allocated
-		if err := ns.ReleaseResources(resources); err != nil {
-			log.V(logLevel).Errorf("%s:update:> %s", logPrefix, err.Error())
-			errors.HTTP.InternalServerError(w)
-			return
-		}
+	var (
+		requestedResources = svc.Spec.GetResourceRequest()
+		changed            = !resources.Equal(requestedResources)
+	)
 
-		if err := ns.AllocateResources(svc.Spec.GetResourceRequest()); err != nil {
-			ns.Status.Resources.Allocated = allocatedResources
+	if changed {
+		if err := ServiceResourcesAllocate(ns, &resources, requestedResources); err != nil {
 			log.V(logLevel).Errorf("%s:update:> %s", logPrefix, err.Error())
 			errors.New("service").BadRequest(err.Error()).Http(w)
 			return
-		} else {
-			if err := nm.Update(ns); err != nil {
-				log.V(logLevel).Errorf("%s:update:> update namespace err: %s", logPrefix, err.Error())
-				errors.HTTP.InternalServerError(w)
-				return
-			}
-
 		}
 	}
 
-	if err := checkServiceVolumes(r.Context(), svc); err != nil {
-		log.V(logLevel).Errorf("%s:create:> create service err: %s", logPrefix, err.Error())
+	if err := CheckServiceVolumes(r.Context(), svc); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update service err: %s", logPrefix, err.Error())
 		errors.HTTP.BadParameter(w, "volume templates")
+		return
+	}
+
+	if changed {
+		if err := nm.Update(ns); err != nil {
+			log.V(logLevel).Errorf("%s:update:> update namespace err: %s", logPrefix, err.Error())
+			if errors.Storage().IsErrEntityConflict(err) {
+				errors.New("namespace").Conflict().Http(w)
+				return
+			}
+			errors.HTTP.InternalServerError(w)
+			return
+		}
 	}
 
 	srv, err := sm.Update(svc)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:update:> update service err: %s", logPrefix, err.Error())
+		if changed {
+			ServiceResourcesRollback(nm, ns, requestedResources, &resources)
+		}
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("service").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
 		return
 	}
@@ -585,11 +675,12 @@ func ServiceLogsH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation GET /namespace/{namespace}/service/{service}/logs service serviceLogs
 	//
-	// Shows logs of the service
+	// Shows logs of the service. If deployment, pod and container are not all set,
+	// logs of all matched pods of active deployment are merged and each line is prefixed with pod and container name
 	//
 	// ---
 	// produces:
-	// - application/json
+	// - text/plain
 	// parameters:
 	//   - name: namespace
 	//     in: path
@@ -603,22 +694,49 @@ func ServiceLogsH(w http.ResponseWriter, r *http.Request) {
 	//     type: string
 	//   - name: deployment
 	//     in: query
-	//     description: deployment id
-	//     required: true
+	//     description: deployment id, active deployment is used by default
+	//     required: false
 	//     type: string
 	//   - name: pod
 	//     in: query
 	//     description: pod id
-	//     required: true
+	//     required: false
 	//     type: string
 	//   - name: container
 	//     in: query
-	//     description: container id
-	//     required: true
+	//     description: container id or name
+	//     required: false
 	//     type: string
+	//   - name: follow
+	//     in: query
+	//     description: follow logs stream
+	//     required: false
+	//     type: boolean
+	//   - name: tail
+	//     in: query
+	//     description: number of lines from the end of each container logs
+	//     required: false
+	//     type: integer
+	//   - name: since
+	//     in: query
+	//     description: show logs since relative duration (10m) or RFC3339 time
+	//     required: false
+	//     type: string
+	//   - name: timestamps
+	//     in: query
+	//     description: show timestamps
+	//     required: false
+	//     type: boolean
+	//   - name: previous
+	//     in: query
+	//     description: show logs of last terminated container
+	//     required: false
+	//     type: boolean
 	// responses:
 	//   '200':
 	//     description: Service logs received
+	//   '400':
+	//     description: Bad request
 	//   '404':
 	//     description: Namespace not found / Service not found
 	//   '500':
@@ -626,154 +744,383 @@ func ServiceLogsH(w http.ResponseWriter, r *http.Request) {
 
 	nid := utils.Vars(r)["namespace"]
 	sid := utils.Vars(r)["service"]
-	did := r.URL.Query().Get("deployment")
-	pid := r.URL.Query().Get("pod")
-	cid := r.URL.Query().Get("container")
 
 	log.V(logLevel).Debugf("%s:logs:> get logs service `%s` in namespace `%s`", logPrefix, sid, nid)
 
-	var (
-		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
-		sm  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
-		pm  = distribution.NewPodModel(r.Context(), envs.Get().GetStorage())
-		dm  = distribution.NewDeploymentModel(r.Context(), envs.Get().GetStorage())
-		nm  = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
-	)
+	opts := v1.Request().Service().LogsOptions()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:logs:> validation query params err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
 
-	ns, err := nsm.Get(nid)
-	if err != nil {
-		log.V(logLevel).Errorf("%s:logs:> get namespace", logPrefix, err.Error())
-		errors.HTTP.InternalServerError(w)
+	if opts.Deployment != "" && opts.Pod != "" && opts.Container != "" {
+
+		pod, node, e := getServicePod(r.Context(), nid, sid, opts.Deployment, opts.Pod)
+		if e != nil {
+			log.V(logLevel).Errorf("%s:logs:> get service pod err: %s", logPrefix, e.Err())
+			e.Http(w)
+			return
+		}
+
+		res, err := podLogsStream(r.Context(), node, pod, opts.Container, opts)
+		if err != nil {
+			log.V(logLevel).Errorf("%s:logs:> get pod logs err: %s", logPrefix, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+		defer res.Close()
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if _, err := io.Copy(flushWriter{w}, res); err != nil {
+			log.V(logLevel).Debugf("%s:logs:> logs stream closed with err: %s", logPrefix, err.Error())
+		}
 		return
 	}
-	if ns == nil {
-		err := errors.New("namespace not found")
-		log.V(logLevel).Errorf("%s:logs:> get namespace", logPrefix, err.Error())
-		errors.New("namespace").NotFound().Http(w)
+
+	streams, e := getServiceLogsStreams(r.Context(), nid, sid, opts)
+	if e != nil {
+		log.V(logLevel).Errorf("%s:logs:> get service logs streams err: %s", logPrefix, e.Err())
+		e.Http(w)
 		return
 	}
 
-	svc, err := sm.Get(ns.Meta.Name, sid)
-	if err != nil {
-		log.V(logLevel).Errorf("%s:logs:> get service by name `%s` err: %s", logPrefix, sid, err.Error())
-		errors.HTTP.InternalServerError(w)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	mergeLogsStreams(flushWriter{w}, streams)
+}
+
+func ServiceExecH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/service/{service}/exec service serviceExec
+	//
+	// Execute command in service pod container, command streams are attached over websocket.
+	// Each websocket message is prefixed with channel byte: 0 - stdin, 1 - stdout, 2 - stderr,
+	// 3 - command exit status, 4 - terminal resize
+	//
+	// ---
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: deployment
+	//     in: query
+	//     description: deployment id
+	//     required: true
+	//     type: string
+	//   - name: pod
+	//     in: query
+	//     description: pod id
+	//     required: true
+	//     type: string
+	//   - name: container
+	//     in: query
+	//     description: container id
+	//     required: true
+	//     type: string
+	//   - name: command
+	//     in: query
+	//     description: command with arguments, one query parameter per argument
+	//     required: true
+	//     type: array
+	//     items:
+	//       type: string
+	//     collectionFormat: multi
+	//   - name: tty
+	//     in: query
+	//     description: allocate pseudo terminal
+	//     required: false
+	//     type: boolean
+	//   - name: stdin
+	//     in: query
+	//     description: attach command input
+	//     required: false
+	//     type: boolean
+	// responses:
+	//   '101':
+	//     description: Switching protocols to websocket
+	//   '400':
+	//     description: Bad request
+	//   '404':
+	//     description: Namespace not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+
+	log.V(logLevel).Debugf("%s:exec:> exec command in service `%s` in namespace `%s`", logPrefix, sid, nid)
+
+	opts := v1.Request().Service().ExecOptions()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:exec:> validation query params err: %s", logPrefix, e.Err())
+		e.Http(w)
 		return
 	}
-	if svc == nil {
-		log.V(logLevel).Warnf("%s:logs:> service name `%s` in namespace `%s` not found", logPrefix, sid, ns.Meta.Name)
-		errors.New("service").NotFound().Http(w)
+
+	pod, node, e := getServicePod(r.Context(), nid, sid, opts.Deployment, opts.Pod)
+	if e != nil {
+		log.V(logLevel).Errorf("%s:exec:> get service pod err: %s", logPrefix, e.Err())
+		e.Http(w)
 		return
 	}
 
-	deployment, err := dm.Get(ns.Meta.Name, svc.Meta.Name, did)
+	// connect to node before upgrade to respond with http error if node is not available
+	backend, err := dialNode(node, fmt.Sprintf("/pod/%s/%s/exec", pod.Meta.SelfLink, opts.Container), opts.ToQuery())
 	if err != nil {
-		log.V(logLevel).Errorf("%s:logs:> get deployment by name err: %s", logPrefix, err.Error())
-		errors.HTTP.InternalServerError(w)
+		log.V(logLevel).Errorf("%s:exec:> connect to node exec err: %s", logPrefix, err.Error())
+		errors.HTTP.BadGateway(w)
 		return
 	}
-	if deployment == nil {
-		log.V(logLevel).Warnf("%s:logs:> deployment `%s` not found", logPrefix, pid)
-		errors.New("service").NotFound().Http(w)
+
+	client, err := ws.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:exec:> set websocket upgrade err: %s", logPrefix, err.Error())
+		backend.Close()
 		return
 	}
 
-	pod, err := pm.Get(ns.Meta.Name, svc.Meta.Name, deployment.Meta.Name, pid)
-	if err != nil {
-		log.V(logLevel).Errorf("%s:logs:> get pod by name` err: %s", logPrefix, err.Error())
-		errors.HTTP.InternalServerError(w)
+	auditor.Session(r, types.AuditSessionExec, &types.AuditSession{
+		Service:   sid,
+		Pod:       pod.Meta.Name,
+		Container: opts.Container,
+		Command:   opts.Command,
+	})
+
+	if err := ws.Proxy(client, backend); err != nil {
+		log.V(logLevel).Debugf("%s:exec:> exec stream closed with err: %s", logPrefix, err.Error())
+	}
+}
+
+func ServicePortForwardH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/service/{service}/portforward service servicePortForward
+	//
+	// Forward tcp connections to service pod port. Connections are multiplexed over websocket,
+	// each message is a frame: type byte (0 - open, 1 - data, 2 - close, 3 - error),
+	// big endian uint32 connection id and payload
+	//
+	// ---
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: deployment
+	//     in: query
+	//     description: deployment id
+	//     required: true
+	//     type: string
+	//   - name: pod
+	//     in: query
+	//     description: pod id
+	//     required: true
+	//     type: string
+	//   - name: port
+	//     in: query
+	//     description: pod port
+	//     required: true
+	//     type: integer
+	// responses:
+	//   '101':
+	//     description: Switching protocols to websocket
+	//   '400':
+	//     description: Bad request
+	//   '404':
+	//     description: Namespace not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+
+	log.V(logLevel).Debugf("%s:portforward:> forward to service `%s` in namespace `%s`", logPrefix, sid, nid)
+
+	opts := v1.Request().Service().PortForwardOptions()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:portforward:> validation query params err: %s", logPrefix, e.Err())
+		e.Http(w)
 		return
 	}
-	if pod == nil {
-		log.V(logLevel).Warnf("%s:logs:> pod `%s` not found", logPrefix, pid)
-		errors.New("service").NotFound().Http(w)
+
+	pod, node, e := getServicePod(r.Context(), nid, sid, opts.Deployment, opts.Pod)
+	if e != nil {
+		log.V(logLevel).Errorf("%s:portforward:> get service pod err: %s", logPrefix, e.Err())
+		e.Http(w)
 		return
 	}
 
-	node, err := nm.Get(pod.Meta.Node)
+	// connect to node before upgrade to respond with http error if node is not available
+	backend, err := dialNode(node, fmt.Sprintf("/pod/%s/portforward", pod.Meta.SelfLink), opts.ToQuery())
 	if err != nil {
-		log.V(logLevel).Errorf("%s:logs:> get node by name err: %s", logPrefix, err.Error())
-		errors.HTTP.InternalServerError(w)
+		log.V(logLevel).Errorf("%s:portforward:> connect to node port forward err: %s", logPrefix, err.Error())
+		errors.HTTP.BadGateway(w)
 		return
 	}
-	if node == nil {
-		log.V(logLevel).Warnf("%s:logs:> node %s not found", logPrefix, pod.Meta.Node)
-		errors.New("service").NotFound().Http(w)
+
+	client, err := ws.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:portforward:> set websocket upgrade err: %s", logPrefix, err.Error())
+		backend.Close()
 		return
 	}
 
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s:%d/pod/%s/%s/logs", node.Meta.ExternalIP, 2969, pod.Meta.SelfLink, cid), nil)
+	auditor.Session(r, types.AuditSessionPortForward, &types.AuditSession{
+		Service: sid,
+		Pod:     pod.Meta.Name,
+		Port:    opts.Port,
+	})
+
+	if err := ws.Proxy(client, backend); err != nil {
+		log.V(logLevel).Debugf("%s:portforward:> forward stream closed with err: %s", logPrefix, err.Error())
+	}
+}
+
+// getServicePod - find service pod and node where pod is running
+func getServicePod(ctx context.Context, namespace, service, deployment, pod string) (*types.Pod, *types.Node, *errors.Err) {
+
+	var (
+		stg = envs.Get().GetStorage()
+		nsm = distribution.NewNamespaceModel(ctx, stg)
+		sm  = distribution.NewServiceModel(ctx, stg)
+		pm  = distribution.NewPodModel(ctx, stg)
+		dm  = distribution.NewDeploymentModel(ctx, stg)
+		nm  = distribution.NewNodeModel(ctx, stg)
+	)
+
+	ns, err := nsm.Get(namespace)
+	if err != nil {
+		return nil, nil, errors.New("namespace").Unknown(err)
+	}
+	if ns == nil {
+		return nil, nil, errors.New("namespace").NotFound()
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, service)
 	if err != nil {
-		log.V(logLevel).Errorf("%s:logs:> create http client err: %s", logPrefix, err.Error())
-		errors.HTTP.InternalServerError(w)
-		return
+		return nil, nil, errors.New("service").Unknown(err)
+	}
+	if svc == nil {
+		return nil, nil, errors.New("service").NotFound()
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	d, err := dm.Get(ns.Meta.Name, svc.Meta.Name, deployment)
 	if err != nil {
-		log.V(logLevel).Errorf("%s:logs:> get pod logs err: %s", logPrefix, err.Error())
-		errors.HTTP.InternalServerError(w)
-		return
+		return nil, nil, errors.New("deployment").Unknown(err)
+	}
+	if d == nil {
+		return nil, nil, errors.New("deployment").NotFound()
+	}
+
+	p, err := pm.Get(ns.Meta.Name, svc.Meta.Name, d.Meta.Name, pod)
+	if err != nil {
+		return nil, nil, errors.New("pod").Unknown(err)
+	}
+	if p == nil {
+		return nil, nil, errors.New("pod").NotFound()
 	}
 
-	notify := w.(http.CloseNotifier).CloseNotify()
-	done := make(chan bool, 1)
+	n, err := nm.Get(p.Meta.Node)
+	if err != nil {
+		return nil, nil, errors.New("node").Unknown(err)
+	}
+	if n == nil {
+		return nil, nil, errors.New("node").NotFound()
+	}
 
-	go func() {
-		<-notify
-		log.V(logLevel).Debugf("%s:logs:> HTTP connection just closed.", logPrefix)
-		done <- true
-	}()
+	return p, n, nil
+}
 
-	var buffer = make([]byte, BUFFER_SIZE)
+// dialNode - open websocket connection to node http server
+func dialNode(node *types.Node, path string, query url.Values) (*websocket.Conn, error) {
 
-	for {
-		select {
-		case <-done:
-			res.Body.Close()
-			return
-		default:
+	header := http.Header{}
+	if token := viper.GetString("security.token"); token != "" {
+		header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
 
-			n, err := res.Body.Read(buffer)
-			if err != nil {
+	endpoint := fmt.Sprintf("ws://%s:%d%s?%s", node.Meta.ExternalIP, 2969, path, query.Encode())
 
-				if err == context.Canceled {
-					log.V(logLevel).Debug("Stream is canceled")
-					return
-				}
+	conn, _, err := websocket.DefaultDialer.Dial(endpoint, header)
+	return conn, err
+}
 
-				log.Errorf("Error read bytes from stream %s", err)
-				return
-			}
+// ServiceResourcesAllocate - allocate service resources in namespace, previous service resources are released
+// if service is updated. Namespace resources are not changed on error
+func ServiceResourcesAllocate(ns *types.Namespace, previous *types.ResourceRequest, requested types.ResourceRequest) error {
 
-			_, err = func(p []byte) (n int, err error) {
+	allocated := ns.Status.Resources
 
-				n, err = w.Write(p)
-				if err != nil {
-					log.Errorf("Error write bytes to stream %s", err)
-					return n, err
-				}
+	if previous != nil {
+		if err := ns.ReleaseResources(*previous); err != nil {
+			ns.Status.Resources = allocated
+			return err
+		}
+	}
 
-				if f, ok := w.(http.Flusher); ok {
-					f.Flush()
-				}
+	if err := ns.AllocateResources(requested); err != nil {
+		ns.Status.Resources = allocated
+		return err
+	}
+
+	return nil
+}
 
-				return n, nil
-			}(buffer[0:n])
+// ServiceResourcesRollback - returns resources allocated in namespace for service which was not saved,
+// previous service resources are allocated back if service was updated
+func ServiceResourcesRollback(nm *distribution.Namespace, ns *types.Namespace, allocated types.ResourceRequest, previous *types.ResourceRequest) {
 
-			if err != nil {
-				log.Errorf("Error written to stream %s", err)
-				return
-			}
+	err := distribution.RetryOnConflict(func() error {
 
-			for i := 0; i < n; i++ {
-				buffer[i] = 0
+		if err := ns.ReleaseResources(allocated); err != nil {
+			return err
+		}
+
+		if previous != nil {
+			if err := ns.AllocateResources(*previous); err != nil {
+				return err
 			}
 		}
-	}
 
+		return nm.Update(ns)
+	}, func() error {
+
+		n, err := nm.Get(ns.Meta.Name)
+		if err != nil {
+			return err
+		}
+
+		if n == nil {
+			return errors.New("namespace not found")
+		}
+
+		ns = n
+		return nil
+	})
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> namespace resources rollback err: %s", logPrefix, err.Error())
+	}
 }
 
-func checkServiceVolumes(ctx context.Context, svc *types.Service) error {
+// CheckServiceVolumes - check volumes used by service are ready and provisioned on the same node
+func CheckServiceVolumes(ctx context.Context, svc *types.Service) error {
 
 	var (
 		stg = envs.Get().GetStorage()
@@ -932,6 +932,78 @@ func TestServiceUpdate(t *testing.T) {
 
 }
 
+// Testing ServiceUpdateH returns namespace resources when service update failed
+func TestServiceUpdateResourcesRollback(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns := getNamespaceAsset("limits", "")
+	ns.Status.Resources.Allocated.RAM = "1536MB"
+	ns.Status.Resources.Allocated.CPU = "1.5"
+	ns.Spec.Resources.Limits.RAM = "2GB"
+	ns.Spec.Resources.Limits.CPU = "2"
+
+	svc := getServiceAsset(ns.Meta.Name, "limited", "demo description")
+	svc.Spec.Template.Containers[0].Resources.Limits.RAM, _ = resource.DecodeMemoryResource("512MB")
+	svc.Spec.Template.Containers[0].Resources.Limits.CPU, _ = resource.DecodeCpuResource("0.5")
+
+	var version int64 = -1
+	mf := getServiceManifest("limited", "image")
+	mf.Meta.ResourceVersion = &version
+	mf.Spec.Template.Containers[0].Resources.Limits.RAM = "600MB"
+	mf.Spec.Template.Containers[0].Resources.Limits.CPU = "0.6"
+
+	clear := func() {
+		assert.NoError(t, stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString))
+		assert.NoError(t, stg.Del(context.Background(), stg.Collection().Service(), types.EmptyString))
+	}
+
+	clear()
+	defer clear()
+
+	err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns.Meta.Name), ns, nil)
+	assert.NoError(t, err)
+
+	err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(ns.Meta.Name, svc.Meta.Name), svc, nil)
+	assert.NoError(t, err)
+
+	bd, err := mf.ToJson()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("/namespace/%s/service/%s", ns.Meta.Name, svc.Meta.Name), strings.NewReader(string(bd)))
+	assert.NoError(t, err)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/namespace/{namespace}/service/{service}", service.ServiceUpdateH)
+
+	setRequestVars(r, req)
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if !assert.Equal(t, http.StatusConflict, res.Code, "status code not equal") {
+		return
+	}
+
+	got := new(types.Namespace)
+	err = stg.Get(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns.Meta.Name), got, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ram, err := resource.DecodeMemoryResource(got.Status.Resources.Allocated.RAM)
+	assert.NoError(t, err)
+	cpu, err := resource.DecodeCpuResource(got.Status.Resources.Allocated.CPU)
+	assert.NoError(t, err)
+
+	wram, _ := resource.DecodeMemoryResource(ns.Status.Resources.Allocated.RAM)
+	wcpu, _ := resource.DecodeCpuResource(ns.Status.Resources.Allocated.CPU)
+
+	assert.Equal(t, wram, ram, "namespace allocated ram should be returned")
+	assert.Equal(t, wcpu, cpu, "namespace allocated cpu should be returned")
+}
+
 // Testing ServiceRemoveH handler
 func TestServiceRemove(t *testing.T) {
 
@@ -1070,6 +1142,148 @@ func TestServiceRemove(t *testing.T) {
 
 }
 
+// Testing ServiceLogsH handler
+func TestServiceLogs(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "")
+	s2 := getServiceAsset(ns1.Meta.Name, "test", "")
+
+	d1 := new(types.Deployment)
+	d1.Meta.SetDefault()
+	d1.Meta.Namespace = ns1.Meta.Name
+	d1.Meta.Service = s1.Meta.Name
+	d1.Meta.Name = "demo"
+	d1.Status.SetReady()
+
+	// pod is not scheduled to node yet, so there are no logs to stream
+	p1 := new(types.Pod)
+	p1.Meta.Name = "demo"
+	p1.Meta.Namespace = ns1.Meta.Name
+	p1.Meta.Service = s1.Meta.Name
+	p1.Meta.Deployment = d1.Meta.Name
+	p1.SelfLink()
+
+	tests := []struct {
+		name         string
+		service      *types.Service
+		query        string
+		deployment   bool
+		err          string
+		expectedCode int
+	}{
+		{
+			name:         "checking get service logs with bad tail parameter",
+			service:      s1,
+			query:        "tail=-1",
+			deployment:   true,
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad tail parameter\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking get service logs with bad since parameter",
+			service:      s1,
+			query:        "since=yesterday",
+			deployment:   true,
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad since parameter\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking get service logs if service not exists",
+			service:      s2,
+			deployment:   true,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Service not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get service logs if active deployment not exists",
+			service:      s1,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Deployment not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get service logs if pod not exists",
+			service:      s1,
+			query:        "pod=test",
+			deployment:   true,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Pod not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get service logs of active deployment successfully",
+			service:      s1,
+			query:        "tail=10&since=10m&timestamps=true",
+			deployment:   true,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Service(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Deployment(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Pod(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			if tc.deployment {
+				err = stg.Put(context.Background(), stg.Collection().Deployment(), stg.Key().Deployment(d1.Meta.Namespace, d1.Meta.Service, d1.Meta.Name), d1, nil)
+				assert.NoError(t, err)
+
+				err = stg.Put(context.Background(), stg.Collection().Pod(), stg.Key().Pod(p1.Meta.Namespace, p1.Meta.Service, p1.Meta.Deployment, p1.Meta.Name), p1, nil)
+				assert.NoError(t, err)
+			}
+
+			req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/service/%s/logs?%s", ns1.Meta.Name, tc.service.Meta.Name, tc.query), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/logs", service.ServiceLogsH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.err != "" {
+				assert.Equal(t, tc.err, string(body), "incorrect error message")
+				return
+			}
+
+			assert.Empty(t, string(body), "unexpected logs")
+		})
+	}
+}
+
 func getNamespaceAsset(name, desc string) *types.Namespace {
 	var n = types.Namespace{}
 	n.Meta.SetDefault()
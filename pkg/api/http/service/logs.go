@@ -0,0 +1,298 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/spf13/viper"
+)
+
+// logsStream - container logs stream with lines prefix
+type logsStream struct {
+	prefix string
+	reader io.ReadCloser
+}
+
+// flushWriter - flush response after each write to deliver logs without buffering
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if fl, ok := f.w.(http.Flusher); ok {
+		fl.Flush()
+	}
+	return n, err
+}
+
+// getServiceLogsStreams - open logs streams of all containers of matched service pods.
+// Active deployment is used if deployment is not set in options
+func getServiceLogsStreams(ctx context.Context, namespace, service string, opts *request.ServiceLogsOptions) ([]*logsStream, *errors.Err) {
+
+	var (
+		stg = envs.Get().GetStorage()
+		nsm = distribution.NewNamespaceModel(ctx, stg)
+		sm  = distribution.NewServiceModel(ctx, stg)
+		dm  = distribution.NewDeploymentModel(ctx, stg)
+		pm  = distribution.NewPodModel(ctx, stg)
+		nm  = distribution.NewNodeModel(ctx, stg)
+	)
+
+	ns, err := nsm.Get(namespace)
+	if err != nil {
+		return nil, errors.New("namespace").Unknown(err)
+	}
+	if ns == nil {
+		return nil, errors.New("namespace").NotFound()
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, service)
+	if err != nil {
+		return nil, errors.New("service").Unknown(err)
+	}
+	if svc == nil {
+		return nil, errors.New("service").NotFound()
+	}
+
+	var d *types.Deployment
+
+	if opts.Deployment != "" {
+		d, err = dm.Get(ns.Meta.Name, svc.Meta.Name, opts.Deployment)
+		if err != nil {
+			return nil, errors.New("deployment").Unknown(err)
+		}
+	} else {
+		dl, err := dm.ListByService(ns.Meta.Name, svc.Meta.Name)
+		if err != nil {
+			return nil, errors.New("deployment").Unknown(err)
+		}
+		d = activeDeployment(dl)
+	}
+
+	if d == nil {
+		return nil, errors.New("deployment").NotFound()
+	}
+
+	pl, err := pm.ListByDeployment(ns.Meta.Name, svc.Meta.Name, d.Meta.Name)
+	if err != nil {
+		return nil, errors.New("pod").Unknown(err)
+	}
+
+	sort.Slice(pl.Items, func(i, j int) bool {
+		return pl.Items[i].Meta.Name < pl.Items[j].Meta.Name
+	})
+
+	var (
+		streams = make([]*logsStream, 0)
+		nodes   = make(map[string]*types.Node)
+		found   bool
+		last    error
+	)
+
+	for _, p := range pl.Items {
+
+		if opts.Pod != "" && opts.Pod != p.Meta.Name {
+			continue
+		}
+		found = true
+
+		if p.Meta.Node == "" {
+			continue
+		}
+
+		node, ok := nodes[p.Meta.Node]
+		if !ok {
+			node, err = nm.Get(p.Meta.Node)
+			if err != nil {
+				closeLogsStreams(streams)
+				return nil, errors.New("node").Unknown(err)
+			}
+			nodes[p.Meta.Node] = node
+		}
+		if node == nil {
+			log.V(logLevel).Warnf("%s:logs:> node %s not found", logPrefix, p.Meta.Node)
+			continue
+		}
+
+		containers := make([]*types.PodContainer, 0)
+		for _, c := range p.Status.Containers {
+			name := containerName(p, c)
+			if opts.Container != "" && opts.Container != c.ID && opts.Container != c.Name && opts.Container != name {
+				continue
+			}
+			containers = append(containers, c)
+		}
+
+		sort.Slice(containers, func(i, j int) bool {
+			return containers[i].Name < containers[j].Name
+		})
+
+		for _, c := range containers {
+			reader, err := podLogsStream(ctx, node, p, c.ID, opts)
+			if err != nil {
+				log.V(logLevel).Errorf("%s:logs:> get pod `%s` container `%s` logs err: %s", logPrefix, p.Meta.Name, c.Name, err.Error())
+				last = err
+				continue
+			}
+
+			streams = append(streams, &logsStream{
+				prefix: fmt.Sprintf("[%s/%s] ", p.Meta.Name, containerName(p, c)),
+				reader: reader,
+			})
+		}
+	}
+
+	if opts.Pod != "" && !found {
+		return nil, errors.New("pod").NotFound()
+	}
+
+	if len(streams) == 0 && last != nil {
+		return nil, errors.New("logs").Unknown(last)
+	}
+
+	return streams, nil
+}
+
+// activeDeployment - get ready deployment with latest template,
+// latest not destroyed deployment is used if there is no ready one
+func activeDeployment(dl *types.DeploymentList) *types.Deployment {
+
+	var ready, latest *types.Deployment
+
+	for _, d := range dl.Items {
+
+		switch d.Status.State {
+		case types.StateDestroy, types.StateDestroyed:
+			continue
+		case types.StateReady:
+			if ready == nil || ready.Spec.Template.Updated.Before(d.Spec.Template.Updated) {
+				ready = d
+			}
+		}
+
+		if latest == nil || latest.Spec.Template.Updated.Before(d.Spec.Template.Updated) {
+			latest = d
+		}
+	}
+
+	if ready != nil {
+		return ready
+	}
+
+	return latest
+}
+
+// containerName - get container name without pod name prefix
+func containerName(p *types.Pod, c *types.PodContainer) string {
+	return strings.TrimPrefix(c.Name, fmt.Sprintf("%s-", p.Meta.Name))
+}
+
+// podLogsStream - open pod container logs stream on node
+func podLogsStream(ctx context.Context, node *types.Node, pod *types.Pod, container string, opts *request.ServiceLogsOptions) (io.ReadCloser, error) {
+
+	endpoint := fmt.Sprintf("http://%s:%d/pod/%s/%s/logs?%s",
+		node.Meta.ExternalIP, 2969, pod.Meta.SelfLink, container, opts.ToQuery().Encode())
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if token := viper.GetString("security.token"); token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		body, _ := ioutil.ReadAll(res.Body)
+		return nil, fmt.Errorf("%d while accessing node logs: %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return res.Body, nil
+}
+
+// mergeLogsStreams - write lines of all streams with stream prefix until all streams are closed
+func mergeLogsStreams(w io.Writer, streams []*logsStream) {
+
+	var (
+		lock sync.Mutex
+		wg   sync.WaitGroup
+	)
+
+	for _, s := range streams {
+		wg.Add(1)
+
+		go func(s *logsStream) {
+			defer wg.Done()
+			defer s.reader.Close()
+
+			reader := bufio.NewReader(s.reader)
+
+			for {
+				line, err := reader.ReadString('\n')
+				if len(line) > 0 {
+					if !strings.HasSuffix(line, "\n") {
+						line += "\n"
+					}
+
+					lock.Lock()
+					_, werr := io.WriteString(w, s.prefix+line)
+					lock.Unlock()
+
+					if werr != nil {
+						return
+					}
+				}
+
+				if err != nil {
+					return
+				}
+			}
+		}(s)
+	}
+
+	wg.Wait()
+}
+
+func closeLogsStreams(streams []*logsStream) {
+	for _, s := range streams {
+		s.reader.Close()
+	}
+}
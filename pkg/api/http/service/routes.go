@@ -19,15 +19,21 @@
 package service
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
-	{Path: "/namespace/{namespace}/service", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceCreateH},
-	{Path: "/namespace/{namespace}/service", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceListH},
-	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceInfoH},
-	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceUpdateH},
-	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceRemoveH},
-	{Path: "/namespace/{namespace}/service/{service}/logs", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceLogsH},
+	{Path: "/namespace/{namespace}/service", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindService, types.VerbCreate), limits.Write.Limit}, Handler: ServiceCreateH, Request: request.ServiceManifest{}, Response: views.Service{}},
+	{Path: "/namespace/{namespace}/service", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindService, types.VerbList), limits.List}, Handler: ServiceListH, Response: views.ServiceList{}},
+	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindService, types.VerbGet), limits.Read.Limit}, Handler: ServiceInfoH, Response: views.Service{}},
+	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindService, types.VerbUpdate), limits.Write.Limit}, Handler: ServiceUpdateH, Request: request.ServiceManifest{}, Response: views.Service{}},
+	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindService, types.VerbDelete), limits.Write.Limit}, Handler: ServiceRemoveH, Response: http.Empty{}},
+	{Path: "/namespace/{namespace}/service/{service}/logs", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindService, types.VerbGet), limits.Stream.Limit}, Handler: ServiceLogsH, Response: http.Raw{}},
+	{Path: "/namespace/{namespace}/service/{service}/exec", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindService, types.VerbUpdate), limits.Stream.Limit}, Handler: ServiceExecH, Response: http.Raw{}},
+	{Path: "/namespace/{namespace}/service/{service}/portforward", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindService, types.VerbUpdate), limits.Stream.Limit}, Handler: ServicePortForwardH, Response: http.Raw{}},
 }
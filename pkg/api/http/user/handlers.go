@@ -0,0 +1,488 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package user
+
+import (
+	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/generator"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+)
+
+const (
+	logLevel  = 2
+	logPrefix = "api:handler:user"
+
+	tokenLength = 64
+)
+
+func UserListH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /user user userList
+	//
+	// Shows a list of users and service accounts
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
+	// responses:
+	//   '200':
+	//     description: User list response
+	//     schema:
+	//       "$ref": "#/definitions/views_user_list"
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:list:> get users list", logPrefix)
+
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
+	um := distribution.NewUserModel(r.Context(), envs.Get().GetStorage())
+
+	if opts.Watch {
+		userWatch(w, r, opts.GetWatchOptions())
+		return
+	}
+
+	items, err := um.Select(opts.GetOptions())
+	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
+		log.V(logLevel).Errorf("%s:list:> get users list err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().User().NewList(items).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	if items.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, items.System.Continue)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+// userWatch - stream users changes as chunked json events
+func userWatch(w http.ResponseWriter, r *http.Request, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch users", logPrefix)
+
+	var (
+		m  = distribution.NewUserModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.UserEvent)
+	)
+
+	watch.Stream(w, "users", ch, func() error {
+		return m.WatchSelect(ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().User().New(data.(*types.User))
+	})
+}
+
+func UserInfoH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /user/{user} user userInfo
+	//
+	// Shows an info about user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: user
+	//     in: path
+	//     description: name of the user
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: User response
+	//     schema:
+	//       "$ref": "#/definitions/views_user"
+	//   '404':
+	//     description: User not found
+	//   '500':
+	//     description: Internal server error
+
+	uid := utils.Vars(r)["user"]
+
+	log.V(logLevel).Debugf("%s:info:> get user `%s`", logPrefix, uid)
+
+	user, e := fetchUser(r, uid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	response, err := v1.View().User().New(user).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:info:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func UserCreateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /user user userCreate
+	//
+	// Creates user or service account and issues access token
+	//
+	// ---
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_user"
+	// responses:
+	//   '200':
+	//     description: User was successfully created, token is shown only once
+	//     schema:
+	//       "$ref": "#/definitions/views_user"
+	//   '400':
+	//     description: Name is already in use
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:create:> create user", logPrefix)
+
+	opts := v1.Request().User().Options()
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:create:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
+	if opts.Name == rbac.RootUser {
+		errors.New("user").NotUnique("name").Http(w)
+		return
+	}
+
+	um := distribution.NewUserModel(r.Context(), envs.Get().GetStorage())
+
+	user, err := um.Get(opts.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> get user err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if user != nil {
+		log.V(logLevel).Warnf("%s:create:> user `%s` already exists", logPrefix, opts.Name)
+		errors.New("user").NotUnique("name").Http(w)
+		return
+	}
+
+	token := generator.GenerateRandomString(tokenLength)
+
+	user = new(types.User)
+	opts.SetUser(user)
+	user.SetToken(token)
+
+	user, err = um.Create(user)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> create user err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().User().NewWithToken(user, token).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:create:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func UserUpdateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /user/{user} user userUpdate
+	//
+	// Updates user description, type and role bindings
+	//
+	// ---
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: user
+	//     in: path
+	//     description: name of the user
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_user"
+	// responses:
+	//   '200':
+	//     description: User was successfully updated
+	//     schema:
+	//       "$ref": "#/definitions/views_user"
+	//   '400':
+	//     description: Bad request
+	//   '404':
+	//     description: User not found
+	//   '500':
+	//     description: Internal server error
+
+	uid := utils.Vars(r)["user"]
+
+	log.V(logLevel).Debugf("%s:update:> update user `%s`", logPrefix, uid)
+
+	opts := v1.Request().User().Options()
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:update:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
+	user, e := fetchUser(r, uid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	opts.SetUser(user)
+
+	um := distribution.NewUserModel(r.Context(), envs.Get().GetStorage())
+	if err := um.Update(user); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update user err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("user").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().User().New(user).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:update:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:update:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func UserTokenH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /user/{user}/token user userToken
+	//
+	// Issues new access token for user, previous token is revoked
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: user
+	//     in: path
+	//     description: name of the user
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Token was successfully issued, token is shown only once
+	//     schema:
+	//       "$ref": "#/definitions/views_user"
+	//   '404':
+	//     description: User not found
+	//   '500':
+	//     description: Internal server error
+
+	uid := utils.Vars(r)["user"]
+
+	log.V(logLevel).Debugf("%s:token:> issue token for user `%s`", logPrefix, uid)
+
+	user, e := fetchUser(r, uid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	token := generator.GenerateRandomString(tokenLength)
+	user.SetToken(token)
+
+	um := distribution.NewUserModel(r.Context(), envs.Get().GetStorage())
+	if err := um.Update(user); err != nil {
+		log.V(logLevel).Errorf("%s:token:> update user err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("user").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().User().NewWithToken(user, token).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:token:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:token:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func UserRemoveH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation DELETE /user/{user} user userRemove
+	//
+	// Removes user and revokes its token
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: user
+	//     in: path
+	//     description: name of the user
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: User was successfully removed
+	//   '404':
+	//     description: User not found
+	//   '500':
+	//     description: Internal server error
+
+	uid := utils.Vars(r)["user"]
+
+	log.V(logLevel).Debugf("%s:remove:> remove user `%s`", logPrefix, uid)
+
+	user, e := fetchUser(r, uid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	um := distribution.NewUserModel(r.Context(), envs.Get().GetStorage())
+	if err := um.Remove(user); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove user err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte{}); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+// fetchUser - get user by name
+func fetchUser(r *http.Request, name string) (*types.User, *errors.Err) {
+
+	um := distribution.NewUserModel(r.Context(), envs.Get().GetStorage())
+
+	user, err := um.Get(name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get user `%s` err: %s", logPrefix, name, err.Error())
+		return nil, errors.New("user").Unknown(err)
+	}
+	if user == nil {
+		log.V(logLevel).Warnf("%s:> user `%s` not found", logPrefix, name)
+		return nil, errors.New("user").NotFound()
+	}
+
+	return user, nil
+}
@@ -0,0 +1,36 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package user
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/http"
+)
+
+var Routes = []http.Route{
+	{Path: "/user", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindUser, types.VerbList), limits.List}, Handler: UserListH, Response: views.UserList{}},
+	{Path: "/user", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindUser, types.VerbCreate), limits.Write.Limit}, Handler: UserCreateH, Request: request.UserOptions{}, Response: views.User{}},
+	{Path: "/user/{user}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindUser, types.VerbGet), limits.Read.Limit}, Handler: UserInfoH, Response: views.User{}},
+	{Path: "/user/{user}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindUser, types.VerbUpdate), limits.Write.Limit}, Handler: UserUpdateH, Request: request.UserOptions{}, Response: views.User{}},
+	{Path: "/user/{user}/token", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindUser, types.VerbUpdate), limits.Write.Limit}, Handler: UserTokenH, Request: http.Empty{}, Response: views.User{}},
+	{Path: "/user/{user}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindUser, types.VerbDelete), limits.Write.Limit}, Handler: UserRemoveH, Response: http.Empty{}},
+}
@@ -19,7 +19,9 @@
 package volume
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 
 	"net/http"
 
@@ -51,6 +53,36 @@ func VolumeListH(w http.ResponseWriter, r *http.Request) {
 	//     description: namespace id
 	//     required: true
 	//     type: string
+	//   - name: labelSelector
+	//     in: query
+	//     description: label selector, like app=web,tier in (front,back)
+	//     required: false
+	//     type: string
+	//   - name: fieldSelector
+	//     in: query
+	//     description: field selector, like status.state=ready
+	//     required: false
+	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: max count of items in response
+	//     required: false
+	//     type: integer
+	//   - name: continue
+	//     in: query
+	//     description: token from X-Lastbackend-Continue header of previous response
+	//     required: false
+	//     type: string
+	//   - name: watch
+	//     in: query
+	//     description: stream changes as chunked json events instead of list
+	//     required: false
+	//     type: boolean
+	//   - name: revision
+	//     in: query
+	//     description: resume watch from revision of last received event
+	//     required: false
+	//     type: integer
 	// responses:
 	//   '200':
 	//     description: Volume list response
@@ -63,6 +95,13 @@ func VolumeListH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debugf("%s:list:> get volumes list", logPrefix)
 
+	opts := v1.Request().List().Options()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.V(logLevel).Errorf("%s:list:> validation incoming data err: %s", logPrefix, e.Err().Error())
+		e.Http(w)
+		return
+	}
+
 	nid := utils.Vars(r)["namespace"]
 
 	var (
@@ -83,8 +122,17 @@ func VolumeListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items, err := rm.ListByNamespace(ns.Meta.Name)
+	if opts.Watch {
+		volumeWatch(w, r, ns.Meta.Name, opts.GetWatchOptions())
+		return
+	}
+
+	items, err := rm.Select(ns.Meta.Name, opts.GetOptions())
 	if err != nil {
+		if errors.Storage().IsErrListContinueInvalid(err) {
+			errors.New("list").BadParameter("continue").Http(w)
+			return
+		}
 		log.V(logLevel).Errorf("%s:list:> find volume list err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
@@ -97,6 +145,10 @@ func VolumeListH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if items.System.Continue != "" {
+		w.Header().Set(views.HeaderContinue, items.System.Continue)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
 		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
@@ -104,6 +156,23 @@ func VolumeListH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// volumeWatch - stream volumes changes as chunked json events
+func volumeWatch(w http.ResponseWriter, r *http.Request, namespace string, opts *types.WatchOptions) {
+
+	log.V(logLevel).Debugf("%s:watch:> watch volumes in namespace %s", logPrefix, namespace)
+
+	var (
+		m  = distribution.NewVolumeModel(r.Context(), envs.Get().GetStorage())
+		ch = make(chan types.VolumeEvent)
+	)
+
+	watch.Stream(w, "volumes", ch, func() error {
+		return m.WatchSelect(namespace, ch, opts)
+	}, func(data interface{}) interface{} {
+		return v1.View().Volume().New(data.(*types.Volume))
+	})
+}
+
 func VolumeInfoH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation GET /namespace/{namespace}/volume/{volume} volume volumeInfo
@@ -358,7 +427,12 @@ func VolumeUpdateH(w http.ResponseWriter, r *http.Request) {
 
 	if err = rm.Update(rs); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update volume `%s` err: %s", logPrefix, ns.Meta.Name, err.Error())
+		if errors.Storage().IsErrEntityConflict(err) {
+			errors.New("volume").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
+		return
 	}
 
 	response, err := v1.View().Volume().New(rs).ToJson()
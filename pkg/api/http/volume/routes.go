@@ -19,15 +19,19 @@
 package volume
 
 import (
+	"github.com/lastbackend/lastbackend/pkg/api/limits"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
-	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
 )
 
 var Routes = []http.Route{
 	// Route handlers
-	{Path: "/namespace/{namespace}/volume", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: VolumeCreateH},
-	{Path: "/namespace/{namespace}/volume", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: VolumeListH},
-	{Path: "/namespace/{namespace}/volume/{volume}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: VolumeInfoH},
-	{Path: "/namespace/{namespace}/volume/{volume}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: VolumeUpdateH},
-	{Path: "/namespace/{namespace}/volume/{volume}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: VolumeRemoveH},
+	{Path: "/namespace/{namespace}/volume", Method: http.MethodPost, Middleware: []http.Middleware{rbac.Authorize(types.KindVolume, types.VerbCreate), limits.Write.Limit}, Handler: VolumeCreateH, Request: request.VolumeManifest{}, Response: views.Volume{}},
+	{Path: "/namespace/{namespace}/volume", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindVolume, types.VerbList), limits.List}, Handler: VolumeListH, Response: views.VolumeList{}},
+	{Path: "/namespace/{namespace}/volume/{volume}", Method: http.MethodGet, Middleware: []http.Middleware{rbac.Authorize(types.KindVolume, types.VerbGet), limits.Read.Limit}, Handler: VolumeInfoH, Response: views.Volume{}},
+	{Path: "/namespace/{namespace}/volume/{volume}", Method: http.MethodPut, Middleware: []http.Middleware{rbac.Authorize(types.KindVolume, types.VerbUpdate), limits.Write.Limit}, Handler: VolumeUpdateH, Request: request.VolumeManifest{}, Response: views.Volume{}},
+	{Path: "/namespace/{namespace}/volume/{volume}", Method: http.MethodDelete, Middleware: []http.Middleware{rbac.Authorize(types.KindVolume, types.VerbDelete), limits.Write.Limit}, Handler: VolumeRemoveH, Response: http.Empty{}},
 }
@@ -0,0 +1,87 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package watch
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+)
+
+const (
+	logLevel  = 2
+	logPrefix = "api:handler:watch"
+)
+
+// Adapter - converts data of watched resource event to api view
+type Adapter func(data interface{}) interface{}
+
+// Stream - runs watch and streams events received from events channel to client
+// until watch is finished or client stream is broken.
+// Events channel should be channel of distribution resource events (types.ServiceEvent, etc.),
+// event data is converted to api view by kind adapter
+func Stream(w http.ResponseWriter, kind string, events interface{}, watch func() error, adapter Adapter) {
+
+	log.V(logLevel).Debugf("%s:stream:> stream %s events", logPrefix, kind)
+
+	var done = make(chan error, 1)
+
+	go func() {
+		done <- watch()
+	}()
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(events)},
+	}
+
+	sw := utils.NewStreamWriter(w)
+
+	for {
+
+		i, v, ok := reflect.Select(cases)
+
+		if i == 0 {
+			if ok && !v.IsNil() {
+				err := v.Interface().(error)
+				log.V(logLevel).Errorf("%s:stream:> watch %s err: %s", logPrefix, kind, err.Error())
+				sw.Write(v1.View().Watch().NewError(err))
+			}
+			return
+		}
+
+		if !ok {
+			return
+		}
+
+		event := v1.View().Watch().New(
+			v.FieldByName("Action").String(),
+			v.FieldByName("Revision").Int(),
+			adapter(v.FieldByName("Data").Interface()),
+		)
+
+		if err := sw.Write(event); err != nil {
+			log.V(logLevel).Errorf("%s:stream:> write %s event err: %s", logPrefix, kind, err.Error())
+			return
+		}
+	}
+}
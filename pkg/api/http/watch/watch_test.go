@@ -0,0 +1,82 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package watch_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/api/http/watch"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+
+	var (
+		ch  = make(chan types.ServiceEvent)
+		res = httptest.NewRecorder()
+	)
+
+	event := func(action string, revision int64, name string) types.ServiceEvent {
+		e := types.ServiceEvent{}
+		e.Action = action
+		e.Revision = revision
+		e.Data = new(types.Service)
+		e.Data.Meta.Name = name
+		return e
+	}
+
+	watch.Stream(res, "services", ch, func() error {
+		ch <- event(types.EventActionCreate, 1, "demo")
+		ch <- event(types.EventActionDelete, 2, "demo")
+		return errors.New("watch closed")
+	}, func(data interface{}) interface{} {
+		return data.(*types.Service).Meta.Name
+	})
+
+	var items = make([]views.WatchEvent, 0)
+
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		e := views.WatchEvent{}
+		if !assert.NoError(t, json.Unmarshal(scanner.Bytes(), &e)) {
+			return
+		}
+		items = append(items, e)
+	}
+
+	if !assert.Len(t, items, 3) {
+		return
+	}
+
+	assert.Equal(t, views.WatchEventAdded, items[0].Type)
+	assert.Equal(t, int64(1), items[0].Revision)
+	assert.Equal(t, "demo", items[0].Data)
+
+	assert.Equal(t, views.WatchEventDeleted, items[1].Type)
+	assert.Equal(t, int64(2), items[1].Revision)
+
+	assert.Equal(t, views.WatchEventError, items[2].Type)
+	assert.Equal(t, "watch closed", items[2].Data)
+}
@@ -0,0 +1,110 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package limits
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
+	"github.com/spf13/viper"
+)
+
+const (
+	logLevel  = 3
+	logPrefix = "api:limits"
+)
+
+// Route groups limits, applied per client token or ip
+var (
+	// Read - limits of get and list requests
+	Read = middleware.NewLimiter(DefaultRead)
+	// Write - limits of mutation requests
+	Write = middleware.NewLimiter(DefaultWrite)
+	// Stream - limits of long-running requests: watch, logs, exec and port forward streams
+	Stream = middleware.NewLimiter(DefaultStream)
+	// System - limits of cluster components requests: node, ingress and discovery status updates.
+	// Components share cluster token, so they are not limited by default
+	System = middleware.NewLimiter(DefaultSystem)
+	// Restore - limits of cluster restore requests, backup archive is larger than write body limit
+	Restore = middleware.NewLimiter(DefaultRestore)
+)
+
+var (
+	DefaultRead    = middleware.LimiterOpts{Rate: 50, Burst: 100, Body: 1 << 20}
+	DefaultWrite   = middleware.LimiterOpts{Rate: 10, Burst: 20, Body: 10 << 20}
+	DefaultStream  = middleware.LimiterOpts{Rate: 1, Burst: 10, Concurrent: 20, Body: 1 << 20}
+	DefaultSystem  = middleware.LimiterOpts{Body: 10 << 20}
+	DefaultRestore = middleware.LimiterOpts{Rate: 1, Burst: 1, Concurrent: 1, Body: 1 << 30}
+)
+
+// Init - configure route groups limits from `api.limits.<group>`, zero value disables limit
+func Init() {
+	configure("read", Read, DefaultRead)
+	configure("write", Write, DefaultWrite)
+	configure("stream", Stream, DefaultStream)
+	configure("system", System, DefaultSystem)
+	configure("restore", Restore, DefaultRestore)
+
+	// limits are applied before authorization, so only verified tokens get own limits
+	for _, l := range []*middleware.Limiter{Read, Write, Stream, System, Restore} {
+		l.Verify(rbac.Verify)
+	}
+}
+
+// List - read limits for list requests and stream limits for watch requests
+func List(h http.HandlerFunc) http.HandlerFunc {
+
+	var (
+		read   = Read.Limit(h)
+		stream = Stream.Limit(h)
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if watch, _ := strconv.ParseBool(r.URL.Query().Get("watch")); watch {
+			stream(w, r)
+			return
+		}
+		read(w, r)
+	}
+}
+
+func configure(group string, l *middleware.Limiter, opts middleware.LimiterOpts) {
+
+	prefix := "api.limits." + group
+
+	if viper.IsSet(prefix + ".rate") {
+		opts.Rate = viper.GetFloat64(prefix + ".rate")
+	}
+	if viper.IsSet(prefix + ".burst") {
+		opts.Burst = viper.GetInt(prefix + ".burst")
+	}
+	if viper.IsSet(prefix + ".concurrent") {
+		opts.Concurrent = viper.GetInt(prefix + ".concurrent")
+	}
+	if viper.IsSet(prefix + ".body") {
+		opts.Body = viper.GetInt64(prefix + ".body")
+	}
+
+	log.V(logLevel).Debugf("%s:configure:> %s limits: rate %v burst %d concurrent %d body %d",
+		logPrefix, group, opts.Rate, opts.Burst, opts.Concurrent, opts.Body)
+
+	l.Configure(opts)
+}
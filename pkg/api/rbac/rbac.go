@@ -0,0 +1,211 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package rbac
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+	"github.com/spf13/viper"
+)
+
+const (
+	logLevel  = 2
+	logPrefix = "api:rbac"
+
+	// RootUser - name of identity authenticated by cluster security token
+	RootUser = "root"
+	// AnonymousUser - name of identity of not authenticated requests
+	AnonymousUser = "anonymous"
+
+	contextUser = "user"
+)
+
+// Authorize - authentication and authorization middleware,
+// checks that request user is granted verb on resource in request namespace
+func Authorize(resource, verb string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+
+			t := viper.GetString("security.token")
+			if t == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := middleware.Token(r)
+			if !ok {
+				errors.HTTP.Unauthorized(w)
+				return
+			}
+
+			if token == t {
+				root := new(types.User)
+				root.Meta.Name = RootUser
+				root.Spec.Type = types.UserTypeUser
+				h.ServeHTTP(w, utils.SetContext(r, contextUser, root))
+				return
+			}
+
+			namespace := utils.Vars(r)["namespace"]
+
+			allowed, user, err := Check(r.Context(), token, namespace, resource, verb)
+			if err != nil {
+				log.V(logLevel).Errorf("%s:> authorize request err: %s", logPrefix, err.Error())
+				errors.HTTP.InternalServerError(w)
+				return
+			}
+
+			if user == nil {
+				errors.HTTP.Unauthorized(w)
+				return
+			}
+
+			if !allowed {
+				log.V(logLevel).Debugf("%s:> user %s is not allowed to %s %s in namespace `%s`",
+					logPrefix, user.Meta.Name, verb, resource, namespace)
+				errors.HTTP.Forbidden(w)
+				return
+			}
+
+			h.ServeHTTP(w, utils.SetContext(r, contextUser, user))
+		}
+	}
+}
+
+// Check - find user by token and check if user roles grant verb on resource in namespace
+func Check(ctx context.Context, token, namespace, resource, verb string) (bool, *types.User, error) {
+
+	var (
+		stg = envs.Get().GetStorage()
+		um  = distribution.NewUserModel(ctx, stg)
+		rm  = distribution.NewRoleModel(ctx, stg)
+	)
+
+	user, err := um.GetByToken(token)
+	if err != nil || user == nil {
+		return false, nil, err
+	}
+
+	rl, err := rm.List()
+	if err != nil {
+		return false, user, err
+	}
+
+	roles := make(map[string]*types.Role, len(rl.Items))
+	for _, role := range rl.Items {
+		roles[role.Meta.Name] = role
+	}
+
+	return user.Allowed(roles, namespace, resource, verb), user, nil
+}
+
+// Allowed - check if user authorized in context is granted verb on resource in namespace,
+// used by handlers which operate on several resources in one request
+func Allowed(ctx context.Context, namespace, resource, verb string) (bool, error) {
+
+	if viper.GetString("security.token") == "" {
+		return true, nil
+	}
+
+	user := User(ctx)
+	if user == nil {
+		return false, nil
+	}
+
+	if user.Meta.Name == RootUser {
+		return true, nil
+	}
+
+	rl, err := distribution.NewRoleModel(ctx, envs.Get().GetStorage()).List()
+	if err != nil {
+		return false, err
+	}
+
+	roles := make(map[string]*types.Role, len(rl.Items))
+	for _, role := range rl.Items {
+		roles[role.Meta.Name] = role
+	}
+
+	return user.Allowed(roles, namespace, resource, verb), nil
+}
+
+// Identity - name of user authenticated by request token
+func Identity(r *http.Request) string {
+
+	t := viper.GetString("security.token")
+	if t == "" {
+		return AnonymousUser
+	}
+
+	token, ok := middleware.Token(r)
+	if !ok {
+		return AnonymousUser
+	}
+
+	if token == t {
+		return RootUser
+	}
+
+	user, err := distribution.NewUserModel(r.Context(), envs.Get().GetStorage()).GetByToken(token)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:identity:> get user by token err: %s", logPrefix, err.Error())
+		return AnonymousUser
+	}
+	if user == nil {
+		return AnonymousUser
+	}
+
+	return user.Meta.Name
+}
+
+// Verify - check if request token is cluster token or token of existing user
+func Verify(r *http.Request, token string) bool {
+
+	t := viper.GetString("security.token")
+	if t == "" {
+		return false
+	}
+
+	if token == t {
+		return true
+	}
+
+	user, err := distribution.NewUserModel(r.Context(), envs.Get().GetStorage()).GetByToken(token)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:verify:> get user by token err: %s", logPrefix, err.Error())
+		return false
+	}
+
+	return user != nil
+}
+
+// User - get authorized user from request context
+func User(ctx context.Context) *types.User {
+	if u, ok := ctx.Value(contextUser).(*types.User); ok {
+		return u
+	}
+	return nil
+}
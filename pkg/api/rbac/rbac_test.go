@@ -0,0 +1,151 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package rbac_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/rbac"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+
+	const root = "roottoken"
+
+	viper.Set("security.token", root)
+	defer viper.Set("security.token", "")
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ctx := context.Background()
+
+	role := new(types.Role)
+	role.Meta.Name = "viewer"
+	role.Spec.Rules = []types.RoleRule{{Resources: []string{types.KindService}, Verbs: []string{types.VerbGet}}}
+
+	user := new(types.User)
+	user.Meta.Name = "demo"
+	user.SetToken("usertoken")
+	user.Spec.Bindings = []types.UserBinding{{Role: "viewer", Namespace: "ns-a"}}
+
+	err := stg.Del(ctx, stg.Collection().User(), "")
+	assert.NoError(t, err)
+	err = stg.Del(ctx, stg.Collection().Role(), "")
+	assert.NoError(t, err)
+	err = stg.Put(ctx, stg.Collection().Role(), stg.Key().Role(role.Meta.Name), role, nil)
+	assert.NoError(t, err)
+	err = stg.Put(ctx, stg.Collection().User(), stg.Key().User(user.Meta.Name), user, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		url          string
+		token        string
+		verb         string
+		expectedCode int
+		expectedUser string
+	}{
+		{
+			name:         "without token",
+			url:          "/namespace/ns-a/service/demo",
+			verb:         types.VerbGet,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "unknown token",
+			url:          "/namespace/ns-a/service/demo",
+			token:        "unknown",
+			verb:         types.VerbGet,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "root token",
+			url:          "/namespace/ns-b/service/demo",
+			token:        root,
+			verb:         types.VerbDelete,
+			expectedCode: http.StatusOK,
+			expectedUser: rbac.RootUser,
+		},
+		{
+			name:         "granted",
+			url:          "/namespace/ns-a/service/demo",
+			token:        "usertoken",
+			verb:         types.VerbGet,
+			expectedCode: http.StatusOK,
+			expectedUser: "demo",
+		},
+		{
+			name:         "verb denied",
+			url:          "/namespace/ns-a/service/demo",
+			token:        "usertoken",
+			verb:         types.VerbDelete,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "namespace denied",
+			url:          "/namespace/ns-b/service/demo",
+			token:        "usertoken",
+			verb:         types.VerbGet,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				u := rbac.User(r.Context())
+				if assert.NotNil(t, u, "user should be set to context") {
+					assert.Equal(t, tc.expectedUser, u.Meta.Name)
+				}
+				w.WriteHeader(http.StatusOK)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			assert.NoError(t, err)
+			if tc.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tc.token))
+			}
+
+			res := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}", rbac.Authorize(types.KindService, tc.verb)(handler))
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			if tc.expectedCode == http.StatusForbidden {
+				body, err := ioutil.ReadAll(res.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "{\"code\":403,\"status\":\"Forbidden\",\"message\":\"Forbidden\"}", string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package request
+
+// ApplyOptions represents apply query parameters
+//
+// swagger:ignore
+type ApplyOptions struct {
+	// Name of manifests source, used to find objects for prune
+	Source string
+	// Validate and plan changes without saving them
+	DryRun bool
+	// Remove objects applied from source and missing in manifests
+	Prune bool
+}
+
+// ApplyManifest represents decoded multi-document manifests stream
+//
+// swagger:ignore
+type ApplyManifest struct {
+	Items []*ApplyDocument
+}
+
+// ApplyDocument represents single manifest document from stream
+//
+// swagger:ignore
+type ApplyDocument struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Hash of normalized document manifest
+	Hash string
+
+	NamespaceManifest *NamespaceManifest
+	SecretManifest    *SecretManifest
+	ConfigManifest    *ConfigManifest
+	VolumeManifest    *VolumeManifest
+	ServiceManifest   *ServiceManifest
+	RouteManifest     *RouteManifest
+}
+
+type applyHeader struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Meta struct {
+		Name      string `json:"name" yaml:"name"`
+		Namespace string `json:"namespace" yaml:"namespace"`
+	} `json:"meta" yaml:"meta"`
+}
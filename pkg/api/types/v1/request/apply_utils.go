@@ -0,0 +1,248 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package request
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/decoder"
+	"github.com/lastbackend/lastbackend/pkg/util/validator"
+	"gopkg.in/yaml.v2"
+)
+
+type ApplyRequest struct{}
+
+func (ApplyRequest) Options() *ApplyOptions {
+	return new(ApplyOptions)
+}
+
+func (ApplyRequest) Manifest() *ApplyManifest {
+	return new(ApplyManifest)
+}
+
+// DecodeAndValidate - parse apply query parameters
+func (a *ApplyOptions) DecodeAndValidate(values url.Values) *errors.Err {
+
+	a.Source = values.Get("source")
+
+	if v := values.Get("dryRun"); v != "" {
+		dry, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("apply").BadParameter("dryRun")
+		}
+		a.DryRun = dry
+	}
+
+	if v := values.Get("prune"); v != "" {
+		prune, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("apply").BadParameter("prune")
+		}
+		a.Prune = prune
+	}
+
+	switch true {
+	case a.Source != types.EmptyString && !validator.IsServiceName(a.Source):
+		return errors.New("apply").BadParameter("source")
+	case a.Prune && a.Source == types.EmptyString:
+		return errors.New("apply").BadParameter("source")
+	}
+
+	return nil
+}
+
+// DecodeAndValidate - parse multi-document stream of manifests.
+// Stream can be a json array, a sequence of json objects or yaml documents separated by `---`
+func (a *ApplyManifest) DecodeAndValidate(reader io.Reader) *errors.Err {
+
+	if reader == nil {
+		err := errors.New("data body can not be null")
+		return errors.New("apply").IncorrectJSON(err)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.New("apply").Unknown(err)
+	}
+
+	docs, isJson, err := splitApplyDocuments(body)
+	if err != nil {
+		return errors.New("apply").IncorrectJSON(err)
+	}
+
+	a.Items = make([]*ApplyDocument, 0)
+	index := make(map[string]bool)
+
+	for i, doc := range docs {
+
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		d, e := decodeApplyDocument(doc, isJson)
+		if e != nil {
+			return errors.New("apply").BadRequest(fmt.Sprintf("document %d: %s", i, e.Error()))
+		}
+
+		// skip documents without content, like comments only
+		if d == nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%s:%s", d.Kind, d.Namespace, d.Name)
+		if index[key] {
+			return errors.New("apply").BadRequest(fmt.Sprintf("document %d: duplicate %s %s", i, d.Kind, d.Name))
+		}
+		index[key] = true
+
+		a.Items = append(a.Items, d)
+	}
+
+	if len(a.Items) == 0 {
+		return errors.New("apply").BadRequest("manifests not found")
+	}
+
+	return nil
+}
+
+func splitApplyDocuments(body []byte) ([][]byte, bool, error) {
+
+	data := bytes.TrimSpace(body)
+	docs := make([][]byte, 0)
+
+	switch true {
+	case bytes.HasPrefix(data, []byte("[")):
+		items := make([]json.RawMessage, 0)
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, true, err
+		}
+		for _, i := range items {
+			docs = append(docs, i)
+		}
+		return docs, true, nil
+
+	case bytes.HasPrefix(data, []byte("{")):
+		dec := json.NewDecoder(bytes.NewReader(data))
+		for {
+			var item json.RawMessage
+			if err := dec.Decode(&item); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, true, err
+			}
+			docs = append(docs, item)
+		}
+		return docs, true, nil
+	}
+
+	return decoder.YamlSplit(data), false, nil
+}
+
+func decodeApplyDocument(data []byte, isJson bool) (*ApplyDocument, error) {
+
+	var (
+		h         = new(applyHeader)
+		unmarshal = yaml.Unmarshal
+	)
+
+	if isJson {
+		unmarshal = json.Unmarshal
+	}
+
+	if err := unmarshal(data, h); err != nil {
+		return nil, err
+	}
+
+	if h.Kind == types.EmptyString && h.Meta.Name == types.EmptyString {
+		return nil, nil
+	}
+
+	d := new(ApplyDocument)
+	d.Kind = strings.ToLower(h.Kind)
+	d.Name = h.Meta.Name
+	d.Namespace = h.Meta.Namespace
+
+	if d.Name == types.EmptyString {
+		return nil, fmt.Errorf("%s name is required", d.Kind)
+	}
+
+	var (
+		m interface {
+			ToJson() ([]byte, error)
+			Validate() *errors.Err
+		}
+	)
+
+	switch d.Kind {
+	case types.KindNamespace:
+		d.NamespaceManifest = new(NamespaceManifest)
+		m = d.NamespaceManifest
+	case types.KindSecret:
+		d.SecretManifest = new(SecretManifest)
+		m = d.SecretManifest
+	case types.KindConfig:
+		d.ConfigManifest = new(ConfigManifest)
+		m = d.ConfigManifest
+	case types.KindVolume:
+		d.VolumeManifest = new(VolumeManifest)
+		m = d.VolumeManifest
+	case types.KindService:
+		d.ServiceManifest = new(ServiceManifest)
+		m = d.ServiceManifest
+	case types.KindRoute:
+		d.RouteManifest = new(RouteManifest)
+		m = d.RouteManifest
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", h.Kind)
+	}
+
+	if err := unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	if d.ServiceManifest != nil && d.ServiceManifest.Spec.Template == nil {
+		return nil, fmt.Errorf("service %s: bad parameter spec", d.Name)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err.Err()
+	}
+
+	buf, err := m.ToJson()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(buf)
+	d.Hash = hex.EncodeToString(hash[:])
+
+	return d, nil
+}
@@ -0,0 +1,67 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package request
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type AuditRequest struct{}
+
+func (AuditRequest) ListOptions() *AuditListOptions {
+	return new(AuditListOptions)
+}
+
+// DecodeAndValidate - parse query parameters, time range is set in RFC3339 format
+func (a *AuditListOptions) DecodeAndValidate(values url.Values) *errors.Err {
+
+	var err error
+
+	a.Namespace = values.Get("namespace")
+
+	if v := values.Get("from"); v != "" {
+		if a.From, err = time.Parse(time.RFC3339, v); err != nil {
+			return errors.New("audit").BadParameter("from")
+		}
+	}
+
+	if v := values.Get("to"); v != "" {
+		if a.To, err = time.Parse(time.RFC3339, v); err != nil {
+			return errors.New("audit").BadParameter("to")
+		}
+	}
+
+	if !a.From.IsZero() && !a.To.IsZero() && a.To.Before(a.From) {
+		return errors.New("audit").BadParameter("to")
+	}
+
+	return nil
+}
+
+// GetFilter - convert options to audit records filter
+func (a *AuditListOptions) GetFilter() *types.AuditFilter {
+	return &types.AuditFilter{
+		Namespace: a.Namespace,
+		From:      a.From,
+		To:        a.To,
+	}
+}
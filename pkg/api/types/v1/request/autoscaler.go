@@ -0,0 +1,48 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package request
+
+// AutoscalerOptions represents service autoscaler options
+//
+// swagger:model request_autoscaler
+type AutoscalerOptions struct {
+	// Minimal replicas count
+	// required: false
+	MinReplicas int `json:"min_replicas"`
+	// Maximal replicas count
+	// required: true
+	MaxReplicas int `json:"max_replicas"`
+	// Target average cpu utilization in percents
+	// required: false
+	CPU int `json:"cpu"`
+	// Target average memory utilization in percents
+	// required: false
+	RAM int `json:"ram"`
+	// Stabilization windows in seconds
+	// required: false
+	Stabilization AutoscalerStabilizationOptions `json:"stabilization"`
+}
+
+// AutoscalerStabilizationOptions represents autoscaler stabilization windows
+//
+// swagger:model request_autoscaler_stabilization
+type AutoscalerStabilizationOptions struct {
+	ScaleUp   int `json:"scale_up"`
+	ScaleDown int `json:"scale_down"`
+}
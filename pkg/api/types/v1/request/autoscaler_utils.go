@@ -0,0 +1,93 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package request
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type AutoscalerRequest struct{}
+
+func (AutoscalerRequest) Options() *AutoscalerOptions {
+	return new(AutoscalerOptions)
+}
+
+func (a *AutoscalerOptions) Validate() *errors.Err {
+	switch true {
+	case a.MinReplicas < 0:
+		return errors.New("autoscaler").BadParameter("min_replicas")
+	case a.MaxReplicas < 1 || a.MaxReplicas < a.MinReplicas:
+		return errors.New("autoscaler").BadParameter("max_replicas")
+	case a.CPU < 0:
+		return errors.New("autoscaler").BadParameter("cpu")
+	case a.RAM < 0:
+		return errors.New("autoscaler").BadParameter("ram")
+	case a.CPU == 0 && a.RAM == 0:
+		return errors.New("autoscaler").BadRequest("cpu or ram utilization target is required")
+	}
+
+	if a.MinReplicas == 0 {
+		a.MinReplicas = 1
+	}
+
+	return nil
+}
+
+func (a *AutoscalerOptions) DecodeAndValidate(reader io.Reader) *errors.Err {
+
+	if reader == nil {
+		err := errors.New("data body can not be null")
+		return errors.New("autoscaler").IncorrectJSON(err)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.New("autoscaler").Unknown(err)
+	}
+
+	err = json.Unmarshal(body, a)
+	if err != nil {
+		return errors.New("autoscaler").IncorrectJSON(err)
+	}
+
+	return a.Validate()
+}
+
+func (a *AutoscalerOptions) ToJson() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// GetSpec - convert options to autoscaler spec
+func (a *AutoscalerOptions) GetSpec() types.AutoscalerSpec {
+	return types.AutoscalerSpec{
+		MinReplicas: a.MinReplicas,
+		MaxReplicas: a.MaxReplicas,
+		CPU:         a.CPU,
+		RAM:         a.RAM,
+		Stabilization: types.AutoscalerSpecStabilization{
+			ScaleUp:   a.Stabilization.ScaleUp,
+			ScaleDown: a.Stabilization.ScaleDown,
+		},
+	}
+}
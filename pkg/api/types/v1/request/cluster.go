@@ -28,3 +28,15 @@ type ClusterUpdateOptions struct {
 
 // swagger:model request_cluster_update_quotas
 type ClusterQuotasOptions struct{}
+
+// HeaderPassphrase - request header with backup archive passphrase
+const HeaderPassphrase = "X-Lastbackend-Passphrase"
+
+// ClusterBackupOptions represents options of cluster backup and restore,
+// passphrase is passed in request header so it is not stored in access logs
+//
+// swagger:ignore
+type ClusterBackupOptions struct {
+	// Passphrase to encrypt secrets in backup archive or decrypt them on restore
+	Passphrase string `json:"-"`
+}
@@ -22,8 +22,10 @@ import (
 	"encoding/json"
 	"io"
 	"io/ioutil"
+	"net/http"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 )
 
 type ClusterRequest struct{}
@@ -32,6 +34,10 @@ func (ClusterRequest) UpdateOptions() *ClusterUpdateOptions {
 	return new(ClusterUpdateOptions)
 }
 
+func (ClusterRequest) BackupOptions() *ClusterBackupOptions {
+	return new(ClusterBackupOptions)
+}
+
 func (c *ClusterUpdateOptions) Validate() *errors.Err {
 	switch true {
 	case c.Description != nil && len(*c.Description) > DEFAULT_DESCRIPTION_LIMIT:
@@ -63,3 +69,13 @@ func (c *ClusterUpdateOptions) DecodeAndValidate(reader io.Reader) *errors.Err {
 func (s *ClusterUpdateOptions) ToJson() ([]byte, error) {
 	return json.Marshal(s)
 }
+
+// DecodeAndValidate - parse backup options from request headers
+func (c *ClusterBackupOptions) DecodeAndValidate(header http.Header) *errors.Err {
+	c.Passphrase = header.Get(HeaderPassphrase)
+	return nil
+}
+
+func (c *ClusterBackupOptions) GetOpts() *types.BackupOptions {
+	return &types.BackupOptions{Passphrase: c.Passphrase}
+}
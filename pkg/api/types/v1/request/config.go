@@ -76,6 +76,10 @@ func (v *ConfigManifest) SetConfigMeta(cfg *types.Config) {
 		cfg.Meta.Labels = v.Meta.Labels
 	}
 
+	if v.Meta.ResourceVersion != nil {
+		cfg.Runtime.System.Revision = *v.Meta.ResourceVersion
+	}
+
 }
 
 // SetConfigSpec - set config spec from manifest
@@ -31,4 +31,7 @@ type DeploymentUpdateOptions struct {
 		State   string `json:"state"`
 		Message string `json:"message"`
 	} `json:"status"`
+	// Deployment resource version update is based on
+	// required: false
+	ResourceVersion *int64 `json:"resource_version,omitempty"`
 }
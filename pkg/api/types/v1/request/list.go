@@ -0,0 +1,37 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package request
+
+// ListOptions represents list query parameters:
+// label and field selectors, pagination and watch
+//
+// swagger:ignore
+type ListOptions struct {
+	// Label selector, like `app=web,tier in (front,back)`
+	LabelSelector string
+	// Field selector, like `status.state=ready,meta.node=n1`
+	FieldSelector string
+	// Max count of items in response
+	Limit int64
+	// Token from previous response to continue list
+	Continue string
+	// Stream changes instead of list
+	Watch bool
+	// Storage revision to resume watch from
+	Revision *int64
+}
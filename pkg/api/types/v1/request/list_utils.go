@@ -0,0 +1,141 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package request
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/selector"
+)
+
+type ListRequest struct{}
+
+func (ListRequest) Options() *ListOptions {
+	return new(ListOptions)
+}
+
+// DecodeAndValidate - parse list query parameters
+func (l *ListOptions) DecodeAndValidate(values url.Values) *errors.Err {
+
+	l.LabelSelector = values.Get("labelSelector")
+	l.FieldSelector = values.Get("fieldSelector")
+	l.Continue = values.Get("continue")
+
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || limit < 1 {
+			return errors.New("list").BadParameter("limit")
+		}
+		l.Limit = limit
+	}
+
+	if v := values.Get("watch"); v != "" {
+		watch, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("list").BadParameter("watch")
+		}
+		l.Watch = watch
+	}
+
+	if v := values.Get("revision"); v != "" {
+		revision, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || revision < 0 {
+			return errors.New("list").BadParameter("revision")
+		}
+		l.Revision = &revision
+	}
+
+	return l.Validate()
+}
+
+func (l *ListOptions) Validate() *errors.Err {
+
+	if _, err := selector.ParseLabels(l.LabelSelector); err != nil {
+		return errors.New("list").BadParameter("labelSelector", err)
+	}
+
+	if _, err := selector.ParseFields(l.FieldSelector); err != nil {
+		return errors.New("list").BadParameter("fieldSelector", err)
+	}
+
+	if l.Revision != nil && !l.Watch {
+		return errors.New("list").BadParameter("revision")
+	}
+
+	// watch streams all matched changes, pagination is not supported
+	if l.Watch && (l.Limit > 0 || l.Continue != "") {
+		return errors.New("list").BadParameter("watch")
+	}
+
+	return nil
+}
+
+// GetOptions - convert query parameters to list options
+func (l *ListOptions) GetOptions() *types.ListOptions {
+
+	// selectors are validated on decode
+	s, _ := selector.New(l.LabelSelector, l.FieldSelector)
+
+	return &types.ListOptions{
+		Selector: s,
+		Limit:    l.Limit,
+		Continue: l.Continue,
+	}
+}
+
+// GetWatchOptions - convert query parameters to watch options
+func (l *ListOptions) GetWatchOptions() *types.WatchOptions {
+
+	// selectors are validated on decode
+	s, _ := selector.New(l.LabelSelector, l.FieldSelector)
+
+	return &types.WatchOptions{
+		Selector: s,
+		Revision: l.Revision,
+	}
+}
+
+// ToQuery - encode list options to query parameters
+func (l *ListOptions) ToQuery() url.Values {
+
+	values := url.Values{}
+
+	if l.LabelSelector != "" {
+		values.Set("labelSelector", l.LabelSelector)
+	}
+	if l.FieldSelector != "" {
+		values.Set("fieldSelector", l.FieldSelector)
+	}
+	if l.Limit > 0 {
+		values.Set("limit", strconv.FormatInt(l.Limit, 10))
+	}
+	if l.Continue != "" {
+		values.Set("continue", l.Continue)
+	}
+	if l.Watch {
+		values.Set("watch", strconv.FormatBool(l.Watch))
+	}
+	if l.Revision != nil {
+		values.Set("revision", strconv.FormatInt(*l.Revision, 10))
+	}
+
+	return values
+}
@@ -23,11 +23,36 @@ import (
 	"strings"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/validator"
 )
 
 type ManifestSpecSelector struct {
-	Node   string            `json:"node,omitempty" yaml:"node,omitempty"`
-	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Node        string                   `json:"node,omitempty" yaml:"node,omitempty"`
+	Labels      map[string]string        `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Tolerations []ManifestSpecToleration `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+}
+
+type ManifestSpecToleration struct {
+	Key   string `json:"key,omitempty" yaml:"key,omitempty"`
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+type ManifestSpecAffinity struct {
+	Affinity     []ManifestSpecAffinityRule `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+	AntiAffinity []ManifestSpecAffinityRule `json:"anti_affinity,omitempty" yaml:"anti_affinity,omitempty"`
+	Spread       *ManifestSpecSpread        `json:"spread,omitempty" yaml:"spread,omitempty"`
+}
+
+type ManifestSpecAffinityRule struct {
+	Service  string `json:"service,omitempty" yaml:"service,omitempty"`
+	Topology string `json:"topology,omitempty" yaml:"topology,omitempty"`
+	Required bool   `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+type ManifestSpecSpread struct {
+	Topology string `json:"topology,omitempty" yaml:"topology,omitempty"`
+	MaxSkew  int    `json:"max_skew,omitempty" yaml:"max_skew,omitempty"`
+	Required bool   `json:"required,omitempty" yaml:"required,omitempty"`
 }
 
 type ManifestSpecNetwork struct {
@@ -36,7 +61,13 @@ type ManifestSpecNetwork struct {
 }
 
 type ManifestSpecStrategy struct {
-	Type *string `json:"type,omitempty" yaml:"type,omitempty"`
+	Type      *string                     `json:"type,omitempty" yaml:"type,omitempty"`
+	Revisions *int                        `json:"revisions,omitempty" yaml:"revisions,omitempty"`
+	Canary    *ManifestSpecStrategyCanary `json:"canary,omitempty" yaml:"canary,omitempty"`
+}
+
+type ManifestSpecStrategyCanary struct {
+	Percent int `json:"percent,omitempty" yaml:"percent,omitempty"`
 }
 
 type ManifestSpecTemplate struct {
@@ -56,6 +87,7 @@ type ManifestSpecTemplateContainer struct {
 	Image         ManifestSpecTemplateContainerImage     `json:"image,omitempty" yaml:"image,omitempty"`
 	Resources     ManifestSpecTemplateContainerResources `json:"resources,omitempty" yaml:"resources,omitempty"`
 	RestartPolicy ManifestSpecTemplateRestartPolicy      `json:"restart,omitempty" yaml:"restart,omitempty"`
+	Probes        ManifestSpecTemplateContainerProbes    `json:"probes,omitempty" yaml:"probes,omitempty"`
 }
 
 type ManifestSpecTemplateContainerEnv struct {
@@ -80,6 +112,34 @@ type ManifestSpecTemplateContainerImage struct {
 	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
 }
 
+type ManifestSpecTemplateContainerProbes struct {
+	// Liveness probe restarts container on failure
+	Live *ManifestSpecTemplateContainerProbe `json:"live,omitempty" yaml:"live,omitempty"`
+	// Readiness probe marks container as ready to receive traffic
+	Ready *ManifestSpecTemplateContainerProbe `json:"ready,omitempty" yaml:"ready,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbe struct {
+	Exec             string                                    `json:"exec,omitempty" yaml:"exec,omitempty"`
+	Socket           *ManifestSpecTemplateContainerProbeSocket `json:"socket,omitempty" yaml:"socket,omitempty"`
+	HTTP             *ManifestSpecTemplateContainerProbeHTTP   `json:"http,omitempty" yaml:"http,omitempty"`
+	InitialDelay     int                                       `json:"initial_delay,omitempty" yaml:"initial_delay,omitempty"`
+	Timeout          int                                       `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Period           int                                       `json:"period,omitempty" yaml:"period,omitempty"`
+	ThresholdSuccess int                                       `json:"threshold_success,omitempty" yaml:"threshold_success,omitempty"`
+	ThresholdFailure int                                       `json:"threshold_failure,omitempty" yaml:"threshold_failure,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbeSocket struct {
+	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Port     int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbeHTTP struct {
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	Port int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
 type ManifestSpecTemplateContainerResources struct {
 	// Limit resources
 	Limits ManifestSpecTemplateContainerResource `json:"limits,omitempty" yaml:"limits,omitempty"`
@@ -158,9 +218,80 @@ func (m ManifestSpecSelector) GetSpec() types.SpecSelector {
 	s.Node = m.Node
 	s.Labels = m.Labels
 
+	for _, t := range m.Tolerations {
+		s.Tolerations = append(s.Tolerations, types.SpecToleration{Key: t.Key, Value: t.Value})
+	}
+
 	return s
 }
 
+func (m ManifestSpecAffinity) GetSpec() types.SpecAffinity {
+	s := types.SpecAffinity{}
+
+	for _, r := range m.Affinity {
+		s.Affinity = append(s.Affinity, types.SpecAffinityRule{
+			Service:  r.Service,
+			Topology: r.Topology,
+			Required: r.Required,
+		})
+	}
+
+	for _, r := range m.AntiAffinity {
+		s.AntiAffinity = append(s.AntiAffinity, types.SpecAffinityRule{
+			Service:  r.Service,
+			Topology: r.Topology,
+			Required: r.Required,
+		})
+	}
+
+	if m.Spread != nil {
+		s.Spread = &types.SpecTopologySpread{
+			Topology: m.Spread.Topology,
+			MaxSkew:  m.Spread.MaxSkew,
+			Required: m.Spread.Required,
+		}
+	}
+
+	return s
+}
+
+func (m ManifestSpecAffinity) valid() bool {
+
+	for _, r := range m.Affinity {
+		if r.Service != types.EmptyString && !validator.IsServiceName(r.Service) {
+			return false
+		}
+	}
+
+	for _, r := range m.AntiAffinity {
+		if r.Service != types.EmptyString && !validator.IsServiceName(r.Service) {
+			return false
+		}
+	}
+
+	if m.Spread != nil && m.Spread.MaxSkew < 0 {
+		return false
+	}
+
+	return true
+}
+
+func (m ManifestSpecStrategy) valid() bool {
+
+	if m.Type != nil && *m.Type != types.EmptyString {
+		s := types.SpecStrategy{Type: *m.Type}
+		if !s.Staged() {
+			return false
+		}
+	}
+
+	if m.Canary != nil && (m.Canary.Percent < 0 || m.Canary.Percent > 100) {
+		return false
+	}
+
+	return true
+}
+
 func (m ManifestSpecTemplate) GetSpec() types.SpecTemplate {
 	var s = types.SpecTemplate{}
 
@@ -219,6 +350,8 @@ func (m ManifestSpecTemplateContainer) GetSpec() types.SpecTemplateContainer {
 	s.RestartPolicy.Policy = m.RestartPolicy.Policy
 	s.RestartPolicy.Attempt = m.RestartPolicy.Attempt
 
+	s.Probes = m.Probes.GetSpec()
+
 	s.Exec.Command = strings.Split(m.Command, " ")
 	s.Exec.Args = m.Args
 	s.Exec.Workdir = m.Workdir
@@ -265,3 +398,41 @@ func (m ManifestSpecTemplateContainer) GetSpec() types.SpecTemplateContainer {
 
 	return s
 }
+
+func (m ManifestSpecTemplateContainerProbes) GetSpec() types.SpecTemplateContainerProbes {
+	var s = types.SpecTemplateContainerProbes{}
+
+	if m.Live != nil {
+		s.LiveProbe = m.Live.GetSpec()
+	}
+
+	if m.Ready != nil {
+		s.ReadProbe = m.Ready.GetSpec()
+	}
+
+	return s
+}
+
+func (m ManifestSpecTemplateContainerProbe) GetSpec() types.SpecTemplateContainerProbe {
+	var s = types.SpecTemplateContainerProbe{}
+
+	s.Exec.Command = strings.Fields(m.Exec)
+
+	if m.Socket != nil {
+		s.Socket.Protocol = m.Socket.Protocol
+		s.Socket.Port = m.Socket.Port
+	}
+
+	if m.HTTP != nil {
+		s.HTTP.Path = m.HTTP.Path
+		s.HTTP.Port = m.HTTP.Port
+	}
+
+	s.InitialDelaySeconds = m.InitialDelay
+	s.TimeoutSeconds = m.Timeout
+	s.PeriodSeconds = m.Period
+	s.ThresholdSuccess = m.ThresholdSuccess
+	s.ThresholdFailure = m.ThresholdFailure
+
+	return s
+}
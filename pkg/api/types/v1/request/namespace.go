@@ -69,6 +69,10 @@ func (s *NamespaceManifest) SetNamespaceMeta(ns *types.Namespace) {
 		ns.Meta.Labels = s.Meta.Labels
 	}
 
+	if s.Meta.ResourceVersion != nil {
+		ns.Runtime.System.Revision = *s.Meta.ResourceVersion
+	}
+
 }
 
 func (s *NamespaceManifest) SetNamespaceSpec(ns *types.Namespace) {
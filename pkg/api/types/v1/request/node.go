@@ -94,6 +94,11 @@ type NodeRouteStatusOptions struct {
 	Message string `json:"message" yaml:"message"`
 }
 
+// swagger:model request_node_taints
+type NodeTaintsOptions struct {
+	Taints []types.NodeTaint `json:"taints"`
+}
+
 // swagger:ignore
 // swagger:model request_node_remove
 type NodeRemoveOptions struct {
@@ -85,6 +85,10 @@ func (s *PodManifest) SetPodSpec(pod *types.Pod) {
 			pod.Spec.Selector.Labels = s.Spec.Selector.Labels
 		}
 
+		if s.Spec.Selector.Tolerations != nil {
+			pod.Spec.Selector.Tolerations = s.Spec.Selector.GetSpec().Tolerations
+		}
+
 	}
 
 	if s.Spec.Template != nil {
@@ -219,6 +223,12 @@ func (s *PodManifest) SetPodSpec(pod *types.Pod) {
 				pod.Spec.Template.Updated = time.Now()
 			}
 
+			probes := c.Probes.GetSpec()
+			if !spec.Probes.LiveProbe.Equal(probes.LiveProbe) || !spec.Probes.ReadProbe.Equal(probes.ReadProbe) {
+				spec.Probes = probes
+				pod.Spec.Template.Updated = time.Now()
+			}
+
 			for _, v := range c.Volumes {
 
 				var f = false
@@ -28,4 +28,6 @@ type RuntimeMeta struct {
 	Name        *string           `json:"name,omitempty" yaml:"name,omitempty"`
 	Description *string           `json:"description,omitempty",yaml:"description,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// ResourceVersion - entity resource version update is based on
+	ResourceVersion *int64 `json:"resource_version,omitempty" yaml:"resource_version,omitempty"`
 }
@@ -0,0 +1,41 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package request
+
+// RoleOptions represents role options
+//
+// swagger:model request_role
+type RoleOptions struct {
+	// Role name
+	// required: true
+	Name string `json:"name"`
+	// Role description
+	// required: false
+	Description string `json:"description"`
+	// Rules granting verbs on resources
+	// required: true
+	Rules []RoleRuleOptions `json:"rules"`
+}
+
+// RoleRuleOptions represents role rule, `*` matches any resource or verb
+//
+// swagger:model request_role_rule
+type RoleRuleOptions struct {
+	Resources []string `json:"resources"`
+	Verbs     []string `json:"verbs"`
+}
@@ -0,0 +1,99 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package request
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/validator"
+)
+
+type RoleRequest struct{}
+
+func (RoleRequest) Options() *RoleOptions {
+	return new(RoleOptions)
+}
+
+func (r *RoleOptions) Validate() *errors.Err {
+	switch true {
+	case !validator.IsServiceName(r.Name):
+		return errors.New("role").BadParameter("name")
+	case len(r.Description) > DEFAULT_DESCRIPTION_LIMIT:
+		return errors.New("role").BadParameter("description")
+	case len(r.Rules) == 0:
+		return errors.New("role").BadParameter("rules")
+	}
+
+	for _, rule := range r.Rules {
+		if len(rule.Resources) == 0 || len(rule.Verbs) == 0 {
+			return errors.New("role").BadParameter("rules")
+		}
+		for _, verb := range rule.Verbs {
+			switch verb {
+			case types.RuleAny, types.VerbGet, types.VerbList, types.VerbCreate, types.VerbUpdate, types.VerbDelete:
+			default:
+				return errors.New("role").BadParameter("verbs")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *RoleOptions) DecodeAndValidate(reader io.Reader) *errors.Err {
+
+	if reader == nil {
+		err := errors.New("data body can not be null")
+		return errors.New("role").IncorrectJSON(err)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.New("role").Unknown(err)
+	}
+
+	err = json.Unmarshal(body, r)
+	if err != nil {
+		return errors.New("role").IncorrectJSON(err)
+	}
+
+	return r.Validate()
+}
+
+func (r *RoleOptions) ToJson() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// SetRole - apply options to role
+func (r *RoleOptions) SetRole(role *types.Role) {
+
+	if role.Meta.Name == types.EmptyString {
+		role.Meta.Name = r.Name
+	}
+
+	role.Meta.Description = r.Description
+
+	role.Spec.Rules = make([]types.RoleRule, 0)
+	for _, rule := range r.Rules {
+		role.Spec.Rules = append(role.Spec.Rules, types.RoleRule{Resources: rule.Resources, Verbs: rule.Verbs})
+	}
+}
@@ -82,6 +82,10 @@ func (r *RouteManifest) SetRouteMeta(route *types.Route) {
 		route.Meta.Labels = r.Meta.Labels
 	}
 
+	if r.Meta.ResourceVersion != nil {
+		route.Runtime.System.Revision = *r.Meta.ResourceVersion
+	}
+
 }
 
 func (r *RouteManifest) SetRouteSpec(route *types.Route, svc *types.ServiceList) {
@@ -72,6 +72,10 @@ func (v *SecretManifest) SetSecretMeta(cfg *types.Secret) {
 		cfg.Meta.Labels = v.Meta.Labels
 	}
 
+	if v.Meta.ResourceVersion != nil {
+		cfg.Runtime.System.Revision = *v.Meta.ResourceVersion
+	}
+
 }
 
 func (v *SecretManifest) SetAuthData(username, password string) {
@@ -24,6 +24,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/resource"
 	"gopkg.in/yaml.v2"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +42,7 @@ type ServiceManifestMeta struct {
 type ServiceManifestSpec struct {
 	Replicas *int                  `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	Selector *ManifestSpecSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Affinity *ManifestSpecAffinity `json:"affinity,omitempty" yaml:"affinity,omitempty"`
 	Network  *ManifestSpecNetwork  `json:"network,omitempty" yaml:"network,omitempty"`
 	Strategy *ManifestSpecStrategy `json:"strategy,omitempty" yaml:"strategy,omitempty"`
 	Template *ManifestSpecTemplate `json:"template,omitempty" yaml:"template,omitempty"`
@@ -76,6 +78,10 @@ func (s *ServiceManifest) SetServiceMeta(svc *types.Service) {
 		svc.Meta.Labels = s.Meta.Labels
 	}
 
+	if s.Meta.ResourceVersion != nil {
+		svc.Runtime.System.Revision = *s.Meta.ResourceVersion
+	}
+
 }
 
 func (s *ServiceManifest) SetServiceSpec(svc *types.Service) (err error) {
@@ -165,6 +171,12 @@ func (s *ServiceManifest) SetServiceSpec(svc *types.Service) (err error) {
 			svc.Spec.Selector.Updated = time.Now()
 		}
 
+		tolerations := s.Spec.Selector.GetSpec().Tolerations
+		if !reflect.DeepEqual(svc.Spec.Selector.Tolerations, tolerations) {
+			svc.Spec.Selector.Tolerations = tolerations
+			svc.Spec.Selector.Updated = time.Now()
+		}
+
 	} else {
 
 		if svc.Spec.Selector.Node != types.EmptyString {
@@ -176,12 +188,39 @@ func (s *ServiceManifest) SetServiceSpec(svc *types.Service) (err error) {
 			svc.Spec.Selector.Labels = make(map[string]string)
 			svc.Spec.Selector.Updated = time.Now()
 		}
+
+		if len(svc.Spec.Selector.Tolerations) > 0 {
+			svc.Spec.Selector.Tolerations = nil
+			svc.Spec.Selector.Updated = time.Now()
+		}
+	}
+
+	if s.Spec.Affinity != nil {
+
+		spec := s.Spec.Affinity.GetSpec()
+		spec.Updated = svc.Spec.Affinity.Updated
+
+		if !reflect.DeepEqual(svc.Spec.Affinity, spec) {
+			spec.Updated = time.Now()
+			svc.Spec.Affinity = spec
+		}
+
+	} else if !svc.Spec.Affinity.Empty() {
+		svc.Spec.Affinity = types.SpecAffinity{Updated: time.Now()}
 	}
 
 	if s.Spec.Strategy != nil {
 		if s.Spec.Strategy.Type != nil {
 			svc.Spec.Strategy.Type = *s.Spec.Strategy.Type
 		}
+
+		if s.Spec.Strategy.Revisions != nil {
+			svc.Spec.Strategy.Revisions = *s.Spec.Strategy.Revisions
+		}
+
+		if s.Spec.Strategy.Canary != nil {
+			svc.Spec.Strategy.CanaryOptions.Percent = s.Spec.Strategy.Canary.Percent
+		}
 	}
 
 	if s.Spec.Template != nil {
@@ -349,6 +388,13 @@ func (s *ServiceManifest) SetServiceSpec(svc *types.Service) (err error) {
 				svc.Spec.Template.Updated = time.Now()
 			}
 
+			// Probes check
+			probes := c.Probes.GetSpec()
+			if !spec.Probes.LiveProbe.Equal(probes.LiveProbe) || !spec.Probes.ReadProbe.Equal(probes.ReadProbe) {
+				spec.Probes = probes
+				svc.Spec.Template.Updated = time.Now()
+			}
+
 			// Volumes check
 			for _, v := range c.Volumes {
 
@@ -560,8 +606,31 @@ type ServiceRemoveOptions struct {
 // swagger:ignore
 // swagger:model request_service_logs
 type ServiceLogsOptions struct {
+	Deployment string    `json:"deployment"`
+	Pod        string    `json:"pod"`
+	Container  string    `json:"container"`
+	Follow     bool      `json:"follow"`
+	Tail       int       `json:"tail"`
+	Since      time.Time `json:"since"`
+	Timestamps bool      `json:"timestamps"`
+	Previous   bool      `json:"previous"`
+}
+
+// swagger:ignore
+// swagger:model request_service_exec
+type ServiceExecOptions struct {
+	Deployment string   `json:"deployment"`
+	Pod        string   `json:"pod"`
+	Container  string   `json:"container"`
+	Command    []string `json:"command"`
+	Tty        bool     `json:"tty"`
+	Stdin      bool     `json:"stdin"`
+}
+
+// swagger:ignore
+// swagger:model request_service_port_forward
+type ServicePortForwardOptions struct {
 	Deployment string `json:"deployment"`
 	Pod        string `json:"pod"`
-	Container  string `json:"container"`
-	Follow     bool   `json:"follow"`
+	Port       uint16 `json:"port"`
 }
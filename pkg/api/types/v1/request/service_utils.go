@@ -22,8 +22,12 @@ import (
 	"encoding/json"
 	"io"
 	"io/ioutil"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/util/validator"
 )
 
@@ -39,6 +43,12 @@ func (s *ServiceManifest) Validate() *errors.Err {
 		return errors.New("service").BadParameter("name")
 	case s.Meta.Description != nil && len(*s.Meta.Description) > DEFAULT_DESCRIPTION_LIMIT:
 		return errors.New("service").BadParameter("description")
+	case s.Spec.Affinity != nil && !s.Spec.Affinity.valid():
+		return errors.New("service").BadParameter("affinity")
+	case s.Spec.Strategy != nil && s.Spec.Strategy.Revisions != nil && *s.Spec.Strategy.Revisions < 0:
+		return errors.New("service").BadParameter("revisions")
+	case s.Spec.Strategy != nil && !s.Spec.Strategy.valid():
+		return errors.New("service").BadParameter("strategy")
 	case len(s.Spec.Template.Containers) == 0:
 		return errors.New("service").BadParameter("spec")
 	case len(s.Spec.Template.Containers) != 0:
@@ -83,3 +93,175 @@ func (ServiceRequest) RemoveOptions() *ServiceRemoveOptions {
 func (s *ServiceRemoveOptions) Validate() *errors.Err {
 	return nil
 }
+
+func (ServiceRequest) LogsOptions() *ServiceLogsOptions {
+	return new(ServiceLogsOptions)
+}
+
+// DecodeAndValidate - parse logs query parameters, since is accepted as duration relative to now or RFC3339 time
+func (s *ServiceLogsOptions) DecodeAndValidate(values url.Values) *errors.Err {
+
+	s.Deployment = values.Get("deployment")
+	s.Pod = values.Get("pod")
+	s.Container = values.Get("container")
+
+	flags := map[string]*bool{
+		"follow":     &s.Follow,
+		"timestamps": &s.Timestamps,
+		"previous":   &s.Previous,
+	}
+
+	for name, flag := range flags {
+		if v := values.Get(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return errors.New("logs").BadParameter(name)
+			}
+			*flag = b
+		}
+	}
+
+	if v := values.Get("tail"); v != "" {
+		tail, err := strconv.Atoi(v)
+		if err != nil || tail < 0 {
+			return errors.New("logs").BadParameter("tail")
+		}
+		s.Tail = tail
+	}
+
+	if v := values.Get("since"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			s.Since = time.Now().UTC().Add(-d)
+		} else if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			s.Since = t
+		} else {
+			return errors.New("logs").BadParameter("since")
+		}
+	}
+
+	return nil
+}
+
+// ToQuery - encode logs options into query parameters
+func (s *ServiceLogsOptions) ToQuery() url.Values {
+
+	values := url.Values{}
+	if s.Deployment != "" {
+		values.Set("deployment", s.Deployment)
+	}
+	if s.Pod != "" {
+		values.Set("pod", s.Pod)
+	}
+	if s.Container != "" {
+		values.Set("container", s.Container)
+	}
+	if s.Tail > 0 {
+		values.Set("tail", strconv.Itoa(s.Tail))
+	}
+	if !s.Since.IsZero() {
+		values.Set("since", s.Since.Format(time.RFC3339Nano))
+	}
+	values.Set("follow", strconv.FormatBool(s.Follow))
+	values.Set("timestamps", strconv.FormatBool(s.Timestamps))
+	values.Set("previous", strconv.FormatBool(s.Previous))
+
+	return values
+}
+
+func (s *ServiceLogsOptions) GetContainerLogs() *types.ContainerLogsOptions {
+	return &types.ContainerLogsOptions{
+		Stdout:     true,
+		Stderr:     true,
+		Follow:     s.Follow,
+		Timestamps: s.Timestamps,
+		Tail:       s.Tail,
+		Since:      s.Since,
+	}
+}
+
+func (ServiceRequest) ExecOptions() *ServiceExecOptions {
+	return new(ServiceExecOptions)
+}
+
+// DecodeAndValidate - parse exec query parameters, command arguments are passed as repeated `command` parameter
+func (s *ServiceExecOptions) DecodeAndValidate(values url.Values) *errors.Err {
+
+	s.Deployment = values.Get("deployment")
+	s.Pod = values.Get("pod")
+	s.Container = values.Get("container")
+	s.Command = values["command"]
+
+	if v := values.Get("tty"); v != "" {
+		tty, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("exec").BadParameter("tty")
+		}
+		s.Tty = tty
+	}
+
+	if v := values.Get("stdin"); v != "" {
+		stdin, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("exec").BadParameter("stdin")
+		}
+		s.Stdin = stdin
+	}
+
+	if len(s.Command) == 0 || s.Command[0] == "" {
+		return errors.New("exec").BadParameter("command")
+	}
+
+	return nil
+}
+
+// ToQuery - encode command options into query parameters of node exec request
+func (s *ServiceExecOptions) ToQuery() url.Values {
+
+	values := url.Values{}
+	values["command"] = s.Command
+	values.Set("tty", strconv.FormatBool(s.Tty))
+	values.Set("stdin", strconv.FormatBool(s.Stdin))
+
+	return values
+}
+
+func (s *ServiceExecOptions) GetContainerExec() *types.ContainerExec {
+	return &types.ContainerExec{
+		Command: s.Command,
+		Tty:     s.Tty,
+	}
+}
+
+func (ServiceRequest) PortForwardOptions() *ServicePortForwardOptions {
+	return new(ServicePortForwardOptions)
+}
+
+// DecodeAndValidate - parse port forward query parameters
+func (s *ServicePortForwardOptions) DecodeAndValidate(values url.Values) *errors.Err {
+
+	s.Deployment = values.Get("deployment")
+	s.Pod = values.Get("pod")
+
+	port, err := strconv.ParseUint(values.Get("port"), 10, 16)
+	if err != nil || port == 0 {
+		return errors.New("port forward").BadParameter("port")
+	}
+	s.Port = uint16(port)
+
+	return nil
+}
+
+// ToQuery - encode port forward options into query parameters
+func (s *ServicePortForwardOptions) ToQuery() url.Values {
+
+	values := url.Values{}
+	if s.Deployment != "" {
+		values.Set("deployment", s.Deployment)
+	}
+	if s.Pod != "" {
+		values.Set("pod", s.Pod)
+	}
+	values.Set("port", strconv.FormatUint(uint64(s.Port), 10))
+
+	return values
+}
@@ -34,6 +34,7 @@ const (
 type IRequest interface {
 	Cluster() *ClusterRequest
 	Deployment() *DeploymentRequest
+	Autoscaler() *AutoscalerRequest
 	Namespace() *NamespaceRequest
 	Node() *NodeRequest
 	Endpoint() *EndpointRequest
@@ -44,6 +45,11 @@ type IRequest interface {
 	Volume() *VolumeRequest
 	Ingress() *IngressRequest
 	Discovery() *DiscoveryRequest
+	User() *UserRequest
+	Role() *RoleRequest
+	Audit() *AuditRequest
+	Apply() *ApplyRequest
+	List() *ListRequest
 }
 
 type Request struct{}
@@ -54,6 +60,9 @@ func (Request) Cluster() *ClusterRequest {
 func (Request) Deployment() *DeploymentRequest {
 	return new(DeploymentRequest)
 }
+func (Request) Autoscaler() *AutoscalerRequest {
+	return new(AutoscalerRequest)
+}
 func (Request) Namespace() *NamespaceRequest {
 	return new(NamespaceRequest)
 }
@@ -84,3 +93,18 @@ func (Request) Ingress() *IngressRequest {
 func (Request) Discovery() *DiscoveryRequest {
 	return new(DiscoveryRequest)
 }
+func (Request) User() *UserRequest {
+	return new(UserRequest)
+}
+func (Request) Role() *RoleRequest {
+	return new(RoleRequest)
+}
+func (Request) Audit() *AuditRequest {
+	return new(AuditRequest)
+}
+func (Request) Apply() *ApplyRequest {
+	return new(ApplyRequest)
+}
+func (Request) List() *ListRequest {
+	return new(ListRequest)
+}
@@ -0,0 +1,45 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package request
+
+// UserOptions represents api user or service account options
+//
+// swagger:model request_user
+type UserOptions struct {
+	// User name
+	// required: true
+	Name string `json:"name"`
+	// User description
+	// required: false
+	Description string `json:"description"`
+	// User type: user or service_account
+	// required: false
+	Type string `json:"type"`
+	// Roles bound to user
+	// required: false
+	Bindings []UserBindingOptions `json:"bindings"`
+}
+
+// UserBindingOptions represents role binding of user,
+// empty namespace binds role to whole cluster
+//
+// swagger:model request_user_binding
+type UserBindingOptions struct {
+	Role      string `json:"role"`
+	Namespace string `json:"namespace"`
+}
@@ -0,0 +1,97 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package request
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/validator"
+)
+
+type UserRequest struct{}
+
+func (UserRequest) Options() *UserOptions {
+	return new(UserOptions)
+}
+
+func (u *UserOptions) Validate() *errors.Err {
+	switch true {
+	case !validator.IsServiceName(u.Name):
+		return errors.New("user").BadParameter("name")
+	case len(u.Description) > DEFAULT_DESCRIPTION_LIMIT:
+		return errors.New("user").BadParameter("description")
+	case u.Type != "" && u.Type != types.UserTypeUser && u.Type != types.UserTypeServiceAccount:
+		return errors.New("user").BadParameter("type")
+	}
+
+	for _, b := range u.Bindings {
+		if b.Role == "" {
+			return errors.New("user").BadParameter("bindings")
+		}
+	}
+
+	if u.Type == "" {
+		u.Type = types.UserTypeUser
+	}
+
+	return nil
+}
+
+func (u *UserOptions) DecodeAndValidate(reader io.Reader) *errors.Err {
+
+	if reader == nil {
+		err := errors.New("data body can not be null")
+		return errors.New("user").IncorrectJSON(err)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.New("user").Unknown(err)
+	}
+
+	err = json.Unmarshal(body, u)
+	if err != nil {
+		return errors.New("user").IncorrectJSON(err)
+	}
+
+	return u.Validate()
+}
+
+func (u *UserOptions) ToJson() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// SetUser - apply options to user
+func (u *UserOptions) SetUser(user *types.User) {
+
+	if user.Meta.Name == types.EmptyString {
+		user.Meta.Name = u.Name
+	}
+
+	user.Meta.Description = u.Description
+	user.Spec.Type = u.Type
+
+	user.Spec.Bindings = make([]types.UserBinding, 0)
+	for _, b := range u.Bindings {
+		user.Spec.Bindings = append(user.Spec.Bindings, types.UserBinding{Role: b.Role, Namespace: b.Namespace})
+	}
+}
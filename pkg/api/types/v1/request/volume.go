@@ -80,6 +80,10 @@ func (v *VolumeManifest) SetVolumeMeta(vol *types.Volume) {
 		vol.Meta.Labels = v.Meta.Labels
 	}
 
+	if v.Meta.ResourceVersion != nil {
+		vol.Runtime.System.Revision = *v.Meta.ResourceVersion
+	}
+
 }
 
 func (v *VolumeManifest) SetVolumeSpec(vol *types.Volume) {
@@ -0,0 +1,65 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type ApplyView struct{}
+
+func (av *ApplyView) New(obj *types.ApplyResult) *ApplyResult {
+	r := &ApplyResult{
+		Kind:      obj.Kind,
+		Namespace: obj.Namespace,
+		Name:      obj.Name,
+		Status:    obj.Status,
+	}
+
+	if obj.Error != nil {
+		r.Error = obj.Error.Error()
+	}
+
+	return r
+}
+
+func (a *ApplyResult) ToJson() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+func (av *ApplyView) NewList(obj *types.ApplyResultList) *ApplyResultList {
+	if obj == nil {
+		return nil
+	}
+
+	al := make(ApplyResultList, 0)
+	for _, v := range obj.Items {
+		al = append(al, av.New(v))
+	}
+	return &al
+}
+
+func (al *ApplyResultList) ToJson() ([]byte, error) {
+	if al == nil {
+		al = &ApplyResultList{}
+	}
+	return json.Marshal(al)
+}
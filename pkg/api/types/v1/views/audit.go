@@ -0,0 +1,51 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package views
+
+import "time"
+
+// AuditRecord is an audit record of api mutation request
+//
+// swagger:model views_audit_record
+type AuditRecord struct {
+	ID        string    `json:"id"`
+	User      string    `json:"user"`
+	Verb      string    `json:"verb"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Namespace string    `json:"namespace"`
+	BodyHash  string    `json:"body_hash"`
+	Truncated bool      `json:"body_truncated"`
+	Code      int       `json:"code"`
+	Timestamp time.Time     `json:"timestamp"`
+	Session   *AuditSession `json:"session,omitempty"`
+}
+
+// AuditSession is an exec or port forward session started in service pod
+//
+// swagger:model views_audit_session
+type AuditSession struct {
+	Service   string   `json:"service"`
+	Pod       string   `json:"pod"`
+	Container string   `json:"container,omitempty"`
+	Command   []string `json:"command,omitempty"`
+	Port      uint16   `json:"port,omitempty"`
+}
+
+// swagger:model views_audit_record_list
+type AuditRecordList []*AuditRecord
@@ -0,0 +1,76 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type AuditView struct{}
+
+func (av *AuditView) New(obj *types.AuditRecord) *AuditRecord {
+	a := &AuditRecord{
+		ID:        obj.ID,
+		User:      obj.User,
+		Verb:      obj.Verb,
+		Method:    obj.Method,
+		Path:      obj.Path,
+		Namespace: obj.Namespace,
+		BodyHash:  obj.BodyHash,
+		Truncated: obj.BodyTruncated,
+		Code:      obj.Code,
+		Timestamp: obj.Timestamp,
+	}
+
+	if obj.Session != nil {
+		a.Session = &AuditSession{
+			Service:   obj.Session.Service,
+			Pod:       obj.Session.Pod,
+			Container: obj.Session.Container,
+			Command:   obj.Session.Command,
+			Port:      obj.Session.Port,
+		}
+	}
+
+	return a
+}
+
+func (a *AuditRecord) ToJson() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+func (av *AuditView) NewList(obj *types.AuditRecordList) *AuditRecordList {
+	if obj == nil {
+		return nil
+	}
+
+	al := make(AuditRecordList, 0)
+	for _, v := range obj.Items {
+		al = append(al, av.New(v))
+	}
+	return &al
+}
+
+func (al *AuditRecordList) ToJson() ([]byte, error) {
+	if al == nil {
+		al = &AuditRecordList{}
+	}
+	return json.Marshal(al)
+}
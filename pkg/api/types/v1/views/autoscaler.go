@@ -0,0 +1,74 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import "time"
+
+// Autoscaler is a service autoscaler model for api
+//
+// swagger:model views_autoscaler
+type Autoscaler struct {
+	Meta   AutoscalerMeta   `json:"meta"`
+	Spec   AutoscalerSpec   `json:"spec"`
+	Status AutoscalerStatus `json:"status"`
+}
+
+// AutoscalerMeta is a meta of autoscaler model for api
+//
+// swagger:model views_autoscaler_meta
+type AutoscalerMeta struct {
+	Namespace       string    `json:"namespace"`
+	Service         string    `json:"service"`
+	SelfLink        string    `json:"self_link"`
+	Created         time.Time `json:"created"`
+	Updated         time.Time `json:"updated"`
+	ResourceVersion int64     `json:"resource_version"`
+}
+
+// AutoscalerSpec is a spec of autoscaler model for api
+//
+// swagger:model views_autoscaler_spec
+type AutoscalerSpec struct {
+	MinReplicas   int                     `json:"min_replicas"`
+	MaxReplicas   int                     `json:"max_replicas"`
+	CPU           int                     `json:"cpu"`
+	RAM           int                     `json:"ram"`
+	Stabilization AutoscalerStabilization `json:"stabilization"`
+}
+
+// AutoscalerStabilization is a stabilization windows of autoscaler in seconds
+//
+// swagger:model views_autoscaler_stabilization
+type AutoscalerStabilization struct {
+	ScaleUp   int `json:"scale_up"`
+	ScaleDown int `json:"scale_down"`
+}
+
+// AutoscalerStatus is a status of autoscaler model for api
+//
+// swagger:model views_autoscaler_status
+type AutoscalerStatus struct {
+	Replicas int       `json:"replicas"`
+	Desired  int       `json:"desired"`
+	CPU      int       `json:"cpu"`
+	RAM      int       `json:"ram"`
+	Message  string    `json:"message"`
+	Scaled   time.Time `json:"scaled"`
+	Updated  time.Time `json:"updated"`
+}
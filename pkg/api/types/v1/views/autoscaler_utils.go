@@ -0,0 +1,75 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type AutoscalerView struct{}
+
+func (av *AutoscalerView) New(obj *types.Autoscaler) *Autoscaler {
+	a := Autoscaler{}
+	a.Meta = a.ToMeta(obj.Meta)
+	a.Meta.ResourceVersion = obj.Runtime.System.Revision
+	a.Spec = a.ToSpec(obj.Spec)
+	a.Status = a.ToStatus(obj.Status)
+	return &a
+}
+
+func (a *Autoscaler) ToMeta(obj types.AutoscalerMeta) AutoscalerMeta {
+	return AutoscalerMeta{
+		Namespace: obj.Namespace,
+		Service:   obj.Service,
+		SelfLink:  obj.SelfLink,
+		Created:   obj.Created,
+		Updated:   obj.Updated,
+	}
+}
+
+func (a *Autoscaler) ToSpec(obj types.AutoscalerSpec) AutoscalerSpec {
+	return AutoscalerSpec{
+		MinReplicas: obj.MinReplicas,
+		MaxReplicas: obj.MaxReplicas,
+		CPU:         obj.CPU,
+		RAM:         obj.RAM,
+		Stabilization: AutoscalerStabilization{
+			ScaleUp:   obj.Stabilization.ScaleUp,
+			ScaleDown: obj.Stabilization.ScaleDown,
+		},
+	}
+}
+
+func (a *Autoscaler) ToStatus(obj types.AutoscalerStatus) AutoscalerStatus {
+	return AutoscalerStatus{
+		Replicas: obj.Replicas,
+		Desired:  obj.Desired,
+		CPU:      obj.CPU,
+		RAM:      obj.RAM,
+		Message:  obj.Message,
+		Scaled:   obj.Scaled,
+		Updated:  obj.Updated,
+	}
+}
+
+func (a *Autoscaler) ToJson() ([]byte, error) {
+	return json.Marshal(a)
+}
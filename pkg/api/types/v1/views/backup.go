@@ -0,0 +1,31 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import "time"
+
+// Backup is a description of backup archive restored into cluster
+//
+// swagger:model views_backup
+type Backup struct {
+	Version     int            `json:"version"`
+	Created     time.Time      `json:"created"`
+	Encrypted   bool           `json:"encrypted"`
+	Collections map[string]int `json:"collections"`
+}
@@ -29,6 +29,7 @@ type ClusterView struct{}
 
 func (cv *ClusterView) New(obj *types.Cluster) *Cluster {
 	c := Cluster{}
+	c.Meta.ResourceVersion = obj.Runtime.System.Revision
 	c.Status = cv.ToClusterStatus(obj.Status)
 	return &c
 }
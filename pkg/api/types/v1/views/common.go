@@ -20,6 +20,9 @@ package views
 
 import "time"
 
+// HeaderContinue - response header with token to request next page of list
+const HeaderContinue = "X-Lastbackend-Continue"
+
 type Meta struct {
 	// Meta name
 	Name string `json:"name,omitempty",yaml:"name,omitempty"`
@@ -33,4 +36,6 @@ type Meta struct {
 	Created time.Time `json:"created",yaml:"created"`
 	// Meta updated time
 	Updated time.Time `json:"updated",yaml:"updated"`
+	// Meta resource version
+	ResourceVersion int64 `json:"resource_version" yaml:"resource_version"`
 }
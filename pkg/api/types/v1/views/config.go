@@ -43,12 +43,13 @@ type ConfigSpecData struct {
 
 // swagger:model views_secret_meta
 type ConfigMeta struct {
-	Name      string    `json:"name"`
-	Namespace string    `json:"namespace"`
-	Kind      string    `json:"kind"`
-	SelfLink  string    `json:"self_link"`
-	Updated   time.Time `json:"updated"`
-	Created   time.Time `json:"created"`
+	Name            string    `json:"name"`
+	Namespace       string    `json:"namespace"`
+	Kind            string    `json:"kind"`
+	SelfLink        string    `json:"self_link"`
+	Updated         time.Time `json:"updated"`
+	Created         time.Time `json:"created"`
+	ResourceVersion int64     `json:"resource_version"`
 }
 
 // swagger:ignore
@@ -53,6 +53,35 @@ type DeploymentMap map[string]*Deployment
 // swagger:model views_deployment_list
 type DeploymentList []*Deployment
 
+// DeploymentRevision is a stored deployment spec of service
+//
+// swagger:model views_deployment_revision
+type DeploymentRevision struct {
+	// Revision version
+	Version int `json:"version"`
+	// Deployment name
+	Name string `json:"name"`
+	// Revision creation time
+	Created time.Time      `json:"created"`
+	Spec    DeploymentSpec `json:"spec"`
+	// Changes from previous revision
+	Diff []DeploymentRevisionDiff `json:"diff"`
+}
+
+// DeploymentRevisionDiff is a changed spec field between revisions
+//
+// swagger:model views_deployment_revision_diff
+type DeploymentRevisionDiff struct {
+	Field    string `json:"field"`
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
+}
+
+// DeploymentRevisionList is a list of deployment revisions
+//
+// swagger:model views_deployment_revision_list
+type DeploymentRevisionList []*DeploymentRevision
+
 // DeploymentMeta is a meta of deployment model for api
 //
 // swagger:model views_deployment_meta
@@ -73,6 +102,8 @@ type DeploymentMeta struct {
 	Created time.Time `json:"created"`
 	// Deployment creation time
 	Updated time.Time `json:"updated"`
+	// Deployment resource version
+	ResourceVersion int64 `json:"resource_version"`
 }
 
 // DeploymentSources is a source of deployment model for api
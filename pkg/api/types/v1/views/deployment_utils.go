@@ -20,6 +20,9 @@ package views
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 )
@@ -29,6 +32,7 @@ type DeploymentView struct{}
 func (dv *DeploymentView) New(obj *types.Deployment, pl *types.PodList) *Deployment {
 	d := Deployment{}
 	d.Meta = d.ToMeta(obj.Meta)
+	d.Meta.ResourceVersion = obj.Runtime.System.Revision
 	d.Status = d.ToStatus(obj.Status)
 	d.Spec = d.ToSpec(obj.Spec)
 
@@ -102,3 +106,111 @@ func (dv *DeploymentView) NewList(obj *types.DeploymentList, pods *types.PodList
 func (di *DeploymentList) ToJson() ([]byte, error) {
 	return json.Marshal(di)
 }
+
+func (dv *DeploymentView) NewRevisionList(obj *types.DeploymentList) *DeploymentRevisionList {
+	rl := make(DeploymentRevisionList, 0)
+
+	var prev *types.Deployment
+	for _, d := range obj.Items {
+
+		r := new(DeploymentRevision)
+		r.Version = d.Meta.Version
+		r.Name = d.Meta.Name
+		r.Created = d.Meta.Created
+		r.Spec = new(Deployment).ToSpec(d.Spec)
+		r.Diff = make([]DeploymentRevisionDiff, 0)
+
+		if prev != nil {
+			r.Diff = revisionDiff(prev.Spec, d.Spec)
+		}
+
+		prev = d
+		rl = append(rl, r)
+	}
+
+	return &rl
+}
+
+func (rl *DeploymentRevisionList) ToJson() ([]byte, error) {
+	return json.Marshal(rl)
+}
+
+// revisionDiff returns changed spec fields between revisions,
+// spec update timestamps are not treated as changes
+func revisionDiff(prev, curr types.DeploymentSpec) []DeploymentRevisionDiff {
+
+	var (
+		diff = make([]DeploymentRevisionDiff, 0)
+		pf   = make(map[string]string)
+		cf   = make(map[string]string)
+		keys = make([]string, 0)
+	)
+
+	revisionFlatten(types.EmptyString, revisionFields(prev), pf)
+	revisionFlatten(types.EmptyString, revisionFields(curr), cf)
+
+	for k := range pf {
+		keys = append(keys, k)
+	}
+
+	for k := range cf {
+		if _, ok := pf[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+
+		if k == "updated" || strings.HasSuffix(k, ".updated") {
+			continue
+		}
+
+		if pf[k] != cf[k] {
+			diff = append(diff, DeploymentRevisionDiff{Field: k, Previous: pf[k], Current: cf[k]})
+		}
+	}
+
+	return diff
+}
+
+func revisionFields(spec types.DeploymentSpec) interface{} {
+
+	var fields interface{}
+
+	buf, err := json.Marshal(spec)
+	if err != nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		return nil
+	}
+
+	return fields
+}
+
+func revisionFlatten(prefix string, obj interface{}, fields map[string]string) {
+
+	key := func(k string) string {
+		if prefix == types.EmptyString {
+			return k
+		}
+		return fmt.Sprintf("%s.%s", prefix, k)
+	}
+
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for k, f := range v {
+			revisionFlatten(key(k), f, fields)
+		}
+	case []interface{}:
+		for i, f := range v {
+			revisionFlatten(key(fmt.Sprintf("%d", i)), f, fields)
+		}
+	case nil:
+	default:
+		fields[prefix] = fmt.Sprintf("%v", v)
+	}
+}
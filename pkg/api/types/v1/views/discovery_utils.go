@@ -29,6 +29,7 @@ type DiscoveryView struct{}
 func (nv *DiscoveryView) New(obj *types.Discovery) *Discovery {
 	n := Discovery{}
 	n.Meta = nv.ToDiscoveryMeta(obj.Meta)
+	n.Meta.ResourceVersion = obj.Runtime.System.Revision
 	n.Status = nv.ToDiscoveryStatus(obj.Status)
 	return &n
 }
@@ -27,10 +27,11 @@ type Endpoint struct {
 }
 
 type EndpointMeta struct {
-	Name     string    `json:"name"`
-	SelfLink string    `json:"self_link"`
-	Updated  time.Time `json:"updated"`
-	Created  time.Time `json:"created"`
+	Name            string    `json:"name"`
+	SelfLink        string    `json:"self_link"`
+	Updated         time.Time `json:"updated"`
+	Created         time.Time `json:"created"`
+	ResourceVersion int64     `json:"resource_version"`
 }
 
 type EndpointSpec struct {
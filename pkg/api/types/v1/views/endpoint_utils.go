@@ -28,6 +28,7 @@ type EndpointView struct{}
 func (ev *EndpointView) New(obj *types.Endpoint) *Endpoint {
 	e := Endpoint{}
 	e.Meta = ev.ToEndpointMeta(obj.Meta)
+	e.Meta.ResourceVersion = obj.Runtime.System.Revision
 	e.Status = ev.ToEndpointStatus(obj.Status)
 	e.Spec = ev.ToEndpointSpec(obj.Spec)
 	return &e
@@ -29,6 +29,7 @@ type IngressView struct{}
 func (nv *IngressView) New(obj *types.Ingress) *Ingress {
 	n := Ingress{}
 	n.Meta = nv.ToIngressMeta(obj.Meta)
+	n.Meta.ResourceVersion = obj.Runtime.System.Revision
 	n.Status = nv.ToIngressStatus(obj.Status)
 	return &n
 }
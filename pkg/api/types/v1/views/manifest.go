@@ -19,8 +19,32 @@
 package views
 
 type ManifestSpecSelector struct {
-	Node   string            `json:"node,omitempty" yaml:"node,omitempty"`
-	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Node        string                   `json:"node,omitempty" yaml:"node,omitempty"`
+	Labels      map[string]string        `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Tolerations []ManifestSpecToleration `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+}
+
+type ManifestSpecToleration struct {
+	Key   string `json:"key,omitempty" yaml:"key,omitempty"`
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+type ManifestSpecAffinity struct {
+	Affinity     []ManifestSpecAffinityRule `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+	AntiAffinity []ManifestSpecAffinityRule `json:"anti_affinity,omitempty" yaml:"anti_affinity,omitempty"`
+	Spread       *ManifestSpecSpread        `json:"spread,omitempty" yaml:"spread,omitempty"`
+}
+
+type ManifestSpecAffinityRule struct {
+	Service  string `json:"service,omitempty" yaml:"service,omitempty"`
+	Topology string `json:"topology,omitempty" yaml:"topology,omitempty"`
+	Required bool   `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+type ManifestSpecSpread struct {
+	Topology string `json:"topology,omitempty" yaml:"topology,omitempty"`
+	MaxSkew  int    `json:"max_skew,omitempty" yaml:"max_skew,omitempty"`
+	Required bool   `json:"required,omitempty" yaml:"required,omitempty"`
 }
 
 type ManifestSpecNetwork struct {
@@ -29,7 +53,13 @@ type ManifestSpecNetwork struct {
 }
 
 type ManifestSpecStrategy struct {
-	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	Type      string                      `json:"type,omitempty" yaml:"type,omitempty"`
+	Revisions int                         `json:"revisions,omitempty" yaml:"revisions,omitempty"`
+	Canary    *ManifestSpecStrategyCanary `json:"canary,omitempty" yaml:"canary,omitempty"`
+}
+
+type ManifestSpecStrategyCanary struct {
+	Percent int `json:"percent,omitempty" yaml:"percent,omitempty"`
 }
 
 type ManifestSpecTemplate struct {
@@ -49,6 +79,7 @@ type ManifestSpecTemplateContainer struct {
 	Resources     ManifestSpecTemplateContainerResources `json:"resources,omitempty" yaml:"resources,omitempty"`
 	Volumes       []ManifestSpecTemplateContainerVolume  `json:"volumes,omitempty" yaml:"volumes,omitempty"`
 	RestartPolicy ManifestSpecTemplateRestartPolicy      `json:"restart_policy,omitempty" yaml:"restart_policy,omitempty"`
+	Probes        ManifestSpecTemplateContainerProbes    `json:"probes,omitempty" yaml:"probes,omitempty"`
 }
 
 type ManifestSpecTemplateContainerEnv struct {
@@ -73,6 +104,32 @@ type ManifestSpecTemplateContainerImage struct {
 	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
 }
 
+type ManifestSpecTemplateContainerProbes struct {
+	Live  *ManifestSpecTemplateContainerProbe `json:"live,omitempty" yaml:"live,omitempty"`
+	Ready *ManifestSpecTemplateContainerProbe `json:"ready,omitempty" yaml:"ready,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbe struct {
+	Exec             string                                    `json:"exec,omitempty" yaml:"exec,omitempty"`
+	Socket           *ManifestSpecTemplateContainerProbeSocket `json:"socket,omitempty" yaml:"socket,omitempty"`
+	HTTP             *ManifestSpecTemplateContainerProbeHTTP   `json:"http,omitempty" yaml:"http,omitempty"`
+	InitialDelay     int                                       `json:"initial_delay,omitempty" yaml:"initial_delay,omitempty"`
+	Timeout          int                                       `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Period           int                                       `json:"period,omitempty" yaml:"period,omitempty"`
+	ThresholdSuccess int                                       `json:"threshold_success,omitempty" yaml:"threshold_success,omitempty"`
+	ThresholdFailure int                                       `json:"threshold_failure,omitempty" yaml:"threshold_failure,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbeSocket struct {
+	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Port     int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbeHTTP struct {
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	Port int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
 type ManifestSpecTemplateContainerResources struct {
 	// Limit resources
 	Limits ManifestSpecTemplateContainerResource `json:"limits,omitempty" yaml:"limits,omitempty"`
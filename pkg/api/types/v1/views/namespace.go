@@ -31,14 +31,15 @@ type Namespace struct {
 
 // swagger:model views_namespace_meta
 type NamespaceMeta struct {
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	SelfLink    string            `json:"self_link"`
-	Endpoint    string            `json:"endpoint"`
-	Suffix      string            `json:"suffix"`
-	Labels      map[string]string `json:"labels"`
-	Created     time.Time         `json:"created"`
-	Updated     time.Time         `json:"updated"`
+	Name            string            `json:"name"`
+	Description     string            `json:"description"`
+	SelfLink        string            `json:"self_link"`
+	Endpoint        string            `json:"endpoint"`
+	Suffix          string            `json:"suffix"`
+	Labels          map[string]string `json:"labels"`
+	Created         time.Time         `json:"created"`
+	Updated         time.Time         `json:"updated"`
+	ResourceVersion int64             `json:"resource_version"`
 }
 
 // swagger:model views_namespace_spec
@@ -53,7 +54,8 @@ type NamespaceStatus struct {
 }
 
 type NamespaceStatusResources struct {
-	Usage NamespaceResource `json:"usage"`
+	Usage     NamespaceResource `json:"usage"`
+	Requested NamespaceResource `json:"requested"`
 }
 
 // swagger:model views_namespace_envs
@@ -30,6 +30,7 @@ type NamespaceView struct{}
 func (nv *NamespaceView) New(obj *types.Namespace) *Namespace {
 	n := Namespace{}
 	n.Meta = n.ToMeta(obj.Meta)
+	n.Meta.ResourceVersion = obj.Runtime.System.Revision
 	n.Spec = n.ToSpec(obj.Spec)
 	return &n
 }
@@ -68,7 +69,8 @@ func (r *Namespace) ToSpec(spec types.NamespaceSpec) NamespaceSpec {
 func (r *Namespace) ToStatus(status types.NamespaceStatus) NamespaceStatus {
 	return NamespaceStatus{
 		Resources: NamespaceStatusResources{
-			Usage: r.ToResources(status.Resources.Allocated),
+			Usage:     r.ToResources(status.Resources.Allocated),
+			Requested: r.ToResources(status.Resources.Requested),
 		},
 	}
 }
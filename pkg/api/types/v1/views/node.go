@@ -40,11 +40,12 @@ type NodeList map[string]*Node
 // swagger:model views_node_meta
 type NodeMeta struct {
 	NodeInfo
-	Name     string            `json:"name"`
-	Labels   map[string]string `json:"labels"`
-	SelfLink string            `json:"self_link"`
-	Created  time.Time         `json:"created"`
-	Updated  time.Time         `json:"updated"`
+	Name            string            `json:"name"`
+	Labels          map[string]string `json:"labels"`
+	SelfLink        string            `json:"self_link"`
+	Created         time.Time         `json:"created"`
+	Updated         time.Time         `json:"updated"`
+	ResourceVersion int64             `json:"resource_version"`
 }
 
 // NodeInfo - node info struct
@@ -89,7 +90,15 @@ type NodeStatus struct {
 // swagger:ignore
 // swagger:model types_node_spec
 type NodeSpec struct {
-	Security NodeSecurity `json:"security"`
+	Security      NodeSecurity `json:"security"`
+	Unschedulable bool         `json:"unschedulable"`
+	Drain         bool         `json:"drain"`
+	Taints        []NodeTaint  `json:"taints"`
+}
+
+type NodeTaint struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 type NodeSecurity struct {
@@ -29,6 +29,7 @@ type NodeView struct{}
 func (nv *NodeView) New(obj *types.Node) *Node {
 	n := Node{}
 	n.Meta = nv.ToNodeMeta(obj.Meta)
+	n.Meta.ResourceVersion = obj.Runtime.System.Revision
 	n.Status = nv.ToNodeStatus(obj.Status)
 	n.Spec = nv.ToNodeSpec(obj.Spec)
 	return &n
@@ -93,6 +94,12 @@ func (nv *NodeView) ToNodeStatus(status types.NodeStatus) NodeStatus {
 func (nv *NodeView) ToNodeSpec(spec types.NodeSpec) NodeSpec {
 	ns := NodeSpec{}
 	ns.Security.TLS = spec.Security.TLS
+	ns.Unschedulable = spec.Unschedulable
+	ns.Drain = spec.Drain
+	ns.Taints = make([]NodeTaint, 0)
+	for _, t := range spec.Taints {
+		ns.Taints = append(ns.Taints, NodeTaint{Key: t.Key, Value: t.Value})
+	}
 	return ns
 }
 
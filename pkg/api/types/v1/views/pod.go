@@ -64,6 +64,8 @@ type PodMeta struct {
 	Created time.Time `json:"created"`
 	// Meta updated time
 	Updated time.Time `json:"updated"`
+	// Meta resource version
+	ResourceVersion int64 `json:"resource_version"`
 }
 
 // PodSpec is a spec of pod
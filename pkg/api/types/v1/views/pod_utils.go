@@ -28,6 +28,7 @@ func (pv *PodViewHelper) New(pod *types.Pod) Pod {
 	p := Pod{}
 	p.ID = pod.Meta.Name
 	p.Meta = p.toMeta(pod.Meta)
+	p.Meta.ResourceVersion = pod.Runtime.System.Revision
 	p.Spec = p.toSpec(pod.Spec)
 	p.Status = p.toStatus(pod.Status)
 	return p
@@ -0,0 +1,58 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package views
+
+import "time"
+
+// Role is a set of rules granting verbs on resources
+//
+// swagger:model views_role
+type Role struct {
+	Meta RoleMeta `json:"meta"`
+	Spec RoleSpec `json:"spec"`
+}
+
+// RoleMeta is a meta of role model for api
+//
+// swagger:model views_role_meta
+type RoleMeta struct {
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	SelfLink        string    `json:"self_link"`
+	Created         time.Time `json:"created"`
+	Updated         time.Time `json:"updated"`
+	ResourceVersion int64     `json:"resource_version"`
+}
+
+// RoleSpec is a spec of role model for api
+//
+// swagger:model views_role_spec
+type RoleSpec struct {
+	Rules []RoleRule `json:"rules"`
+}
+
+// RoleRule is a rule of role model for api
+//
+// swagger:model views_role_rule
+type RoleRule struct {
+	Resources []string `json:"resources"`
+	Verbs     []string `json:"verbs"`
+}
+
+// swagger:model views_role_list
+type RoleList []*Role
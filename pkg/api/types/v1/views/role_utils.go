@@ -0,0 +1,77 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type RoleView struct{}
+
+func (rv *RoleView) New(obj *types.Role) *Role {
+	r := Role{}
+	r.Meta = r.ToMeta(obj.Meta)
+	r.Meta.ResourceVersion = obj.Runtime.System.Revision
+	r.Spec = r.ToSpec(obj.Spec)
+	return &r
+}
+
+func (r *Role) ToMeta(obj types.RoleMeta) RoleMeta {
+	return RoleMeta{
+		Name:        obj.Name,
+		Description: obj.Description,
+		SelfLink:    obj.SelfLink,
+		Created:     obj.Created,
+		Updated:     obj.Updated,
+	}
+}
+
+func (r *Role) ToSpec(obj types.RoleSpec) RoleSpec {
+	spec := RoleSpec{
+		Rules: make([]RoleRule, 0),
+	}
+	for _, rule := range obj.Rules {
+		spec.Rules = append(spec.Rules, RoleRule{Resources: rule.Resources, Verbs: rule.Verbs})
+	}
+	return spec
+}
+
+func (r *Role) ToJson() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (rv *RoleView) NewList(obj *types.RoleList) *RoleList {
+	if obj == nil {
+		return nil
+	}
+
+	rl := make(RoleList, 0)
+	for _, v := range obj.Items {
+		rl = append(rl, rv.New(v))
+	}
+	return &rl
+}
+
+func (rl *RoleList) ToJson() ([]byte, error) {
+	if rl == nil {
+		rl = &RoleList{}
+	}
+	return json.Marshal(rl)
+}
@@ -29,6 +29,7 @@ type RouteView struct{}
 func (rv *RouteView) New(obj *types.Route) *Route {
 	r := Route{}
 	r.Meta = r.ToMeta(obj.Meta)
+	r.Meta.ResourceVersion = obj.Runtime.System.Revision
 	r.Spec = r.ToSpec(obj.Spec)
 	r.Status = r.ToStatus(obj.Status)
 	return &r
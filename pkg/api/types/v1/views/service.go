@@ -40,15 +40,16 @@ type Service struct {
 
 // swagger:model views_service_meta
 type ServiceMeta struct {
-	Name        string            `json:"name"`
-	Namespace   string            `json:"namespace"`
-	Description string            `json:"description"`
-	SelfLink    string            `json:"self_link"`
-	Endpoint    string            `json:"endpoint"`
-	Replicas    int               `json:"replicas"`
-	Labels      map[string]string `json:"labels"`
-	Created     time.Time         `json:"created"`
-	Updated     time.Time         `json:"updated"`
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	Description     string            `json:"description"`
+	SelfLink        string            `json:"self_link"`
+	Endpoint        string            `json:"endpoint"`
+	Replicas        int               `json:"replicas"`
+	Labels          map[string]string `json:"labels"`
+	Created         time.Time         `json:"created"`
+	Updated         time.Time         `json:"updated"`
+	ResourceVersion int64             `json:"resource_version"`
 }
 
 // swagger:ignore
@@ -89,6 +90,7 @@ type ServiceDeployment struct {
 // swagger:model views_service_spec
 type ServiceSpec struct {
 	Selector ManifestSpecSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Affinity ManifestSpecAffinity `json:"affinity,omitempty" yaml:"affinity,omitempty"`
 	Replicas int                  `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	Network  ManifestSpecNetwork  `json:"network,omitempty" yaml:"network,omitempty"`
 	Strategy ManifestSpecStrategy `json:"strategy,omitempty" yaml:"strategy,omitempty"`
@@ -38,6 +38,7 @@ type ServiceView struct{}
 func (sv *ServiceView) New(srv *types.Service) *Service {
 	s := new(Service)
 	s.Meta = s.ToMeta(srv.Meta)
+	s.Meta.ResourceVersion = srv.Runtime.System.Revision
 	s.Status = s.ToStatus(srv.Status)
 	s.Spec = s.ToSpec(srv.Spec)
 	return s
@@ -46,6 +47,7 @@ func (sv *ServiceView) New(srv *types.Service) *Service {
 func (sv *ServiceView) NewWithDeployment(srv *types.Service, d *types.DeploymentList, p *types.PodList) *Service {
 	s := new(Service)
 	s.Meta = s.ToMeta(srv.Meta)
+	s.Meta.ResourceVersion = srv.Runtime.System.Revision
 	s.Status = s.ToStatus(srv.Status)
 	s.Spec = s.ToSpec(srv.Spec)
 
@@ -100,10 +102,43 @@ func (sv *Service) ToSpec(obj types.ServiceSpec) ServiceSpec {
 			Ports: obj.Network.Ports,
 		},
 		Strategy: ManifestSpecStrategy{
-			Type: obj.Strategy.Type,
+			Type:      obj.Strategy.Type,
+			Revisions: obj.Strategy.Revisions,
 		},
 	}
 
+	if obj.Strategy.CanaryOptions.Percent > 0 {
+		spec.Strategy.Canary = &ManifestSpecStrategyCanary{Percent: obj.Strategy.CanaryOptions.Percent}
+	}
+
+	for _, t := range obj.Selector.Tolerations {
+		spec.Selector.Tolerations = append(spec.Selector.Tolerations, ManifestSpecToleration{Key: t.Key, Value: t.Value})
+	}
+
+	for _, r := range obj.Affinity.Affinity {
+		spec.Affinity.Affinity = append(spec.Affinity.Affinity, ManifestSpecAffinityRule{
+			Service:  r.Service,
+			Topology: r.Topology,
+			Required: r.Required,
+		})
+	}
+
+	for _, r := range obj.Affinity.AntiAffinity {
+		spec.Affinity.AntiAffinity = append(spec.Affinity.AntiAffinity, ManifestSpecAffinityRule{
+			Service:  r.Service,
+			Topology: r.Topology,
+			Required: r.Required,
+		})
+	}
+
+	if obj.Affinity.Spread != nil {
+		spec.Affinity.Spread = &ManifestSpecSpread{
+			Topology: obj.Affinity.Spread.Topology,
+			MaxSkew:  obj.Affinity.Spread.MaxSkew,
+			Required: obj.Affinity.Spread.Required,
+		}
+	}
+
 	for _, s := range obj.Template.Containers {
 
 		c := ManifestSpecTemplateContainer{
@@ -149,6 +184,9 @@ func (sv *Service) ToSpec(obj types.ServiceSpec) ServiceSpec {
 		c.Resources.Request.RAM = s.Resources.Request.RAM
 		c.Resources.Request.CPU = s.Resources.Request.CPU
 
+		c.Probes.Live = toManifestProbe(s.Probes.LiveProbe)
+		c.Probes.Ready = toManifestProbe(s.Probes.ReadProbe)
+
 		spec.Template.Containers = append(spec.Template.Containers, c)
 	}
 
@@ -226,9 +264,30 @@ func (sv Service) ToRequestManifest() *request.ServiceManifest {
 	if sm.Spec.Selector.Labels == nil {
 		sm.Spec.Selector.Labels = make(map[string]string, 0)
 	}
+	for _, t := range sv.Spec.Selector.Tolerations {
+		sm.Spec.Selector.Tolerations = append(sm.Spec.Selector.Tolerations, request.ManifestSpecToleration(t))
+	}
+
+	sm.Spec.Affinity = new(request.ManifestSpecAffinity)
+	for _, r := range sv.Spec.Affinity.Affinity {
+		sm.Spec.Affinity.Affinity = append(sm.Spec.Affinity.Affinity, request.ManifestSpecAffinityRule(r))
+	}
+	for _, r := range sv.Spec.Affinity.AntiAffinity {
+		sm.Spec.Affinity.AntiAffinity = append(sm.Spec.Affinity.AntiAffinity, request.ManifestSpecAffinityRule(r))
+	}
+	if sv.Spec.Affinity.Spread != nil {
+		spread := request.ManifestSpecSpread(*sv.Spec.Affinity.Spread)
+		sm.Spec.Affinity.Spread = &spread
+	}
 
 	sm.Spec.Strategy = new(request.ManifestSpecStrategy)
 	sm.Spec.Strategy.Type = &sv.Spec.Strategy.Type
+	if sv.Spec.Strategy.Revisions > 0 {
+		sm.Spec.Strategy.Revisions = &sv.Spec.Strategy.Revisions
+	}
+	if sv.Spec.Strategy.Canary != nil {
+		sm.Spec.Strategy.Canary = &request.ManifestSpecStrategyCanary{Percent: sv.Spec.Strategy.Canary.Percent}
+	}
 
 	sm.Spec.Network = new(request.ManifestSpecNetwork)
 	sm.Spec.Network.IP = &sv.Spec.Network.IP
@@ -332,6 +391,9 @@ func (sv Service) ToRequestManifest() *request.ServiceManifest {
 			data.RestartPolicy.Policy = v.RestartPolicy.Policy
 			data.RestartPolicy.Attempt = v.RestartPolicy.Attempt
 
+			data.Probes.Live = v.Probes.Live.toRequest()
+			data.Probes.Ready = v.Probes.Ready.toRequest()
+
 			sm.Spec.Template.Containers = append(sm.Spec.Template.Containers, data)
 		}
 	}
@@ -339,6 +401,70 @@ func (sv Service) ToRequestManifest() *request.ServiceManifest {
 	return sm
 }
 
+func toManifestProbe(p types.SpecTemplateContainerProbe) *ManifestSpecTemplateContainerProbe {
+
+	if p.Type() == types.EmptyString {
+		return nil
+	}
+
+	probe := &ManifestSpecTemplateContainerProbe{
+		Exec:             strings.Join(p.Exec.Command, " "),
+		InitialDelay:     p.InitialDelaySeconds,
+		Timeout:          p.TimeoutSeconds,
+		Period:           p.PeriodSeconds,
+		ThresholdSuccess: p.ThresholdSuccess,
+		ThresholdFailure: p.ThresholdFailure,
+	}
+
+	if p.Socket.Port > 0 {
+		probe.Socket = &ManifestSpecTemplateContainerProbeSocket{
+			Protocol: p.Socket.Protocol,
+			Port:     p.Socket.Port,
+		}
+	}
+
+	if p.HTTP.Port > 0 {
+		probe.HTTP = &ManifestSpecTemplateContainerProbeHTTP{
+			Path: p.HTTP.Path,
+			Port: p.HTTP.Port,
+		}
+	}
+
+	return probe
+}
+
+func (p *ManifestSpecTemplateContainerProbe) toRequest() *request.ManifestSpecTemplateContainerProbe {
+
+	if p == nil {
+		return nil
+	}
+
+	probe := &request.ManifestSpecTemplateContainerProbe{
+		Exec:             p.Exec,
+		InitialDelay:     p.InitialDelay,
+		Timeout:          p.Timeout,
+		Period:           p.Period,
+		ThresholdSuccess: p.ThresholdSuccess,
+		ThresholdFailure: p.ThresholdFailure,
+	}
+
+	if p.Socket != nil {
+		probe.Socket = &request.ManifestSpecTemplateContainerProbeSocket{
+			Protocol: p.Socket.Protocol,
+			Port:     p.Socket.Port,
+		}
+	}
+
+	if p.HTTP != nil {
+		probe.HTTP = &request.ManifestSpecTemplateContainerProbeHTTP{
+			Path: p.HTTP.Path,
+			Port: p.HTTP.Port,
+		}
+	}
+
+	return probe
+}
+
 func (sv *ServiceView) NewList(obj *types.ServiceList, d *types.DeploymentList, pl *types.PodList) *ServiceList {
 	if obj == nil {
 		return nil
@@ -32,10 +32,17 @@ type IView interface {
 	Secret() *SecretView
 	Config() *ConfigView
 	Deployment() *DeploymentView
+	Autoscaler() *AutoscalerView
 	Endpoint() *EndpointView
 	Pod() *Pod
 	Container() *ContainerView
 	Volume() *VolumeView
+	User() *UserView
+	Role() *RoleView
+	Audit() *AuditView
+	Watch() *WatchView
+	Apply() *ApplyView
+	Backup() *BackupView
 }
 
 type View struct{}
@@ -71,6 +78,9 @@ func (View) Config() *ConfigView {
 func (View) Deployment() *DeploymentView {
 	return new(DeploymentView)
 }
+func (View) Autoscaler() *AutoscalerView {
+	return new(AutoscalerView)
+}
 func (View) Pod() *Pod {
 	return new(Pod)
 }
@@ -84,3 +94,22 @@ func (View) Container() *ContainerView {
 func (View) Volume() *VolumeView {
 	return new(VolumeView)
 }
+
+func (View) User() *UserView {
+	return new(UserView)
+}
+func (View) Role() *RoleView {
+	return new(RoleView)
+}
+func (View) Audit() *AuditView {
+	return new(AuditView)
+}
+func (View) Watch() *WatchView {
+	return new(WatchView)
+}
+func (View) Apply() *ApplyView {
+	return new(ApplyView)
+}
+func (View) Backup() *BackupView {
+	return new(BackupView)
+}
@@ -0,0 +1,61 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package views
+
+import "time"
+
+// User is an api user or service account model for api
+//
+// swagger:model views_user
+type User struct {
+	Meta UserMeta `json:"meta"`
+	Spec UserSpec `json:"spec"`
+	// Access token, returned only when token is issued
+	Token string `json:"token,omitempty"`
+}
+
+// UserMeta is a meta of user model for api
+//
+// swagger:model views_user_meta
+type UserMeta struct {
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	SelfLink        string    `json:"self_link"`
+	Created         time.Time `json:"created"`
+	Updated         time.Time `json:"updated"`
+	ResourceVersion int64     `json:"resource_version"`
+}
+
+// UserSpec is a spec of user model for api
+//
+// swagger:model views_user_spec
+type UserSpec struct {
+	Type     string        `json:"type"`
+	Bindings []UserBinding `json:"bindings"`
+}
+
+// UserBinding is a role binding of user, empty namespace means whole cluster
+//
+// swagger:model views_user_binding
+type UserBinding struct {
+	Role      string `json:"role"`
+	Namespace string `json:"namespace"`
+}
+
+// swagger:model views_user_list
+type UserList []*User
@@ -0,0 +1,85 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type UserView struct{}
+
+func (uv *UserView) New(obj *types.User) *User {
+	u := User{}
+	u.Meta = u.ToMeta(obj.Meta)
+	u.Meta.ResourceVersion = obj.Runtime.System.Revision
+	u.Spec = u.ToSpec(obj.Spec)
+	return &u
+}
+
+// NewWithToken - user view with issued access token
+func (uv *UserView) NewWithToken(obj *types.User, token string) *User {
+	u := uv.New(obj)
+	u.Token = token
+	return u
+}
+
+func (u *User) ToMeta(obj types.UserMeta) UserMeta {
+	return UserMeta{
+		Name:        obj.Name,
+		Description: obj.Description,
+		SelfLink:    obj.SelfLink,
+		Created:     obj.Created,
+		Updated:     obj.Updated,
+	}
+}
+
+func (u *User) ToSpec(obj types.UserSpec) UserSpec {
+	spec := UserSpec{
+		Type:     obj.Type,
+		Bindings: make([]UserBinding, 0),
+	}
+	for _, b := range obj.Bindings {
+		spec.Bindings = append(spec.Bindings, UserBinding{Role: b.Role, Namespace: b.Namespace})
+	}
+	return spec
+}
+
+func (u *User) ToJson() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+func (uv *UserView) NewList(obj *types.UserList) *UserList {
+	if obj == nil {
+		return nil
+	}
+
+	ul := make(UserList, 0)
+	for _, v := range obj.Items {
+		ul = append(ul, uv.New(v))
+	}
+	return &ul
+}
+
+func (ul *UserList) ToJson() ([]byte, error) {
+	if ul == nil {
+		ul = &UserList{}
+	}
+	return json.Marshal(ul)
+}
@@ -30,6 +30,7 @@ type VolumeView struct{}
 func (rv *VolumeView) New(obj *types.Volume) *Volume {
 	r := Volume{}
 	r.Meta = r.ToMeta(obj.Meta)
+	r.Meta.ResourceVersion = obj.Runtime.System.Revision
 	r.Spec = r.ToSpec(obj.Spec)
 	r.Status = r.ToStatus(obj.Status)
 	return &r
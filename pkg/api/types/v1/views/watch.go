@@ -0,0 +1,38 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+const (
+	WatchEventAdded    = "ADDED"
+	WatchEventModified = "MODIFIED"
+	WatchEventDeleted  = "DELETED"
+	WatchEventError    = "ERROR"
+)
+
+// WatchEvent is a single event of resource watch stream
+//
+// swagger:model views_watch_event
+type WatchEvent struct {
+	// Event type: ADDED, MODIFIED, DELETED or ERROR
+	Type string `json:"type"`
+	// Storage revision of change, pass it to resume watch
+	Revision int64 `json:"revision"`
+	// Resource view or error message
+	Data interface{} `json:"data"`
+}
@@ -0,0 +1,58 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type WatchView struct{}
+
+func (wv *WatchView) New(action string, revision int64, data interface{}) *WatchEvent {
+
+	e := new(WatchEvent)
+	e.Revision = revision
+	e.Data = data
+
+	switch action {
+	case types.EventActionCreate:
+		e.Type = WatchEventAdded
+	case types.EventActionDelete:
+		e.Type = WatchEventDeleted
+	case types.EventActionError:
+		e.Type = WatchEventError
+	default:
+		e.Type = WatchEventModified
+	}
+
+	return e
+}
+
+func (wv *WatchView) NewError(err error) *WatchEvent {
+	return &WatchEvent{
+		Type: WatchEventError,
+		Data: err.Error(),
+	}
+}
+
+func (e *WatchEvent) ToJson() ([]byte, error) {
+	return json.Marshal(e)
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/lastbackend/lastbackend/pkg/controller/ipam"
 	"github.com/lastbackend/lastbackend/pkg/controller/runtime"
+	"github.com/lastbackend/lastbackend/pkg/controller/scheduler"
 	"github.com/lastbackend/lastbackend/pkg/storage"
 	"github.com/spf13/viper"
 )
@@ -45,7 +46,7 @@ func Daemon() bool {
 
 	log.Info("Start State Controller")
 
-	stg, err := storage.Get(viper.GetString("etcd"))
+	stg, err := storage.Get(viper.GetString("storage.driver"))
 	if err != nil {
 		log.Fatalf("Cannot initialize storage: %s", err.Error())
 	}
@@ -57,6 +58,12 @@ func Daemon() bool {
 	}
 	env.SetIPAM(ipm)
 
+	sch, err := scheduler.New(viper.GetStringSlice("scheduler.priorities")...)
+	if err != nil {
+		log.Fatalf("Cannot initialize scheduler: %s", err.Error())
+	}
+	env.SetScheduler(sch)
+
 	// Initialize Runtime
 	r := runtime.NewRuntime(context.Background())
 	r.Loop()
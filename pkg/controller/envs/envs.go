@@ -20,14 +20,16 @@ package envs
 
 import (
 	"github.com/lastbackend/lastbackend/pkg/controller/ipam/ipam"
+	"github.com/lastbackend/lastbackend/pkg/controller/scheduler"
 	"github.com/lastbackend/lastbackend/pkg/storage"
 )
 
 var e Env
 
 type Env struct {
-	storage storage.Storage
-	ipam    ipam.IPAM
+	storage   storage.Storage
+	ipam      ipam.IPAM
+	scheduler *scheduler.Scheduler
 }
 
 func Get() *Env {
@@ -49,3 +51,11 @@ func (c *Env) SetIPAM(ipam ipam.IPAM) {
 func (c *Env) GetIPAM() ipam.IPAM {
 	return c.ipam
 }
+
+func (c *Env) SetScheduler(s *scheduler.Scheduler) {
+	c.scheduler = s
+}
+
+func (c *Env) GetScheduler() *scheduler.Scheduler {
+	return c.scheduler
+}
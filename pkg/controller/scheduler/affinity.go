@@ -0,0 +1,243 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import (
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+// FilterAffinity - check required affinity, anti-affinity and topology spread rules
+func FilterAffinity(r *Request, n *types.Node) bool {
+
+	for _, rule := range r.Affinity.Affinity {
+		if rule.Required && !r.affinity(rule, n) {
+			return false
+		}
+	}
+
+	for _, rule := range r.Affinity.AntiAffinity {
+		if rule.Required && !r.antiAffinity(rule, n) {
+			return false
+		}
+	}
+
+	if s := r.Affinity.Spread; s != nil && s.Required {
+		skew, ok := r.skew(s, n)
+		if !ok || skew > maxSkew(s) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Affinity - prefer nodes which satisfy preferred affinity, anti-affinity and topology spread rules
+func Affinity(r *Request, nodes []*types.Node) map[string]int {
+
+	scores := make(map[string]int, len(nodes))
+
+	for _, n := range nodes {
+
+		var total, matched int
+
+		for _, rule := range r.Affinity.Affinity {
+			if rule.Required {
+				continue
+			}
+			total += MaxPriority
+			if r.affinity(rule, n) {
+				matched += MaxPriority
+			}
+		}
+
+		for _, rule := range r.Affinity.AntiAffinity {
+			if rule.Required {
+				continue
+			}
+			total += MaxPriority
+			if r.antiAffinity(rule, n) {
+				matched += MaxPriority
+			}
+		}
+
+		if s := r.Affinity.Spread; s != nil && !s.Required {
+			total += MaxPriority
+			matched += r.spread(s, n)
+		}
+
+		if total == 0 {
+			scores[n.SelfLink()] = 0
+			continue
+		}
+
+		scores[n.SelfLink()] = MaxPriority * matched / total
+	}
+
+	return scores
+}
+
+// affinity returns true if node topology domain has pods of rule service.
+// First pod of service with self affinity can be placed in any domain
+func (r *Request) affinity(rule types.SpecAffinityRule, n *types.Node) bool {
+
+	if _, ok := domain(rule.Topology, n); !ok {
+		return false
+	}
+
+	link := r.link(rule.Service)
+	if r.count(link, rule.Topology, n) > 0 {
+		return true
+	}
+
+	return link == r.Service && r.total(link) == 0
+}
+
+// antiAffinity returns true if node topology domain has no pods of rule service
+func (r *Request) antiAffinity(rule types.SpecAffinityRule, n *types.Node) bool {
+	return r.count(r.link(rule.Service), rule.Topology, n) == 0
+}
+
+// skew returns difference between service pods count in node topology domain
+// after lease and the least loaded domain. Returns false if node has no topology label
+func (r *Request) skew(s *types.SpecTopologySpread, n *types.Node) (int, bool) {
+
+	d, ok := domain(s.Topology, n)
+	if !ok {
+		return 0, false
+	}
+
+	counts := r.domains(s.Topology)
+	if _, ok := counts[d]; !ok {
+		counts[d] = 0
+	}
+
+	min := -1
+	for _, c := range counts {
+		if min < 0 || c < min {
+			min = c
+		}
+	}
+
+	return counts[d] + 1 - min, true
+}
+
+// spread scores node topology domain from 0 to MaxPriority, less service pods in domain - higher score
+func (r *Request) spread(s *types.SpecTopologySpread, n *types.Node) int {
+
+	d, ok := domain(s.Topology, n)
+	if !ok {
+		return 0
+	}
+
+	var max int
+
+	counts := r.domains(s.Topology)
+	for _, c := range counts {
+		if c > max {
+			max = c
+		}
+	}
+
+	if max == 0 {
+		return MaxPriority
+	}
+
+	return MaxPriority * (max - counts[d]) / max
+}
+
+// domains returns service pods count in every topology domain of nodes matched by selector
+func (r *Request) domains(topology string) map[string]int {
+
+	counts := make(map[string]int)
+
+	for _, node := range r.nodes {
+
+		if !FilterSelector(r, node) {
+			continue
+		}
+
+		v, ok := domain(topology, node)
+		if !ok {
+			continue
+		}
+
+		counts[v] += r.Placement[node.SelfLink()][r.Service]
+	}
+
+	return counts
+}
+
+// count returns service pods count in node topology domain
+func (r *Request) count(service, topology string, n *types.Node) int {
+
+	d, ok := domain(topology, n)
+	if !ok {
+		return 0
+	}
+
+	var c int
+	for _, node := range r.nodes {
+		if v, ok := domain(topology, node); ok && v == d {
+			c += r.Placement[node.SelfLink()][service]
+		}
+	}
+
+	return c
+}
+
+// total returns service pods count in cluster
+func (r *Request) total(service string) int {
+	var c int
+	for _, s := range r.Placement {
+		c += s[service]
+	}
+	return c
+}
+
+// link returns service self link for rule service name, empty name means requested service
+func (r *Request) link(service string) string {
+
+	if service == types.EmptyString {
+		return r.Service
+	}
+
+	namespace := strings.SplitN(r.Service, ":", 2)[0]
+	return new(types.Service).CreateSelfLink(namespace, service)
+}
+
+// domain returns node topology domain by label key, node itself is used if key is empty
+func domain(topology string, n *types.Node) (string, bool) {
+
+	if topology == types.EmptyString {
+		return n.SelfLink(), true
+	}
+
+	v, ok := n.Meta.Labels[topology]
+	return v, ok
+}
+
+// maxSkew returns allowed skew, at least one pod difference is always allowed
+func maxSkew(s *types.SpecTopologySpread) int {
+	if s.MaxSkew < 1 {
+		return 1
+	}
+	return s.MaxSkew
+}
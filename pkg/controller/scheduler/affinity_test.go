@@ -0,0 +1,231 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterAffinity(t *testing.T) {
+
+	type suit struct {
+		name  string
+		req   *Request
+		nodes []*types.Node
+		want  []string
+	}
+
+	var (
+		svc = "ns:web"
+		db  = "ns:db"
+
+		nodes = func() []*types.Node {
+			return []*types.Node{
+				getNodeAsset("n1", 1000, 0, "zone", "a"),
+				getNodeAsset("n2", 1000, 0, "zone", "a"),
+				getNodeAsset("n3", 1000, 0, "zone", "b"),
+				getNodeAsset("n4", 1000, 0),
+			}
+		}
+	)
+
+	var tests = []suit{
+		{
+			name: "required affinity should keep only nodes with service pods",
+			req: &Request{
+				Service: svc,
+				Affinity: types.SpecAffinity{Affinity: []types.SpecAffinityRule{
+					{Service: "db", Required: true},
+				}},
+				Placement: map[string]map[string]int{"n2": {db: 1}},
+			},
+			nodes: nodes(),
+			want:  []string{"n2"},
+		},
+		{
+			name: "required affinity should use topology domain",
+			req: &Request{
+				Service: svc,
+				Affinity: types.SpecAffinity{Affinity: []types.SpecAffinityRule{
+					{Service: "db", Topology: "zone", Required: true},
+				}},
+				Placement: map[string]map[string]int{"n2": {db: 1}},
+			},
+			nodes: nodes(),
+			want:  []string{"n1", "n2"},
+		},
+		{
+			name: "required self affinity should allow first pod anywhere",
+			req: &Request{
+				Service: svc,
+				Affinity: types.SpecAffinity{Affinity: []types.SpecAffinityRule{
+					{Topology: "zone", Required: true},
+				}},
+			},
+			nodes: nodes(),
+			want:  []string{"n1", "n2", "n3"},
+		},
+		{
+			name: "required anti affinity should skip nodes with service pods",
+			req: &Request{
+				Service: svc,
+				Affinity: types.SpecAffinity{AntiAffinity: []types.SpecAffinityRule{
+					{Required: true},
+				}},
+				Placement: map[string]map[string]int{"n1": {svc: 1}, "n3": {svc: 2}},
+			},
+			nodes: nodes(),
+			want:  []string{"n2", "n4"},
+		},
+		{
+			name: "required anti affinity should skip whole topology domain",
+			req: &Request{
+				Service: svc,
+				Affinity: types.SpecAffinity{AntiAffinity: []types.SpecAffinityRule{
+					{Topology: "zone", Required: true},
+				}},
+				Placement: map[string]map[string]int{"n1": {svc: 1}},
+			},
+			nodes: nodes(),
+			want:  []string{"n3", "n4"},
+		},
+		{
+			name: "preferred rules should not filter nodes",
+			req: &Request{
+				Service: svc,
+				Affinity: types.SpecAffinity{AntiAffinity: []types.SpecAffinityRule{
+					{},
+				}},
+				Placement: map[string]map[string]int{"n1": {svc: 1}},
+			},
+			nodes: nodes(),
+			want:  []string{"n1", "n2", "n3", "n4"},
+		},
+		{
+			name: "required spread should keep max skew between domains",
+			req: &Request{
+				Service: svc,
+				Affinity: types.SpecAffinity{Spread: &types.SpecTopologySpread{
+					Topology: "zone",
+					MaxSkew:  1,
+					Required: true,
+				}},
+				Placement: map[string]map[string]int{"n1": {svc: 1}},
+			},
+			nodes: nodes(),
+			want:  []string{"n3"},
+		},
+	}
+
+	s, err := New()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, n := range s.Filter(tt.req, tt.nodes) {
+				got = append(got, n.SelfLink())
+			}
+			assert.Equal(t, tt.want, got, "filtered nodes are different")
+		})
+	}
+}
+
+func TestSchedulerScheduleAffinity(t *testing.T) {
+
+	type suit struct {
+		name  string
+		req   *Request
+		nodes []*types.Node
+		want  string
+	}
+
+	var (
+		svc = "ns:web"
+		db  = "ns:db"
+	)
+
+	var tests = []suit{
+		{
+			name: "preferred affinity should select node with service pods",
+			req: &Request{
+				Memory:  100,
+				Service: svc,
+				Affinity: types.SpecAffinity{Affinity: []types.SpecAffinityRule{
+					{Service: "db"},
+				}},
+				Placement: map[string]map[string]int{"n1": {db: 1}},
+			},
+			nodes: []*types.Node{getNodeAsset("n1", 1000, 500), getNodeAsset("n2", 1000, 0)},
+			want:  "n1",
+		},
+		{
+			name: "preferred anti affinity should select node without service pods",
+			req: &Request{
+				Memory:  100,
+				Service: svc,
+				Affinity: types.SpecAffinity{AntiAffinity: []types.SpecAffinityRule{
+					{},
+				}},
+				Placement: map[string]map[string]int{"n2": {svc: 1}},
+			},
+			nodes: []*types.Node{getNodeAsset("n1", 1000, 500), getNodeAsset("n2", 1000, 0)},
+			want:  "n1",
+		},
+		{
+			name: "preferred spread should select less loaded zone",
+			req: &Request{
+				Memory:  100,
+				Service: svc,
+				Affinity: types.SpecAffinity{Spread: &types.SpecTopologySpread{
+					Topology: "zone",
+				}},
+				Placement: map[string]map[string]int{"n2": {svc: 2}},
+			},
+			nodes: []*types.Node{
+				getNodeAsset("n1", 1000, 500, "zone", "b"),
+				getNodeAsset("n2", 1000, 0, "zone", "a"),
+				getNodeAsset("n3", 1000, 0, "zone", "a"),
+			},
+			want: "n1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s, err := New()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			n := s.Schedule(tt.req, tt.nodes)
+			if !assert.NotNil(t, n, "node should not be nil") {
+				return
+			}
+
+			assert.Equal(t, tt.want, n.SelfLink(), "selected node is different")
+		})
+	}
+}
@@ -0,0 +1,85 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import "github.com/lastbackend/lastbackend/pkg/distribution/types"
+
+// FilterSelector - check node name and node labels selectors
+func FilterSelector(r *Request, n *types.Node) bool {
+
+	if r.Selector.Node != types.EmptyString && n.SelfLink() != r.Selector.Node {
+		return false
+	}
+
+	for k, v := range r.Selector.Labels {
+		if l, ok := n.Meta.Labels[k]; !ok || l != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterSchedulable - skip cordoned nodes
+func FilterSchedulable(r *Request, n *types.Node) bool {
+	return !n.Spec.Unschedulable
+}
+
+// FilterTaints - skip nodes with taints not tolerated by request
+func FilterTaints(r *Request, n *types.Node) bool {
+	for _, t := range n.Spec.Taints {
+		if !r.Selector.Tolerates(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterPods - check node has free pods slots
+func FilterPods(r *Request, n *types.Node) bool {
+	if r.Pods == 0 || n.Status.Capacity.Pods == 0 {
+		return true
+	}
+	return n.Status.Capacity.Pods-n.Status.Allocated.Pods >= r.Pods
+}
+
+// FilterMemory - check node has enough free memory
+func FilterMemory(r *Request, n *types.Node) bool {
+	if r.Memory == 0 {
+		return true
+	}
+	return n.Status.Capacity.Memory-n.Status.Allocated.Memory >= r.Memory
+}
+
+// FilterCPU - check node has enough free cpu.
+// Nodes which do not report cpu capacity are not filtered
+func FilterCPU(r *Request, n *types.Node) bool {
+	if r.CPU == 0 || n.Status.Capacity.Cpu == 0 {
+		return true
+	}
+	return int64(n.Status.Capacity.Cpu-n.Status.Allocated.Cpu) >= r.CPU
+}
+
+// FilterStorage - check node has enough free storage
+func FilterStorage(r *Request, n *types.Node) bool {
+	if r.Storage == 0 {
+		return true
+	}
+	return n.Status.Capacity.Storage-n.Status.Allocated.Storage >= r.Storage
+}
@@ -0,0 +1,97 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import "github.com/lastbackend/lastbackend/pkg/distribution/types"
+
+// LeastAllocated - prefer nodes with more free resources after lease
+func LeastAllocated(r *Request, nodes []*types.Node) map[string]int {
+	scores := make(map[string]int, len(nodes))
+	for _, n := range nodes {
+		scores[n.SelfLink()] = MaxPriority - usage(r, n)
+	}
+	return scores
+}
+
+// MostAllocated - prefer nodes with less free resources after lease to pack pods tightly
+func MostAllocated(r *Request, nodes []*types.Node) map[string]int {
+	scores := make(map[string]int, len(nodes))
+	for _, n := range nodes {
+		scores[n.SelfLink()] = usage(r, n)
+	}
+	return scores
+}
+
+// Spread - prefer nodes with less pods running
+func Spread(r *Request, nodes []*types.Node) map[string]int {
+
+	var max int
+
+	scores := make(map[string]int, len(nodes))
+
+	for _, n := range nodes {
+		if n.Status.Allocated.Pods > max {
+			max = n.Status.Allocated.Pods
+		}
+	}
+
+	for _, n := range nodes {
+		if max == 0 {
+			scores[n.SelfLink()] = MaxPriority
+			continue
+		}
+		scores[n.SelfLink()] = MaxPriority * (max - n.Status.Allocated.Pods) / max
+	}
+
+	return scores
+}
+
+// usage returns average node resources usage after lease in 0..MaxPriority range
+func usage(r *Request, n *types.Node) int {
+
+	var (
+		total int64
+		count int64
+	)
+
+	fraction := func(requested, allocated, capacity int64) {
+		if capacity <= 0 {
+			return
+		}
+		used := allocated + requested
+		if used > capacity {
+			used = capacity
+		}
+		total += used * MaxPriority / capacity
+		count++
+	}
+
+	fraction(r.Memory, n.Status.Allocated.Memory, n.Status.Capacity.Memory)
+	fraction(r.CPU, int64(n.Status.Allocated.Cpu), int64(n.Status.Capacity.Cpu))
+
+	if r.Storage > 0 {
+		fraction(r.Storage, n.Status.Allocated.Storage, n.Status.Capacity.Storage)
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return int(total / count)
+}
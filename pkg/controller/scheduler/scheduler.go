@@ -0,0 +1,212 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+const (
+	// MaxPriority - max score a single priority can give to a node
+	MaxPriority = 10
+
+	PriorityLeastAllocated = "least-allocated"
+	PriorityMostAllocated  = "most-allocated"
+	PrioritySpread         = "spread"
+	PriorityAffinity       = "affinity"
+)
+
+// Request describes resources and placement rules of a lease request
+type Request struct {
+	// Node selector: node name and labels
+	Selector types.SpecSelector
+	// Requested memory
+	Memory int64
+	// Requested cpu
+	CPU int64
+	// Requested storage
+	Storage int64
+	// Requested pods slots
+	Pods int
+	// Requested pod service self link
+	Service string
+	// Pod placement rules relative to other services pods
+	Affinity types.SpecAffinity
+	// Services pods count placed on nodes: node -> service -> pods count
+	Placement map[string]map[string]int
+
+	// nodes used to calculate topology domains
+	nodes []*types.Node
+}
+
+// FilterFunc - returns true if node can accept request
+type FilterFunc func(r *Request, n *types.Node) bool
+
+// PriorityFunc - scores every node from 0 to MaxPriority
+type PriorityFunc func(r *Request, nodes []*types.Node) map[string]int
+
+type priority struct {
+	name   string
+	weight int
+	score  PriorityFunc
+}
+
+// Scheduler selects node for lease request in two phases:
+// filter nodes which can not accept request and score the rest
+type Scheduler struct {
+	filters    []FilterFunc
+	priorities []priority
+}
+
+var priorities = map[string]PriorityFunc{
+	PriorityLeastAllocated: LeastAllocated,
+	PriorityMostAllocated:  MostAllocated,
+	PrioritySpread:         Spread,
+	PriorityAffinity:       Affinity,
+}
+
+// New returns scheduler with selected priorities.
+// Priority can be passed with weight in "name:weight" format.
+// Least allocated priority is used if no priorities provided,
+// affinity priority is always used to respect preferred placement rules
+func New(names ...string) (*Scheduler, error) {
+
+	s := new(Scheduler)
+	s.filters = []FilterFunc{
+		FilterSchedulable,
+		FilterSelector,
+		FilterTaints,
+		FilterPods,
+		FilterMemory,
+		FilterCPU,
+		FilterStorage,
+		FilterAffinity,
+	}
+
+	if len(names) == 0 {
+		names = []string{PriorityLeastAllocated}
+	}
+
+	for _, n := range names {
+
+		var (
+			name   = strings.TrimSpace(n)
+			weight = 1
+		)
+
+		if parts := strings.SplitN(name, ":", 2); len(parts) == 2 {
+			w, err := strconv.Atoi(parts[1])
+			if err != nil || w < 0 {
+				return nil, fmt.Errorf("invalid priority weight: %s", n)
+			}
+			name = parts[0]
+			weight = w
+		}
+
+		fn, ok := priorities[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown priority: %s", name)
+		}
+
+		s.priorities = append(s.priorities, priority{name: name, weight: weight, score: fn})
+	}
+
+	for _, p := range s.priorities {
+		if p.name == PriorityAffinity {
+			return s, nil
+		}
+	}
+
+	s.priorities = append(s.priorities, priority{name: PriorityAffinity, weight: 1, score: Affinity})
+
+	return s, nil
+}
+
+// Filter returns nodes which pass all filters
+func (s *Scheduler) Filter(r *Request, nodes []*types.Node) []*types.Node {
+
+	fit := make([]*types.Node, 0)
+	r.nodes = nodes
+
+	for _, n := range nodes {
+
+		var ok = true
+		for _, f := range s.filters {
+			if !f(r, n) {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			fit = append(fit, n)
+		}
+	}
+
+	return fit
+}
+
+// Score returns weighted sum of priorities for every node
+func (s *Scheduler) Score(r *Request, nodes []*types.Node) map[string]int {
+
+	scores := make(map[string]int, len(nodes))
+
+	if r.nodes == nil {
+		r.nodes = nodes
+	}
+
+	for _, n := range nodes {
+		scores[n.SelfLink()] = 0
+	}
+
+	for _, p := range s.priorities {
+		for node, score := range p.score(r, nodes) {
+			scores[node] += score * p.weight
+		}
+	}
+
+	return scores
+}
+
+// Schedule returns node with the highest score or nil if no nodes can accept request.
+// Nodes with equal score are ordered by name to keep result stable
+func (s *Scheduler) Schedule(r *Request, nodes []*types.Node) *types.Node {
+
+	fit := s.Filter(r, nodes)
+	if len(fit) == 0 {
+		return nil
+	}
+
+	scores := s.Score(r, fit)
+
+	sort.Slice(fit, func(i, j int) bool {
+		si, sj := scores[fit[i].SelfLink()], scores[fit[j].SelfLink()]
+		if si != sj {
+			return si > sj
+		}
+		return fit[i].SelfLink() < fit[j].SelfLink()
+	})
+
+	return fit[0]
+}
@@ -0,0 +1,311 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerFilter(t *testing.T) {
+
+	type suit struct {
+		name  string
+		req   *Request
+		nodes []*types.Node
+		want  []string
+	}
+
+	var tests = []suit{
+		{
+			name:  "node selector should filter nodes by name",
+			req:   &Request{Selector: types.SpecSelector{Node: "n2"}},
+			nodes: []*types.Node{getNodeAsset("n1", 1000, 0), getNodeAsset("n2", 1000, 0)},
+			want:  []string{"n2"},
+		},
+		{
+			name: "labels selector should filter nodes without all labels",
+			req: &Request{Selector: types.SpecSelector{Labels: map[string]string{
+				"zone": "a",
+				"disk": "ssd",
+			}}},
+			nodes: []*types.Node{
+				getNodeAsset("n1", 1000, 0, "zone", "a"),
+				getNodeAsset("n2", 1000, 0, "zone", "a", "disk", "ssd"),
+				getNodeAsset("n3", 1000, 0, "zone", "b", "disk", "ssd"),
+				getNodeAsset("n4", 1000, 0),
+			},
+			want: []string{"n2"},
+		},
+		{
+			name:  "memory filter should skip nodes without free memory",
+			req:   &Request{Memory: 512},
+			nodes: []*types.Node{getNodeAsset("n1", 1000, 600), getNodeAsset("n2", 1000, 488)},
+			want:  []string{"n2"},
+		},
+		{
+			name: "cpu filter should skip nodes without free cpu",
+			req:  &Request{CPU: 500},
+			nodes: func() []*types.Node {
+				n1 := getNodeAsset("n1", 1000, 0)
+				n1.Status.Capacity.Cpu = 1000
+				n1.Status.Allocated.Cpu = 600
+				n2 := getNodeAsset("n2", 1000, 0)
+				n2.Status.Capacity.Cpu = 1000
+				n3 := getNodeAsset("n3", 1000, 0)
+				return []*types.Node{n1, n2, n3}
+			}(),
+			want: []string{"n2", "n3"},
+		},
+		{
+			name: "storage filter should skip nodes without free storage",
+			req:  &Request{Storage: 100},
+			nodes: func() []*types.Node {
+				n1 := getNodeAsset("n1", 1000, 0)
+				n1.Status.Capacity.Storage = 50
+				n2 := getNodeAsset("n2", 1000, 0)
+				n2.Status.Capacity.Storage = 100
+				return []*types.Node{n1, n2}
+			}(),
+			want: []string{"n2"},
+		},
+		{
+			name: "pods filter should skip nodes without free pods slots",
+			req:  &Request{Pods: 1},
+			nodes: func() []*types.Node {
+				n1 := getNodeAsset("n1", 1000, 0)
+				n1.Status.Capacity.Pods = 2
+				n1.Status.Allocated.Pods = 2
+				n2 := getNodeAsset("n2", 1000, 0)
+				n2.Status.Capacity.Pods = 2
+				n2.Status.Allocated.Pods = 1
+				return []*types.Node{n1, n2}
+			}(),
+			want: []string{"n2"},
+		},
+		{
+			name: "cordoned nodes should be skipped",
+			req:  &Request{},
+			nodes: func() []*types.Node {
+				n1 := getNodeAsset("n1", 1000, 0)
+				n1.Spec.Unschedulable = true
+				n2 := getNodeAsset("n2", 1000, 0)
+				return []*types.Node{n1, n2}
+			}(),
+			want: []string{"n2"},
+		},
+		{
+			name: "tainted nodes should be skipped without matching tolerations",
+			req: &Request{Selector: types.SpecSelector{Tolerations: []types.SpecToleration{
+				{Key: "gpu"},
+				{Key: "dedicated", Value: "db"},
+			}}},
+			nodes: func() []*types.Node {
+				n1 := getNodeAsset("n1", 1000, 0)
+				n1.Spec.Taints = []types.NodeTaint{{Key: "gpu", Value: "nvidia"}}
+				n2 := getNodeAsset("n2", 1000, 0)
+				n2.Spec.Taints = []types.NodeTaint{{Key: "dedicated", Value: "web"}}
+				n3 := getNodeAsset("n3", 1000, 0)
+				n3.Spec.Taints = []types.NodeTaint{{Key: "dedicated", Value: "db"}}
+				n4 := getNodeAsset("n4", 1000, 0)
+				n4.Spec.Taints = []types.NodeTaint{{Key: "maintenance"}}
+				return []*types.Node{n1, n2, n3, n4}
+			}(),
+			want: []string{"n1", "n3"},
+		},
+	}
+
+	s, err := New()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, n := range s.Filter(tt.req, tt.nodes) {
+				got = append(got, n.SelfLink())
+			}
+			assert.Equal(t, tt.want, got, "filtered nodes are different")
+		})
+	}
+}
+
+func TestSchedulerSchedule(t *testing.T) {
+
+	type suit struct {
+		name       string
+		priorities []string
+		req        *Request
+		nodes      []*types.Node
+		want       string
+	}
+
+	var tests = []suit{
+		{
+			name:       "least allocated should select node with most free resources",
+			priorities: []string{PriorityLeastAllocated},
+			req:        &Request{Memory: 100, Pods: 1},
+			nodes: []*types.Node{
+				getNodeAsset("n1", 1000, 500),
+				getNodeAsset("n2", 1000, 100),
+				getNodeAsset("n3", 1000, 800),
+			},
+			want: "n2",
+		},
+		{
+			name:       "most allocated should pack pods on the busiest node",
+			priorities: []string{PriorityMostAllocated},
+			req:        &Request{Memory: 100, Pods: 1},
+			nodes: []*types.Node{
+				getNodeAsset("n1", 1000, 500),
+				getNodeAsset("n2", 1000, 100),
+				getNodeAsset("n3", 1000, 800),
+			},
+			want: "n3",
+		},
+		{
+			name:       "most allocated should skip nodes without enough memory",
+			priorities: []string{PriorityMostAllocated},
+			req:        &Request{Memory: 300, Pods: 1},
+			nodes: []*types.Node{
+				getNodeAsset("n1", 1000, 500),
+				getNodeAsset("n2", 1000, 100),
+				getNodeAsset("n3", 1000, 800),
+			},
+			want: "n1",
+		},
+		{
+			name:       "spread should select node with less pods",
+			priorities: []string{PrioritySpread},
+			req:        &Request{Memory: 100, Pods: 1},
+			nodes: func() []*types.Node {
+				n1 := getNodeAsset("n1", 1000, 0)
+				n1.Status.Allocated.Pods = 3
+				n2 := getNodeAsset("n2", 1000, 500)
+				n2.Status.Allocated.Pods = 1
+				return []*types.Node{n1, n2}
+			}(),
+			want: "n2",
+		},
+		{
+			name:       "weighted priorities should be summed",
+			priorities: []string{PriorityLeastAllocated, "spread:3"},
+			req:        &Request{Memory: 100, Pods: 1},
+			nodes: func() []*types.Node {
+				n1 := getNodeAsset("n1", 1000, 0)
+				n1.Status.Allocated.Pods = 4
+				n2 := getNodeAsset("n2", 1000, 500)
+				n2.Status.Allocated.Pods = 1
+				return []*types.Node{n1, n2}
+			}(),
+			want: "n2",
+		},
+		{
+			name:       "nodes with equal score should be ordered by name",
+			priorities: []string{PriorityLeastAllocated},
+			req:        &Request{Memory: 100, Pods: 1},
+			nodes: []*types.Node{
+				getNodeAsset("n3", 1000, 0),
+				getNodeAsset("n1", 1000, 0),
+				getNodeAsset("n2", 1000, 0),
+			},
+			want: "n1",
+		},
+		{
+			name:       "no node should be selected if nothing fits",
+			priorities: []string{PriorityLeastAllocated},
+			req:        &Request{Memory: 2000, Pods: 1},
+			nodes: []*types.Node{
+				getNodeAsset("n1", 1000, 0),
+				getNodeAsset("n2", 1000, 0),
+			},
+			want: types.EmptyString,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s, err := New(tt.priorities...)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			n := s.Schedule(tt.req, tt.nodes)
+			if tt.want == types.EmptyString {
+				assert.Nil(t, n, "node should be nil")
+				return
+			}
+
+			if !assert.NotNil(t, n, "node should not be nil") {
+				return
+			}
+
+			assert.Equal(t, tt.want, n.SelfLink(), "selected node is different")
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+
+	type suit struct {
+		name       string
+		priorities []string
+		err        bool
+	}
+
+	var tests = []suit{
+		{name: "default priorities", priorities: nil},
+		{name: "known priorities", priorities: []string{PriorityLeastAllocated, PrioritySpread}},
+		{name: "weighted priority", priorities: []string{"most-allocated:2"}},
+		{name: "unknown priority", priorities: []string{"random"}, err: true},
+		{name: "invalid weight", priorities: []string{"spread:x"}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.priorities...)
+			if tt.err {
+				assert.Error(t, err, "error should be presented")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func getNodeAsset(name string, memory, allocated int64, labels ...string) *types.Node {
+
+	n := new(types.Node)
+	n.Meta.Name = name
+	n.Meta.Labels = make(map[string]string)
+
+	for i := 0; i+1 < len(labels); i += 2 {
+		n.Meta.Labels[labels[i]] = labels[i+1]
+	}
+
+	n.Status.Capacity.Memory = memory
+	n.Status.Capacity.Pods = 10
+	n.Status.Allocated.Memory = allocated
+
+	return n
+}
@@ -21,6 +21,7 @@ package cluster
 import (
 	"context"
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/controller/scheduler"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 )
@@ -38,8 +39,12 @@ type NodeLease struct {
 type NodeLeaseOptions struct {
 	Node     *string
 	Memory   *int64
+	CPU      *int64
 	Storage  *int64
 	Selector types.SpecSelector
+	Pod      *string
+	Service  *string
+	Affinity *types.SpecAffinity
 }
 
 func (nl *NodeLease) Wait() {
@@ -54,72 +59,61 @@ func handleNodeLease(cs *ClusterState, nl *NodeLease) error {
 		}
 	}()
 
-	for _, n := range cs.node.list {
-
-		// check selectors first
+	var (
+		req   = new(scheduler.Request)
+		nodes = make([]*types.Node, 0, len(cs.node.list))
+	)
 
-		if nl.Request.Selector.Node != types.EmptyString {
-			if n.SelfLink() != nl.Request.Selector.Node {
-				continue
-			}
-		}
+	req.Selector = nl.Request.Selector
 
-		if len(nl.Request.Selector.Labels) > 0 {
-			if len(n.Meta.Labels) == 0 {
-				continue
-			}
+	if nl.Request.Memory != nil {
+		req.Memory = *nl.Request.Memory
+		req.Pods = 1
+	}
 
-			for k, v := range nl.Request.Selector.Labels {
-				if _, ok := n.Meta.Labels[k]; !ok {
-					continue
-				}
+	if nl.Request.CPU != nil {
+		req.CPU = *nl.Request.CPU
+	}
 
-				if n.Meta.Labels[k] != v {
-					continue
-				}
-			}
+	if nl.Request.Storage != nil {
+		req.Storage = *nl.Request.Storage
+	}
 
-		}
+	if nl.Request.Service != nil {
+		req.Service = *nl.Request.Service
+	}
 
-		var (
-			node      *types.Node
-			allocated = new(types.NodeResources)
-		)
-
-		if nl.Request.Memory != nil {
-			if (n.Status.Capacity.Memory - n.Status.Allocated.Memory) > *nl.Request.Memory {
-				node = n
-				allocated.Pods++
-				allocated.Memory += *nl.Request.Memory
-			}
-		}
+	if nl.Request.Affinity != nil {
+		req.Affinity = *nl.Request.Affinity
+	}
 
-		if nl.Request.Storage != nil {
-			if (n.Status.Capacity.Storage - n.Status.Allocated.Storage) > *nl.Request.Storage {
-				if node == nil {
-					node = n
-				}
-				allocated.Storage += *nl.Request.Storage
-			}
-		}
+	req.Placement = cs.placement()
 
-		if node != nil {
+	for _, n := range cs.node.list {
+		nodes = append(nodes, n)
+	}
 
-			node.Status.Allocated.Pods += allocated.Pods
-			node.Status.Allocated.Memory += allocated.Memory
-			node.Status.Allocated.Storage += allocated.Storage
+	node := cs.scheduler().Schedule(req, nodes)
+	if node == nil {
+		return nil
+	}
 
-			nm := distribution.NewNodeModel(context.Background(), envs.Get().GetStorage())
-			if err := nm.Set(n); err != nil {
-				return err
-			}
+	node.Status.Allocated.Pods += req.Pods
+	node.Status.Allocated.Memory += req.Memory
+	node.Status.Allocated.Cpu += int(req.CPU)
+	node.Status.Allocated.Storage += req.Storage
 
-			nl.Response.Node = n
-			return nil
-		}
+	nm := distribution.NewNodeModel(context.Background(), envs.Get().GetStorage())
+	if err := nm.Set(node); err != nil {
+		nl.Response.Err = err
+		return err
+	}
 
+	if nl.Request.Pod != nil {
+		cs.placePod(node.SelfLink(), *nl.Request.Pod, req.Service)
 	}
 
+	nl.Response.Node = node
 	return nil
 }
 
@@ -131,6 +125,10 @@ func handleNodeRelease(cs *ClusterState, nl *NodeLease) error {
 		}
 	}()
 
+	if nl.Request.Pod != nil {
+		cs.unplacePod(*nl.Request.Node, *nl.Request.Pod)
+	}
+
 	if _, ok := cs.node.list[*nl.Request.Node]; !ok {
 		return nil
 	}
@@ -142,6 +140,10 @@ func handleNodeRelease(cs *ClusterState, nl *NodeLease) error {
 		n.Status.Allocated.Memory -= *nl.Request.Memory
 	}
 
+	if nl.Request.CPU != nil {
+		n.Status.Allocated.Cpu -= int(*nl.Request.CPU)
+	}
+
 	if nl.Request.Storage != nil {
 		n.Status.Allocated.Storage -= *nl.Request.Storage
 	}
@@ -151,3 +153,43 @@ func handleNodeRelease(cs *ClusterState, nl *NodeLease) error {
 
 	return nil
 }
+
+// placement returns services pods count on every node
+func (cs *ClusterState) placement() map[string]map[string]int {
+
+	placement := make(map[string]map[string]int, len(cs.pod.list))
+
+	for node, pods := range cs.pod.list {
+		placement[node] = make(map[string]int)
+		for _, svc := range pods {
+			placement[node][svc]++
+		}
+	}
+
+	return placement
+}
+
+type podPlacement struct {
+	node    string
+	pod     string
+	service string
+}
+
+// placePod stores pod placement on node, should be called only from cluster state loop
+func (cs *ClusterState) placePod(node, pod, service string) {
+	if _, ok := cs.pod.list[node]; !ok {
+		cs.pod.list[node] = make(map[string]string)
+	}
+	cs.pod.list[node][pod] = service
+}
+
+// unplacePod removes pod placement from node
+func (cs *ClusterState) unplacePod(node, pod string) {
+	if _, ok := cs.pod.list[node]; !ok {
+		return
+	}
+	delete(cs.pod.list[node], pod)
+	if len(cs.pod.list[node]) == 0 {
+		delete(cs.pod.list, node)
+	}
+}
@@ -20,9 +20,11 @@ package cluster
 
 import (
 	"context"
+	"sync"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/controller/ipam/ipam"
+	"github.com/lastbackend/lastbackend/pkg/controller/scheduler"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
@@ -62,6 +64,16 @@ type ClusterState struct {
 		release  chan *NodeLease
 		list     map[string]*types.Node
 	}
+	pod struct {
+		observer chan *podPlacement
+		// pods placement on nodes: node -> pod -> service
+		list map[string]map[string]string
+	}
+	drain struct {
+		lock    sync.RWMutex
+		list    map[string]bool
+		handler func(node string)
+	}
 }
 
 // Runtime cluster describes main cluster state loop
@@ -70,7 +82,9 @@ func (cs *ClusterState) Observe() {
 	for {
 		select {
 		case l := <-cs.node.lease:
-			handleNodeLease(cs, l)
+			if err := handleNodeLease(cs, l); err != nil {
+				log.Errorf("%s:> node lease err: %s", logPrefix, err.Error())
+			}
 			break
 		case l := <-cs.node.release:
 			handleNodeRelease(cs, l)
@@ -95,6 +109,10 @@ func (cs *ClusterState) Observe() {
 			log.V(7).Debugf("ingress: %s", i.SelfLink())
 			cs.ingress.list[i.Meta.SelfLink] = i
 			break
+		case p := <-cs.pod.observer:
+			log.V(7).Debugf("pod placement: %s on %s", p.pod, p.node)
+			cs.placePod(p.node, p.pod, p.service)
+			break
 		}
 	}
 }
@@ -120,6 +138,7 @@ func (cs *ClusterState) Loop() error {
 	}
 	for _, n := range nl.Items {
 		// Add node to local cache
+		cs.setNodeDrain(n)
 		cs.SetNode(n)
 		// Run node observers
 	}
@@ -148,6 +167,29 @@ func (cs *ClusterState) Loop() error {
 		// Run route observers
 	}
 
+	// Get pods placement in cluster
+	ns := distribution.NewNamespaceModel(context.Background(), envs.Get().GetStorage())
+	nsl, err := ns.List()
+	if err != nil {
+		return err
+	}
+
+	pm := distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
+	for _, n := range nsl.Items {
+
+		pl, err := pm.ListByNamespace(n.Meta.Name)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range pl.Items {
+			if p.Meta.Node == types.EmptyString || p.Status.State == types.StateDestroyed {
+				continue
+			}
+			cs.SetPodPlacement(p.Meta.Node, p.SelfLink(), p.ServiceLink())
+		}
+	}
+
 	go cs.watchNode(context.Background(), &nl.System.Revision)
 	go cs.watchIngress(context.Background(), &il.System.Revision)
 	go cs.watchRoute(context.Background(), &rl.System.Revision)
@@ -175,10 +217,12 @@ func (cs *ClusterState) watchNode(ctx context.Context, rev *int64) {
 				}
 
 				if w.IsActionRemove() {
+					cs.delNodeDrain(w.Data)
 					cs.DelNode(w.Data)
 					continue
 				}
 
+				cs.setNodeDrain(w.Data)
 				cs.SetNode(w.Data)
 			}
 		}
@@ -308,6 +352,15 @@ func (cs *ClusterState) IPAM() ipam.IPAM {
 	return envs.Get().GetIPAM()
 }
 
+// scheduler used for node leases, falls back to default priorities if not configured
+func (cs *ClusterState) scheduler() *scheduler.Scheduler {
+	if s := envs.Get().GetScheduler(); s != nil {
+		return s
+	}
+	s, _ := scheduler.New()
+	return s
+}
+
 func (cs *ClusterState) SetNode(n *types.Node) {
 	cs.node.observer <- n
 }
@@ -316,6 +369,55 @@ func (cs *ClusterState) DelNode(n *types.Node) {
 	delete(cs.node.list, n.Meta.SelfLink)
 }
 
+// OnNodeDrain sets handler called when node drain is requested
+func (cs *ClusterState) OnNodeDrain(fn func(node string)) {
+	cs.drain.lock.Lock()
+	cs.drain.handler = fn
+	cs.drain.lock.Unlock()
+}
+
+// IsNodeDrain returns true if pods should be moved from node
+func (cs *ClusterState) IsNodeDrain(node string) bool {
+	cs.drain.lock.RLock()
+	defer cs.drain.lock.RUnlock()
+	return cs.drain.list[node]
+}
+
+// setNodeDrain updates node drain state and calls drain handler on node drain request
+func (cs *ClusterState) setNodeDrain(n *types.Node) {
+
+	cs.drain.lock.Lock()
+
+	var (
+		drain   = n.Spec.Drain && !cs.drain.list[n.SelfLink()]
+		handler = cs.drain.handler
+	)
+
+	if n.Spec.Drain {
+		cs.drain.list[n.SelfLink()] = true
+	} else {
+		delete(cs.drain.list, n.SelfLink())
+	}
+
+	cs.drain.lock.Unlock()
+
+	if drain && handler != nil {
+		log.V(logLevel).Debugf("%s:> drain node: %s", logPrefix, n.SelfLink())
+		go handler(n.SelfLink())
+	}
+}
+
+func (cs *ClusterState) delNodeDrain(n *types.Node) {
+	cs.drain.lock.Lock()
+	delete(cs.drain.list, n.SelfLink())
+	cs.drain.lock.Unlock()
+}
+
+// SetPodPlacement stores pod placement on node in cluster state loop
+func (cs *ClusterState) SetPodPlacement(node, pod, service string) {
+	cs.pod.observer <- &podPlacement{node: node, pod: pod, service: service}
+}
+
 func (cs *ClusterState) SetIngress(i *types.Ingress) {
 	cs.ingress.observer <- i
 }
@@ -350,15 +452,25 @@ func (cs *ClusterState) DelRoute(r *types.Route) {
 
 func (cs *ClusterState) PodLease(p *types.Pod) (*types.Node, error) {
 
-	var RAM int64
+	var RAM, CPU int64
 
 	for _, s := range p.Spec.Template.Containers {
 		RAM += s.Resources.Request.RAM
+		CPU += s.Resources.Request.CPU
 	}
 
+	var (
+		pod = p.SelfLink()
+		svc = p.ServiceLink()
+	)
+
 	opts := NodeLeaseOptions{
 		Selector: p.Spec.Selector,
 		Memory:   &RAM,
+		CPU:      &CPU,
+		Pod:      &pod,
+		Service:  &svc,
+		Affinity: &p.Spec.Affinity,
 	}
 
 	node, err := cs.lease(opts)
@@ -371,15 +483,20 @@ func (cs *ClusterState) PodLease(p *types.Pod) (*types.Node, error) {
 }
 
 func (cs *ClusterState) PodRelease(p *types.Pod) (*types.Node, error) {
-	var RAM int64
+	var RAM, CPU int64
 
 	for _, s := range p.Spec.Template.Containers {
 		RAM += s.Resources.Request.RAM
+		CPU += s.Resources.Request.CPU
 	}
 
+	pod := p.SelfLink()
+
 	opts := NodeLeaseOptions{
 		Node:   &p.Meta.Node,
 		Memory: &RAM,
+		CPU:    &CPU,
+		Pod:    &pod,
 	}
 
 	node, err := cs.release(opts)
@@ -444,6 +561,10 @@ func NewClusterState() *ClusterState {
 	cs.node.lease = make(chan *NodeLease)
 	cs.node.release = make(chan *NodeLease)
 
+	cs.pod.observer = make(chan *podPlacement)
+	cs.pod.list = make(map[string]map[string]string)
+	cs.drain.list = make(map[string]bool)
+
 	cs.node.observer = make(chan *types.Node)
 
 	cs.route.observer = make(chan *types.Route)
@@ -151,8 +151,7 @@ func handleVolumeStateDestroyed (cs *ClusterState, v *types.Volume)  error {
 func volumeUpdate(v *types.Volume, timestamp time.Time) error {
 
 	if timestamp.Before(v.Meta.Updated) {
-		vm := distribution.NewVolumeModel(context.Background(), envs.Get().GetStorage())
-		if err := vm.Update(v); err != nil {
+		if err := volumeSave(v); err != nil {
 			log.Errorf("%s", err.Error())
 			return err
 		}
@@ -161,6 +160,35 @@ func volumeUpdate(v *types.Volume, timestamp time.Time) error {
 	return nil
 }
 
+// volumeSave - save volume, on revision conflict node, status and destroy state
+// managed by controller are applied on latest volume state
+func volumeSave(v *types.Volume) error {
+
+	vm := distribution.NewVolumeModel(context.Background(), envs.Get().GetStorage())
+
+	return distribution.RetryOnConflict(func() error {
+		return vm.Update(v)
+	}, func() error {
+
+		latest, err := vm.Get(v.Meta.Namespace, v.Meta.Name)
+		if err != nil {
+			return err
+		}
+		if latest == nil {
+			return errors.Storage().NewErrEntityNotFound()
+		}
+
+		log.V(logLevel).Debugf("%s:> volume %s changed, retry update", logPrefixVolume, v.SelfLink())
+
+		latest.Meta.Node = v.Meta.Node
+		latest.Meta.Updated = v.Meta.Updated
+		latest.Spec.State.Destroy = latest.Spec.State.Destroy || v.Spec.State.Destroy
+		latest.Status = v.Status
+		*v = *latest
+		return nil
+	})
+}
+
 func volumeProvision(cs *ClusterState, volume *types.Volume) (err error) {
 
 	t := volume.Meta.Updated
@@ -0,0 +1,359 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const (
+	logAutoscalerPrefix = "state:observer:autoscaler"
+
+	autoscalerDeploymentNotReady = "deployment is not ready"
+	autoscalerMetricsMissing     = "pods metrics are not reported"
+)
+
+// autoscaleRecommendation - replicas count recommended by autoscaler at check time
+type autoscaleRecommendation struct {
+	replicas int
+	time     time.Time
+}
+
+// autoscaleUsage - average utilization of deployment pods resources in percents
+type autoscaleUsage struct {
+	// pods with reported metrics
+	pods int
+	// cpu utilization, -1 if it can not be calculated
+	cpu int
+	// memory utilization, -1 if it can not be calculated
+	ram int
+}
+
+// autoscalerObserve - check service autoscaler and scale service replicas
+func autoscalerObserve(ss *ServiceState) error {
+
+	svc := ss.service
+	if svc == nil || svc.Status.State == types.StateDestroy || svc.Status.State == types.StateDestroyed {
+		return nil
+	}
+
+	am := distribution.NewAutoscalerModel(context.Background(), envs.Get().GetStorage())
+	as, err := am.Get(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		log.Errorf("%s:> autoscaler get err: %s", logAutoscalerPrefix, err.Error())
+		return err
+	}
+
+	if as == nil {
+		ss.autoscaler.recommendations = nil
+		return nil
+	}
+
+	return autoscale(ss, as, time.Now())
+}
+
+// autoscale - calculate replicas count from deployment pods metrics and apply it to service
+func autoscale(ss *ServiceState, as *types.Autoscaler, now time.Time) error {
+
+	log.V(logLevel).Debugf("%s:> autoscale service: %s", logAutoscalerPrefix, as.ServiceLink())
+
+	var (
+		svc    = ss.service
+		status = as.Status
+		d      = ss.deployment.active
+	)
+
+	status.Updated = now
+	status.Replicas = svc.Spec.Replicas
+	status.Message = types.EmptyString
+
+	if ss.deployment.provision != nil && deploymentSpecValidate(ss.deployment.provision, svc) {
+		d = ss.deployment.provision
+	}
+
+	if d == nil || d.Status.State != types.StateReady || rolloutActive(ss) {
+		status.Message = autoscalerDeploymentNotReady
+		return autoscalerStatusSet(as, status)
+	}
+
+	usage := autoscaleUsageGet(ss, d)
+	status.CPU = usage.cpu
+	status.RAM = usage.ram
+
+	if usage.pods == 0 {
+		status.Message = autoscalerMetricsMissing
+		return autoscalerStatusSet(as, status)
+	}
+
+	desired := autoscaleDesired(as.Spec, svc.Spec.Replicas, usage)
+	replicas := autoscaleStabilize(ss, as.Spec, svc.Spec.Replicas, desired, now)
+	status.Desired = replicas
+
+	if replicas == svc.Spec.Replicas {
+		return autoscalerStatusSet(as, status)
+	}
+
+	if replicas > svc.Spec.Replicas {
+		scaled := *svc
+		scaled.Spec.Replicas = replicas
+		if err := quotaCheck(&scaled); err != nil {
+			log.Warnf("%s:> service %s scale is not allowed: %s", logAutoscalerPrefix, svc.SelfLink(), err.Error())
+			status.Message = err.Error()
+			return autoscalerStatusSet(as, status)
+		}
+	}
+
+	log.V(logLevel).Debugf("%s:> scale service %s: %d -> %d", logAutoscalerPrefix, svc.SelfLink(), svc.Spec.Replicas, replicas)
+
+	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+
+	svc.Spec.Replicas = replicas
+	svc.Meta.Updated = now
+
+	err := distribution.RetryOnConflict(func() error {
+		_, err := sm.Update(svc)
+		return err
+	}, func() error {
+
+		latest, err := sm.Get(svc.Meta.Namespace, svc.Meta.Name)
+		if err != nil {
+			return err
+		}
+		if latest == nil {
+			return errors.Storage().NewErrEntityNotFound()
+		}
+
+		latest.Spec.Replicas = replicas
+		latest.Meta.Updated = now
+		*svc = *latest
+		return nil
+	})
+	if err != nil {
+		log.Errorf("%s:> service update err: %s", logAutoscalerPrefix, err.Error())
+		return err
+	}
+
+	if err := quotaAccount(svc.Meta.Namespace); err != nil {
+		log.Errorf("%s:> namespace resources account err: %s", logAutoscalerPrefix, err.Error())
+	}
+
+	status.Replicas = replicas
+	status.Scaled = now
+
+	return autoscalerStatusSet(as, status)
+}
+
+// autoscaleUsageGet - calculate average utilization of ready deployment pods
+// relative to containers requests or limits if requests are not set
+func autoscaleUsageGet(ss *ServiceState, d *types.Deployment) autoscaleUsage {
+
+	var (
+		usage            = autoscaleUsage{cpu: -1, ram: -1}
+		refCPU, refRAM   int64
+		usedCPU, usedRAM int64
+	)
+
+	for _, c := range d.Spec.Template.Containers {
+		refCPU += autoscaleResource(c.Resources.Request.CPU, c.Resources.Limits.CPU)
+		refRAM += autoscaleResource(c.Resources.Request.RAM, c.Resources.Limits.RAM)
+	}
+
+	pl, ok := ss.pod.list[d.SelfLink()]
+	if !ok {
+		return usage
+	}
+
+	for _, p := range pl {
+
+		if !p.Status.Ready() || len(p.Status.Containers) == 0 {
+			continue
+		}
+
+		var reported = true
+		for _, c := range p.Status.Containers {
+			if c.Usage.Updated.IsZero() {
+				reported = false
+				break
+			}
+		}
+
+		if !reported {
+			continue
+		}
+
+		for _, c := range p.Status.Containers {
+			usedCPU += c.Usage.CPU
+			usedRAM += c.Usage.RAM
+		}
+
+		usage.pods++
+	}
+
+	if usage.pods == 0 {
+		return usage
+	}
+
+	if refCPU > 0 {
+		usage.cpu = int(usedCPU * 100 / (refCPU * int64(usage.pods)))
+	}
+
+	if refRAM > 0 {
+		usage.ram = int(usedRAM * 100 / (refRAM * int64(usage.pods)))
+	}
+
+	return usage
+}
+
+// autoscaleDesired - calculate replicas count needed to reach utilization targets
+func autoscaleDesired(spec types.AutoscalerSpec, current int, usage autoscaleUsage) int {
+
+	var desired = -1
+
+	calc := func(used, target int) {
+		if target <= 0 || used < 0 {
+			return
+		}
+
+		ratio := float64(used) / float64(target)
+
+		// small deviations from target are ignored to prevent replicas flapping
+		if math.Abs(ratio-1) <= float64(types.DefaultAutoscalerTolerance)/100 {
+			if desired < current {
+				desired = current
+			}
+			return
+		}
+
+		r := int(math.Ceil(ratio * float64(usage.pods)))
+
+		// scale down only when all replicas reported metrics
+		if r < current && usage.pods < current {
+			r = current
+		}
+
+		if r > desired {
+			desired = r
+		}
+	}
+
+	calc(usage.cpu, spec.CPU)
+	calc(usage.ram, spec.RAM)
+
+	if desired < 0 {
+		desired = current
+	}
+
+	return spec.Bound(desired)
+}
+
+// autoscaleStabilize - choose replicas count from recommendations in stabilization windows:
+// scale up to the lowest recommendation in scale up window
+// and scale down to the highest recommendation in scale down window
+func autoscaleStabilize(ss *ServiceState, spec types.AutoscalerSpec, current, desired int, now time.Time) int {
+
+	var (
+		upWindow   = spec.Stabilization.ScaleUpWindow()
+		downWindow = spec.Stabilization.ScaleDownWindow()
+		keep       = upWindow
+		up         = desired
+		down       = desired
+		list       = make([]autoscaleRecommendation, 0)
+	)
+
+	if downWindow > keep {
+		keep = downWindow
+	}
+
+	ss.autoscaler.recommendations = append(ss.autoscaler.recommendations, autoscaleRecommendation{replicas: desired, time: now})
+
+	for _, r := range ss.autoscaler.recommendations {
+
+		age := now.Sub(r.time)
+		if age > keep {
+			continue
+		}
+
+		list = append(list, r)
+
+		if age <= upWindow && r.replicas < up {
+			up = r.replicas
+		}
+
+		if age <= downWindow && r.replicas > down {
+			down = r.replicas
+		}
+	}
+
+	ss.autoscaler.recommendations = list
+
+	replicas := current
+	if replicas < up {
+		replicas = up
+	}
+
+	if replicas > down {
+		replicas = down
+	}
+
+	return replicas
+}
+
+// autoscalerStatusSet - save autoscaler status if it is changed
+func autoscalerStatusSet(as *types.Autoscaler, status types.AutoscalerStatus) error {
+
+	am := distribution.NewAutoscalerModel(context.Background(), envs.Get().GetStorage())
+	if _, err := am.SetStatus(as, status); err != nil {
+		log.Errorf("%s:> autoscaler status set err: %s", logAutoscalerPrefix, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// autoscalerRemove - remove service autoscaler
+func autoscalerRemove(svc *types.Service) error {
+
+	am := distribution.NewAutoscalerModel(context.Background(), envs.Get().GetStorage())
+
+	as, err := am.Get(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		return err
+	}
+
+	if as == nil {
+		return nil
+	}
+
+	return am.Remove(as)
+}
+
+func autoscaleResource(request, limit int64) int64 {
+	if request > 0 {
+		return request
+	}
+	return limit
+}
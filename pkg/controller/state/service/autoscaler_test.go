@@ -0,0 +1,292 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoscaleDesired(t *testing.T) {
+
+	type suit struct {
+		name    string
+		spec    types.AutoscalerSpec
+		current int
+		usage   autoscaleUsage
+		want    int
+	}
+
+	spec := types.AutoscalerSpec{MinReplicas: 1, MaxReplicas: 10, CPU: 50}
+
+	var tests = []suit{
+		{
+			name:    "scale up by cpu utilization",
+			spec:    spec,
+			current: 2,
+			usage:   autoscaleUsage{pods: 2, cpu: 100, ram: -1},
+			want:    4,
+		},
+		{
+			name:    "scale down by cpu utilization",
+			spec:    spec,
+			current: 4,
+			usage:   autoscaleUsage{pods: 4, cpu: 25, ram: -1},
+			want:    2,
+		},
+		{
+			name:    "keep replicas in tolerance",
+			spec:    spec,
+			current: 3,
+			usage:   autoscaleUsage{pods: 3, cpu: 54, ram: -1},
+			want:    3,
+		},
+		{
+			name:    "keep replicas while pods metrics are missing",
+			spec:    spec,
+			current: 4,
+			usage:   autoscaleUsage{pods: 2, cpu: 10, ram: -1},
+			want:    4,
+		},
+		{
+			name:    "bound by max replicas",
+			spec:    spec,
+			current: 5,
+			usage:   autoscaleUsage{pods: 5, cpu: 200, ram: -1},
+			want:    10,
+		},
+		{
+			name:    "bound by min replicas",
+			spec:    types.AutoscalerSpec{MinReplicas: 2, MaxReplicas: 10, CPU: 50},
+			current: 3,
+			usage:   autoscaleUsage{pods: 3, cpu: 1, ram: -1},
+			want:    2,
+		},
+		{
+			name:    "highest recommendation of cpu and ram is used",
+			spec:    types.AutoscalerSpec{MinReplicas: 1, MaxReplicas: 10, CPU: 50, RAM: 50},
+			current: 2,
+			usage:   autoscaleUsage{pods: 2, cpu: 50, ram: 150},
+			want:    6,
+		},
+		{
+			name:    "keep replicas without utilization",
+			spec:    spec,
+			current: 3,
+			usage:   autoscaleUsage{pods: 3, cpu: -1, ram: -1},
+			want:    3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, autoscaleDesired(tc.spec, tc.current, tc.usage), "replicas mismatch")
+		})
+	}
+}
+
+func TestAutoscaleStabilize(t *testing.T) {
+
+	type step struct {
+		offset  time.Duration
+		desired int
+		want    int
+	}
+
+	type suit struct {
+		name    string
+		spec    types.AutoscalerSpec
+		current int
+		steps   []step
+	}
+
+	var tests = []suit{
+		{
+			name:    "scale up immediately",
+			spec:    types.AutoscalerSpec{},
+			current: 2,
+			steps: []step{
+				{offset: 0, desired: 4, want: 4},
+			},
+		},
+		{
+			name:    "scale down after window",
+			spec:    types.AutoscalerSpec{Stabilization: types.AutoscalerSpecStabilization{ScaleDown: 60}},
+			current: 4,
+			steps: []step{
+				{offset: 0, desired: 4, want: 4},
+				{offset: 30 * time.Second, desired: 2, want: 4},
+				{offset: 59 * time.Second, desired: 2, want: 4},
+				{offset: 61 * time.Second, desired: 2, want: 2},
+			},
+		},
+		{
+			name:    "scale up to lowest recommendation in window",
+			spec:    types.AutoscalerSpec{Stabilization: types.AutoscalerSpecStabilization{ScaleUp: 60, ScaleDown: -1}},
+			current: 2,
+			steps: []step{
+				{offset: 0, desired: 3, want: 3},
+				{offset: 30 * time.Second, desired: 6, want: 3},
+				{offset: 91 * time.Second, desired: 6, want: 6},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var (
+				ss      = new(ServiceState)
+				now     = time.Now()
+				current = tc.current
+			)
+
+			for _, s := range tc.steps {
+				current = autoscaleStabilize(ss, tc.spec, current, s.desired, now.Add(s.offset))
+				if !assert.Equal(t, s.want, current, "replicas mismatch at %s", s.offset) {
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestAutoscale(t *testing.T) {
+
+	type suit struct {
+		name     string
+		replicas int
+		spec     types.AutoscalerSpec
+		usage    []int64
+		state    string
+		want     int
+		message  string
+	}
+
+	var tests = []suit{
+		{
+			name:     "scale up service",
+			replicas: 2,
+			spec:     types.AutoscalerSpec{MinReplicas: 1, MaxReplicas: 5, CPU: 60},
+			usage:    []int64{180, 180},
+			state:    types.StateReady,
+			want:     5,
+		},
+		{
+			name:     "scale down service",
+			replicas: 3,
+			spec:     types.AutoscalerSpec{MinReplicas: 1, MaxReplicas: 5, CPU: 60, Stabilization: types.AutoscalerSpecStabilization{ScaleDown: -1}},
+			usage:    []int64{20, 20, 20},
+			state:    types.StateReady,
+			want:     1,
+		},
+		{
+			name:     "skip service without metrics",
+			replicas: 2,
+			spec:     types.AutoscalerSpec{MinReplicas: 1, MaxReplicas: 5, CPU: 60},
+			usage:    []int64{},
+			state:    types.StateReady,
+			want:     2,
+			message:  autoscalerMetricsMissing,
+		},
+		{
+			name:     "skip service with deployment in provision",
+			replicas: 2,
+			spec:     types.AutoscalerSpec{MinReplicas: 1, MaxReplicas: 5, CPU: 60},
+			usage:    []int64{180, 180},
+			state:    types.StateProvision,
+			want:     2,
+			message:  autoscalerDeploymentNotReady,
+		},
+	}
+
+	var (
+		ctx = context.Background()
+		stg = envs.Get().GetStorage()
+		sm  = distribution.NewServiceModel(ctx, stg)
+		am  = distribution.NewAutoscalerModel(ctx, stg)
+	)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			if !assert.NoError(t, stg.Del(ctx, stg.Collection().Service(), "")) ||
+				!assert.NoError(t, stg.Del(ctx, stg.Collection().Autoscaler(), "")) {
+				return
+			}
+
+			svc := getServiceAsset(types.StateReady, "")
+			svc.Spec.Replicas = tc.replicas
+
+			err := stg.Put(ctx, stg.Collection().Service(), stg.Key().Service(svc.Meta.Namespace, svc.Meta.Name), svc, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			as, err := am.Create(svc, tc.spec)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			d := getDeploymentAsset(svc, tc.state, "")
+			d.Spec.Template.Containers[0].Resources.Request.CPU = 100
+
+			ss := getServiceStateAsset(svc)
+			ss.deployment.active = d
+			ss.deployment.list[d.SelfLink()] = d
+			ss.pod.list[d.SelfLink()] = make(map[string]*types.Pod)
+
+			// pods with synthetic metrics reported by nodes
+			for _, u := range tc.usage {
+				p := getPodAsset(d, types.StateReady, "")
+				p.Status.Containers = map[string]*types.PodContainer{
+					"demo": {
+						Name:  "demo",
+						Ready: true,
+						Usage: types.PodContainerUsage{CPU: u, Updated: time.Now()},
+					},
+				}
+				ss.pod.list[d.SelfLink()][p.SelfLink()] = p
+			}
+
+			if !assert.NoError(t, autoscale(ss, as, time.Now())) {
+				return
+			}
+
+			s, err := sm.Get(svc.Meta.Namespace, svc.Meta.Name)
+			if !assert.NoError(t, err) || !assert.NotNil(t, s) {
+				return
+			}
+			assert.Equal(t, tc.want, s.Spec.Replicas, "service replicas mismatch")
+
+			a, err := am.Get(svc.Meta.Namespace, svc.Meta.Name)
+			if !assert.NoError(t, err) || !assert.NotNil(t, a) {
+				return
+			}
+			assert.Equal(t, tc.want, a.Status.Replicas, "autoscaler replicas mismatch")
+			assert.Equal(t, tc.message, a.Status.Message, "autoscaler message mismatch")
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"time"
@@ -132,6 +133,18 @@ func handleDeploymentStateReady(ss *ServiceState, d *types.Deployment) error {
 
 	log.V(logLevel).Debugf("%s:> handleDeploymentStateReady: %s > %s", logDeploymentPrefix, d.SelfLink(), d.Status.State)
 
+	// deployment is activated by rollout when all replicas are replaced
+	if rolloutActive(ss) && ss.deployment.provision.SelfLink() == d.SelfLink() {
+		ss.deployment.provision = d
+		return deploymentRollout(ss)
+	}
+
+	return deploymentActivate(ss, d)
+}
+
+// deploymentActivate - marks deployment as active and destroys previous active deployment
+func deploymentActivate(ss *ServiceState, d *types.Deployment) error {
+
 	if ss.deployment.active != nil {
 		if ss.deployment.active.SelfLink() != d.SelfLink() {
 			if err := deploymentDestroy(ss, ss.deployment.active); err != nil {
@@ -160,6 +173,11 @@ func handleDeploymentStateError(ss *ServiceState, d *types.Deployment) error {
 
 	log.V(logLevel).Debugf("%s:> handleDeploymentStateError: %s > %s", logDeploymentPrefix, d.SelfLink(), d.Status.State)
 
+	if rolloutActive(ss) && ss.deployment.provision.SelfLink() == d.SelfLink() {
+		ss.deployment.provision = d
+		return deploymentRollback(ss, d.Status.Message)
+	}
+
 	if ss.deployment.active == nil {
 		ss.deployment.provision = nil
 		ss.deployment.active = d
@@ -248,8 +266,7 @@ func handleDeploymentStateDestroyed(ss *ServiceState, d *types.Deployment) error
 		}
 
 		d.Status.State = types.StateDestroy
-		dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
-		return dm.Update(d)
+		return deploymentSave(d)
 	}
 
 	if err := deploymentRemove(d); err != nil {
@@ -262,7 +279,9 @@ func handleDeploymentStateDestroyed(ss *ServiceState, d *types.Deployment) error
 }
 
 func deploymentSpecValidate(d *types.Deployment, svc *types.Service) bool {
-	return d.Spec.Template.Updated.Equal(svc.Spec.Template.Updated) && d.Spec.Selector.Updated.Equal(svc.Spec.Selector.Updated)
+	return d.Spec.Template.Updated.Equal(svc.Spec.Template.Updated) &&
+		d.Spec.Selector.Updated.Equal(svc.Spec.Selector.Updated) &&
+		d.Spec.Affinity.Updated.Equal(svc.Spec.Affinity.Updated)
 }
 
 // deploymentPodProvision - handles deployment provision logic
@@ -306,6 +325,11 @@ func deploymentPodProvision(ss *ServiceState, d *types.Deployment) (err error) {
 
 		for _, p := range pods {
 
+			// Pods on draining nodes are replaced and evicted when replacement is ready
+			if p.Meta.Node != types.EmptyString && ss.cluster.IsNodeDrain(p.Meta.Node) {
+				continue
+			}
+
 			if p.Status.State != types.StateDestroy && p.Status.State != types.StateDestroyed {
 
 				if p.Meta.Node != types.EmptyString {
@@ -371,6 +395,10 @@ func deploymentPodProvision(ss *ServiceState, d *types.Deployment) (err error) {
 
 	}
 
+	if err = deploymentPodEvict(ss, d); err != nil {
+		return err
+	}
+
 	if provision {
 		if d.Status.State != types.StateProvision {
 			d.Status.State = types.StateProvision
@@ -381,22 +409,126 @@ func deploymentPodProvision(ss *ServiceState, d *types.Deployment) (err error) {
 	return nil
 }
 
-func deploymentCreate(svc *types.Service, version int) (*types.Deployment, error) {
+// deploymentPodEvict - destroys pods placed on draining nodes
+// only when enough ready pods are running on other nodes to keep replicas count
+func deploymentPodEvict(ss *ServiceState, d *types.Deployment) error {
+
+	var (
+		ready int
+		evict = make([]*types.Pod, 0)
+	)
+
+	for _, p := range ss.pod.list[d.SelfLink()] {
+
+		if p.Status.State == types.StateDestroy || p.Status.State == types.StateDestroyed {
+			continue
+		}
+
+		if p.Meta.Node != types.EmptyString && ss.cluster.IsNodeDrain(p.Meta.Node) {
+			evict = append(evict, p)
+			continue
+		}
+
+		if p.Status.State == types.StateReady && p.Status.Running {
+			ready++
+		}
+	}
+
+	if len(evict) == 0 || ready < d.Spec.Replicas {
+		return nil
+	}
+
+	for _, p := range evict {
+		log.V(logLevel).Debugf("%s:> evict pod %s from node %s", logDeploymentPrefix, p.SelfLink(), p.Meta.Node)
+		if err := podDestroy(ss, p); err != nil {
+			log.Errorf("%s", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deploymentNodeDrain - reschedules pods of active deployments from draining node
+func deploymentNodeDrain(ss *ServiceState, node string) error {
+
+	for _, d := range ss.deployment.list {
+
+		if d.Status.State == types.StateDestroy || d.Status.State == types.StateDestroyed {
+			continue
+		}
+
+		var placed bool
+		for _, p := range ss.pod.list[d.SelfLink()] {
+			if p.Meta.Node == node {
+				placed = true
+				break
+			}
+		}
+
+		if !placed {
+			continue
+		}
+
+		if err := deploymentPodProvision(ss, d); err != nil {
+			log.Errorf("%s", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deploymentCreate(svc *types.Service, version, replicas int) (*types.Deployment, error) {
 
 	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
 
-	d, err := dm.Create(svc, version)
+	spec := *svc
+	spec.Spec.Replicas = replicas
+
+	d, err := dm.Create(&spec, version)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := deploymentRevision(svc, d); err != nil {
+		log.Errorf("%s:> deployment revision err: %s", logDeploymentPrefix, err.Error())
+		return nil, err
+	}
+
 	return d, nil
 }
 
+// deploymentRevision - stores deployment spec in service revisions history
+// and removes the oldest revisions over service revisions limit
+func deploymentRevision(svc *types.Service, d *types.Deployment) error {
+
+	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
+
+	rev := *d
+	rev.Spec.Replicas = svc.Spec.Replicas
+
+	if err := dm.PutRevision(&rev); err != nil {
+		return err
+	}
+
+	rl, err := dm.ListRevisions(d.Meta.Namespace, d.Meta.Service)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(rl.Items)-svc.Spec.Strategy.RevisionsLimit(); i++ {
+		if err := dm.RemoveRevision(rl.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func deploymentUpdate(d *types.Deployment, timestamp time.Time) error {
 	if timestamp.Before(d.Meta.Updated) {
-		dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
-		if err := dm.Update(d); err != nil {
+		if err := deploymentSave(d); err != nil {
 			log.Errorf("%s", err.Error())
 			return err
 		}
@@ -405,6 +537,34 @@ func deploymentUpdate(d *types.Deployment, timestamp time.Time) error {
 	return nil
 }
 
+// deploymentSave - save deployment, on revision conflict status and replicas
+// managed by controller are applied on latest deployment state
+func deploymentSave(d *types.Deployment) error {
+
+	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
+
+	return distribution.RetryOnConflict(func() error {
+		return dm.Update(d)
+	}, func() error {
+
+		latest, err := dm.Get(d.Meta.Namespace, d.Meta.Service, d.Meta.Name)
+		if err != nil {
+			return err
+		}
+		if latest == nil {
+			return errors.Storage().NewErrEntityNotFound()
+		}
+
+		log.V(logLevel).Debugf("%s:> deployment %s changed, retry update", logDeploymentPrefix, d.SelfLink())
+
+		latest.Meta.Updated = d.Meta.Updated
+		latest.Spec.Replicas = d.Spec.Replicas
+		latest.Status = d.Status
+		*d = *latest
+		return nil
+	})
+}
+
 func deploymentDestroy(ss *ServiceState, d *types.Deployment) (err error) {
 
 	t := d.Meta.Updated
@@ -463,8 +623,7 @@ func deploymentRemove(d *types.Deployment) error {
 func deploymentScale(d *types.Deployment, replicas int) error {
 	d.Status.State = types.StateProvision
 	d.Spec.Replicas = replicas
-	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
-	return dm.Update(d)
+	return deploymentSave(d)
 }
 
 func deploymentStatusState(d *types.Deployment, pl map[string]*types.Pod) (err error) {
@@ -22,9 +22,11 @@ import (
 	"context"
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/controller/ipam"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func testDeploymentObserver(t *testing.T, name, werr string, wst *ServiceState, state *ServiceState, d *types.Deployment) {
@@ -48,6 +50,10 @@ func testDeploymentObserver(t *testing.T, name, werr string, wst *ServiceState,
 		return
 	}
 
+	if !assert.NoError(t, putServiceStateAsset(state, d)) {
+		return
+	}
+
 	t.Run(name, func(t *testing.T) {
 
 		err := deploymentObserve(state, d)
@@ -1040,3 +1046,375 @@ func TestHandleDeploymentStateDestroyed(t *testing.T) {
 		testDeploymentObserver(t, tt.name, tt.want.err, tt.want.state, tt.args.state, tt.args.d)
 	}
 }
+
+func TestDeploymentRollout(t *testing.T) {
+
+	type suit struct {
+		name string
+		args struct {
+			state *ServiceState
+			d     *types.Deployment
+		}
+		want struct {
+			err       string
+			active    *types.Deployment
+			provision *types.Deployment
+			replicas  map[string]int
+			state     map[string]string
+			message   map[string]string
+			action    string
+		}
+	}
+
+	var tests []suit
+
+	getRolloutStateAsset := func(svc *types.Service, active, provision *types.Deployment, ar, pr int) *ServiceState {
+
+		ss := getServiceStateAsset(svc)
+		ss.deployment.active = active
+		ss.deployment.provision = provision
+		ss.deployment.list[active.SelfLink()] = active
+		ss.deployment.list[provision.SelfLink()] = provision
+		ss.pod.list[active.SelfLink()] = make(map[string]*types.Pod)
+		ss.pod.list[provision.SelfLink()] = make(map[string]*types.Pod)
+
+		for i := 0; i < ar; i++ {
+			p := getPodAsset(active, types.StateReady, types.EmptyString)
+			p.Meta.Node = "node"
+			ss.pod.list[active.SelfLink()][p.SelfLink()] = p
+		}
+
+		for i := 0; i < pr; i++ {
+			p := getPodAsset(provision, types.StateReady, types.EmptyString)
+			p.Meta.Node = "node"
+			ss.pod.list[provision.SelfLink()][p.SelfLink()] = p
+		}
+
+		return ss
+	}
+
+	getRollingServiceAsset := func() *types.Service {
+		svc := getServiceAsset(types.StateProvision, types.EmptyString)
+		svc.Spec.Replicas = 3
+		svc.Spec.Strategy.Type = types.StrategyRolling
+		svc.Spec.Strategy.RollingOptions.MaxSurge = 1
+		return svc
+	}
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "successful rollout step with scale up"}
+
+		svc := getRollingServiceAsset()
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2.Spec.Replicas = 1
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 3, 1)
+		s.args.d = dp2
+
+		s.want.active = dp1
+		s.want.provision = dp2
+		s.want.replicas = map[string]int{dp1.SelfLink(): 2, dp2.SelfLink(): 2}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateProvision, dp2.SelfLink(): types.StateProvision}
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "successful rollout finish"}
+
+		svc := getRollingServiceAsset()
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp1.Spec.Replicas = 0
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 0, 3)
+		s.args.d = dp2
+
+		s.want.active = dp2
+		s.want.replicas = map[string]int{dp1.SelfLink(): 0, dp2.SelfLink(): 3}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateDestroyed, dp2.SelfLink(): types.StateReady}
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "rollback on deployment failure"}
+
+		svc := getRollingServiceAsset()
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateError, "image not found")
+		dp1.Spec.Replicas = 2
+		dp2.Spec.Replicas = 2
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 2, 1)
+		s.args.d = dp2
+
+		s.want.active = dp1
+		s.want.replicas = map[string]int{dp1.SelfLink(): 3, dp2.SelfLink(): 0}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateProvision, dp2.SelfLink(): types.StateError}
+		s.want.message = map[string]string{dp2.SelfLink(): "image not found"}
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "rollback on rollout deadline exceeded"}
+
+		svc := getRollingServiceAsset()
+		svc.Spec.Strategy.Deadline = 1
+
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2.Spec.Replicas = 1
+		dp2.Meta.Created = time.Now().Add(-2 * time.Second)
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 3, 1)
+		s.args.d = dp2
+
+		s.want.active = dp1
+		s.want.replicas = map[string]int{dp1.SelfLink(): 3, dp2.SelfLink(): 0}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateReady, dp2.SelfLink(): types.StateError}
+		s.want.message = map[string]string{dp2.SelfLink(): rolloutDeadlineExceeded}
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "rollback on rollout step timeout exceeded"}
+
+		svc := getRollingServiceAsset()
+		svc.Spec.Strategy.RollingOptions.Timeout = 1
+
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2.Spec.Replicas = 2
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 3, 1)
+		s.args.state.rollout.step = time.Now().Add(-2 * time.Second)
+		s.args.d = dp2
+
+		s.want.active = dp1
+		s.want.replicas = map[string]int{dp1.SelfLink(): 3, dp2.SelfLink(): 0}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateReady, dp2.SelfLink(): types.StateError}
+		s.want.message = map[string]string{dp2.SelfLink(): rolloutTimeoutExceeded}
+
+		return s
+	}())
+
+	getManualServiceAsset := func(strategy, action string) *types.Service {
+		svc := getServiceAsset(types.StateProvision, types.EmptyString)
+		svc.Spec.Replicas = 3
+		svc.Spec.Strategy.Type = strategy
+		svc.Spec.Strategy.Action = action
+		return svc
+	}
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "canary rollout waits for action"}
+
+		svc := getManualServiceAsset(types.StrategyCanary, types.EmptyString)
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2.Spec.Replicas = rolloutReplicas(svc, dp1.Spec.Replicas)
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 3, 1)
+		s.args.d = dp2
+
+		s.want.active = dp1
+		s.want.provision = dp2
+		s.want.replicas = map[string]int{dp1.SelfLink(): 3, dp2.SelfLink(): 1}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateReady, dp2.SelfLink(): types.StateReady}
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "canary rollout promote with scale up"}
+
+		svc := getManualServiceAsset(types.StrategyCanary, types.StrategyActionPromote)
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2.Spec.Replicas = 1
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 3, 1)
+		s.args.d = dp2
+
+		s.want.active = dp1
+		s.want.provision = dp2
+		s.want.replicas = map[string]int{dp1.SelfLink(): 3, dp2.SelfLink(): 3}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateReady, dp2.SelfLink(): types.StateProvision}
+		s.want.action = types.StrategyActionPromote
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "blue-green rollout promote switches active deployment"}
+
+		svc := getManualServiceAsset(types.StrategyBlueGreen, types.StrategyActionPromote)
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 3, 3)
+		s.args.d = dp2
+
+		s.want.active = dp2
+		s.want.replicas = map[string]int{dp1.SelfLink(): 3, dp2.SelfLink(): 3}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateDestroy, dp2.SelfLink(): types.StateReady}
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "blue-green rollout abort"}
+
+		svc := getManualServiceAsset(types.StrategyBlueGreen, types.StrategyActionAbort)
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+
+		s.args.state = getRolloutStateAsset(svc, dp1, dp2, 3, 3)
+		s.args.d = dp2
+
+		s.want.active = dp1
+		s.want.replicas = map[string]int{dp1.SelfLink(): 3, dp2.SelfLink(): 0}
+		s.want.state = map[string]string{dp1.SelfLink(): types.StateReady, dp2.SelfLink(): types.StateError}
+		s.want.message = map[string]string{dp2.SelfLink(): rolloutAborted}
+
+		return s
+	}())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if !assert.NoError(t, putServiceStateAsset(tt.args.state, tt.args.d)) {
+				return
+			}
+
+			err := deploymentObserve(tt.args.state, tt.args.d)
+			if tt.want.err != types.EmptyString {
+				if !assert.Error(t, err, "error should be presented") {
+					return
+				}
+				assert.Equal(t, tt.want.err, err.Error(), "err message different")
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if tt.want.active == nil {
+				assert.Nil(t, tt.args.state.deployment.active, "active deployment should be nil")
+			} else if assert.NotNil(t, tt.args.state.deployment.active, "active deployment should be not nil") {
+				assert.Equal(t, tt.want.active.SelfLink(), tt.args.state.deployment.active.SelfLink(),
+					"active deployment is different")
+			}
+
+			if tt.want.provision == nil {
+				assert.Nil(t, tt.args.state.deployment.provision, "provision deployment should be nil")
+				assert.Nil(t, tt.args.state.rollout.timer, "rollout timer should be stopped")
+			} else if assert.NotNil(t, tt.args.state.deployment.provision, "provision deployment should be not nil") {
+				assert.Equal(t, tt.want.provision.SelfLink(), tt.args.state.deployment.provision.SelfLink(),
+					"provision deployment is different")
+			}
+
+			for link, replicas := range tt.want.replicas {
+				assert.Equal(t, replicas, tt.args.state.deployment.list[link].Spec.Replicas,
+					"deployment replicas not match")
+			}
+
+			for link, state := range tt.want.state {
+				assert.Equal(t, state, tt.args.state.deployment.list[link].Status.State,
+					"deployment status state not match")
+			}
+
+			for link, message := range tt.want.message {
+				assert.Equal(t, message, tt.args.state.deployment.list[link].Status.Message,
+					"deployment status message not match")
+			}
+
+			assert.Equal(t, tt.want.action, tt.args.state.service.Spec.Strategy.Action,
+				"rollout action not match")
+		})
+	}
+}
+
+func TestDeploymentRevision(t *testing.T) {
+
+	var (
+		ctx = context.Background()
+		stg = envs.Get().GetStorage()
+		dm  = distribution.NewDeploymentModel(ctx, stg)
+	)
+
+	err := stg.Del(ctx, stg.Collection().Revision(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	svc := getServiceAsset(types.StateProvision, types.EmptyString)
+	svc.Spec.Strategy.Revisions = 2
+
+	for i := 0; i < 3; i++ {
+		if _, err := deploymentCreate(svc, i, svc.Spec.Replicas); !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	rl, err := dm.ListRevisions(svc.Meta.Namespace, svc.Meta.Name)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Len(t, rl.Items, 2, "revisions count should be limited") {
+		return
+	}
+
+	assert.Equal(t, 1, rl.Items[0].Meta.Version, "oldest revision should be removed")
+	assert.Equal(t, 2, rl.Items[1].Meta.Version, "latest revision should be stored")
+}
+
+func TestRolloutActionReset(t *testing.T) {
+
+	svc := getServiceAsset(types.StateProvision, types.EmptyString)
+	svc.Spec.Replicas = 3
+	svc.Spec.Strategy.Type = types.StrategyBlueGreen
+	svc.Spec.Strategy.Action = types.StrategyActionPromote
+
+	state := getServiceStateAsset(svc)
+	if !assert.NoError(t, putServiceStateAsset(state)) {
+		return
+	}
+
+	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+
+	// service is changed after it was cached by controller
+	cp := *svc
+	cp.Spec.Replicas = 5
+	if _, err := sm.Update(&cp); !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.NoError(t, rolloutActionReset(state)) {
+		return
+	}
+
+	got, err := sm.Get(svc.Meta.Namespace, svc.Meta.Name)
+	if !assert.NoError(t, err) || !assert.NotNil(t, got) {
+		return
+	}
+
+	assert.Equal(t, types.EmptyString, got.Spec.Strategy.Action, "action should be reset")
+	assert.Equal(t, 5, got.Spec.Replicas, "service changes should be kept")
+	assert.Equal(t, types.EmptyString, state.service.Spec.Strategy.Action, "cached action should be reset")
+}
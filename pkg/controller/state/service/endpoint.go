@@ -176,12 +176,30 @@ func endpointSet(ss *ServiceState, svc *types.Service) error {
 		RouteStrategy: svc.Spec.Network.Strategy.Route,
 	}
 
-	ss.endpoint.endpoint, err = em.Update(ss.endpoint.endpoint, &opts)
+	endpoint := ss.endpoint.endpoint
+
+	err = distribution.RetryOnConflict(func() error {
+		_, err := em.Update(endpoint, &opts)
+		return err
+	}, func() error {
+
+		latest, err := em.Get(endpoint.Meta.Namespace, endpoint.Meta.Name)
+		if err != nil {
+			return err
+		}
+		if latest == nil {
+			return errors.Storage().NewErrEntityNotFound()
+		}
+
+		endpoint = latest
+		return nil
+	})
 	if err != nil {
 		log.Errorf("%s> set endpoint error: %s", logPrefix, err.Error())
 		return err
 	}
 
+	ss.endpoint.endpoint = endpoint
 	return nil
 }
 
@@ -203,7 +221,9 @@ func endpointDel(ss *ServiceState) error {
 func endpointCheck(ss *ServiceState) error {
 
 	if ss.deployment.active != nil {
-		if ss.deployment.active.Status.State == types.StateReady {
+		// degraded deployment keeps serving traffic through pods which are still ready
+		if ss.deployment.active.Status.State == types.StateReady ||
+			ss.deployment.active.Status.State == types.StateDegradation {
 			if err := endpointManifestProvision(ss); err != nil {
 				return err
 			}
@@ -297,13 +317,7 @@ func endpointManifestProvision(ss *ServiceState) error {
 
 	if ss.endpoint.manifest != nil {
 
-		var pl = make(map[string]*types.Pod)
-
-		if ss.deployment.active != nil {
-			if _, ok := ss.pod.list[ss.deployment.active.SelfLink()]; ok {
-				pl = ss.pod.list[ss.deployment.active.SelfLink()]
-			}
-		}
+		var pl = endpointPods(ss)
 
 		if !endpointManifestSpecEqual(ss.endpoint.endpoint, ss.endpoint.manifest) || !endpointManifestUpstreamsEqual(ss.endpoint.manifest, pl) {
 			if err := endpointManifestSet(ss); err != nil {
@@ -311,6 +325,9 @@ func endpointManifestProvision(ss *ServiceState) error {
 			}
 		}
 
+		if err := endpointStatusReady(ss, pl); err != nil {
+			return err
+		}
 	}
 
 	if ss.endpoint.manifest == nil {
@@ -327,7 +344,7 @@ func endpointManifestAdd(ss *ServiceState) error {
 	var (
 		err error
 		em  = distribution.NewEndpointModel(context.Background(), envs.Get().GetStorage())
-		pl  = make(map[string]*types.Pod)
+		pl  map[string]*types.Pod
 	)
 
 	if ss.endpoint.endpoint == nil {
@@ -335,11 +352,7 @@ func endpointManifestAdd(ss *ServiceState) error {
 		return nil
 	}
 
-	if ss.deployment.active != nil {
-		if _, ok := ss.pod.list[ss.deployment.active.SelfLink()]; ok {
-			pl = ss.pod.list[ss.deployment.active.SelfLink()]
-		}
-	}
+	pl = endpointPods(ss)
 
 	epm, err := em.ManifestGet(ss.endpoint.endpoint.SelfLink())
 	if err != nil {
@@ -380,7 +393,7 @@ func endpointManifestSet(ss *ServiceState) error {
 	var (
 		err error
 		em  = distribution.NewEndpointModel(context.Background(), envs.Get().GetStorage())
-		pl  = make(map[string]*types.Pod)
+		pl  map[string]*types.Pod
 	)
 
 	if ss.endpoint.endpoint == nil {
@@ -388,11 +401,7 @@ func endpointManifestSet(ss *ServiceState) error {
 		return nil
 	}
 
-	if ss.deployment.active != nil {
-		if _, ok := ss.pod.list[ss.deployment.active.SelfLink()]; ok {
-			pl = ss.pod.list[ss.deployment.active.SelfLink()]
-		}
-	}
+	pl = endpointPods(ss)
 
 	ss.endpoint.manifest.EndpointSpec = ss.endpoint.endpoint.Spec
 	ss.endpoint.manifest.Upstreams = endpointManifestGetUpstreams(pl)
@@ -421,12 +430,85 @@ func endpointManifestDel(ss *ServiceState) error {
 	return nil
 }
 
+// endpointRouted - check deployment pods are used as endpoint upstreams:
+// active deployment pods and new deployment pods rolled out next to active deployment.
+// Blue-green deployment pods are used only after rollout is promoted
+func endpointRouted(ss *ServiceState, d *types.Deployment) bool {
+
+	if ss.deployment.active != nil && ss.deployment.active.SelfLink() == d.SelfLink() {
+		return true
+	}
+
+	if !rolloutActive(ss) || ss.service.Spec.Strategy.BlueGreen() {
+		return false
+	}
+
+	return ss.deployment.provision.SelfLink() == d.SelfLink()
+}
+
+// endpointPods returns pods of deployments used as endpoint upstreams
+func endpointPods(ss *ServiceState) map[string]*types.Pod {
+
+	var pl = make(map[string]*types.Pod)
+
+	for _, d := range []*types.Deployment{ss.deployment.active, ss.deployment.provision} {
+
+		if d == nil || !endpointRouted(ss, d) {
+			continue
+		}
+
+		for k, p := range ss.pod.list[d.SelfLink()] {
+			pl[k] = p
+		}
+	}
+
+	return pl
+}
+
+// endpointStatusReady - save readiness of upstream pods into endpoint status
+func endpointStatusReady(ss *ServiceState, pl map[string]*types.Pod) error {
+
+	var (
+		ready = make(map[string]bool)
+		em    = distribution.NewEndpointModel(context.Background(), envs.Get().GetStorage())
+	)
+
+	for k, p := range pl {
+		ready[k] = p.Status.Ready()
+	}
+
+	if len(ss.endpoint.endpoint.Status.Ready) == len(ready) {
+
+		var changed bool
+		for k, r := range ready {
+			if s, ok := ss.endpoint.endpoint.Status.Ready[k]; !ok || s != r {
+				changed = true
+				break
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+	}
+
+	status := ss.endpoint.endpoint.Status
+	status.Ready = ready
+
+	if _, err := em.SetStatus(ss.endpoint.endpoint, &status); err != nil {
+		log.Errorf("%s> set endpoint status error: %s", logPrefix, err.Error())
+		return err
+	}
+
+	return nil
+}
+
 func endpointManifestGetUpstreams(pl map[string]*types.Pod) []string {
 
 	ips := make([]string, 0)
 
 	for _, p := range pl {
-		if p.Status.State == types.StateReady && p.Status.Network.PodIP != types.EmptyString {
+		if p.Status.Ready() && p.Status.Network.PodIP != types.EmptyString {
 			ips = append(ips, p.Status.Network.PodIP)
 		}
 	}
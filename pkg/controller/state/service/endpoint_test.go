@@ -0,0 +1,85 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointManifestGetUpstreams(t *testing.T) {
+
+	type suit struct {
+		name  string
+		pods  map[string]*types.Pod
+		wants []string
+	}
+
+	svc := getServiceAsset(types.StateReady, "")
+	d := getDeploymentAsset(svc, types.StateReady, "")
+
+	pod := func(ip, state string, ready ...bool) *types.Pod {
+		p := getPodAsset(d, state, "")
+		p.Status.Network.PodIP = ip
+		p.Status.Containers = make(map[string]*types.PodContainer)
+		for i, r := range ready {
+			c := &types.PodContainer{ID: strconv.Itoa(i), Ready: r}
+			p.Status.Containers[c.ID] = c
+		}
+		return p
+	}
+
+	var tests = []suit{
+		{
+			name: "ready pods are used as upstreams",
+			pods: map[string]*types.Pod{
+				"p1": pod("10.0.0.1", types.StateReady, true),
+				"p2": pod("10.0.0.2", types.StateReady, true, true),
+			},
+			wants: []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name: "pods with not ready containers are skipped",
+			pods: map[string]*types.Pod{
+				"p1": pod("10.0.0.1", types.StateReady, true),
+				"p2": pod("10.0.0.2", types.StateReady, true, false),
+				"p3": pod("10.0.0.3", types.StateReady, false),
+			},
+			wants: []string{"10.0.0.1"},
+		},
+		{
+			name: "pods in provision state are skipped",
+			pods: map[string]*types.Pod{
+				"p1": pod("10.0.0.1", types.StateProvision, true),
+				"p2": pod("", types.StateReady, true),
+			},
+			wants: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := endpointManifestGetUpstreams(tc.pods)
+			assert.ElementsMatch(t, tc.wants, got, "upstreams mismatch")
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/controller/state/cluster"
@@ -57,10 +58,29 @@ type ServiceState struct {
 		list map[string]map[string]*types.Pod
 	}
 
+	rollout struct {
+		// last rollout step time
+		step  time.Time
+		timer *time.Timer
+	}
+
+	quota struct {
+		// last scale up error caused by namespace quota
+		err error
+	}
+
+	autoscaler struct {
+		ticker *time.Ticker
+		// replicas recommendations kept for stabilization windows
+		recommendations []autoscaleRecommendation
+	}
+
 	observers struct {
 		service    chan *types.Service
 		deployment chan *types.Deployment
 		pod        chan *types.Pod
+		node       chan string
+		done       chan bool
 	}
 }
 
@@ -111,13 +131,26 @@ func (ss *ServiceState) Restore() error {
 			fmt.Println(err)
 		}
 
-		if ss.deployment.index < index {
-			ss.deployment.index = index
+		if ss.deployment.index <= index {
+			ss.deployment.index = index + 1
 		}
 
 		ss.deployment.list[d.SelfLink()] = d
 	}
 
+	// Get stored revisions to continue deployment versions after removed deployments
+	rl, err := dm.ListRevisions(ss.service.Meta.Namespace, ss.service.Meta.Name)
+	if err != nil {
+		log.Errorf("%s:restore:> get deployment revisions error: %v", logPrefix, err)
+		return err
+	}
+
+	for _, r := range rl.Items {
+		if ss.deployment.index <= r.Meta.Version {
+			ss.deployment.index = r.Meta.Version + 1
+		}
+	}
+
 	// Set service current spec and provision spec
 	switch ss.service.Status.State {
 	// if service is in ready state - mark deployment with same spec as current
@@ -198,23 +231,53 @@ func (ss *ServiceState) Observe() {
 			}
 			break
 
+		case n := <-ss.observers.node:
+			log.V(logLevel).Debugf("%s:observe:node drain:> %s", logPrefix, n)
+			if err := deploymentNodeDrain(ss, n); err != nil {
+				log.Errorf("%s:observe:node drain err:> %s", logPrefix, err.Error())
+			}
+			break
+
 		case s := <-ss.observers.service:
 			log.V(logLevel).Debugf("%s:observe:service:> %s", logPrefix, s.SelfLink())
 			if err := serviceObserve(ss, s); err != nil {
 				log.Errorf("%s:observe:service err:> %s", logPrefix, err.Error())
 			}
 			break
+
+		case <-ss.autoscaler.ticker.C:
+			if err := autoscalerObserve(ss); err != nil {
+				log.Errorf("%s:observe:autoscaler err:> %s", logPrefix, err.Error())
+			}
+			break
+
+		case <-ss.observers.done:
+			log.V(logLevel).Debugf("%s:observe:stop", logPrefix)
+			ss.autoscaler.ticker.Stop()
+			rolloutStop(ss)
+			return
 		}
 
 	}
 }
 
+// Stop stops service state observer when service is removed
+func (ss *ServiceState) Stop() {
+	close(ss.observers.done)
+}
+
 func (ss *ServiceState) SetService(s *types.Service) {
-	ss.observers.service <- s
+	select {
+	case ss.observers.service <- s:
+	case <-ss.observers.done:
+	}
 }
 
 func (ss *ServiceState) SetDeployment(d *types.Deployment) {
-	ss.observers.deployment <- d
+	select {
+	case ss.observers.deployment <- d:
+	case <-ss.observers.done:
+	}
 }
 
 func (ss *ServiceState) DelDeployment(d *types.Deployment) {
@@ -244,8 +307,19 @@ func (ss *ServiceState) DelDeployment(d *types.Deployment) {
 
 }
 
+// DrainNode reschedules service pods from node
+func (ss *ServiceState) DrainNode(node string) {
+	select {
+	case ss.observers.node <- node:
+	case <-ss.observers.done:
+	}
+}
+
 func (ss *ServiceState) SetPod(p *types.Pod) {
-	ss.observers.pod <- p
+	select {
+	case ss.observers.pod <- p:
+	case <-ss.observers.done:
+	}
 }
 
 func (ss *ServiceState) DelPod(p *types.Pod) {
@@ -266,10 +340,14 @@ func NewServiceState(cs *cluster.ClusterState, s *types.Service) *ServiceState {
 	ss.observers.service = make(chan *types.Service)
 	ss.observers.deployment = make(chan *types.Deployment)
 	ss.observers.pod = make(chan *types.Pod)
+	ss.observers.node = make(chan string)
+	ss.observers.done = make(chan bool)
 
 	ss.deployment.list = make(map[string]*types.Deployment)
 	ss.pod.list = make(map[string]map[string]*types.Pod)
 
+	ss.autoscaler.ticker = time.NewTicker(types.DefaultAutoscalerPeriod * time.Second)
+
 	go ss.Observe()
 
 	return ss
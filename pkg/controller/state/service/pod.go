@@ -99,11 +99,14 @@ func PodObserve(ss *ServiceState, p *types.Pod) error {
 		return err
 	}
 
-	if ss.deployment.active != nil {
-		if ss.deployment.active.SelfLink() == d.SelfLink() && d.Status.State == types.StateReady {
-			if err := endpointCheck(ss); err != nil {
-				return err
-			}
+	if err := deploymentRollout(ss); err != nil {
+		return err
+	}
+
+	// pod readiness changes endpoint upstreams
+	if endpointRouted(ss, d) {
+		if err := endpointCheck(ss); err != nil {
+			return err
 		}
 	}
 
@@ -135,6 +138,13 @@ func handlePodStateReady(ss *ServiceState, p *types.Pod) error {
 
 	log.V(logLevel).Debugf("%s:> handlePodStateReady: %s > %s", logPodPrefix, p.SelfLink(), p.Status.State)
 
+	// Replacement pod is ready, pods from draining nodes can be removed
+	if d, ok := ss.deployment.list[p.DeploymentLink()]; ok {
+		if err := deploymentPodEvict(ss, d); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -311,8 +321,7 @@ func podRemove(ss *ServiceState, p *types.Pod) (err error) {
 func podUpdate(p *types.Pod, timestamp time.Time) error {
 
 	if timestamp.Before(p.Meta.Updated) {
-		pm := distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
-		if err := pm.Update(p); err != nil {
+		if err := podSave(p); err != nil {
 			log.Errorf("%s", err.Error())
 			return err
 		}
@@ -321,6 +330,35 @@ func podUpdate(p *types.Pod, timestamp time.Time) error {
 	return nil
 }
 
+// podSave - save pod, on revision conflict node, status and destroy state
+// managed by controller are applied on latest pod state
+func podSave(p *types.Pod) error {
+
+	pm := distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
+
+	return distribution.RetryOnConflict(func() error {
+		return pm.Update(p)
+	}, func() error {
+
+		latest, err := pm.Get(p.Meta.Namespace, p.Meta.Service, p.Meta.Deployment, p.Meta.Name)
+		if err != nil {
+			return err
+		}
+		if latest == nil {
+			return errors.Storage().NewErrEntityNotFound()
+		}
+
+		log.V(logLevel).Debugf("%s:> pod %s changed, retry update", logPodPrefix, p.SelfLink())
+
+		latest.Meta.Node = p.Meta.Node
+		latest.Meta.Updated = p.Meta.Updated
+		latest.Spec.State.Destroy = latest.Spec.State.Destroy || p.Spec.State.Destroy
+		latest.Status = p.Status
+		*p = *latest
+		return nil
+	})
+}
+
 func podManifestPut(p *types.Pod) error {
 
 	mm := distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
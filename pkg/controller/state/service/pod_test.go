@@ -49,6 +49,10 @@ func testPodObserver(t *testing.T, name, werr string, wst *ServiceState, state *
 		return
 	}
 
+	if !assert.NoError(t, putServiceStateAsset(state, p)) {
+		return
+	}
+
 	t.Run(name, func(t *testing.T) {
 
 		err := PodObserve(state, p)
@@ -0,0 +1,135 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"context"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const logQuotaPrefix = "state:observer:quota"
+
+// quotaCheck - check service resources fit into namespace quotas
+// together with resources of other services in namespace
+func quotaCheck(svc *types.Service) error {
+
+	nm := distribution.NewNamespaceModel(context.Background(), envs.Get().GetStorage())
+	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+
+	ns, err := nm.Get(svc.Meta.Namespace)
+	if err != nil {
+		log.Errorf("%s:> namespace fetch err: %s", logQuotaPrefix, err.Error())
+		return err
+	}
+
+	if ns == nil {
+		return nil
+	}
+
+	sl, err := sm.List(ns.Meta.Name)
+	if err != nil {
+		log.Errorf("%s:> service list fetch err: %s", logQuotaPrefix, err.Error())
+		return err
+	}
+
+	resources := make([]types.ResourceRequest, 0)
+	for _, s := range sl.Items {
+		if s.SelfLink() == svc.SelfLink() || !quotaAccounted(s) {
+			continue
+		}
+		resources = append(resources, s.Spec.GetResourceRequest())
+	}
+
+	quota := new(types.Namespace)
+	quota.Spec.Resources = ns.Spec.Resources
+	if err := quota.SetResources(resources); err != nil {
+		return err
+	}
+
+	return quota.AllocateResources(svc.Spec.GetResourceRequest())
+}
+
+// quotaAccount - recalculate namespace allocated resources from services in namespace,
+// recalculation is repeated if namespace was changed while it was running
+func quotaAccount(namespace string) error {
+	return distribution.RetryOnConflict(func() error {
+		return quotaRecalculate(namespace)
+	}, func() error {
+		return nil
+	})
+}
+
+// quotaRecalculate - calculate and save namespace allocated resources
+func quotaRecalculate(namespace string) error {
+
+	nm := distribution.NewNamespaceModel(context.Background(), envs.Get().GetStorage())
+	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+
+	ns, err := nm.Get(namespace)
+	if err != nil {
+		log.Errorf("%s:> namespace fetch err: %s", logQuotaPrefix, err.Error())
+		return err
+	}
+
+	if ns == nil {
+		return nil
+	}
+
+	sl, err := sm.List(ns.Meta.Name)
+	if err != nil {
+		log.Errorf("%s:> service list fetch err: %s", logQuotaPrefix, err.Error())
+		return err
+	}
+
+	resources := make([]types.ResourceRequest, 0)
+	for _, s := range sl.Items {
+		if !quotaAccounted(s) {
+			continue
+		}
+		resources = append(resources, s.Spec.GetResourceRequest())
+	}
+
+	status := ns.Status.Resources
+	if err := ns.SetResources(resources); err != nil {
+		log.Errorf("%s:> namespace resources calculate err: %s", logQuotaPrefix, err.Error())
+		return err
+	}
+
+	if status == ns.Status.Resources {
+		return nil
+	}
+
+	log.V(logLevel).Debugf("%s:> namespace %s resources usage updated: %#v", logQuotaPrefix, ns.Meta.Name, ns.Status.Resources)
+
+	if err := nm.Update(ns); err != nil {
+		log.Errorf("%s:> namespace update err: %s", logQuotaPrefix, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// quotaAccounted - check service resources are counted in namespace usage
+func quotaAccounted(svc *types.Service) bool {
+	return svc.Status.State != types.StateDestroy && svc.Status.State != types.StateDestroyed
+}
@@ -0,0 +1,431 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const (
+	logRolloutPrefix = "state:observer:rollout"
+
+	rolloutDeadlineExceeded = "rollout deadline exceeded"
+	rolloutTimeoutExceeded  = "rollout step timeout exceeded"
+	rolloutAborted          = "rollout aborted"
+)
+
+// rolloutActive - check provision deployment is rolled out over active deployment
+func rolloutActive(ss *ServiceState) bool {
+
+	if ss.service == nil || !ss.service.Spec.Strategy.Staged() {
+		return false
+	}
+
+	if ss.deployment.provision == nil || ss.deployment.active == nil {
+		return false
+	}
+
+	return ss.deployment.provision.SelfLink() != ss.deployment.active.SelfLink()
+}
+
+// rolloutBudget returns max surge and max unavailable pods count for rollout.
+// At least one surge pod is used if both budgets are empty
+func rolloutBudget(svc *types.Service) (int, int) {
+
+	var (
+		opts        = svc.Spec.Strategy.RollingOptions
+		surge       = opts.MaxSurge
+		unavailable = opts.MaxUnavailable
+	)
+
+	if surge < 0 {
+		surge = 0
+	}
+
+	if unavailable < 0 {
+		unavailable = 0
+	}
+
+	if unavailable > svc.Spec.Replicas {
+		unavailable = svc.Spec.Replicas
+	}
+
+	if surge == 0 && unavailable == 0 {
+		surge = 1
+	}
+
+	return surge, unavailable
+}
+
+// rolloutReplicas returns initial replicas count of new deployment
+// when active deployment runs all service replicas
+func rolloutReplicas(svc *types.Service, active int) int {
+
+	switch true {
+	case svc.Spec.Strategy.Canary():
+		return svc.Spec.Strategy.CanaryOptions.Replicas(svc.Spec.Replicas)
+	case svc.Spec.Strategy.BlueGreen():
+		return svc.Spec.Replicas
+	}
+
+	surge, _ := rolloutBudget(svc)
+
+	replicas := svc.Spec.Replicas + surge - active
+	if replicas < 0 {
+		replicas = 0
+	}
+
+	if replicas > svc.Spec.Replicas {
+		replicas = svc.Spec.Replicas
+	}
+
+	return replicas
+}
+
+// rolloutReady returns ready pods count of deployment
+func rolloutReady(ss *ServiceState, d *types.Deployment) int {
+
+	var ready int
+
+	for _, p := range ss.pod.list[d.SelfLink()] {
+
+		if p.Status.State == types.StateDestroy || p.Status.State == types.StateDestroyed {
+			continue
+		}
+
+		if p.Status.Ready() {
+			ready++
+		}
+	}
+
+	return ready
+}
+
+// deploymentRollout - makes next rollout step: waits until new deployment pods are ready,
+// scales old deployment down and new deployment up within surge and unavailability budgets.
+// Old deployment is scaled back if new deployment failed or rollout deadline is exceeded
+func deploymentRollout(ss *ServiceState) (err error) {
+
+	if !rolloutActive(ss) {
+		return nil
+	}
+
+	var (
+		svc      = ss.service
+		nd       = ss.deployment.provision
+		od       = ss.deployment.active
+		opts     = svc.Spec.Strategy.RollingOptions
+		replicas = svc.Spec.Replicas
+		next     time.Time
+	)
+
+	log.V(logLevel).Debugf("%s:> rollout step: %s -> %s", logRolloutPrefix, od.SelfLink(), nd.SelfLink())
+
+	// observe service again when next timer is reached to continue rollout without pods events
+	wake := func(t time.Time) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+
+	defer func() {
+		if err == nil && !next.IsZero() && rolloutActive(ss) {
+			rolloutSchedule(ss, time.Until(next))
+		}
+	}()
+
+	if ss.rollout.step.IsZero() {
+		ss.rollout.step = time.Now()
+	}
+
+	if nd.Status.State == types.StateError {
+		return deploymentRollback(ss, nd.Status.Message)
+	}
+
+	if svc.Spec.Strategy.Deadline > 0 {
+		deadline := nd.Meta.Created.Add(time.Duration(svc.Spec.Strategy.Deadline) * time.Second)
+		if time.Now().After(deadline) {
+			return deploymentRollback(ss, rolloutDeadlineExceeded)
+		}
+		wake(deadline)
+	}
+
+	if svc.Spec.Strategy.Manual() {
+		return deploymentRolloutAction(ss)
+	}
+
+	ready := rolloutReady(ss, nd)
+
+	// wait until pods of current step are ready
+	if ready < nd.Spec.Replicas {
+
+		if opts.Timeout > 0 {
+			timeout := ss.rollout.step.Add(time.Duration(opts.Timeout) * time.Second)
+			if time.Now().After(timeout) {
+				return deploymentRollback(ss, rolloutTimeoutExceeded)
+			}
+			wake(timeout)
+		}
+
+		return nil
+	}
+
+	// rollout is finished: new deployment runs all replicas
+	if nd.Spec.Replicas >= replicas && od.Spec.Replicas == 0 {
+		log.V(logLevel).Debugf("%s:> rollout finished: %s", logRolloutPrefix, nd.SelfLink())
+		rolloutStop(ss)
+		return deploymentActivate(ss, nd)
+	}
+
+	if opts.Interval > 0 {
+		interval := ss.rollout.step.Add(time.Duration(opts.Interval) * time.Second)
+		if time.Now().Before(interval) {
+			wake(interval)
+			return nil
+		}
+	}
+
+	surge, unavailable := rolloutBudget(svc)
+
+	// scale old deployment down while enough pods are available
+	down := ready + rolloutReady(ss, od) - (replicas - unavailable)
+	if down < 0 {
+		down = 0
+	}
+	if down > od.Spec.Replicas {
+		down = od.Spec.Replicas
+	}
+
+	// scale new deployment up while total pods count fits surge budget
+	up := replicas + surge - (od.Spec.Replicas - down)
+	if up > replicas {
+		up = replicas
+	}
+	if up < nd.Spec.Replicas {
+		up = nd.Spec.Replicas
+	}
+
+	if down > 0 {
+		log.V(logLevel).Debugf("%s:> scale down %s: %d -> %d", logRolloutPrefix, od.SelfLink(), od.Spec.Replicas, od.Spec.Replicas-down)
+		if err := deploymentScale(od, od.Spec.Replicas-down); err != nil {
+			log.Errorf("%s:> deployment scale err: %s", logRolloutPrefix, err.Error())
+			return err
+		}
+	}
+
+	if up != nd.Spec.Replicas {
+		log.V(logLevel).Debugf("%s:> scale up %s: %d -> %d", logRolloutPrefix, nd.SelfLink(), nd.Spec.Replicas, up)
+		if err := deploymentScale(nd, up); err != nil {
+			log.Errorf("%s:> deployment scale err: %s", logRolloutPrefix, err.Error())
+			return err
+		}
+	}
+
+	ss.rollout.step = time.Now()
+
+	return nil
+}
+
+// deploymentRolloutAction - handles promote and abort actions of canary and blue-green rollout.
+// New deployment runs all replicas after promote and replaces active deployment when pods are ready,
+// endpoint upstreams are switched to new deployment pods on activation
+func deploymentRolloutAction(ss *ServiceState) error {
+
+	var (
+		svc      = ss.service
+		nd       = ss.deployment.provision
+		replicas = svc.Spec.Replicas
+	)
+
+	switch svc.Spec.Strategy.Action {
+	case types.StrategyActionAbort:
+
+		log.V(logLevel).Debugf("%s:> rollout aborted: %s", logRolloutPrefix, nd.SelfLink())
+		if err := deploymentRollback(ss, rolloutAborted); err != nil {
+			return err
+		}
+
+		return rolloutActionReset(ss)
+
+	case types.StrategyActionPromote:
+
+		if nd.Spec.Replicas != replicas {
+			log.V(logLevel).Debugf("%s:> scale up %s: %d -> %d", logRolloutPrefix, nd.SelfLink(), nd.Spec.Replicas, replicas)
+			if err := deploymentScale(nd, replicas); err != nil {
+				log.Errorf("%s:> deployment scale err: %s", logRolloutPrefix, err.Error())
+				return err
+			}
+			return nil
+		}
+
+		if rolloutReady(ss, nd) < replicas {
+			return nil
+		}
+
+		log.V(logLevel).Debugf("%s:> rollout promoted: %s", logRolloutPrefix, nd.SelfLink())
+		rolloutStop(ss)
+		if err := deploymentActivate(ss, nd); err != nil {
+			return err
+		}
+
+		if err := endpointCheck(ss); err != nil {
+			return err
+		}
+
+		return rolloutActionReset(ss)
+	}
+
+	// wait for promote or abort action
+	return nil
+}
+
+// rolloutActionReset - clears handled or outdated rollout action of service.
+// Only strategy action is updated with revision check, so user changes made
+// since service was cached are not overwritten by controller
+func rolloutActionReset(ss *ServiceState) error {
+
+	if ss.service == nil || ss.service.Spec.Strategy.Action == types.EmptyString {
+		return nil
+	}
+
+	var (
+		sm     = distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+		action = ss.service.Spec.Strategy.Action
+		cp     = *ss.service
+		svc    = &cp
+	)
+
+	err := distribution.RetryOnConflict(func() error {
+
+		// service is removed or action is already changed
+		if svc == nil || svc.Spec.Strategy.Action != action {
+			return nil
+		}
+
+		svc.Spec.Strategy.Action = types.EmptyString
+		svc.Meta.Updated = time.Now()
+
+		_, err := sm.Update(svc)
+		return err
+	}, func() error {
+		var err error
+		svc, err = sm.Get(ss.service.Meta.Namespace, ss.service.Meta.Name)
+		return err
+	})
+	if err != nil {
+		log.Errorf("%s:> service update err: %s", logRolloutPrefix, err.Error())
+		return err
+	}
+
+	ss.service.Spec.Strategy.Action = types.EmptyString
+
+	return nil
+}
+
+// deploymentRollback - stops rollout: marks new deployment as errored,
+// removes its pods and scales active deployment back to service replicas
+func deploymentRollback(ss *ServiceState, message string) error {
+
+	var (
+		nd = ss.deployment.provision
+		od = ss.deployment.active
+	)
+
+	log.V(logLevel).Debugf("%s:> rollback %s: %s", logRolloutPrefix, nd.SelfLink(), message)
+
+	for _, p := range ss.pod.list[nd.SelfLink()] {
+		if p.Status.State == types.StateDestroy || p.Status.State == types.StateDestroyed {
+			continue
+		}
+		if err := podDestroy(ss, p); err != nil {
+			log.Errorf("%s:> pod destroy err: %s", logRolloutPrefix, err.Error())
+			return err
+		}
+	}
+
+	t := nd.Meta.Updated
+
+	nd.Spec.Replicas = 0
+	nd.Status.State = types.StateError
+	nd.Status.Message = message
+	nd.Meta.Updated = time.Now()
+
+	if err := deploymentUpdate(nd, t); err != nil {
+		return err
+	}
+
+	ss.deployment.provision = nil
+	rolloutStop(ss)
+
+	if od.Spec.Replicas != ss.service.Spec.Replicas {
+		if err := deploymentScale(od, ss.service.Spec.Replicas); err != nil {
+			log.Errorf("%s:> deployment scale err: %s", logRolloutPrefix, err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rolloutSchedule - observes service again after delay to continue rollout without pods events.
+// Service is read from storage when timer fires, because cached service can be outdated at that time
+func rolloutSchedule(ss *ServiceState, delay time.Duration) {
+
+	if ss.rollout.timer != nil {
+		ss.rollout.timer.Stop()
+	}
+
+	var (
+		namespace = ss.service.Meta.Namespace
+		name      = ss.service.Meta.Name
+	)
+
+	ss.rollout.timer = time.AfterFunc(delay, func() {
+
+		sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+		svc, err := sm.Get(namespace, name)
+		if err != nil {
+			log.Errorf("%s:> service get err: %s", logRolloutPrefix, err.Error())
+			return
+		}
+
+		if svc == nil {
+			return
+		}
+
+		ss.SetService(svc)
+	})
+}
+
+// rolloutStop - stops scheduled rollout step when rollout is finished, rolled back or replaced
+func rolloutStop(ss *ServiceState) {
+
+	if ss.rollout.timer != nil {
+		ss.rollout.timer.Stop()
+		ss.rollout.timer = nil
+	}
+
+	ss.rollout.step = time.Time{}
+}
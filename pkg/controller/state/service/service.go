@@ -21,7 +21,7 @@ package service
 import (
 	"context"
 	"errors"
-
+	"fmt"
 	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
@@ -32,6 +32,8 @@ import (
 
 const (
 	logServicePrefix = "state:observer:service"
+
+	serviceQuotaExceeded = "scale is not allowed by namespace quota"
 )
 
 // serviceObserve manage handlers based on service state
@@ -204,6 +206,11 @@ func handleServiceStateDestroy(ss *ServiceState, svc *types.Service) (err error)
 
 	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
 	if len(ss.deployment.list) == 0 {
+		if err = serviceRevisionsRemove(svc); err != nil {
+			log.Errorf("%s:> service revisions remove err: %s", logServicePrefix, err.Error())
+			return err
+		}
+
 		sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
 		if err = sm.Remove(svc); err != nil {
 			log.Errorf("%s:> service remove err: %s", logServicePrefix, err.Error())
@@ -273,33 +280,50 @@ func handleServiceStateDestroyed(ss *ServiceState, svc *types.Service) (err erro
 	}
 
 	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
-	nm := distribution.NewNamespaceModel(context.Background(), envs.Get().GetStorage())
 
-	ns, err := nm.Get(svc.Meta.Namespace)
-	if err != nil {
-		log.Errorf("%s:> namespece fetch err: %s", logServicePrefix, err.Error())
+	if err := autoscalerRemove(svc); err != nil {
+		log.Errorf("%s:> service autoscaler remove err: %s", logServicePrefix, err.Error())
+		return err
 	}
 
-	if ns != nil {
-		resource := svc.Spec.GetResourceRequest()
-		if err := ns.ReleaseResources(resource); err != nil {
-			log.Errorf("%s:> namespece resource release err: %s", logServicePrefix, err.Error())
-		}
-
-		if err := nm.Update(ns); err != nil {
-			log.Errorf("%s:> namespece update err: %s", logServicePrefix, err.Error())
-		}
+	if err := serviceRevisionsRemove(svc); err != nil {
+		log.Errorf("%s:> service revisions remove err: %s", logServicePrefix, err.Error())
+		return err
 	}
 
-	if err = sm.Remove(svc); err != nil {
+	if err := sm.Remove(svc); err != nil {
 		log.Errorf("%s:> service remove err: %s", logServicePrefix, err.Error())
 		return err
 	}
 
+	// released service resources are returned to namespace quota
+	if err := quotaAccount(svc.Meta.Namespace); err != nil {
+		log.Errorf("%s:> namespace resources account err: %s", logServicePrefix, err.Error())
+	}
+
 	ss.service = nil
 	return nil
 }
 
+// serviceRevisionsRemove removes stored deployment revisions of service
+func serviceRevisionsRemove(svc *types.Service) error {
+
+	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
+
+	rl, err := dm.ListRevisions(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rl.Items {
+		if err := dm.RemoveRevision(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // serviceEndpointProvision function handles all cases for endpoint management
 func serviceEndpointProvision(ss *ServiceState, svc *types.Service) error {
 
@@ -329,6 +353,10 @@ func serviceDeploymentProvision(ss *ServiceState, svc *types.Service) error {
 		d *types.Deployment
 	)
 
+	// rollout steps and actions are handled for current service spec
+	ss.service = svc
+	ss.quota.err = nil
+
 	// select deployment for provision
 	switch true {
 
@@ -343,9 +371,24 @@ func serviceDeploymentProvision(ss *ServiceState, svc *types.Service) error {
 		break
 	}
 
+	// if deployment is rolled out: replicas are managed by rollout steps
+	if d != nil && rolloutActive(ss) && ss.deployment.provision.SelfLink() == d.SelfLink() {
+		return deploymentRollout(ss)
+	}
+
 	// if deployment found for provision: check and update replicas
 	if d != nil {
 		if d.Spec.Replicas != svc.Spec.Replicas {
+
+			// scale up is allowed only when service fits into namespace quotas
+			if d.Spec.Replicas < svc.Spec.Replicas {
+				if err := quotaCheck(svc); err != nil {
+					log.Warnf("%s:> deployment %s scale is not allowed: %s", logServicePrefix, d.SelfLink(), err.Error())
+					ss.quota.err = err
+					return nil
+				}
+			}
+
 			if err := deploymentScale(d, svc.Spec.Replicas); err != nil {
 				log.Errorf("%s:> deployment scale err: %s", logServicePrefix, err.Error())
 				return err
@@ -356,7 +399,31 @@ func serviceDeploymentProvision(ss *ServiceState, svc *types.Service) error {
 	// create deployment if needed
 	if d == nil {
 
-		d, err := deploymentCreate(svc, ss.deployment.index)
+		var (
+			replicas = svc.Spec.Replicas
+			rolling  = svc.Spec.Strategy.Staged() && ss.deployment.active != nil &&
+				ss.deployment.active.Status.State != types.StateDestroy &&
+				ss.deployment.active.Status.State != types.StateDestroyed
+		)
+
+		// action requested for previous rollout is not applied to new one
+		if err := rolloutActionReset(ss); err != nil {
+			return err
+		}
+
+		if rolling {
+
+			// do not retry rollout of spec which is already rolled back
+			for _, od := range ss.deployment.list {
+				if od.Status.State == types.StateError && deploymentSpecValidate(od, svc) {
+					return nil
+				}
+			}
+
+			replicas = rolloutReplicas(svc, ss.deployment.active.Spec.Replicas)
+		}
+
+		d, err := deploymentCreate(svc, ss.deployment.index, replicas)
 		if err != nil {
 			log.Errorf("%s:> deployment create err: %s", logServicePrefix, err.Error())
 			return err
@@ -365,8 +432,8 @@ func serviceDeploymentProvision(ss *ServiceState, svc *types.Service) error {
 
 		for _, od := range ss.deployment.list {
 
-			if ss.deployment.active != nil {
-				if ss.deployment.active.SelfLink() == od.SelfLink() && od.Status.State == types.StateReady {
+			if ss.deployment.active != nil && ss.deployment.active.SelfLink() == od.SelfLink() {
+				if od.Status.State == types.StateReady || rolling {
 					continue
 				}
 			}
@@ -381,6 +448,7 @@ func serviceDeploymentProvision(ss *ServiceState, svc *types.Service) error {
 
 		ss.deployment.list[d.SelfLink()] = d
 		ss.deployment.provision = d
+		rolloutStop(ss)
 	}
 
 	return nil
@@ -392,6 +460,13 @@ func serviceStatusState(ss *ServiceState) (err error) {
 	status := ss.service.Status
 
 	defer func() {
+
+		// scale up blocked by namespace quota is reported as service error until spec is changed
+		if ss.quota.err != nil && ss.service.Status.State != types.StateDestroy && ss.service.Status.State != types.StateDestroyed {
+			ss.service.Status.State = types.StateError
+			ss.service.Status.Message = fmt.Sprintf("%s: %s", serviceQuotaExceeded, ss.quota.err.Error())
+		}
+
 		if status.State == ss.service.Status.State && status.Message == ss.service.Status.Message {
 			return
 		}
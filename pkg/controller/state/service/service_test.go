@@ -59,6 +59,10 @@ func testServiceObserver(t *testing.T, name, werr string, wst *ServiceState, sta
 		return
 	}
 
+	if !assert.NoError(t, putServiceStateAsset(state, svc)) {
+		return
+	}
+
 	t.Run(name, func(t *testing.T) {
 
 		err := serviceObserve(state, svc)
@@ -1214,6 +1218,26 @@ func TestServiceStatusState(t *testing.T) {
 	}
 }
 
+func TestServiceStateStop(t *testing.T) {
+
+	svc := getServiceAsset(types.StateReady, types.EmptyString)
+	ss := getServiceStateAsset(svc)
+	ss.Stop()
+
+	done := make(chan bool)
+	go func() {
+		ss.SetService(svc)
+		ss.DrainNode("node")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("stopped service state should not block observers")
+	}
+}
+
 func getServiceAsset(state, message string) *types.Service {
 	s := new(types.Service)
 
@@ -1288,6 +1312,83 @@ func getPodAsset(d *types.Deployment, state, message string) *types.Pod {
 	return p
 }
 
+// putServiceStateAsset - store state objects as controller restores state from storage,
+// observers can update only stored objects
+func putServiceStateAsset(state *ServiceState, items ...interface{}) error {
+
+	var (
+		ctx = context.Background()
+		stg = envs.Get().GetStorage()
+	)
+
+	for _, c := range []string{stg.Collection().Service(), stg.Collection().Deployment(), stg.Collection().Pod()} {
+		if err := stg.Del(ctx, c, ""); err != nil {
+			return err
+		}
+	}
+
+	if state.service != nil {
+		items = append(items, state.service)
+	}
+
+	for _, d := range state.deployment.list {
+		items = append(items, d)
+	}
+
+	for _, pl := range state.pod.list {
+		for _, p := range pl {
+			items = append(items, p)
+		}
+	}
+
+	opts := storage.GetOpts()
+	opts.Force = true
+
+	// pods scheduled to node have manifests
+	manifests := make(map[string][]*types.Pod)
+	for _, item := range items {
+		if p, ok := item.(*types.Pod); ok && p.Meta.Node != types.EmptyString {
+			manifests[p.Meta.Node] = append(manifests[p.Meta.Node], p)
+		}
+	}
+
+	for node, pods := range manifests {
+
+		if err := stg.Del(ctx, stg.Collection().Manifest().Pod(node), ""); err != nil {
+			return err
+		}
+
+		for _, p := range pods {
+			pm := types.PodManifest(p.Spec)
+			if err := stg.Set(ctx, stg.Collection().Manifest().Pod(node), p.SelfLink(), &pm, opts); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, item := range items {
+
+		var collection, key string
+
+		switch i := item.(type) {
+		case *types.Service:
+			collection, key = stg.Collection().Service(), stg.Key().Service(i.Meta.Namespace, i.Meta.Name)
+		case *types.Deployment:
+			collection, key = stg.Collection().Deployment(), stg.Key().Deployment(i.Meta.Namespace, i.Meta.Service, i.Meta.Name)
+		case *types.Pod:
+			collection, key = stg.Collection().Pod(), stg.Key().Pod(i.Meta.Namespace, i.Meta.Service, i.Meta.Deployment, i.Meta.Name)
+		default:
+			continue
+		}
+
+		if err := stg.Set(ctx, collection, key, item, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func getServiceStateAsset(svc *types.Service) *ServiceState {
 
 	n := new(types.Node)
@@ -1303,6 +1404,14 @@ func getServiceStateAsset(svc *types.Service) *ServiceState {
 	}
 	n.SelfLink()
 
+	// node is updated in storage on pods lease
+	var (
+		stg  = envs.Get().GetStorage()
+		opts = storage.GetOpts()
+	)
+	opts.Force = true
+	stg.Set(context.Background(), stg.Collection().Node().Info(), stg.Key().Node(n.Meta.Name), n, opts)
+
 	cs := cluster.NewClusterState()
 	cs.SetNode(n)
 	s := NewServiceState(cs, svc)
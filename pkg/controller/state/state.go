@@ -20,6 +20,7 @@ package state
 
 import (
 	"context"
+	"sync"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/controller/state/cluster"
@@ -32,6 +33,7 @@ import (
 const logLevel = 3
 
 type State struct {
+	lock    sync.RWMutex
 	Cluster *cluster.ClusterState
 	Service map[string]*service.ServiceState
 }
@@ -90,11 +92,7 @@ func (s *State) Loop() {
 		for _, svc := range ss.Items {
 
 			log.V(logLevel).Debugf("restore service state: %s \n", svc.SelfLink())
-			if _, ok := s.Service[svc.SelfLink()]; !ok {
-				s.Service[svc.SelfLink()] = service.NewServiceState(s.Cluster, svc)
-			}
-
-			s.Service[svc.SelfLink()].Restore()
+			s.serviceState(svc).Restore()
 		}
 
 		vl, err := vm.ListByNamespace(n.SelfLink())
@@ -139,19 +137,11 @@ func (s *State) watchServices(ctx context.Context, rev *int64) {
 				}
 
 				if w.IsActionRemove() {
-					_, ok := s.Service[w.Data.SelfLink()]
-					if ok {
-						delete(s.Service, w.Data.SelfLink())
-					}
+					s.delServiceState(w.Data.SelfLink())
 					continue
 				}
 
-				_, ok := s.Service[w.Data.SelfLink()]
-				if !ok {
-					s.Service[w.Data.SelfLink()] = service.NewServiceState(s.Cluster, w.Data)
-				}
-
-				s.Service[w.Data.SelfLink()].SetService(w.Data)
+				s.serviceState(w.Data).SetService(w.Data)
 			}
 		}
 	}()
@@ -179,20 +169,17 @@ func (s *State) watchDeployments(ctx context.Context, rev *int64) {
 					continue
 				}
 
-				if w.IsActionRemove() {
-					_, ok := s.Service[w.Data.ServiceLink()]
-					if ok {
-						s.Service[w.Data.ServiceLink()].DelDeployment(w.Data)
-					}
+				ss, ok := s.getServiceState(w.Data.ServiceLink())
+				if !ok {
 					continue
 				}
 
-				_, ok := s.Service[w.Data.ServiceLink()]
-				if !ok {
-					break
+				if w.IsActionRemove() {
+					ss.DelDeployment(w.Data)
+					continue
 				}
 
-				s.Service[w.Data.ServiceLink()].SetDeployment(w.Data)
+				ss.SetDeployment(w.Data)
 			}
 		}
 	}()
@@ -220,20 +207,17 @@ func (s *State) watchPods(ctx context.Context, rev *int64) {
 					continue
 				}
 
-				if w.IsActionRemove() {
-					_, ok := s.Service[w.Data.ServiceLink()]
-					if ok {
-						s.Service[w.Data.ServiceLink()].DelPod(w.Data)
-					}
+				ss, ok := s.getServiceState(w.Data.ServiceLink())
+				if !ok {
 					continue
 				}
 
-				_, ok := s.Service[w.Data.ServiceLink()]
-				if !ok {
-					break
+				if w.IsActionRemove() {
+					ss.DelPod(w.Data)
+					continue
 				}
 
-				s.Service[w.Data.ServiceLink()].SetPod(w.Data)
+				ss.SetPod(w.Data)
 			}
 		}
 	}()
@@ -272,9 +256,57 @@ func (s *State) watchVolumes(ctx context.Context, rev *int64) {
 	sm.Watch(vl, rev)
 }
 
+// serviceState returns service state, creates it if not exists
+func (s *State) serviceState(svc *types.Service) *service.ServiceState {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ss, ok := s.Service[svc.SelfLink()]
+	if !ok {
+		ss = service.NewServiceState(s.Cluster, svc)
+		s.Service[svc.SelfLink()] = ss
+	}
+
+	return ss
+}
+
+func (s *State) getServiceState(link string) (*service.ServiceState, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	ss, ok := s.Service[link]
+	return ss, ok
+}
+
+func (s *State) delServiceState(link string) {
+	s.lock.Lock()
+	ss, ok := s.Service[link]
+	delete(s.Service, link)
+	s.lock.Unlock()
+
+	if ok {
+		ss.Stop()
+	}
+}
+
+// drainNode moves pods from node in every service state
+func (s *State) drainNode(node string) {
+
+	s.lock.RLock()
+	list := make([]*service.ServiceState, 0, len(s.Service))
+	for _, ss := range s.Service {
+		list = append(list, ss)
+	}
+	s.lock.RUnlock()
+
+	for _, ss := range list {
+		ss.DrainNode(node)
+	}
+}
+
 func NewState() *State {
 	var state = new(State)
 	state.Cluster = cluster.NewClusterState()
 	state.Service = make(map[string]*service.ServiceState)
+	state.Cluster.OnNodeDrain(state.drainNode)
 	return state
 }
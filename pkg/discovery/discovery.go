@@ -58,7 +58,7 @@ func Daemon() bool {
 	st.Discovery().Info = runtime.DiscoveryInfo()
 	st.Discovery().Status = runtime.DiscoveryStatus()
 
-	stg, err := storage.Get(viper.GetString("etcd"))
+	stg, err := storage.Get(viper.GetString("storage.driver"))
 	if err != nil {
 		log.Fatalf("Cannot initialize storage: %v", err)
 	}
@@ -0,0 +1,92 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package distribution
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+)
+
+const (
+	logAuditPrefix = "distribution:audit"
+)
+
+type Audit struct {
+	context context.Context
+	storage storage.Storage
+}
+
+// List - list of audit records matched filter ordered by time
+func (a *Audit) List(filter *types.AuditFilter) (*types.AuditRecordList, error) {
+
+	log.V(logLevel).Debugf("%s:list:> get audit records in namespace `%s`", logAuditPrefix, filter.Namespace)
+
+	var (
+		list = types.NewAuditRecordList()
+		q    string
+	)
+
+	if filter.Namespace != "" {
+		q = a.storage.Filter().Audit().ByNamespace(filter.Namespace)
+	}
+
+	err := a.storage.List(a.context, a.storage.Collection().Audit(), q, list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> get audit records err: %v", logAuditPrefix, err)
+		return nil, err
+	}
+
+	items := make([]*types.AuditRecord, 0)
+	for _, item := range list.Items {
+		if filter.Match(item) {
+			items = append(items, item)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	list.Items = items
+	return list, nil
+}
+
+// Create - store audit record, record is removed by storage after ttl seconds
+func (a *Audit) Create(record *types.AuditRecord, ttl uint64) error {
+
+	log.V(logLevel).Debugf("%s:create:> create audit record %s", logAuditPrefix, record.ID)
+
+	opts := storage.GetOpts()
+	opts.Ttl = ttl
+
+	if err := a.storage.Put(a.context, a.storage.Collection().Audit(),
+		a.storage.Key().Audit(record.Namespace, record.ID), record, opts); err != nil {
+		log.V(logLevel).Errorf("%s:create:> create audit record %s err: %v", logAuditPrefix, record.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+func NewAuditModel(ctx context.Context, stg storage.Storage) *Audit {
+	return &Audit{ctx, stg}
+}
@@ -0,0 +1,163 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package distribution
+
+import (
+	"context"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+)
+
+const (
+	logAutoscalerPrefix = "distribution:autoscaler"
+)
+
+type Autoscaler struct {
+	context context.Context
+	storage storage.Storage
+}
+
+// Get - get autoscaler of service
+func (a *Autoscaler) Get(namespace, service string) (*types.Autoscaler, error) {
+
+	log.V(logLevel).Debugf("%s:get:> get autoscaler by namespace %s and service %s", logAutoscalerPrefix, namespace, service)
+
+	item := new(types.Autoscaler)
+
+	err := a.storage.Get(a.context, a.storage.Collection().Autoscaler(), a.storage.Key().Autoscaler(namespace, service), &item, nil)
+	if err != nil {
+
+		if errors.Storage().IsErrEntityNotFound(err) {
+			return nil, nil
+		}
+
+		log.V(logLevel).Errorf("%s:get:> get autoscaler err: %v", logAutoscalerPrefix, err)
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ListByNamespace - list of autoscalers in namespace
+func (a *Autoscaler) ListByNamespace(namespace string) (*types.AutoscalerList, error) {
+
+	log.V(logLevel).Debugf("%s:listbynamespace:> in namespace: %s", logAutoscalerPrefix, namespace)
+
+	list := types.NewAutoscalerList()
+
+	err := a.storage.List(a.context, a.storage.Collection().Autoscaler(), a.storage.Filter().Autoscaler().ByNamespace(namespace), list, nil)
+	if err != nil {
+		log.Errorf("%s:listbynamespace:> in namespace: %s err: %v", logAutoscalerPrefix, namespace, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Create - create autoscaler for service
+func (a *Autoscaler) Create(service *types.Service, spec types.AutoscalerSpec) (*types.Autoscaler, error) {
+
+	log.V(logLevel).Debugf("%s:create:> create autoscaler for service %s", logAutoscalerPrefix, service.SelfLink())
+
+	as := new(types.Autoscaler)
+	as.Meta.SetDefault()
+	as.Meta.Name = service.Meta.Name
+	as.Meta.Namespace = service.Meta.Namespace
+	as.Meta.Service = service.Meta.Name
+	as.SelfLink()
+
+	as.Spec = spec
+	as.Status.Replicas = service.Spec.Replicas
+	as.Status.Desired = service.Spec.Replicas
+
+	if err := a.storage.Put(a.context, a.storage.Collection().Autoscaler(),
+		a.storage.Key().Autoscaler(as.Meta.Namespace, as.Meta.Service), as, nil); err != nil {
+		log.Errorf("%s:create:> create autoscaler %s err: %v", logAutoscalerPrefix, as.SelfLink(), err)
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// SetSpec - update autoscaler spec
+func (a *Autoscaler) SetSpec(as *types.Autoscaler, spec types.AutoscalerSpec) (*types.Autoscaler, error) {
+
+	log.V(logLevel).Debugf("%s:setspec:> update autoscaler %s spec", logAutoscalerPrefix, as.SelfLink())
+
+	as.Spec = spec
+	as.Meta.Updated = time.Now()
+
+	if err := a.storage.Set(a.context, a.storage.Collection().Autoscaler(),
+		a.storage.Key().Autoscaler(as.Meta.Namespace, as.Meta.Service), as, nil); err != nil {
+		log.Errorf("%s:setspec:> update autoscaler %s spec err: %v", logAutoscalerPrefix, as.SelfLink(), err)
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// SetStatus - update autoscaler status
+func (a *Autoscaler) SetStatus(as *types.Autoscaler, status types.AutoscalerStatus) (*types.Autoscaler, error) {
+
+	log.V(logLevel).Debugf("%s:setstatus:> update autoscaler %s status", logAutoscalerPrefix, as.SelfLink())
+
+	as.Status = status
+
+	key := a.storage.Key().Autoscaler(as.Meta.Namespace, as.Meta.Service)
+
+	err := RetryOnConflict(func() error {
+		return a.storage.Set(a.context, a.storage.Collection().Autoscaler(), key, as, updateOpts(as.Runtime))
+	}, func() error {
+		latest := new(types.Autoscaler)
+		if err := a.storage.Get(a.context, a.storage.Collection().Autoscaler(), key, latest, nil); err != nil {
+			return err
+		}
+		latest.Status = status
+		*as = *latest
+		return nil
+	})
+	if err != nil {
+		log.Errorf("%s:setstatus:> update autoscaler %s status err: %v", logAutoscalerPrefix, as.SelfLink(), err)
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// Remove - remove autoscaler of service
+func (a *Autoscaler) Remove(as *types.Autoscaler) error {
+
+	log.V(logLevel).Debugf("%s:remove:> remove autoscaler %s", logAutoscalerPrefix, as.SelfLink())
+
+	if err := a.storage.Del(a.context, a.storage.Collection().Autoscaler(),
+		a.storage.Key().Autoscaler(as.Meta.Namespace, as.Meta.Service)); err != nil {
+		log.V(logLevel).Debugf("%s:remove:> remove autoscaler %s err: %v", logAutoscalerPrefix, as.SelfLink(), err)
+		return err
+	}
+
+	return nil
+}
+
+func NewAutoscalerModel(ctx context.Context, stg storage.Storage) *Autoscaler {
+	return &Autoscaler{ctx, stg}
+}
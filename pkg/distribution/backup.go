@@ -0,0 +1,572 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package distribution
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/encryption"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
+)
+
+const (
+	logBackupPrefix = "distribution:backup"
+)
+
+// backupCollection - storage collection stored in backup archive
+type backupCollection struct {
+	// collection name in archive
+	name string
+	// objects are encrypted in archive when passphrase is set
+	sensitive bool
+	// storage collection name
+	collection func(c st.Collection) string
+	// new list and new item of collection objects
+	list func() interface{}
+	item func() interface{}
+	// storage key of object
+	key func(k st.Key, obj interface{}) string
+	// reset runtime only fields before restore, object is skipped if false is returned
+	reset func(obj interface{}) bool
+	// convert object read from storage to archive form and back
+	export  func(obj interface{}) error
+	restore func(obj interface{}) error
+}
+
+// backupCollections - collections in restore order: objects are restored after objects they depend on.
+// Pods, deployments, endpoints, nodes, ingresses and manifests are not stored:
+// they are created again by controllers and cluster components from restored objects
+var backupCollections = []backupCollection{
+	{
+		name:       "namespace",
+		collection: func(c st.Collection) string { return c.Namespace() },
+		list:       func() interface{} { return types.NewNamespaceList() },
+		item:       func() interface{} { return new(types.Namespace) },
+		key: func(k st.Key, obj interface{}) string {
+			return k.Namespace(obj.(*types.Namespace).Meta.Name)
+		},
+		// namespace status is kept: it holds resources usage of restored services
+	},
+	{
+		name:       "role",
+		collection: func(c st.Collection) string { return c.Role() },
+		list:       func() interface{} { return types.NewRoleList() },
+		item:       func() interface{} { return new(types.Role) },
+		key: func(k st.Key, obj interface{}) string {
+			return k.Role(obj.(*types.Role).Meta.Name)
+		},
+	},
+	{
+		name:       "user",
+		collection: func(c st.Collection) string { return c.User() },
+		list:       func() interface{} { return types.NewUserList() },
+		item:       func() interface{} { return new(types.User) },
+		key: func(k st.Key, obj interface{}) string {
+			return k.User(obj.(*types.User).Meta.Name)
+		},
+	},
+	{
+		name:       "secret",
+		sensitive:  true,
+		collection: func(c st.Collection) string { return c.Secret() },
+		list:       func() interface{} { return types.NewSecretList() },
+		item:       func() interface{} { return new(types.Secret) },
+		key: func(k st.Key, obj interface{}) string {
+			s := obj.(*types.Secret)
+			return k.Secret(s.Meta.Namespace, s.Meta.Name)
+		},
+		// secrets are stored in archive decrypted, so backup can be restored with other storage key
+		export: func(obj interface{}) error {
+			return encryption.Decrypt(obj.(*types.Secret))
+		},
+		restore: func(obj interface{}) error {
+			return encryption.Encrypt(obj.(*types.Secret))
+		},
+	},
+	{
+		name:       "config",
+		collection: func(c st.Collection) string { return c.Config() },
+		list:       func() interface{} { return types.NewConfigList() },
+		item:       func() interface{} { return new(types.Config) },
+		key: func(k st.Key, obj interface{}) string {
+			c := obj.(*types.Config)
+			return k.Config(c.Meta.Namespace, c.Meta.Name)
+		},
+	},
+	{
+		name:       "volume",
+		collection: func(c st.Collection) string { return c.Volume() },
+		list:       func() interface{} { return types.NewVolumeList() },
+		item:       func() interface{} { return new(types.Volume) },
+		key: func(k st.Key, obj interface{}) string {
+			v := obj.(*types.Volume)
+			return k.Volume(v.Meta.Namespace, v.Meta.Name)
+		},
+		reset: func(obj interface{}) bool {
+			v := obj.(*types.Volume)
+			if v.Spec.State.Destroy || destroyed(v.Status.State) {
+				return false
+			}
+			v.Meta.Node = types.EmptyString
+			v.Status = types.VolumeStatus{State: types.StateCreated}
+			return true
+		},
+	},
+	{
+		name:       "service",
+		collection: func(c st.Collection) string { return c.Service() },
+		list:       func() interface{} { return types.NewServiceList() },
+		item:       func() interface{} { return new(types.Service) },
+		key: func(k st.Key, obj interface{}) string {
+			s := obj.(*types.Service)
+			return k.Service(s.Meta.Namespace, s.Meta.Name)
+		},
+		reset: func(obj interface{}) bool {
+			s := obj.(*types.Service)
+			if destroyed(s.Status.State) {
+				return false
+			}
+			s.Meta.IP = types.EmptyString
+			s.Status = types.ServiceStatus{State: types.StateCreated}
+			return true
+		},
+	},
+	{
+		name:       "revision",
+		collection: func(c st.Collection) string { return c.Revision() },
+		list:       func() interface{} { return types.NewDeploymentList() },
+		item:       func() interface{} { return new(types.Deployment) },
+		key: func(k st.Key, obj interface{}) string {
+			d := obj.(*types.Deployment)
+			return k.Revision(d.Meta.Namespace, d.Meta.Service, d.Meta.Version)
+		},
+	},
+	{
+		name:       "autoscaler",
+		collection: func(c st.Collection) string { return c.Autoscaler() },
+		list:       func() interface{} { return types.NewAutoscalerList() },
+		item:       func() interface{} { return new(types.Autoscaler) },
+		key: func(k st.Key, obj interface{}) string {
+			a := obj.(*types.Autoscaler)
+			return k.Autoscaler(a.Meta.Namespace, a.Meta.Service)
+		},
+		reset: func(obj interface{}) bool {
+			obj.(*types.Autoscaler).Status = types.AutoscalerStatus{}
+			return true
+		},
+	},
+	{
+		name:       "route",
+		collection: func(c st.Collection) string { return c.Route() },
+		list:       func() interface{} { return types.NewRouteList() },
+		item:       func() interface{} { return new(types.Route) },
+		key: func(k st.Key, obj interface{}) string {
+			r := obj.(*types.Route)
+			return k.Route(r.Meta.Namespace, r.Meta.Name)
+		},
+		reset: func(obj interface{}) bool {
+			r := obj.(*types.Route)
+			if destroyed(r.Status.State) {
+				return false
+			}
+			r.Meta.Ingress = types.EmptyString
+			r.Status = types.RouteStatus{State: types.StateCreated}
+			return true
+		},
+	},
+}
+
+type Backup struct {
+	context context.Context
+	storage storage.Storage
+}
+
+// Export - write all backed up collections into gzipped tar archive
+func (b *Backup) Export(w io.Writer, opts *types.BackupOptions) (*types.BackupManifest, error) {
+
+	log.V(logLevel).Debugf("%s:export:> export storage collections", logBackupPrefix)
+
+	if opts == nil {
+		opts = new(types.BackupOptions)
+	}
+
+	// secrets are decrypted for archive, encrypted cluster secrets are not exported as plain data
+	if opts.Passphrase == types.EmptyString && encryption.Get() != nil {
+		return nil, types.ErrBackupPassphraseRequired
+	}
+
+	var (
+		manifest = new(types.BackupManifest)
+		items    = make(map[string][]interface{})
+		key      []byte
+	)
+
+	manifest.Version = types.BackupVersion
+	manifest.Created = time.Now()
+	manifest.Collections = make(map[string]int)
+
+	if opts.Passphrase != types.EmptyString {
+
+		salt, err := crypto.Random(crypto.SaltSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if key, err = crypto.DeriveKey(opts.Passphrase, salt); err != nil {
+			return nil, err
+		}
+
+		manifest.Encryption = &types.BackupEncryption{
+			Cipher:      types.BackupCipher,
+			KDF:         types.BackupKDF,
+			Salt:        salt,
+			Collections: make([]string, 0),
+		}
+	}
+
+	for _, c := range backupCollections {
+
+		list := c.list()
+		if err := b.storage.List(b.context, c.collection(b.storage.Collection()), types.EmptyString, list, nil); err != nil {
+			log.V(logLevel).Errorf("%s:export:> list %s err: %v", logBackupPrefix, c.name, err)
+			return nil, err
+		}
+
+		items[c.name] = listItems(list)
+		manifest.Collections[c.name] = len(items[c.name])
+
+		if c.export != nil {
+			for _, obj := range items[c.name] {
+				if err := c.export(obj); err != nil {
+					log.V(logLevel).Errorf("%s:export:> export %s err: %v", logBackupPrefix, c.name, err)
+					return nil, err
+				}
+			}
+		}
+
+		if c.sensitive && manifest.Encryption != nil {
+			manifest.Encryption.Collections = append(manifest.Encryption.Collections, c.name)
+		}
+	}
+
+	var (
+		gw = gzip.NewWriter(w)
+		tw = tar.NewWriter(gw)
+	)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeEntry(tw, types.BackupManifestFile, data, manifest.Created); err != nil {
+		return nil, err
+	}
+
+	for _, c := range backupCollections {
+		for _, obj := range items[c.name] {
+
+			data, err := json.MarshalIndent(obj, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+
+			if manifest.Encryption.Encrypted(c.name) {
+				if data, err = crypto.Seal(key, data, nil); err != nil {
+					return nil, err
+				}
+			}
+
+			name := path.Join(c.name, fmt.Sprintf("%s.json", c.key(b.storage.Key(), obj)))
+			if err := writeEntry(tw, name, data, manifest.Created); err != nil {
+				log.V(logLevel).Errorf("%s:export:> write %s err: %v", logBackupPrefix, name, err)
+				return nil, err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// Restore - restore collections from archive created by Export into empty storage.
+// Archive is validated and decoded before any object is stored. Runtime status of restored
+// objects is reset, so controllers reconcile them from scratch. Objects already stored are removed
+// if restore failed
+func (b *Backup) Restore(r io.Reader, opts *types.BackupOptions) (*types.BackupManifest, error) {
+
+	log.V(logLevel).Debugf("%s:restore:> restore storage collections", logBackupPrefix)
+
+	if opts == nil {
+		opts = new(types.BackupOptions)
+	}
+
+	manifest, entries, err := readArchive(r)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:restore:> read archive err: %v", logBackupPrefix, err)
+		return nil, &types.BackupArchiveError{Err: err}
+	}
+
+	if manifest.Version != types.BackupVersion {
+		return nil, &types.BackupArchiveError{Err: types.ErrBackupVersion}
+	}
+
+	var key []byte
+
+	if manifest.Encryption != nil && len(manifest.Encryption.Collections) > 0 {
+
+		if opts.Passphrase == types.EmptyString {
+			return nil, &types.BackupArchiveError{Err: types.ErrBackupPassphrase}
+		}
+
+		if key, err = crypto.DeriveKey(opts.Passphrase, manifest.Encryption.Salt); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		result = new(types.BackupManifest)
+		items  = make(map[string][]interface{})
+	)
+
+	result.Version = manifest.Version
+	result.Created = manifest.Created
+	result.Encryption = manifest.Encryption
+	result.Collections = make(map[string]int)
+
+	for _, c := range backupCollections {
+		for _, data := range entries[c.name] {
+
+			if manifest.Encryption.Encrypted(c.name) {
+				if data, err = crypto.Open(key, data, nil); err != nil {
+					return nil, &types.BackupArchiveError{Err: fmt.Errorf("decrypt %s: %v", c.name, err)}
+				}
+			}
+
+			obj := c.item()
+			if err := json.Unmarshal(data, obj); err != nil {
+				return nil, &types.BackupArchiveError{Err: fmt.Errorf("decode %s: %v", c.name, err)}
+			}
+
+			if c.reset != nil && !c.reset(obj) {
+				continue
+			}
+
+			if c.restore != nil {
+				if err := c.restore(obj); err != nil {
+					return nil, err
+				}
+			}
+
+			items[c.name] = append(items[c.name], obj)
+		}
+		delete(entries, c.name)
+	}
+
+	for name := range entries {
+		return nil, &types.BackupArchiveError{Err: fmt.Errorf("unknown collection %s", name)}
+	}
+
+	if err := b.empty(); err != nil {
+		return nil, err
+	}
+
+	// restored objects are removed if restore failed, so restore can be retried into empty storage
+	restored := make([]backupObject, 0)
+
+	for _, c := range backupCollections {
+		for _, obj := range items[c.name] {
+
+			var (
+				collection = c.collection(b.storage.Collection())
+				k          = c.key(b.storage.Key(), obj)
+			)
+
+			if err := b.storage.Put(b.context, collection, k, obj, nil); err != nil {
+				log.V(logLevel).Errorf("%s:restore:> put %s %s err: %v", logBackupPrefix, c.name, k, err)
+				b.rollback(restored)
+				return nil, err
+			}
+
+			restored = append(restored, backupObject{collection: collection, key: k})
+			result.Collections[c.name]++
+		}
+	}
+
+	return result, nil
+}
+
+type backupObject struct {
+	collection string
+	key        string
+}
+
+// rollback - remove restored objects in reverse order: dependent objects are removed first
+func (b *Backup) rollback(restored []backupObject) {
+
+	log.V(logLevel).Debugf("%s:rollback:> remove %d restored objects", logBackupPrefix, len(restored))
+
+	for i := len(restored) - 1; i >= 0; i-- {
+		o := restored[i]
+		if err := b.storage.Del(b.context, o.collection, o.key); err != nil {
+			log.V(logLevel).Errorf("%s:rollback:> remove %s %s err: %v", logBackupPrefix, o.collection, o.key, err)
+		}
+	}
+}
+
+// empty - check that restored collections have no objects
+func (b *Backup) empty() error {
+
+	for _, c := range backupCollections {
+
+		opts := storage.GetOpts()
+		opts.Limit = 1
+
+		list := c.list()
+		if err := b.storage.List(b.context, c.collection(b.storage.Collection()), types.EmptyString, list, opts); err != nil {
+			return err
+		}
+
+		if len(listItems(list)) > 0 {
+			log.V(logLevel).Warnf("%s:restore:> collection %s is not empty", logBackupPrefix, c.name)
+			return types.ErrBackupStorageNotEmpty
+		}
+	}
+
+	return nil
+}
+
+// readArchive - read manifest and raw objects data by collection from archive
+func readArchive(r io.Reader) (*types.BackupManifest, map[string][][]byte, error) {
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gr.Close()
+
+	var (
+		tr       = tar.NewReader(gr)
+		manifest *types.BackupManifest
+		entries  = make(map[string][][]byte)
+	)
+
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if h.Name == types.BackupManifestFile {
+			manifest = new(types.BackupManifest)
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		if manifest == nil {
+			return nil, nil, types.ErrBackupManifest
+		}
+
+		parts := strings.SplitN(h.Name, "/", 2)
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("invalid entry %s", h.Name)
+		}
+
+		entries[parts[0]] = append(entries[parts[0]], data)
+	}
+
+	if manifest == nil {
+		return nil, nil, types.ErrBackupManifest
+	}
+
+	return manifest, entries, nil
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte, mod time.Time) error {
+
+	h := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: mod,
+	}
+
+	if err := tw.WriteHeader(h); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(data)
+	return err
+}
+
+// listItems - items of storage list structure
+func listItems(list interface{}) []interface{} {
+
+	f := reflect.ValueOf(list).Elem().FieldByName("Items")
+
+	items := make([]interface{}, f.Len())
+	for i := 0; i < f.Len(); i++ {
+		items[i] = f.Index(i).Interface()
+	}
+
+	return items
+}
+
+func destroyed(state string) bool {
+	return state == types.StateDestroy || state == types.StateDestroyed
+}
+
+func NewBackupModel(ctx context.Context, stg storage.Storage) *Backup {
+	return &Backup{ctx, stg}
+}
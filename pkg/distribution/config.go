@@ -25,6 +25,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 )
 
 const (
@@ -79,6 +80,55 @@ func (n *Config) List(filter string) (*types.ConfigList, error) {
 	return list, nil
 }
 
+// Select - list of configs in namespace matched by list options
+func (n *Config) Select(namespace string, opts *types.ListOptions) (*types.ConfigList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get configs list in namespace %s", logConfigPrefix, namespace)
+
+	q := n.storage.Filter().Config().ByNamespace(namespace)
+	list := types.NewConfigList()
+
+	err := n.storage.List(n.context, n.storage.Collection().Config(), q, list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get configs list in namespace %s err: %v", logConfigPrefix, namespace, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch configs changes matched by watch options
+func (n *Config) WatchSelect(namespace string, ch chan types.ConfigEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch configs in namespace %s", logConfigPrefix, namespace)
+
+	o := watchOpts(opts)
+	o.Selector = o.Selector.Field("meta.namespace", namespace)
+
+	return watch(n.context, n.storage, n.storage.Collection().Config(), o, func(e *st.WatcherEvent) {
+
+		res := types.ConfigEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Config)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logConfigPrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-n.context.Done():
+		}
+	})
+}
+
 func (n *Config) Create(namespace *types.Namespace, config *types.Config) (*types.Config, error) {
 
 	log.V(logLevel).Debugf("%s:create:> create config %#v", logConfigPrefix, config.Meta.Name)
@@ -103,7 +153,7 @@ func (n *Config) Update(config *types.Config) (*types.Config, error) {
 
 
 	if err := n.storage.Set(n.context, n.storage.Collection().Config(),
-		n.storage.Key().Config(config.Meta.Namespace, config.Meta.Name), config, nil); err != nil {
+		n.storage.Key().Config(config.Meta.Namespace, config.Meta.Name), config, updateOpts(config.Runtime)); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update config err: %s", logConfigPrefix, err)
 		return nil, err
 	}
@@ -152,6 +202,7 @@ func (n *Config) Watch(ch chan types.ConfigEvent, rev *int64) error {
 					log.Errorf("%s:> parse data err: %v", logConfigPrefix, err)
 					continue
 				}
+				config.Runtime.System.Revision = e.System.Revision
 
 				res.Data = config
 
@@ -21,12 +21,14 @@ package distribution
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"encoding/json"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 )
@@ -84,6 +86,7 @@ func (d *Deployment) Create(service *types.Service, version int) (*types.Deploym
 	deployment.Meta.Service = service.Meta.Name
 	deployment.Meta.Status = types.StateCreated
 	deployment.Meta.Name = fmt.Sprintf("v%d", version)
+	deployment.Meta.Version = version
 	deployment.Meta.Created = time.Now()
 	deployment.Meta.Updated = time.Now()
 
@@ -93,6 +96,7 @@ func (d *Deployment) Create(service *types.Service, version int) (*types.Deploym
 		Replicas: service.Spec.Replicas,
 		Template: service.Spec.Template,
 		Selector: service.Spec.Selector,
+		Affinity: service.Spec.Affinity,
 	}
 
 	deployment.Status.SetProvision()
@@ -140,13 +144,63 @@ func (d *Deployment) ListByService(namespace, service string) (*types.Deployment
 	return dl, nil
 }
 
+// Select - list of deployments of service matched by list options
+func (d *Deployment) Select(namespace, service string, opts *types.ListOptions) (*types.DeploymentList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get deployments list of service %s:%s", logDeploymentPrefix, namespace, service)
+
+	q := d.storage.Filter().Deployment().ByService(namespace, service)
+	list := types.NewDeploymentList()
+
+	err := d.storage.List(d.context, d.storage.Collection().Deployment(), q, list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get deployments list of service %s:%s err: %v", logDeploymentPrefix, namespace, service, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch deployments changes matched by watch options
+func (d *Deployment) WatchSelect(namespace, service string, ch chan types.DeploymentEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch deployments of service %s:%s", logDeploymentPrefix, namespace, service)
+
+	o := watchOpts(opts)
+	o.Selector = o.Selector.Field("meta.namespace", namespace)
+	o.Selector = o.Selector.Field("meta.service", service)
+
+	return watch(d.context, d.storage, d.storage.Collection().Deployment(), o, func(e *st.WatcherEvent) {
+
+		res := types.DeploymentEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Deployment)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logDeploymentPrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-d.context.Done():
+		}
+	})
+}
+
 // Update deployment
 func (d *Deployment) Update(dt *types.Deployment) error {
 
 	log.V(logLevel).Debugf("%s:update:> update deployment %s", logDeploymentPrefix, dt.Meta.Name)
 
 	if err := d.storage.Set(d.context, d.storage.Collection().Deployment(),
-		d.storage.Key().Deployment(dt.Meta.Namespace, dt.Meta.Service, dt.Meta.Name), dt, nil); err != nil {
+		d.storage.Key().Deployment(dt.Meta.Namespace, dt.Meta.Service, dt.Meta.Name), dt, updateOpts(dt.Runtime)); err != nil {
 		log.Errorf("%s:update:> update for deployment %s err: %v", logDeploymentPrefix, dt.Meta.Name, err)
 		return err
 	}
@@ -205,6 +259,79 @@ func (d *Deployment) Remove(dt *types.Deployment) error {
 	return nil
 }
 
+// Revision - get stored deployment revision by service and version
+func (d *Deployment) Revision(namespace, service string, version int) (*types.Deployment, error) {
+
+	log.V(logLevel).Debugf("%s:revision:> namespace %s and service %s by version %d", logDeploymentPrefix, namespace, service, version)
+
+	dp := new(types.Deployment)
+
+	err := d.storage.Get(d.context, d.storage.Collection().Revision(), d.storage.Key().Revision(namespace, service, version), &dp, nil)
+	if err != nil {
+		if errors.Storage().IsErrEntityNotFound(err) {
+			log.V(logLevel).Warnf("%s:revision:> in service %s by version %d not found", logDeploymentPrefix, service, version)
+			return nil, nil
+		}
+
+		log.V(logLevel).Errorf("%s:revision:> in service %s by version %d error: %s", logDeploymentPrefix, service, version, err)
+		return nil, err
+	}
+
+	return dp, nil
+}
+
+// ListRevisions - list of stored deployment revisions by service sorted by version
+func (d *Deployment) ListRevisions(namespace, service string) (*types.DeploymentList, error) {
+
+	log.V(logLevel).Debugf("%s:listrevisions:> in namespace: %s and service %s", logDeploymentPrefix, namespace, service)
+
+	q := d.storage.Filter().Revision().ByService(namespace, service)
+	dl := types.NewDeploymentList()
+
+	err := d.storage.List(d.context, d.storage.Collection().Revision(), q, dl, nil)
+	if err != nil {
+		log.Errorf("%s:listrevisions:> in namespace: %s and service %s err: %v", logDeploymentPrefix, namespace, service, err)
+		return nil, err
+	}
+
+	sort.Slice(dl.Items, func(i, j int) bool {
+		return dl.Items[i].Meta.Version < dl.Items[j].Meta.Version
+	})
+
+	return dl, nil
+}
+
+// PutRevision - store deployment spec as service revision
+func (d *Deployment) PutRevision(dt *types.Deployment) error {
+
+	log.V(logLevel).Debugf("%s:putrevision:> put revision %s", logDeploymentPrefix, dt.Meta.Name)
+
+	opts := storage.GetOpts()
+	opts.Force = true
+
+	if err := d.storage.Set(d.context, d.storage.Collection().Revision(),
+		d.storage.Key().Revision(dt.Meta.Namespace, dt.Meta.Service, dt.Meta.Version), dt, opts); err != nil {
+		log.Errorf("%s:putrevision:> put revision %s err: %v", logDeploymentPrefix, dt.Meta.Name, err)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveRevision - remove stored deployment revision
+func (d *Deployment) RemoveRevision(dt *types.Deployment) error {
+
+	log.V(logLevel).Debugf("%s:removerevision:> remove revision %s", logDeploymentPrefix, dt.Meta.Name)
+
+	if err := d.storage.Del(d.context, d.storage.Collection().Revision(),
+		d.storage.Key().Revision(dt.Meta.Namespace, dt.Meta.Service, dt.Meta.Version)); err != nil {
+		log.V(logLevel).Debugf("%s:removerevision:> remove revision %s err: %v", logDeploymentPrefix, dt.Meta.Name, err)
+		return err
+	}
+
+	return nil
+}
+
 // Watch deployment changes
 func (d *Deployment) Watch(dt chan types.DeploymentEvent, rev *int64) error {
 
@@ -234,6 +361,7 @@ func (d *Deployment) Watch(dt chan types.DeploymentEvent, rev *int64) error {
 					log.Errorf("%s:> parse data err: %v", logDeploymentPrefix, err)
 					continue
 				}
+				deployment.Runtime.System.Revision = e.System.Revision
 
 				res.Data = deployment
 
@@ -25,6 +25,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 	"time"
 )
 
@@ -49,6 +50,51 @@ func (n *Discovery) List() (*types.DiscoveryList, error) {
 	return list, nil
 }
 
+// Select - list of discoveries matched by list options
+func (n *Discovery) Select(opts *types.ListOptions) (*types.DiscoveryList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get discoveries list", logDiscoveryPrefix)
+
+	list := types.NewDiscoveryList()
+
+	err := n.storage.List(n.context, n.storage.Collection().Discovery().Info(), "", list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get discoveries list err: %v", logDiscoveryPrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch discoveries changes matched by watch options
+func (n *Discovery) WatchSelect(ch chan types.DiscoveryEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch discoveries", logDiscoveryPrefix)
+
+	o := watchOpts(opts)
+	return watch(n.context, n.storage, n.storage.Collection().Discovery().Info(), o, func(e *st.WatcherEvent) {
+
+		res := types.DiscoveryEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Discovery)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logDiscoveryPrefix, err)
+			return
+		}
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-n.context.Done():
+		}
+	})
+}
+
 func (n *Discovery) Put(discovery *types.Discovery) error {
 
 	log.V(logLevel).Debugf("%s:create:> create discovery in cluster", logDiscoveryPrefix)
@@ -99,14 +145,14 @@ func (n *Discovery) Set(discovery *types.Discovery) error {
 	opts.Force = true
 
 	err := n.storage.Set(n.context, n.storage.Collection().Discovery().Info(),
-		n.storage.Key().Discovery(discovery.Meta.Name), discovery, nil)
+		n.storage.Key().Discovery(discovery.Meta.Name), discovery, opts)
 	if err != nil {
 		log.V(logLevel).Debugf("%s:get:> set discovery `%s` err: %v", logDiscoveryPrefix, discovery.Meta.Name, err)
 		return err
 	}
 
 	if err := n.storage.Set(n.context, n.storage.Collection().Discovery().Status(),
-		n.storage.Key().Discovery(discovery.Meta.Name), discovery.Status, nil); err != nil {
+		n.storage.Key().Discovery(discovery.Meta.Name), discovery.Status, opts); err != nil {
 		log.V(logLevel).Debugf("%s:get:> set discovery status `%s` err: %v", logDiscoveryPrefix, discovery.Meta.Name, err)
 		return err
 	}
@@ -122,7 +168,7 @@ func (n *Discovery) SetOnline(discovery *types.Discovery) error {
 	opts.Force = true
 
 	err := n.storage.Set(n.context, n.storage.Collection().Discovery().Status(),
-		n.storage.Key().Discovery(discovery.Meta.Name), discovery.Status, nil)
+		n.storage.Key().Discovery(discovery.Meta.Name), discovery.Status, opts)
 	if err != nil {
 		log.V(logLevel).Debugf("%s:get:> set discovery `%s` err: %v", logDiscoveryPrefix, discovery.Meta.Name, err)
 		return err
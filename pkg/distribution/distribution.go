@@ -18,4 +18,96 @@
 
 package distribution
 
-const logLevel = 4
+import (
+	"context"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
+)
+
+const (
+	logLevel = 4
+	// conflictRetries - max update retries on revision conflict
+	conflictRetries = 5
+)
+
+// listOpts - storage options for list options
+func listOpts(opts *types.ListOptions) *st.Opts {
+
+	o := storage.GetOpts()
+	if opts == nil {
+		return o
+	}
+
+	o.Selector = opts.Selector
+	o.Limit = opts.Limit
+	o.Continue = opts.Continue
+	return o
+}
+
+// watchOpts - storage options for watch options
+func watchOpts(opts *types.WatchOptions) *st.Opts {
+
+	o := storage.GetOpts()
+	if opts == nil {
+		return o
+	}
+
+	o.Selector = opts.Selector
+	o.Rev = opts.Revision
+	return o
+}
+
+// updateOpts - storage options for update, item revision is used as update
+// precondition so changes made since item was read are not overwritten
+func updateOpts(rt types.Runtime) *st.Opts {
+
+	o := storage.GetOpts()
+	if rt.System.Revision == 0 {
+		return o
+	}
+
+	rev := rt.System.Revision
+	o.Rev = &rev
+	return o
+}
+
+// RetryOnConflict - call update while it fails with revision conflict, before
+// each retry refresh should load latest entity state and apply changes on it
+func RetryOnConflict(update, refresh func() error) error {
+
+	err := update()
+	for i := 0; i < conflictRetries && err != nil && errors.Storage().IsErrEntityConflict(err); i++ {
+		if err := refresh(); err != nil {
+			return err
+		}
+		err = update()
+	}
+
+	return err
+}
+
+// watch - watch collection changes and pass events with item data to handler
+// until context is done
+func watch(ctx context.Context, stg storage.Storage, collection string, opts *st.Opts, handler func(e *st.WatcherEvent)) error {
+
+	watcher := storage.NewWatcher()
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-watcher:
+				if e.Data == nil {
+					continue
+				}
+				handler(e)
+			}
+		}
+	}()
+
+	return stg.Watch(ctx, collection, watcher, opts)
+}
@@ -0,0 +1,264 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package encryption
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	logLevel  = 3
+	logPrefix = "distribution:encryption"
+)
+
+var (
+	ErrDisabled       = errors.New("secrets encryption key file is not configured")
+	ErrKeyNotFound    = errors.New("secret encryption key is not found in key file")
+	ErrKeyFileIsEmpty = errors.New("secrets encryption key file has no keys")
+)
+
+var (
+	lock    sync.RWMutex
+	keyring *Keyring
+)
+
+// Key - key encryption key in key file, secret is base64 encoded 32 bytes key
+type Key struct {
+	ID     string `yaml:"id"`
+	Secret string `yaml:"secret"`
+}
+
+// Keyring - key encryption keys: first key encrypts secrets,
+// all keys decrypt secrets, so secrets encrypted before key rotation can be read
+type Keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// Primary - id of key used to encrypt secrets
+func (k *Keyring) Primary() string {
+	return k.primary
+}
+
+// Seal - encrypt secret data with new data key, plain data is replaced with envelope.
+// Envelope is bound to secret storage key, so it can not be opened as other secret
+func (k *Keyring) Seal(secret *types.Secret) error {
+
+	data, err := json.Marshal(secret.Spec.Data)
+	if err != nil {
+		return err
+	}
+
+	dk, err := crypto.Random(crypto.KeySize)
+	if err != nil {
+		return err
+	}
+
+	var (
+		env = new(types.SecretEnvelope)
+		ad  = aad(secret)
+	)
+
+	env.Key = k.primary
+
+	if env.Data, err = crypto.Seal(dk, data, ad); err != nil {
+		return err
+	}
+
+	if env.DataKey, err = crypto.Seal(k.keys[k.primary], dk, ad); err != nil {
+		return err
+	}
+
+	secret.Spec.Data = nil
+	secret.Spec.Envelope = env
+	return nil
+}
+
+// Open - decrypt secret envelope, envelope is replaced with plain data
+func (k *Keyring) Open(secret *types.Secret) error {
+
+	var (
+		env = secret.Spec.Envelope
+		ad  = aad(secret)
+	)
+
+	kek, ok := k.keys[env.Key]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	dk, err := crypto.Open(kek, env.DataKey, ad)
+	if err != nil {
+		return err
+	}
+
+	data, err := crypto.Open(dk, env.Data, ad)
+	if err != nil {
+		return err
+	}
+
+	secret.Spec.Data = make(map[string][]byte)
+	if err := json.Unmarshal(data, &secret.Spec.Data); err != nil {
+		return err
+	}
+
+	secret.Spec.Envelope = nil
+	return nil
+}
+
+// aad - secret storage key used as additional authenticated data of envelope
+func aad(secret *types.Secret) []byte {
+	return []byte(secret.CreateSelfLink(secret.Meta.Namespace, secret.Meta.Name))
+}
+
+// Parse - parse keyring from yaml key file content
+func Parse(data []byte) (*Keyring, error) {
+
+	var file struct {
+		Keys []Key `yaml:"keys"`
+	}
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	if len(file.Keys) == 0 {
+		return nil, ErrKeyFileIsEmpty
+	}
+
+	k := new(Keyring)
+	k.primary = file.Keys[0].ID
+	k.keys = make(map[string][]byte)
+
+	for _, key := range file.Keys {
+
+		if key.ID == types.EmptyString {
+			return nil, errors.New("secrets encryption key id is empty")
+		}
+
+		if _, ok := k.keys[key.ID]; ok {
+			return nil, fmt.Errorf("secrets encryption key %s is duplicated", key.ID)
+		}
+
+		secret, err := base64.StdEncoding.DecodeString(key.Secret)
+		if err != nil || len(secret) != crypto.KeySize {
+			return nil, fmt.Errorf("secrets encryption key %s must be base64 encoded %d bytes", key.ID, crypto.KeySize)
+		}
+
+		k.keys[key.ID] = secret
+	}
+
+	return k, nil
+}
+
+// Load - read keyring from key file
+func Load(path string) (*Keyring, error) {
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+// Init - load keyring from `secrets.encryption.key_file`, secrets are stored
+// as plain data when key file is not set
+func Init() error {
+
+	path := viper.GetString("secrets.encryption.key_file")
+	if path == types.EmptyString {
+		log.V(logLevel).Debugf("%s:init:> secrets encryption is disabled", logPrefix)
+		Set(nil)
+		return nil
+	}
+
+	k, err := Load(path)
+	if err != nil {
+		return err
+	}
+
+	log.V(logLevel).Debugf("%s:init:> secrets are encrypted with key %s", logPrefix, k.Primary())
+
+	Set(k)
+	return nil
+}
+
+// Get - configured keyring, nil if encryption is disabled
+func Get() *Keyring {
+	lock.RLock()
+	defer lock.RUnlock()
+	return keyring
+}
+
+// Set - replace configured keyring
+func Set(k *Keyring) {
+	lock.Lock()
+	defer lock.Unlock()
+	keyring = k
+}
+
+// Encrypt - seal secret data with configured keyring, secret is not changed if encryption is disabled
+func Encrypt(secret *types.Secret) error {
+
+	k := Get()
+	if k == nil || secret.Spec.Envelope != nil {
+		return nil
+	}
+
+	return k.Seal(secret)
+}
+
+// Decrypt - open secret envelope with configured keyring, plain secret is not changed
+func Decrypt(secret *types.Secret) error {
+
+	if secret.Spec.Envelope == nil {
+		return nil
+	}
+
+	k := Get()
+	if k == nil {
+		return ErrDisabled
+	}
+
+	return k.Open(secret)
+}
+
+// Current - check if secret is stored in form configured keyring produces:
+// encrypted with primary key, or plain when encryption is disabled
+func Current(secret *types.Secret) bool {
+
+	k := Get()
+	if k == nil {
+		return secret.Spec.Envelope == nil
+	}
+
+	return secret.Spec.Envelope != nil && secret.Spec.Envelope.Key == k.Primary()
+}
@@ -0,0 +1,163 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+
+	key := getKeyAsset(t)
+
+	tests := []struct {
+		name    string
+		data    string
+		primary string
+		wantErr bool
+	}{
+		{
+			name:    "first key is primary",
+			data:    fmt.Sprintf("keys:\n- id: new\n  secret: %s\n- id: old\n  secret: %s\n", key, key),
+			primary: "new",
+		},
+		{
+			name:    "empty key file",
+			data:    "keys: []\n",
+			wantErr: true,
+		},
+		{
+			name:    "short key",
+			data:    fmt.Sprintf("keys:\n- id: new\n  secret: %s\n", base64.StdEncoding.EncodeToString([]byte("short"))),
+			wantErr: true,
+		},
+		{
+			name:    "key without id",
+			data:    fmt.Sprintf("keys:\n- secret: %s\n", key),
+			wantErr: true,
+		},
+		{
+			name:    "duplicated key id",
+			data:    fmt.Sprintf("keys:\n- id: new\n  secret: %s\n- id: new\n  secret: %s\n", key, key),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, err := Parse([]byte(tc.data))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.primary, k.Primary())
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+
+	defer Set(nil)
+
+	var (
+		oldKey = getKeyAsset(t)
+		newKey = getKeyAsset(t)
+		data   = map[string][]byte{"password": []byte("registry password")}
+	)
+
+	old, err := Parse([]byte(fmt.Sprintf("keys:\n- id: old\n  secret: %s\n", oldKey)))
+	assert.NoError(t, err)
+
+	rotated, err := Parse([]byte(fmt.Sprintf("keys:\n- id: new\n  secret: %s\n- id: old\n  secret: %s\n", newKey, oldKey)))
+	assert.NoError(t, err)
+
+	// plain secret is not changed when encryption is disabled
+	Set(nil)
+	s := getSecretAsset(data)
+	assert.NoError(t, Encrypt(s))
+	assert.Nil(t, s.Spec.Envelope)
+	assert.Equal(t, data, s.Spec.Data)
+	assert.True(t, Current(s))
+
+	// secret data is replaced with envelope
+	Set(old)
+	assert.NoError(t, Encrypt(s))
+	if !assert.NotNil(t, s.Spec.Envelope) {
+		return
+	}
+	assert.Nil(t, s.Spec.Data)
+	assert.Equal(t, "old", s.Spec.Envelope.Key)
+	assert.True(t, Current(s))
+
+	raw, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(raw, []byte("registry password")), "stored secret contains plain data")
+
+	// secret encrypted with previous key is decrypted after rotation
+	Set(rotated)
+	assert.False(t, Current(s))
+
+	c := *s
+	assert.NoError(t, Decrypt(&c))
+	assert.Nil(t, c.Spec.Envelope)
+	assert.Equal(t, data, c.Spec.Data)
+
+	assert.NoError(t, Encrypt(&c))
+	assert.Equal(t, "new", c.Spec.Envelope.Key)
+	assert.True(t, Current(&c))
+
+	// envelope can not be opened as other secret
+	m := c
+	m.Meta.Name = "other"
+	assert.Equal(t, crypto.ErrDataInvalid, Decrypt(&m))
+
+	// secret encrypted with removed key can not be decrypted
+	Set(old)
+	n := c
+	assert.Equal(t, ErrKeyNotFound, Decrypt(&n))
+
+	// encrypted secret can not be read when encryption is disabled
+	Set(nil)
+	n = c
+	assert.Equal(t, ErrDisabled, Decrypt(&n))
+}
+
+func getKeyAsset(t *testing.T) string {
+	key, err := crypto.Random(crypto.KeySize)
+	assert.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func getSecretAsset(data map[string][]byte) *types.Secret {
+	s := new(types.Secret)
+	s.Meta.Name = "registry"
+	s.Meta.Namespace = "demo"
+	s.Spec.Type = types.KindSecretOpaque
+	s.Spec.Data = data
+	return s
+}
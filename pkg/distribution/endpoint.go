@@ -123,7 +123,7 @@ func (e *Endpoint) Update(endpoint *types.Endpoint, opts *types.EndpointUpdateOp
 	endpoint.Spec.Strategy.Bind = opts.BindStrategy
 
 	if err := e.storage.Set(e.context, e.storage.Collection().Endpoint(),
-		e.storage.Key().Endpoint(endpoint.Meta.Namespace, endpoint.Meta.Name), endpoint, nil); err != nil {
+		e.storage.Key().Endpoint(endpoint.Meta.Namespace, endpoint.Meta.Name), endpoint, updateOpts(endpoint.Runtime)); err != nil {
 		log.Errorf("%s:create:> distribution update endpoint: %s err: %v", logEndpointPrefix, endpoint.SelfLink(), err)
 		return nil, err
 	}
@@ -141,6 +141,29 @@ func (e *Endpoint) SetSpec(endpoint *types.Endpoint, spec *types.EndpointSpec) (
 	return endpoint, nil
 }
 
+func (e *Endpoint) SetStatus(endpoint *types.Endpoint, status *types.EndpointStatus) (*types.Endpoint, error) {
+	endpoint.Status = *status
+
+	key := e.storage.Key().Endpoint(endpoint.Meta.Namespace, endpoint.Meta.Name)
+
+	err := RetryOnConflict(func() error {
+		return e.storage.Set(e.context, e.storage.Collection().Endpoint(), key, endpoint, updateOpts(endpoint.Runtime))
+	}, func() error {
+		latest := new(types.Endpoint)
+		if err := e.storage.Get(e.context, e.storage.Collection().Endpoint(), key, latest, nil); err != nil {
+			return err
+		}
+		latest.Status = *status
+		*endpoint = *latest
+		return nil
+	})
+	if err != nil {
+		log.Errorf("%s:create:> distribution update endpoint status: %s err: %v", logEndpointPrefix, endpoint.SelfLink(), err)
+		return nil, err
+	}
+	return endpoint, nil
+}
+
 func (e *Endpoint) Remove(endpoint *types.Endpoint) error {
 	log.V(logLevel).Debugf("%s:remove:> remove endpoint %s", logEndpointPrefix, endpoint.Meta.Name)
 	if err := e.storage.Del(e.context, e.storage.Collection().Endpoint(),
@@ -181,6 +204,7 @@ func (e *Endpoint) Watch(ch chan types.EndpointEvent, rev *int64) error {
 					log.Errorf("%s:> parse data err: %v", logEndpointPrefix, err)
 					continue
 				}
+				endpoint.Runtime.System.Revision = e.System.Revision
 
 				res.Data = endpoint
 
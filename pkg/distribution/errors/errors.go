@@ -133,6 +133,14 @@ func (e *err) NotFound(err ...error) *Err {
 	}
 }
 
+func (e *err) Conflict(err ...error) *Err {
+	return &Err{
+		Code:   http.StatusText(http.StatusConflict),
+		origin: getError(joinNameAndMessage(e.s, "revision conflict"), err...),
+		http:   HTTP.getConflict(e.s),
+	}
+}
+
 func (e *err) NotUnique(attr string, err ...error) *Err {
 	return &Err{
 		Code:   StatusNotUnique,
@@ -52,6 +52,10 @@ func (Http) NotFound(w http.ResponseWriter, args ...string) {
 	HTTP.getNotFound(args...).send(w)
 }
 
+func (Http) Conflict(w http.ResponseWriter, args ...string) {
+	HTTP.getConflict(args...).send(w)
+}
+
 func (Http) InternalServerError(w http.ResponseWriter, msg ...string) {
 	HTTP.getInternalServerError(msg...).send(w)
 }
@@ -68,6 +72,14 @@ func (Http) NotImplemented(w http.ResponseWriter, msg ...string) {
 	HTTP.getPaymentRequired(msg...).send(w)
 }
 
+func (Http) TooManyRequests(w http.ResponseWriter, msg ...string) {
+	HTTP.getTooManyRequests(msg...).send(w)
+}
+
+func (Http) RequestEntityTooLarge(w http.ResponseWriter, msg ...string) {
+	HTTP.getRequestEntityTooLarge(msg...).send(w)
+}
+
 func (Http) BadParameter(w http.ResponseWriter, args ...string) {
 	HTTP.getBadParameter(args...).send(w)
 }
@@ -123,6 +135,14 @@ func (Http) getNotImplemented(msg ...string) *Http {
 	return getHttpError(http.StatusNotImplemented, msg...)
 }
 
+func (Http) getTooManyRequests(msg ...string) *Http {
+	return getHttpError(http.StatusTooManyRequests, msg...)
+}
+
+func (Http) getRequestEntityTooLarge(msg ...string) *Http {
+	return getHttpError(http.StatusRequestEntityTooLarge, msg...)
+}
+
 func (Http) getBadRequest(msg ...string) *Http {
 	return getHttpError(http.StatusBadRequest, msg...)
 }
@@ -144,6 +164,23 @@ func (Http) getNotFound(args ...string) *Http {
 	}
 }
 
+func (Http) getConflict(args ...string) *Http {
+	message := "Resource version conflict"
+	for i, a := range args {
+		switch i {
+		case 0:
+			message = fmt.Sprintf("%s was changed by another request, reload it and retry", toUpperFirstChar(a))
+		default:
+			panic("Wrong parameter count: (is allowed from 0 to 1)")
+		}
+	}
+	return &Http{
+		Code:    http.StatusConflict,
+		Status:  http.StatusText(http.StatusConflict),
+		Message: message,
+	}
+}
+
 func (Http) getNotUnique(name string) *Http {
 	return &Http{
 		Code:    http.StatusBadRequest,
@@ -26,11 +26,15 @@ const (
 	ErrEntityExists          = "entity exists"
 	ErrOperationFailure      = "operation failure"
 	ErrEntityNotFound        = "entity not found"
+	ErrEntityConflict        = "entity revision conflict"
 	ErrStructArgIsNil        = "input structure is nil"
 	ErrStructOutIsNil        = "output structure is nil"
 	ErrStructArgIsInvalid    = "input structure is invalid"
 	ErrStructOutIsInvalid    = "output structure is invalid"
 	ErrStructOutIsNotPointer = "output structure is not pointer"
+	ErrListContinueInvalid   = "list continue token is invalid"
+	ErrRevisionCompacted     = "requested revision is compacted"
+	ErrWatchOverflow         = "watch events queue overflow"
 )
 
 type storage struct{}
@@ -59,6 +63,14 @@ func (storage) NewErrEntityNotFound() error {
 	return errors.New(ErrEntityNotFound)
 }
 
+func (storage) IsErrEntityConflict(err error) bool {
+	return err.Error() == ErrEntityConflict
+}
+
+func (storage) NewErrEntityConflict() error {
+	return errors.New(ErrEntityConflict)
+}
+
 func (storage) IsErrStructArgIsNil(err error) bool {
 	return err.Error() == ErrStructArgIsNil
 }
@@ -94,6 +106,30 @@ func (storage) NewErrStructOutIsNotPointer() error {
 	return errors.New(ErrStructOutIsNotPointer)
 }
 
+func (storage) IsErrListContinueInvalid(err error) bool {
+	return err.Error() == ErrListContinueInvalid
+}
+
+func (storage) NewErrListContinueInvalid() error {
+	return errors.New(ErrListContinueInvalid)
+}
+
+func (storage) IsErrRevisionCompacted(err error) bool {
+	return err.Error() == ErrRevisionCompacted
+}
+
+func (storage) NewErrRevisionCompacted() error {
+	return errors.New(ErrRevisionCompacted)
+}
+
+func (storage) IsErrWatchOverflow(err error) bool {
+	return err.Error() == ErrWatchOverflow
+}
+
+func (storage) NewErrWatchOverflow() error {
+	return errors.New(ErrWatchOverflow)
+}
+
 func Storage() storage {
 	return storage{}
 }
@@ -26,3 +26,5 @@ const ResourcesRamLimitIsRequired = "resources ram limit is required"
 const ResourcesCpuLimitIsRequired = "resources cpu limit is required"
 const ResourcesRamLimitExceeded = "resources ram limit exceeded"
 const ResourcesCpuLimitExceeded = "resources cpu limit exceeded"
+const ResourcesRamRequestExceeded = "resources ram request exceeded"
+const ResourcesCpuRequestExceeded = "resources cpu request exceeded"
@@ -25,6 +25,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 )
 
 const (
@@ -47,6 +48,51 @@ func (n *Ingress) List() (*types.IngressList, error) {
 	return list, nil
 }
 
+// Select - list of ingresses matched by list options
+func (n *Ingress) Select(opts *types.ListOptions) (*types.IngressList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get ingresses list", logIngressPrefix)
+
+	list := types.NewIngressList()
+
+	err := n.storage.List(n.context, n.storage.Collection().Ingress().Info(), "", list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get ingresses list err: %v", logIngressPrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch ingresses changes matched by watch options
+func (n *Ingress) WatchSelect(ch chan types.IngressEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch ingresses", logIngressPrefix)
+
+	o := watchOpts(opts)
+	return watch(n.context, n.storage, n.storage.Collection().Ingress().Info(), o, func(e *st.WatcherEvent) {
+
+		res := types.IngressEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Ingress)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logIngressPrefix, err)
+			return
+		}
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-n.context.Done():
+		}
+	})
+}
+
 func (n *Ingress) Put(ingress *types.Ingress) error {
 
 	log.V(logLevel).Debugf("%s:create:> create ingress in cluster", logIngressPrefix)
@@ -28,6 +28,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 	"github.com/spf13/viper"
 )
 
@@ -70,6 +71,52 @@ func (n *Namespace) List() (*types.NamespaceList, error) {
 	return list, nil
 }
 
+// Select - list of namespaces matched by list options
+func (n *Namespace) Select(opts *types.ListOptions) (*types.NamespaceList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get namespaces list", logNamespacePrefix)
+
+	list := types.NewNamespaceList()
+
+	err := n.storage.List(n.context, n.storage.Collection().Namespace(), "", list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get namespaces list err: %v", logNamespacePrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch namespaces changes matched by watch options
+func (n *Namespace) WatchSelect(ch chan types.NamespaceEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch namespaces", logNamespacePrefix)
+
+	o := watchOpts(opts)
+	return watch(n.context, n.storage, n.storage.Collection().Namespace(), o, func(e *st.WatcherEvent) {
+
+		res := types.NamespaceEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Namespace)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logNamespacePrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-n.context.Done():
+		}
+	})
+}
+
 func (n *Namespace) Get(name string) (*types.Namespace, error) {
 
 	log.V(logLevel).Infof("%s:get:> get namespace %s", logNamespacePrefix, name)
@@ -115,7 +162,7 @@ func (n *Namespace) Update(namespace *types.Namespace) error {
 	log.V(logLevel).Debugf("%s:update:> update Namespace %#v", logNamespacePrefix, namespace)
 
 	if err := n.storage.Set(n.context, n.storage.Collection().Namespace(),
-		n.storage.Key().Namespace(namespace.Meta.Name), namespace, nil); err != nil {
+		n.storage.Key().Namespace(namespace.Meta.Name), namespace, updateOpts(namespace.Runtime)); err != nil {
 		log.V(logLevel).Errorf("%s:update:> namespace update err: %v", logNamespacePrefix, err)
 		return err
 	}
@@ -164,6 +211,7 @@ func (n *Namespace) Watch(ch chan types.NamespaceEvent) error {
 					log.Errorf("%s:watch:> parse json", logNamespacePrefix)
 					continue
 				}
+				obj.Runtime.System.Revision = e.System.Revision
 
 				res.Data = obj
 
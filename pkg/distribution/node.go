@@ -27,6 +27,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 )
 
 const (
@@ -51,6 +52,51 @@ func (n *Node) List() (*types.NodeList, error) {
 	return nodes, nil
 }
 
+// Select - list of nodes matched by list options
+func (n *Node) Select(opts *types.ListOptions) (*types.NodeList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get nodes list", logNodePrefix)
+
+	list := types.NewNodeList()
+
+	err := n.storage.List(n.context, n.storage.Collection().Node().Info(), "", list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get nodes list err: %v", logNodePrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch nodes changes matched by watch options
+func (n *Node) WatchSelect(ch chan types.NodeEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch nodes", logNodePrefix)
+
+	o := watchOpts(opts)
+	return watch(n.context, n.storage, n.storage.Collection().Node().Info(), o, func(e *st.WatcherEvent) {
+
+		res := types.NodeEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Node)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logNodePrefix, err)
+			return
+		}
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-n.context.Done():
+		}
+	})
+}
+
 func (n *Node) Put(opts *types.NodeCreateOptions) (*types.Node, error) {
 
 	log.V(logLevel).Debugf("%s:create:> create node in cluster", logNodePrefix)
@@ -105,6 +105,7 @@ func (p *Pod) Create(deployment *types.Deployment) (*types.Pod, error) {
 	}
 
 	pod.Spec.Selector = deployment.Spec.Selector
+	pod.Spec.Affinity = deployment.Spec.Affinity
 	if err := p.storage.Put(p.context, p.storage.Collection().Pod(),
 		p.storage.Key().Pod(pod.Meta.Namespace, pod.Meta.Service, pod.Meta.Deployment, pod.Meta.Name), pod, nil); err != nil {
 		log.Errorf("%s:create:> insert pod err %v", logPodPrefix, err)
@@ -185,7 +186,7 @@ func (p *Pod) Update(pod *types.Pod) error {
 
 	if err := p.storage.Set(p.context, p.storage.Collection().Pod(),
 		p.storage.Key().Pod(pod.Meta.Namespace, pod.Meta.Service, pod.Meta.Deployment, pod.Meta.Name),
-		pod, nil); err != nil {
+		pod, updateOpts(pod.Runtime)); err != nil {
 		log.Errorf("%s:update:> pod update err: %v", logPodPrefix, err)
 		return err
 	}
@@ -243,6 +244,7 @@ func (p *Pod) Watch(ch chan types.PodEvent, rev *int64) error {
 					log.Errorf("%s:watch:> parse json", logPodPrefix)
 					continue
 				}
+				obj.Runtime.System.Revision = e.System.Revision
 
 				res.Data = obj
 
@@ -0,0 +1,172 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package distribution
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
+)
+
+const (
+	logRolePrefix = "distribution:role"
+)
+
+type Role struct {
+	context context.Context
+	storage storage.Storage
+}
+
+// Get - get role by name
+func (r *Role) Get(name string) (*types.Role, error) {
+
+	log.V(logLevel).Debugf("%s:get:> get role %s", logRolePrefix, name)
+
+	item := new(types.Role)
+
+	err := r.storage.Get(r.context, r.storage.Collection().Role(), r.storage.Key().Role(name), &item, nil)
+	if err != nil {
+
+		if errors.Storage().IsErrEntityNotFound(err) {
+			return nil, nil
+		}
+
+		log.V(logLevel).Errorf("%s:get:> get role %s err: %v", logRolePrefix, name, err)
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// List - list of roles
+func (r *Role) List() (*types.RoleList, error) {
+
+	log.V(logLevel).Debugf("%s:list:> get roles list", logRolePrefix)
+
+	list := types.NewRoleList()
+
+	err := r.storage.List(r.context, r.storage.Collection().Role(), "", list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> get roles list err: %v", logRolePrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Select - list of roles matched by list options
+func (r *Role) Select(opts *types.ListOptions) (*types.RoleList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get roles list", logRolePrefix)
+
+	list := types.NewRoleList()
+
+	err := r.storage.List(r.context, r.storage.Collection().Role(), "", list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get roles list err: %v", logRolePrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch roles changes matched by watch options
+func (r *Role) WatchSelect(ch chan types.RoleEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch roles", logRolePrefix)
+
+	o := watchOpts(opts)
+	return watch(r.context, r.storage, r.storage.Collection().Role(), o, func(e *st.WatcherEvent) {
+
+		res := types.RoleEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Role)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logRolePrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-r.context.Done():
+		}
+	})
+}
+
+// Create - create new role
+func (r *Role) Create(role *types.Role) (*types.Role, error) {
+
+	log.V(logLevel).Debugf("%s:create:> create role %s", logRolePrefix, role.Meta.Name)
+
+	role.Meta.SetDefault()
+	role.SelfLink()
+
+	if err := r.storage.Put(r.context, r.storage.Collection().Role(),
+		r.storage.Key().Role(role.Meta.Name), role, nil); err != nil {
+		log.V(logLevel).Errorf("%s:create:> create role %s err: %v", logRolePrefix, role.Meta.Name, err)
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Update - update role
+func (r *Role) Update(role *types.Role) error {
+
+	log.V(logLevel).Debugf("%s:update:> update role %s", logRolePrefix, role.Meta.Name)
+
+	role.Meta.Updated = time.Now()
+
+	if err := r.storage.Set(r.context, r.storage.Collection().Role(),
+		r.storage.Key().Role(role.Meta.Name), role, updateOpts(role.Runtime)); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update role %s err: %v", logRolePrefix, role.Meta.Name, err)
+		return err
+	}
+
+	return nil
+}
+
+// Remove - remove role
+func (r *Role) Remove(role *types.Role) error {
+
+	log.V(logLevel).Debugf("%s:remove:> remove role %s", logRolePrefix, role.Meta.Name)
+
+	if err := r.storage.Del(r.context, r.storage.Collection().Role(), r.storage.Key().Role(role.Meta.Name)); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove role %s err: %v", logRolePrefix, role.Meta.Name, err)
+		return err
+	}
+
+	return nil
+}
+
+func NewRoleModel(ctx context.Context, stg storage.Storage) *Role {
+	return &Role{ctx, stg}
+}
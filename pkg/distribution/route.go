@@ -31,6 +31,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 )
 
 const (
@@ -86,6 +87,55 @@ func (r *Route) ListByNamespace(namespace string) (*types.RouteList, error) {
 	return list, nil
 }
 
+// Select - list of routes in namespace matched by list options
+func (r *Route) Select(namespace string, opts *types.ListOptions) (*types.RouteList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get routes list in namespace %s", logRoutePrefix, namespace)
+
+	q := r.storage.Filter().Route().ByNamespace(namespace)
+	list := types.NewRouteList()
+
+	err := r.storage.List(r.context, r.storage.Collection().Route(), q, list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get routes list in namespace %s err: %v", logRoutePrefix, namespace, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch routes changes matched by watch options
+func (r *Route) WatchSelect(namespace string, ch chan types.RouteEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch routes in namespace %s", logRoutePrefix, namespace)
+
+	o := watchOpts(opts)
+	o.Selector = o.Selector.Field("meta.namespace", namespace)
+
+	return watch(r.context, r.storage, r.storage.Collection().Route(), o, func(e *st.WatcherEvent) {
+
+		res := types.RouteEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Route)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logRoutePrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-r.context.Done():
+		}
+	})
+}
+
 func (r *Route) Get(namespace, name string) (*types.Route, error) {
 
 	log.V(logLevel).Debug("%s:get:> get route by id %s/%s", logRoutePrefix, namespace, name)
@@ -129,7 +179,7 @@ func (r *Route) Set(route *types.Route) (*types.Route, error) {
 	log.V(logLevel).Debugf("%s:update:> update route %s", logRoutePrefix, route.Meta.Name)
 
 	if err := r.storage.Set(r.context, r.storage.Collection().Route(),
-		r.storage.Key().Route(route.Meta.Namespace, route.Meta.Name), route, nil); err != nil {
+		r.storage.Key().Route(route.Meta.Namespace, route.Meta.Name), route, updateOpts(route.Runtime)); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update route err: %v", logRoutePrefix, err)
 		return nil, err
 	}
@@ -178,6 +228,7 @@ func (r *Route) Watch(ch chan types.RouteEvent, rev *int64) error {
 					log.Errorf("%s:> parse data err: %v", logRoutePrefix, err)
 					continue
 				}
+				route.Runtime.System.Revision = e.System.Revision
 
 				res.Data = route
 
@@ -21,10 +21,12 @@ package distribution
 import (
 	"context"
 	"encoding/json"
+	"github.com/lastbackend/lastbackend/pkg/distribution/encryption"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 )
 
 const (
@@ -54,6 +56,11 @@ func (n *Secret) Get(namespace, name string) (*types.Secret, error) {
 		return nil, err
 	}
 
+	if err := encryption.Decrypt(item); err != nil {
+		log.V(logLevel).Errorf("%s:get:> decrypt secret %s err: %v", logSecretPrefix, name, err)
+		return nil, err
+	}
+
 	return item, nil
 }
 
@@ -74,11 +81,75 @@ func (n *Secret) List(filter string) (*types.SecretList, error) {
 		return list, err
 	}
 
+	if err := decryptList(list); err != nil {
+		log.V(logLevel).Errorf("%s:list:> decrypt secrets err: %v", logSecretPrefix, err)
+		return list, err
+	}
+
 	log.V(logLevel).Debugf("%s:list:> get secrets list by namespace result: %d", logSecretPrefix, len(list.Items))
 
 	return list, nil
 }
 
+// Select - list of secrets in namespace matched by list options
+func (n *Secret) Select(namespace string, opts *types.ListOptions) (*types.SecretList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get secrets list in namespace %s", logSecretPrefix, namespace)
+
+	q := n.storage.Filter().Secret().ByNamespace(namespace)
+	list := types.NewSecretList()
+
+	err := n.storage.List(n.context, n.storage.Collection().Secret(), q, list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get secrets list in namespace %s err: %v", logSecretPrefix, namespace, err)
+		return nil, err
+	}
+
+	if err := decryptList(list); err != nil {
+		log.V(logLevel).Errorf("%s:select:> decrypt secrets err: %v", logSecretPrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch secrets changes matched by watch options
+func (n *Secret) WatchSelect(namespace string, ch chan types.SecretEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch secrets in namespace %s", logSecretPrefix, namespace)
+
+	o := watchOpts(opts)
+	o.Selector = o.Selector.Field("meta.namespace", namespace)
+
+	return watch(n.context, n.storage, n.storage.Collection().Secret(), o, func(e *st.WatcherEvent) {
+
+		res := types.SecretEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Secret)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logSecretPrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		if err := encryption.Decrypt(obj); err != nil {
+			log.Errorf("%s:watchselect:> decrypt secret err: %v", logSecretPrefix, err)
+			return
+		}
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-n.context.Done():
+		}
+	})
+}
+
 
 func (n *Secret) Create(namespace *types.Namespace, secret *types.Secret) (*types.Secret, error) {
 
@@ -88,12 +159,19 @@ func (n *Secret) Create(namespace *types.Namespace, secret *types.Secret) (*type
 	secret.Meta.Namespace = namespace.Meta.Name
 	secret.SelfLink()
 
+	item, err := sealed(secret)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> encrypt secret err: %v", logSecretPrefix, err)
+		return nil, err
+	}
+
 	if err := n.storage.Put(n.context, n.storage.Collection().Secret(),
-		n.storage.Key().Secret(secret.Meta.Namespace, secret.Meta.Name), secret, nil); err != nil {
+		n.storage.Key().Secret(secret.Meta.Namespace, secret.Meta.Name), item, nil); err != nil {
 		log.V(logLevel).Errorf("%s:create:> insert secret err: %v", logSecretPrefix, err)
 		return nil, err
 	}
 
+	secret.Runtime = item.Runtime
 	return secret, nil
 }
 
@@ -102,12 +180,19 @@ func (n *Secret) Update(secret *types.Secret) (*types.Secret, error) {
 
 	log.V(logLevel).Debugf("%s:update:> update secret %s", logSecretPrefix, secret.Meta.Name)
 
+	item, err := sealed(secret)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:update:> encrypt secret err: %v", logSecretPrefix, err)
+		return nil, err
+	}
+
 	if err := n.storage.Set(n.context, n.storage.Collection().Secret(),
-		n.storage.Key().Secret(secret.Meta.Namespace, secret.Meta.Name), secret, nil); err != nil {
+		n.storage.Key().Secret(secret.Meta.Namespace, secret.Meta.Name), item, updateOpts(secret.Runtime)); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update secret err: %s", logSecretPrefix, err)
 		return nil, err
 	}
 
+	secret.Runtime = item.Runtime
 	return secret, nil
 }
 
@@ -153,6 +238,12 @@ func (n *Secret) Watch(ch chan types.SecretEvent, rev *int64) error {
 					log.Errorf("%s:> parse data err: %v", logSecretPrefix, err)
 					continue
 				}
+				secret.Runtime.System.Revision = e.System.Revision
+
+				if err := encryption.Decrypt(secret); err != nil {
+					log.Errorf("%s:> decrypt secret err: %v", logSecretPrefix, err)
+					continue
+				}
 
 				res.Data = secret
 
@@ -169,6 +260,79 @@ func (n *Secret) Watch(ch chan types.SecretEvent, rev *int64) error {
 	return nil
 }
 
+// Rotate - encrypt stored secrets with primary key of configured keyring:
+// secrets encrypted with previous keys or stored as plain data are encrypted again.
+// Returns count of encrypted secrets
+func (n *Secret) Rotate() (int, error) {
+
+	log.V(logLevel).Debugf("%s:rotate:> encrypt secrets with current key", logSecretPrefix)
+
+	if encryption.Get() == nil {
+		return 0, encryption.ErrDisabled
+	}
+
+	list := types.NewSecretList()
+	if err := n.storage.List(n.context, n.storage.Collection().Secret(), types.EmptyString, list, nil); err != nil {
+		log.V(logLevel).Errorf("%s:rotate:> get secrets list err: %v", logSecretPrefix, err)
+		return 0, err
+	}
+
+	var count int
+
+	for _, item := range list.Items {
+
+		if encryption.Current(item) {
+			continue
+		}
+
+		key := n.storage.Key().Secret(item.Meta.Namespace, item.Meta.Name)
+
+		err := RetryOnConflict(func() error {
+
+			if err := encryption.Decrypt(item); err != nil {
+				return err
+			}
+
+			if err := encryption.Encrypt(item); err != nil {
+				return err
+			}
+
+			return n.storage.Set(n.context, n.storage.Collection().Secret(), key, item, updateOpts(item.Runtime))
+		}, func() error {
+			item = new(types.Secret)
+			return n.storage.Get(n.context, n.storage.Collection().Secret(), key, item, nil)
+		})
+		if err != nil {
+			log.V(logLevel).Errorf("%s:rotate:> encrypt secret %s err: %v", logSecretPrefix, key, err)
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// sealed - copy of secret with data encrypted for storage
+func sealed(secret *types.Secret) (*types.Secret, error) {
+
+	item := *secret
+	if err := encryption.Encrypt(&item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func decryptList(list *types.SecretList) error {
+	for _, item := range list.Items {
+		if err := encryption.Decrypt(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func NewSecretModel(ctx context.Context, stg storage.Storage) *Secret {
 	return &Secret{ctx, stg}
 }
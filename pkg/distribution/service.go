@@ -27,6 +27,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 )
 
 const (
@@ -91,6 +92,55 @@ func (s *Service) List(namespace string) (*types.ServiceList, error) {
 	return list, nil
 }
 
+// Select - list of services in namespace matched by list options
+func (s *Service) Select(namespace string, opts *types.ListOptions) (*types.ServiceList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get services list in namespace %s", logServicePrefix, namespace)
+
+	q := s.storage.Filter().Service().ByNamespace(namespace)
+	list := types.NewServiceList()
+
+	err := s.storage.List(s.context, s.storage.Collection().Service(), q, list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get services list in namespace %s err: %v", logServicePrefix, namespace, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch services changes matched by watch options
+func (s *Service) WatchSelect(namespace string, ch chan types.ServiceEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch services in namespace %s", logServicePrefix, namespace)
+
+	o := watchOpts(opts)
+	o.Selector = o.Selector.Field("meta.namespace", namespace)
+
+	return watch(s.context, s.storage, s.storage.Collection().Service(), o, func(e *st.WatcherEvent) {
+
+		res := types.ServiceEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Service)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logServicePrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-s.context.Done():
+		}
+	})
+}
+
 // Create new service model in namespace
 func (s *Service) Create(namespace *types.Namespace, svc *types.Service) (*types.Service, error) {
 
@@ -122,7 +172,7 @@ func (s *Service) Update(service *types.Service) (*types.Service, error) {
 	log.V(logLevel).Debugf("%s:update:> %#v -> %#v", logServicePrefix, service)
 
 	if err := s.storage.Set(s.context, s.storage.Collection().Service(),
-		s.storage.Key().Service(service.Meta.Namespace, service.Meta.Name), service, nil); err != nil {
+		s.storage.Key().Service(service.Meta.Namespace, service.Meta.Name), service, updateOpts(service.Runtime)); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update service spec err: %v", logServicePrefix, err)
 		return nil, err
 	}
@@ -170,8 +220,23 @@ func (s *Service) Set(service *types.Service) error {
 
 	log.V(logLevel).Debugf("%s:setstatus:> set state for service %s", logServicePrefix, service.Meta.Name)
 
-	key := s.storage.Key().Service(service.Meta.Namespace, service.Meta.Name)
-	if err := s.storage.Set(s.context, s.storage.Collection().Service(), key, service, nil); err != nil {
+	var (
+		key    = s.storage.Key().Service(service.Meta.Namespace, service.Meta.Name)
+		status = service.Status
+	)
+
+	err := RetryOnConflict(func() error {
+		return s.storage.Set(s.context, s.storage.Collection().Service(), key, service, updateOpts(service.Runtime))
+	}, func() error {
+		latest := new(types.Service)
+		if err := s.storage.Get(s.context, s.storage.Collection().Service(), key, latest, nil); err != nil {
+			return err
+		}
+		latest.Status = status
+		*service = *latest
+		return nil
+	})
+	if err != nil {
 		log.Errorf("%s:setstatus:> set state for service %s err: %v", logServicePrefix, service.Meta.Name, err)
 		return err
 	}
@@ -208,6 +273,7 @@ func (s *Service) Watch(ch chan types.ServiceEvent, rev *int64) error {
 					log.Errorf("%s:> parse data err: %v", logServicePrefix, err)
 					continue
 				}
+				service.Runtime.System.Revision = e.System.Revision
 
 				res.Data = service
 
@@ -0,0 +1,70 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package types
+
+const (
+	// LabelApplySource - label with name of source the object was applied from
+	LabelApplySource = "apply.lastbackend/source"
+	// LabelApplyHash - label with hash of last applied object manifest
+	LabelApplyHash = "apply.lastbackend/hash"
+
+	ApplyStatusCreated   = "created"
+	ApplyStatusUpdated   = "updated"
+	ApplyStatusUnchanged = "unchanged"
+	ApplyStatusPruned    = "pruned"
+	ApplyStatusFailed    = "failed"
+)
+
+// ApplyKinds - kinds of objects supported by apply in dependency order
+var ApplyKinds = []string{KindNamespace, KindSecret, KindConfig, KindVolume, KindService, KindRoute}
+
+// ApplyLabels - set apply source and manifest hash labels to object labels
+func ApplyLabels(labels map[string]string, source, hash string) map[string]string {
+
+	l := make(map[string]string, len(labels)+2)
+	for k, v := range labels {
+		l[k] = v
+	}
+
+	l[LabelApplyHash] = hash
+	if source != EmptyString {
+		l[LabelApplySource] = source
+	}
+
+	return l
+}
+
+// ApplyResult - result of applying single manifest document
+type ApplyResult struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Status    string
+	Error     error
+}
+
+type ApplyResultList struct {
+	Items []*ApplyResult
+}
+
+func NewApplyResultList() *ApplyResultList {
+	dm := new(ApplyResultList)
+	dm.Items = make([]*ApplyResult, 0)
+	return dm
+}
@@ -0,0 +1,116 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AuditRecord - record of api mutation request or exec and port forward session
+type AuditRecord struct {
+	Runtime
+	// Record id, sortable by record time
+	ID string `json:"id"`
+	// Name of user performed request
+	User string `json:"user"`
+	// Request verb: create, update or delete, or session type: exec or portforward
+	Verb string `json:"verb"`
+	// Request http method
+	Method string `json:"method"`
+	// Request path
+	Path string `json:"path"`
+	// Request namespace, empty for cluster scoped requests
+	Namespace string `json:"namespace"`
+	// Sha256 hash of request body read by handler
+	BodyHash string `json:"body_hash"`
+	// Body hash covers only part of request body: body is larger than audit body limit or was not received
+	BodyTruncated bool `json:"body_truncated"`
+	// Response status code
+	Code int `json:"code"`
+	// Request time
+	Timestamp time.Time `json:"timestamp"`
+	// Exec or port forward session, empty for mutation requests
+	Session *AuditSession `json:"session,omitempty"`
+}
+
+// AuditSession - exec or port forward session started in service pod
+type AuditSession struct {
+	Service   string   `json:"service"`
+	Pod       string   `json:"pod"`
+	Container string   `json:"container,omitempty"`
+	Command   []string `json:"command,omitempty"`
+	Port      uint16   `json:"port,omitempty"`
+}
+
+type AuditRecordList struct {
+	Runtime
+	Items []*AuditRecord
+}
+
+// AuditFilter - audit records query options
+type AuditFilter struct {
+	Namespace string
+	From      time.Time
+	To        time.Time
+}
+
+// SetID - set record id from record time, ids of records are ordered by time
+func (a *AuditRecord) SetID(suffix string) {
+	a.ID = fmt.Sprintf("%020d-%s", a.Timestamp.UnixNano(), suffix)
+}
+
+// Match - check if record matches filter, zero filter fields are ignored
+func (f *AuditFilter) Match(a *AuditRecord) bool {
+	switch true {
+	case f.Namespace != "" && f.Namespace != a.Namespace:
+		return false
+	case !f.From.IsZero() && a.Timestamp.Before(f.From):
+		return false
+	case !f.To.IsZero() && a.Timestamp.After(f.To):
+		return false
+	}
+	return true
+}
+
+// Audit session types, used as record verb
+const (
+	AuditSessionExec        = "exec"
+	AuditSessionPortForward = "portforward"
+)
+
+// AuditVerb - verb of mutation request by http method,
+// empty string for requests not changing state
+func AuditVerb(method string) string {
+	switch method {
+	case http.MethodPost:
+		return VerbCreate
+	case http.MethodPut, http.MethodPatch:
+		return VerbUpdate
+	case http.MethodDelete:
+		return VerbDelete
+	}
+	return ""
+}
+
+func NewAuditRecordList() *AuditRecordList {
+	dm := new(AuditRecordList)
+	dm.Items = make([]*AuditRecord, 0)
+	return dm
+}
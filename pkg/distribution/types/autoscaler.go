@@ -0,0 +1,161 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultAutoscalerPeriod - default interval between autoscaler checks in seconds
+	DefaultAutoscalerPeriod = 15
+	// DefaultAutoscalerScaleDownWindow - default scale down stabilization window in seconds
+	DefaultAutoscalerScaleDownWindow = 300
+	// DefaultAutoscalerTolerance - utilization deviation from target in percents ignored by autoscaler
+	DefaultAutoscalerTolerance = 10
+)
+
+// swagger:ignore
+// Autoscaler - horizontal autoscaler of service replicas
+type Autoscaler struct {
+	Runtime
+	Meta   AutoscalerMeta   `json:"meta"`
+	Spec   AutoscalerSpec   `json:"spec"`
+	Status AutoscalerStatus `json:"status"`
+}
+
+type AutoscalerList struct {
+	Runtime
+	Items []*Autoscaler
+}
+
+// swagger:ignore
+// AutoscalerMeta - autoscaler meta data
+type AutoscalerMeta struct {
+	Meta
+	// Namespace name
+	Namespace string `json:"namespace"`
+	// Service name
+	Service string `json:"service"`
+	// Autoscaler self link
+	SelfLink string `json:"self_link"`
+}
+
+// AutoscalerSpec - autoscaler replicas bounds and utilization targets
+// swagger:model types_autoscaler_spec
+type AutoscalerSpec struct {
+	// Minimal replicas count
+	MinReplicas int `json:"min_replicas"`
+	// Maximal replicas count
+	MaxReplicas int `json:"max_replicas"`
+	// Target average cpu utilization of containers resources in percents
+	CPU int `json:"cpu"`
+	// Target average memory utilization of containers resources in percents
+	RAM int `json:"ram"`
+	// Stabilization windows
+	Stabilization AutoscalerSpecStabilization `json:"stabilization"`
+}
+
+// AutoscalerSpecStabilization - periods in seconds used to smooth replicas recommendations
+// swagger:model types_autoscaler_spec_stabilization
+type AutoscalerSpecStabilization struct {
+	// Scale up stabilization window
+	ScaleUp int `json:"scale_up"`
+	// Scale down stabilization window
+	ScaleDown int `json:"scale_down"`
+}
+
+// swagger:ignore
+// AutoscalerStatus - autoscaler last observed state
+type AutoscalerStatus struct {
+	// Current replicas count
+	Replicas int `json:"replicas"`
+	// Recommended replicas count
+	Desired int `json:"desired"`
+	// Current average cpu utilization in percents
+	CPU int `json:"cpu"`
+	// Current average memory utilization in percents
+	RAM int `json:"ram"`
+	// Autoscaler status message
+	Message string `json:"message"`
+	// Last replicas change time
+	Scaled time.Time `json:"scaled"`
+	// Last check time
+	Updated time.Time `json:"updated"`
+}
+
+func (a *Autoscaler) SelfLink() string {
+	if a.Meta.SelfLink == "" {
+		a.Meta.SelfLink = a.CreateSelfLink(a.Meta.Namespace, a.Meta.Service)
+	}
+	return a.Meta.SelfLink
+}
+
+func (a *Autoscaler) CreateSelfLink(namespace, service string) string {
+	return fmt.Sprintf("%s:%s", namespace, service)
+}
+
+// ServiceLink - link of service scaled by autoscaler
+func (a *Autoscaler) ServiceLink() string {
+	return fmt.Sprintf("%s:%s", a.Meta.Namespace, a.Meta.Service)
+}
+
+// Bound - fit replicas count into autoscaler limits
+func (s AutoscalerSpec) Bound(replicas int) int {
+
+	if s.MaxReplicas > 0 && replicas > s.MaxReplicas {
+		replicas = s.MaxReplicas
+	}
+
+	if replicas < s.MinReplicas {
+		replicas = s.MinReplicas
+	}
+
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	return replicas
+}
+
+// ScaleUpWindow - scale up stabilization window
+func (s AutoscalerSpecStabilization) ScaleUpWindow() time.Duration {
+	if s.ScaleUp < 0 {
+		return 0
+	}
+	return time.Duration(s.ScaleUp) * time.Second
+}
+
+// ScaleDownWindow - scale down stabilization window, 5 minutes by default, negative value disables it
+func (s AutoscalerSpecStabilization) ScaleDownWindow() time.Duration {
+	switch true {
+	case s.ScaleDown < 0:
+		return 0
+	case s.ScaleDown == 0:
+		return DefaultAutoscalerScaleDownWindow * time.Second
+	}
+	return time.Duration(s.ScaleDown) * time.Second
+}
+
+func NewAutoscalerList() *AutoscalerList {
+	dm := new(AutoscalerList)
+	dm.Items = make([]*Autoscaler, 0)
+	return dm
+}
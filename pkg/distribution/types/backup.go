@@ -0,0 +1,95 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// BackupVersion - version of backup archive format
+	BackupVersion = 1
+	// BackupManifestFile - archive entry with backup manifest, written first
+	BackupManifestFile = "manifest.json"
+
+	BackupCipher = "aes-256-gcm"
+	BackupKDF    = "scrypt"
+)
+
+var (
+	ErrBackupVersion         = errors.New("backup archive version is not supported")
+	ErrBackupManifest        = errors.New("backup archive manifest is missing")
+	ErrBackupPassphrase      = errors.New("backup archive is encrypted, passphrase is required")
+	ErrBackupStorageNotEmpty = errors.New("storage is not empty, restore is allowed only into empty storage")
+	// ErrBackupPassphraseRequired - secrets are encrypted in cluster, so they are exported only with passphrase
+	ErrBackupPassphraseRequired = errors.New("secrets encryption is enabled, passphrase is required to export secrets")
+)
+
+// BackupArchiveError - backup archive is invalid or can not be decrypted
+type BackupArchiveError struct {
+	Err error
+}
+
+func (e *BackupArchiveError) Error() string {
+	return "backup archive: " + e.Err.Error()
+}
+
+// swagger:ignore
+// BackupManifest - backup archive description
+type BackupManifest struct {
+	// Archive format version
+	Version int `json:"version"`
+	// Backup creation time
+	Created time.Time `json:"created"`
+	// Encryption of sensitive collections, nil when archive is not encrypted
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+	// Objects count by collection
+	Collections map[string]int `json:"collections"`
+}
+
+// swagger:ignore
+// BackupEncryption - passphrase encryption of sensitive collections
+type BackupEncryption struct {
+	Cipher string `json:"cipher"`
+	KDF    string `json:"kdf"`
+	Salt   []byte `json:"salt"`
+	// Encrypted collections
+	Collections []string `json:"collections"`
+}
+
+// Encrypted - check if collection objects are encrypted in archive
+func (e *BackupEncryption) Encrypted(collection string) bool {
+	if e == nil {
+		return false
+	}
+	for _, c := range e.Collections {
+		if c == collection {
+			return true
+		}
+	}
+	return false
+}
+
+// swagger:ignore
+// BackupOptions - backup and restore options
+type BackupOptions struct {
+	// Passphrase to encrypt or decrypt secrets, secrets are stored as plain json if empty
+	Passphrase string
+}
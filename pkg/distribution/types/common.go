@@ -58,7 +58,9 @@ type MetaUpdateOptions struct {
 }
 
 func (m *Meta) SetDefault() {
-	m.Labels = make(map[string]string, 0)
+	if m.Labels == nil {
+		m.Labels = make(map[string]string, 0)
+	}
 	m.Created = time.Now().UTC()
 	m.Updated = time.Now().UTC()
 }
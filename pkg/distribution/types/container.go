@@ -21,6 +21,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"time"
 
@@ -72,6 +73,57 @@ type Container struct {
 	Exec SpecTemplateContainerExec `json:"exec"`
 }
 
+// ContainerExec - command to execute inside running container
+type ContainerExec struct {
+	// Command with arguments to run
+	Command []string `json:"command"`
+	// Environments list
+	Envs []string `json:"envs"`
+	// Allocate pseudo terminal for command
+	Tty bool `json:"tty"`
+}
+
+// ContainerExecStream - streams attached to executed command,
+// command runs detached from streams if stream is nil
+type ContainerExecStream struct {
+	// Stdin - command input, not attached if nil
+	Stdin io.Reader
+	// Stdout - command output, with tty stderr is written to it too
+	Stdout io.Writer
+	// Stderr - command errors output
+	Stderr io.Writer
+	// Resize - terminal size changes, used with tty
+	Resize <-chan *TerminalSize
+}
+
+// TerminalSize - size of pseudo terminal in characters
+type TerminalSize struct {
+	Width  uint16 `json:"width"`
+	Height uint16 `json:"height"`
+}
+
+// ContainerLogsOptions - container logs stream options
+type ContainerLogsOptions struct {
+	Stdout     bool
+	Stderr     bool
+	Follow     bool
+	Timestamps bool
+	// Tail - number of lines from the end of logs, all lines are returned if zero
+	Tail int
+	// Since - show logs after this time, not limited if zero
+	Since time.Time
+	// Until - show logs before this time, not limited if zero
+	Until time.Time
+}
+
+// ContainerStats - container resources usage
+type ContainerStats struct {
+	// Used cpu in nanocores
+	CPU int64 `json:"cpu"`
+	// Used memory in bytes
+	RAM int64 `json:"ram"`
+}
+
 type Port struct {
 	// HostIP is the host IP Address
 	HostIP string `json:"host_ip"`
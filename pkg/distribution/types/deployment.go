@@ -20,6 +20,9 @@ package types
 
 import "fmt"
 
+// DefaultDeploymentRevisions - count of deployment revisions stored for service by default
+const DefaultDeploymentRevisions = 10
+
 type DeploymentMap struct {
 	Runtime
 	Items map[string]*Deployment
@@ -58,6 +61,7 @@ type DeploymentSpec struct {
 	Replicas int          `json:"replicas"`
 	State    SpecState    `json:"state"`
 	Selector SpecSelector `json:"selector"`
+	Affinity SpecAffinity `json:"affinity"`
 	Template SpecTemplate `json:"template"`
 }
 
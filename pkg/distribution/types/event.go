@@ -29,6 +29,7 @@ type event struct {
 	Action   string
 	Name     string
 	SelfLink string
+	Revision int64
 }
 
 type Event struct {
@@ -159,6 +160,16 @@ type NodeStatusEvent struct {
 	Data *NodeStatus
 }
 
+type UserEvent struct {
+	event
+	Data *User
+}
+
+type RoleEvent struct {
+	event
+	Data *Role
+}
+
 func (e *event) IsActionCreate() bool {
 	return e.Action == EventActionCreate
 }
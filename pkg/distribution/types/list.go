@@ -0,0 +1,39 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package types
+
+import "github.com/lastbackend/lastbackend/pkg/util/selector"
+
+// ListOptions - selector and pagination options of list requests
+type ListOptions struct {
+	// Selector - selects items by labels and fields
+	Selector *selector.Selector
+	// Limit - max count of items, zero means no limit
+	Limit int64
+	// Continue - token returned by previous list in Runtime.System.Continue
+	Continue string
+}
+
+// WatchOptions - selector and revision options of watch requests
+type WatchOptions struct {
+	// Selector - selects watched items by labels and fields
+	Selector *selector.Selector
+	// Revision - storage revision to resume watch from,
+	// items changed since revision are sent before new events
+	Revision *int64
+}
@@ -24,7 +24,6 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/util/resource"
-	"github.com/lastbackend/registry/pkg/distribution/types"
 )
 
 const (
@@ -45,6 +44,7 @@ type NamespaceList struct {
 
 // swagger:ignore
 type Namespace struct {
+	Runtime
 	Meta   NamespaceMeta   `json:"meta"`
 	Status NamespaceStatus `json:"status"`
 	Spec   NamespaceSpec   `json:"spec"`
@@ -79,7 +79,10 @@ type NamespaceStatus struct {
 }
 
 type NamespaceStatusResources struct {
+	// Sum of services resources limits
 	Allocated ResourceRequestItem `json:"usage"`
+	// Sum of services resources requests
+	Requested ResourceRequestItem `json:"requested"`
 }
 
 type ResourceRequest struct {
@@ -187,171 +190,194 @@ type ResourceRequestItemOption struct {
 	Storage *string `json:"storage"`
 }
 
+// AllocateResources - check requested resources fit into namespace quotas and allocate them
 func (n *Namespace) AllocateResources(resources ResourceRequest) error {
 
 	var (
-		availableRam int64
-		availableCpu int64
-
-		allocatedRam int64
-		allocatedCpu int64
-
-		requestedRam int64
-		requestedCpu int64
-
-		err error
+		limits    resourceQuota
+		request   resourceQuota
+		allocated resourceQuota
+		requested resourceQuota
+		reqLimits resourceQuota
+		reqQuota  resourceQuota
+		err       error
 	)
 
-	var handleErr = func(msg string, e error) error {
-		log.Errorf("allocate %s error: %s", msg, e.Error())
-		return e
+	if limits, err = newResourceQuota("ns limits", n.Spec.Resources.Limits); err != nil {
+		return err
 	}
 
-	if n.Spec.Resources.Limits.RAM != types.EmptyString {
-		availableRam, err = resource.DecodeMemoryResource(n.Spec.Resources.Limits.RAM)
-		if err != nil {
-			return handleErr("ns limit ram", err)
-		}
+	if request, err = newResourceQuota("ns request", n.Spec.Resources.Request); err != nil {
+		return err
 	}
 
-	if n.Spec.Resources.Limits.CPU != types.EmptyString {
-		availableCpu, err = resource.DecodeCpuResource(n.Spec.Resources.Limits.CPU)
-		if err != nil {
-			return handleErr("ns limit cpu", err)
-		}
+	if allocated, err = newResourceQuota("ns allocated", n.Status.Resources.Allocated); err != nil {
+		return err
 	}
 
-	if n.Status.Resources.Allocated.RAM != types.EmptyString {
-		allocatedRam, err = resource.DecodeMemoryResource(n.Status.Resources.Allocated.RAM)
-		if err != nil {
-			return handleErr("ns allocated ram", err)
-		}
+	if requested, err = newResourceQuota("ns requested", n.Status.Resources.Requested); err != nil {
+		return err
 	}
 
-	if n.Status.Resources.Allocated.CPU != types.EmptyString {
-		allocatedCpu, err = resource.DecodeCpuResource(n.Status.Resources.Allocated.CPU)
-		if err != nil {
-			return handleErr("ns allocated cpu", err)
-		}
+	if reqLimits, err = newResourceQuota("req limits", resources.Limits); err != nil {
+		return err
 	}
 
-	if resources.Limits.RAM != types.EmptyString {
-		requestedRam, err = resource.DecodeMemoryResource(resources.Limits.RAM)
-		if err != nil {
-			return handleErr("req limit ram", err)
-		}
+	if reqQuota, err = newResourceQuota("req request", resources.Request); err != nil {
+		return err
 	}
 
-	if resources.Limits.CPU != types.EmptyString {
-		requestedCpu, err = resource.DecodeCpuResource(resources.Limits.CPU)
-		if err != nil {
-			return handleErr("req limit cpu", err)
-		}
-	}
-
-	if availableRam > 0 && availableCpu > 0 {
-
-		if requestedRam == 0 {
+	if limits.ram > 0 {
+		if reqLimits.ram == 0 {
 			return errors.New(errors.ResourcesRamLimitIsRequired)
 		}
 
-		if requestedCpu == 0 {
-			return errors.New(errors.ResourcesCpuLimitIsRequired)
+		if allocated.ram+reqLimits.ram > limits.ram {
+			return errors.New(errors.ResourcesRamLimitExceeded)
 		}
+	}
 
-
-		if (availableRam - allocatedRam - requestedRam) <= 0 {
-			return errors.New(errors.ResourcesRamLimitExceeded)
+	if limits.cpu > 0 {
+		if reqLimits.cpu == 0 {
+			return errors.New(errors.ResourcesCpuLimitIsRequired)
 		}
 
-		if (availableCpu - allocatedCpu - requestedCpu) <= 0 {
+		if allocated.cpu+reqLimits.cpu > limits.cpu {
 			return errors.New(errors.ResourcesCpuLimitExceeded)
 		}
 	}
-	allocatedRam += requestedRam
-	allocatedCpu += requestedCpu
 
-	n.Status.Resources.Allocated.RAM = resource.EncodeMemoryResource(allocatedRam)
-	n.Status.Resources.Allocated.CPU = resource.EncodeCpuResource(allocatedCpu)
+	if request.ram > 0 && requested.ram+reqQuota.ram > request.ram {
+		return errors.New(errors.ResourcesRamRequestExceeded)
+	}
+
+	if request.cpu > 0 && requested.cpu+reqQuota.cpu > request.cpu {
+		return errors.New(errors.ResourcesCpuRequestExceeded)
+	}
+
+	n.Status.Resources.Allocated = allocated.add(reqLimits).item()
+	n.Status.Resources.Requested = requested.add(reqQuota).item()
 
 	return nil
 }
 
+// ReleaseResources - return resources allocated by service back to namespace
 func (n *Namespace) ReleaseResources(resources ResourceRequest) error {
 
 	var (
-		availableRam int64
-		availableCpu int64
-		allocatedRam int64
-		allocatedCpu int64
-		requestedRam int64
-		requestedCpu int64
-		err error
+		allocated resourceQuota
+		requested resourceQuota
+		reqLimits resourceQuota
+		reqQuota  resourceQuota
+		err       error
 	)
 
-	var handleErr = func(msg string, e error) error {
-		log.Errorf("allocate %s error: %s", msg, e.Error())
-		return e
+	if allocated, err = newResourceQuota("ns allocated", n.Status.Resources.Allocated); err != nil {
+		return err
 	}
 
-	if n.Spec.Resources.Limits.RAM != types.EmptyString {
-		availableRam, err = resource.DecodeMemoryResource(n.Spec.Resources.Limits.RAM)
-		if err != nil {
-			return handleErr("ns limit ram", err)
-		}
+	if requested, err = newResourceQuota("ns requested", n.Status.Resources.Requested); err != nil {
+		return err
 	}
 
-	if n.Spec.Resources.Limits.CPU != types.EmptyString {
-		availableCpu, err = resource.DecodeCpuResource(n.Spec.Resources.Limits.CPU)
-		if err != nil {
-			return handleErr("ns limit cpu", err)
-		}
+	if reqLimits, err = newResourceQuota("req limits", resources.Limits); err != nil {
+		return err
 	}
 
-	if n.Status.Resources.Allocated.RAM != types.EmptyString {
-		allocatedRam, err = resource.DecodeMemoryResource(n.Status.Resources.Allocated.RAM)
+	if reqQuota, err = newResourceQuota("req request", resources.Request); err != nil {
+		return err
+	}
+
+	n.Status.Resources.Allocated = allocated.sub(reqLimits).item()
+	n.Status.Resources.Requested = requested.sub(reqQuota).item()
+
+	return nil
+}
+
+// SetResources - set namespace resources usage calculated from services resources
+func (n *Namespace) SetResources(resources []ResourceRequest) error {
+
+	var (
+		allocated resourceQuota
+		requested resourceQuota
+	)
+
+	for _, r := range resources {
+
+		limits, err := newResourceQuota("req limits", r.Limits)
 		if err != nil {
-			return handleErr("ns allocated ram", err)
+			return err
 		}
-	}
 
-	if n.Status.Resources.Allocated.CPU != types.EmptyString {
-		allocatedCpu, err = resource.DecodeCpuResource(n.Status.Resources.Allocated.CPU)
+		request, err := newResourceQuota("req request", r.Request)
 		if err != nil {
-			return handleErr("ns allocated cpu", err)
+			return err
 		}
+
+		allocated = allocated.add(limits)
+		requested = requested.add(request)
 	}
 
-	if resources.Limits.RAM != types.EmptyString {
-		requestedRam, err = resource.DecodeMemoryResource(resources.Limits.RAM)
-		if err != nil {
-			return handleErr("req limit ram", err)
+	n.Status.Resources.Allocated = allocated.item()
+	n.Status.Resources.Requested = requested.item()
+
+	return nil
+}
+
+// resourceQuota - decoded resources values
+type resourceQuota struct {
+	ram int64
+	cpu int64
+}
+
+func newResourceQuota(name string, item ResourceRequestItem) (resourceQuota, error) {
+
+	var (
+		q   resourceQuota
+		err error
+	)
+
+	if item.RAM != EmptyString {
+		if q.ram, err = resource.DecodeMemoryResource(item.RAM); err != nil {
+			log.Errorf("allocate %s ram error: %s", name, err.Error())
+			return q, err
 		}
 	}
 
-	if resources.Limits.CPU != types.EmptyString {
-		requestedCpu, err = resource.DecodeCpuResource(resources.Limits.CPU)
-		if err != nil {
-			return handleErr("req limit cpu", err)
+	if item.CPU != EmptyString {
+		if q.cpu, err = resource.DecodeCpuResource(item.CPU); err != nil {
+			log.Errorf("allocate %s cpu error: %s", name, err.Error())
+			return q, err
 		}
 	}
 
-	if (allocatedRam+requestedRam) > availableRam && (availableRam > 0) {
-		allocatedRam = availableRam
-	} else {
-		allocatedRam -= requestedRam
+	return q, nil
+}
+
+func (q resourceQuota) add(r resourceQuota) resourceQuota {
+	return resourceQuota{ram: q.ram + r.ram, cpu: q.cpu + r.cpu}
+}
+
+func (q resourceQuota) sub(r resourceQuota) resourceQuota {
+
+	q.ram -= r.ram
+	if q.ram < 0 {
+		q.ram = 0
 	}
 
-	if (allocatedCpu+requestedCpu) > availableCpu && (availableRam > 0) {
-		allocatedCpu = availableCpu
-	} else {
-		allocatedCpu -= requestedCpu
+	q.cpu -= r.cpu
+	if q.cpu < 0 {
+		q.cpu = 0
 	}
 
-	n.Status.Resources.Allocated.RAM = resource.EncodeMemoryResource(allocatedRam)
-	n.Status.Resources.Allocated.CPU = resource.EncodeCpuResource(allocatedCpu)
+	return q
+}
 
-	return nil
+func (q resourceQuota) item() ResourceRequestItem {
+	return ResourceRequestItem{
+		RAM: resource.EncodeMemoryResource(q.ram),
+		CPU: resource.EncodeCpuResource(q.cpu),
+	}
 }
 
 func NewNamespaceList() *NamespaceList {
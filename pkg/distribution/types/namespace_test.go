@@ -0,0 +1,143 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceAllocateResources(t *testing.T) {
+
+	type suit struct {
+		name      string
+		spec      types.ResourceRequest
+		allocated types.ResourceRequestItem
+		request   types.ResourceRequest
+		err       string
+		usage     types.ResourceRequestItem
+	}
+
+	limits := types.ResourceRequest{
+		Request: types.ResourceRequestItem{RAM: "1GB", CPU: "1"},
+		Limits:  types.ResourceRequestItem{RAM: "2GB", CPU: "2"},
+	}
+
+	request := func(ram, cpu string) types.ResourceRequest {
+		return types.ResourceRequest{
+			Request: types.ResourceRequestItem{RAM: ram, CPU: cpu},
+			Limits:  types.ResourceRequestItem{RAM: ram, CPU: cpu},
+		}
+	}
+
+	var tests = []suit{
+		{
+			name:    "without quotas",
+			request: request("512MB", "0.5"),
+			usage:   types.ResourceRequestItem{RAM: "512MiB", CPU: "0.500"},
+		},
+		{
+			name:    "fits into quotas",
+			spec:    limits,
+			request: request("512MB", "0.5"),
+			usage:   types.ResourceRequestItem{RAM: "512MiB", CPU: "0.500"},
+		},
+		{
+			name:    "exactly fits into quotas",
+			spec:    limits,
+			request: request("1GB", "1"),
+			usage:   types.ResourceRequestItem{RAM: "1GiB", CPU: "1.000"},
+		},
+		{
+			name:    "ram limit is required",
+			spec:    limits,
+			request: types.ResourceRequest{Limits: types.ResourceRequestItem{CPU: "1"}},
+			err:     errors.ResourcesRamLimitIsRequired,
+		},
+		{
+			name:    "cpu limit is required",
+			spec:    limits,
+			request: types.ResourceRequest{Limits: types.ResourceRequestItem{RAM: "1GB"}},
+			err:     errors.ResourcesCpuLimitIsRequired,
+		},
+		{
+			name:      "ram limit exceeded",
+			spec:      limits,
+			allocated: types.ResourceRequestItem{RAM: "1.5GB", CPU: "0"},
+			request:   types.ResourceRequest{Limits: types.ResourceRequestItem{RAM: "1GB", CPU: "1"}},
+			err:       errors.ResourcesRamLimitExceeded,
+		},
+		{
+			name:      "cpu limit exceeded",
+			spec:      limits,
+			allocated: types.ResourceRequestItem{RAM: "0", CPU: "1.5"},
+			request:   types.ResourceRequest{Limits: types.ResourceRequestItem{RAM: "1GB", CPU: "1"}},
+			err:       errors.ResourcesCpuLimitExceeded,
+		},
+		{
+			name:    "ram request exceeded",
+			spec:    limits,
+			request: types.ResourceRequest{Request: types.ResourceRequestItem{RAM: "2GB"}, Limits: types.ResourceRequestItem{RAM: "2GB", CPU: "1"}},
+			err:     errors.ResourcesRamRequestExceeded,
+		},
+		{
+			name:    "cpu request exceeded",
+			spec:    limits,
+			request: types.ResourceRequest{Request: types.ResourceRequestItem{CPU: "2"}, Limits: types.ResourceRequestItem{RAM: "1GB", CPU: "2"}},
+			err:     errors.ResourcesCpuRequestExceeded,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			ns := new(types.Namespace)
+			ns.Spec.Resources = tc.spec
+			ns.Status.Resources.Allocated = tc.allocated
+
+			err := ns.AllocateResources(tc.request)
+			if tc.err != types.EmptyString {
+				if assert.Error(t, err, "error should be returned") {
+					assert.Equal(t, tc.err, err.Error(), "error mismatch")
+				}
+				assert.Equal(t, tc.allocated, ns.Status.Resources.Allocated, "allocated resources should not be changed")
+				return
+			}
+
+			if !assert.NoError(t, err, "error should not be returned") {
+				return
+			}
+
+			assert.Equal(t, tc.usage, ns.Status.Resources.Allocated, "allocated resources mismatch")
+			assert.Equal(t, tc.usage, ns.Status.Resources.Requested, "requested resources mismatch")
+
+			err = ns.ReleaseResources(tc.request)
+			if !assert.NoError(t, err, "error should not be returned") {
+				return
+			}
+
+			empty := types.ResourceRequestItem{RAM: "0B", CPU: "0.000"}
+			assert.Equal(t, empty, ns.Status.Resources.Allocated, "allocated resources should be released")
+			assert.Equal(t, empty, ns.Status.Resources.Requested, "requested resources should be released")
+		})
+	}
+}
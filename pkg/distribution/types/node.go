@@ -133,6 +133,18 @@ type NodeStatusInterfaceState struct {
 // swagger:model types_node_spec
 type NodeSpec struct {
 	Security NodeSecurity `json:"security"`
+	// Node is cordoned and excluded from scheduling
+	Unschedulable bool `json:"unschedulable"`
+	// Node is drained: pods are rescheduled to other nodes
+	Drain bool `json:"drain"`
+	// Pods without matching tolerations are not scheduled on node
+	Taints []NodeTaint `json:"taints"`
+}
+
+// swagger:model types_node_taint
+type NodeTaint struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 type NodeSecurity struct {
@@ -72,6 +72,7 @@ type PodSpec struct {
 	Local    bool         `json:"local,omitempty"`
 	State    SpecState    `json:"state"`
 	Selector SpecSelector `json:"selector"`
+	Affinity SpecAffinity `json:"affinity"`
 	Template SpecTemplate `json:"template" yaml:"template"`
 }
 
@@ -147,6 +148,19 @@ type PodContainer struct {
 	Binds []string `json:"-"`
 	// Pod container ports
 	Ports []*SpecTemplateContainerPort `json:"ports"`
+	// Pod container resources usage
+	Usage PodContainerUsage `json:"usage" yaml:"usage"`
+}
+
+// PodContainerUsage is a resources usage of pod container collected on node
+// swagger:model types_pod_container_usage
+type PodContainerUsage struct {
+	// Used cpu in nanocores
+	CPU int64 `json:"cpu" yaml:"cpu"`
+	// Used memory in bytes
+	RAM int64 `json:"ram" yaml:"ram"`
+	// Usage collection time
+	Updated time.Time `json:"updated" yaml:"updated"`
 }
 
 // PodContainer is a container of the pod
@@ -189,6 +203,8 @@ type PodContainerState struct {
 type PodContainerStateRestarted struct {
 	Count     int       `json:"count" yaml:"count"`
 	Restarted time.Time `json:"restarted" yaml:"restarted"`
+	// Start time of container run before last restart
+	Previous time.Time `json:"previous" yaml:"previous"`
 }
 
 // swagger:model types_pod_container_state_created
@@ -221,6 +237,30 @@ type PodContainerStateExit struct {
 	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
 }
 
+// SetStarted - mark container as started, start time of previous container run is kept
+// to get logs of previous container instance
+func (s *PodContainerState) SetStarted() {
+
+	var (
+		now       = time.Now().UTC()
+		restarted = s.Restarted
+	)
+
+	if !s.Started.Timestamp.IsZero() {
+		restarted.Count++
+		restarted.Restarted = now
+		restarted.Previous = s.Started.Timestamp
+	}
+
+	*s = PodContainerState{
+		Restarted: restarted,
+		Started: PodContainerStateStarted{
+			Started:   true,
+			Timestamp: now,
+		},
+	}
+}
+
 func (s *PodStatus) SetInitialized() {
 	s.State = StateProvision
 	s.Status = StatusInitialized
@@ -281,6 +321,53 @@ func (s *PodStatus) SetError(err error) {
 	s.Message = err.Error()
 }
 
+// Copy - returns pod status copy with own containers, steps and volumes maps,
+// so copy can be changed without affecting original status
+func (s *PodStatus) Copy() *PodStatus {
+
+	cp := *s
+
+	if s.Steps != nil {
+		cp.Steps = make(PodSteps, len(s.Steps))
+		for k, v := range s.Steps {
+			cp.Steps[k] = v
+		}
+	}
+
+	if s.Containers != nil {
+		cp.Containers = make(map[string]*PodContainer, len(s.Containers))
+		for k, v := range s.Containers {
+			c := *v
+			cp.Containers[k] = &c
+		}
+	}
+
+	if s.Volumes != nil {
+		cp.Volumes = make(map[string]*VolumeClaim, len(s.Volumes))
+		for k, v := range s.Volumes {
+			cp.Volumes[k] = v
+		}
+	}
+
+	return &cp
+}
+
+// Ready - pod is running and all pod containers are ready to receive traffic
+func (s *PodStatus) Ready() bool {
+
+	if s.State != StateReady || !s.Running {
+		return false
+	}
+
+	for _, c := range s.Containers {
+		if !c.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
 func NewPod() *Pod {
 	pod := new(Pod)
 	pod.Status = *NewPodStatus()
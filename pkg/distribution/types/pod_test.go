@@ -0,0 +1,75 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPodStatusCopy(t *testing.T) {
+
+	status := types.NewPodStatus()
+	status.SetRunning()
+	status.Steps["ready"] = types.PodStep{Ready: true}
+	status.Containers["c1"] = &types.PodContainer{ID: "c1", Ready: true}
+
+	cp := status.Copy()
+
+	cp.SetError(assert.AnError)
+	cp.Steps["ready"] = types.PodStep{}
+	cp.Containers["c1"].Ready = false
+	cp.Containers["c2"] = &types.PodContainer{ID: "c2"}
+
+	assert.Equal(t, types.StateReady, status.State, "status state should not be changed")
+	assert.True(t, status.Steps["ready"].Ready, "status steps should not be changed")
+	assert.True(t, status.Containers["c1"].Ready, "status container should not be changed")
+	assert.Len(t, status.Containers, 1, "status containers should not be changed")
+
+	assert.Equal(t, types.StateError, cp.State)
+	assert.False(t, cp.Containers["c1"].Ready)
+	assert.Len(t, cp.Containers, 2)
+}
+
+func TestPodContainerStateSetStarted(t *testing.T) {
+
+	state := new(types.PodContainerState)
+
+	state.SetStarted()
+	assert.True(t, state.Started.Started)
+	assert.Zero(t, state.Restarted.Count, "first start should not be counted as restart")
+	assert.True(t, state.Restarted.Previous.IsZero(), "first start should not have previous run")
+
+	first := state.Started.Timestamp
+	state.Started.Started = false
+	state.Stopped.Stopped = true
+
+	state.SetStarted()
+	assert.True(t, state.Started.Started)
+	assert.False(t, state.Stopped.Stopped)
+	assert.Equal(t, 1, state.Restarted.Count)
+	assert.Equal(t, first, state.Restarted.Previous, "previous run start should be kept")
+
+	second := state.Started.Timestamp
+	state.SetStarted()
+	assert.Equal(t, 2, state.Restarted.Count)
+	assert.Equal(t, second, state.Restarted.Previous, "only last previous run should be kept")
+}
@@ -0,0 +1,188 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package types
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	UserTypeUser           = "user"
+	UserTypeServiceAccount = "service_account"
+
+	VerbGet    = "get"
+	VerbList   = "list"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbDelete = "delete"
+
+	// RuleAny - matches any resource or verb in role rule
+	RuleAny = "*"
+)
+
+// swagger:ignore
+// User - api user or service account
+type User struct {
+	Runtime
+	Meta UserMeta `json:"meta"`
+	Spec UserSpec `json:"spec"`
+}
+
+type UserList struct {
+	Runtime
+	Items []*User
+}
+
+// swagger:ignore
+type UserMeta struct {
+	Meta
+}
+
+// swagger:ignore
+type UserSpec struct {
+	// User type: user or service account
+	Type string `json:"type"`
+	// Sha256 hash of user token
+	Token string `json:"token"`
+	// Roles bound to user
+	Bindings []UserBinding `json:"bindings"`
+}
+
+// UserBinding - binds role to user in namespace,
+// empty namespace binds role to whole cluster
+type UserBinding struct {
+	Role      string `json:"role"`
+	Namespace string `json:"namespace"`
+}
+
+// swagger:ignore
+// Role - set of rules granting verbs on resources
+type Role struct {
+	Runtime
+	Meta RoleMeta `json:"meta"`
+	Spec RoleSpec `json:"spec"`
+}
+
+type RoleList struct {
+	Runtime
+	Items []*Role
+}
+
+// swagger:ignore
+type RoleMeta struct {
+	Meta
+}
+
+// swagger:ignore
+type RoleSpec struct {
+	Rules []RoleRule `json:"rules"`
+}
+
+type RoleRule struct {
+	Resources []string `json:"resources"`
+	Verbs     []string `json:"verbs"`
+}
+
+func (u *User) SelfLink() string {
+	if u.Meta.SelfLink == "" {
+		u.Meta.SelfLink = fmt.Sprintf("%s", u.Meta.Name)
+	}
+	return u.Meta.SelfLink
+}
+
+// SetToken - store token hash in user spec
+func (u *User) SetToken(token string) {
+	u.Spec.Token = UserTokenHash(token)
+}
+
+// CheckToken - compare token with stored token hash
+func (u *User) CheckToken(token string) bool {
+	if u.Spec.Token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(u.Spec.Token), []byte(UserTokenHash(token))) == 1
+}
+
+// Allowed - check if user roles grant verb on resource in namespace,
+// empty namespace means cluster scoped resource
+func (u *User) Allowed(roles map[string]*Role, namespace, resource, verb string) bool {
+	for _, b := range u.Spec.Bindings {
+
+		if b.Namespace != "" && b.Namespace != namespace {
+			continue
+		}
+
+		role, ok := roles[b.Role]
+		if !ok || role == nil {
+			continue
+		}
+
+		if role.Spec.Allowed(resource, verb) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Role) SelfLink() string {
+	if r.Meta.SelfLink == "" {
+		r.Meta.SelfLink = fmt.Sprintf("%s", r.Meta.Name)
+	}
+	return r.Meta.SelfLink
+}
+
+// Allowed - check if any role rule grants verb on resource
+func (rs RoleSpec) Allowed(resource, verb string) bool {
+	for _, rule := range rs.Rules {
+		if ruleMatch(rule.Resources, resource) && ruleMatch(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleMatch(items []string, value string) bool {
+	for _, i := range items {
+		if i == RuleAny || i == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UserTokenHash - hash of user token kept in storage
+func UserTokenHash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+func NewUserList() *UserList {
+	dm := new(UserList)
+	dm.Items = make([]*User, 0)
+	return dm
+}
+
+func NewRoleList() *RoleList {
+	dm := new(RoleList)
+	dm.Items = make([]*Role, 0)
+	return dm
+}
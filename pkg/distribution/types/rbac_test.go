@@ -0,0 +1,123 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package types_test
+
+import (
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAllowed(t *testing.T) {
+
+	type suit struct {
+		name      string
+		bindings  []types.UserBinding
+		namespace string
+		resource  string
+		verb      string
+		allowed   bool
+	}
+
+	roles := map[string]*types.Role{
+		"viewer": {Spec: types.RoleSpec{Rules: []types.RoleRule{
+			{Resources: []string{types.KindService}, Verbs: []string{types.VerbGet, types.VerbList}},
+		}}},
+		"admin": {Spec: types.RoleSpec{Rules: []types.RoleRule{
+			{Resources: []string{types.RuleAny}, Verbs: []string{types.RuleAny}},
+		}}},
+	}
+
+	var tests = []suit{
+		{
+			name:      "no bindings",
+			namespace: "ns-a",
+			resource:  types.KindService,
+			verb:      types.VerbGet,
+		},
+		{
+			name:      "granted in bound namespace",
+			bindings:  []types.UserBinding{{Role: "viewer", Namespace: "ns-a"}},
+			namespace: "ns-a",
+			resource:  types.KindService,
+			verb:      types.VerbList,
+			allowed:   true,
+		},
+		{
+			name:      "verb not granted",
+			bindings:  []types.UserBinding{{Role: "viewer", Namespace: "ns-a"}},
+			namespace: "ns-a",
+			resource:  types.KindService,
+			verb:      types.VerbUpdate,
+		},
+		{
+			name:      "resource not granted",
+			bindings:  []types.UserBinding{{Role: "viewer", Namespace: "ns-a"}},
+			namespace: "ns-a",
+			resource:  types.KindSecret,
+			verb:      types.VerbGet,
+		},
+		{
+			name:      "other namespace",
+			bindings:  []types.UserBinding{{Role: "viewer", Namespace: "ns-a"}},
+			namespace: "ns-b",
+			resource:  types.KindService,
+			verb:      types.VerbGet,
+		},
+		{
+			name:     "cluster scope with namespaced binding",
+			bindings: []types.UserBinding{{Role: "admin", Namespace: "ns-a"}},
+			resource: types.KindNode,
+			verb:     types.VerbList,
+		},
+		{
+			name:      "cluster binding",
+			bindings:  []types.UserBinding{{Role: "admin"}},
+			namespace: "ns-b",
+			resource:  types.KindSecret,
+			verb:      types.VerbDelete,
+			allowed:   true,
+		},
+		{
+			name:     "unknown role",
+			bindings: []types.UserBinding{{Role: "unknown"}},
+			resource: types.KindNode,
+			verb:     types.VerbGet,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u := new(types.User)
+			u.Spec.Bindings = tc.bindings
+			assert.Equal(t, tc.allowed, u.Allowed(roles, tc.namespace, tc.resource, tc.verb))
+		})
+	}
+}
+
+func TestUserCheckToken(t *testing.T) {
+
+	u := new(types.User)
+	assert.False(t, u.CheckToken(""), "user without token")
+
+	u.SetToken("token")
+	assert.NotEqual(t, "token", u.Spec.Token, "token should be stored as hash")
+	assert.True(t, u.CheckToken("token"))
+	assert.False(t, u.CheckToken("other"))
+}
@@ -64,6 +64,19 @@ type SecretMeta struct {
 type SecretSpec struct {
 	Type string            `json:"type"`
 	Data map[string][]byte `json:"data" yaml:"data"`
+	// Encrypted data, stored instead of plain data when secrets encryption is enabled
+	Envelope *SecretEnvelope `json:"envelope,omitempty" yaml:"-"`
+}
+
+// SecretEnvelope - secret data encrypted with data key,
+// data key is encrypted with key encryption key
+type SecretEnvelope struct {
+	// Key encryption key id
+	Key string `json:"key"`
+	// Data key encrypted with key encryption key
+	DataKey []byte `json:"data_key"`
+	// Secret data encrypted with data key
+	Data []byte `json:"data"`
 }
 
 type SecretManifest struct {
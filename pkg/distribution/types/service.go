@@ -70,6 +70,7 @@ type ServiceSpec struct {
 	Network  SpecNetwork  `json:"network" yaml:"network" `
 	Strategy SpecStrategy `json:"strategy" yaml:"strategy"`
 	Selector SpecSelector `json:"selector" yaml:"selector"`
+	Affinity SpecAffinity `json:"affinity" yaml:"affinity"`
 	Template SpecTemplate `json:"template" yaml:"template"`
 }
 
@@ -23,6 +23,7 @@ import (
 	"io"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -320,11 +321,18 @@ type SpecTemplateContainerProbe struct {
 		Command []string `json:"command"`
 	} `json:"exec"`
 
+	// Open socket connection to check container liveness
 	Socket struct {
 		Protocol string `json:"protocol"`
 		Port     int    `json:"port"`
 	} `json:"socket"`
 
+	// Send http get request to check container liveness
+	HTTP struct {
+		Path string `json:"path"`
+		Port int    `json:"port"`
+	} `json:"http"`
+
 	InitialDelaySeconds int `json:"initial_delay"`
 	TimeoutSeconds      int `json:"timeout_seconds"`
 	PeriodSeconds       int `json:"period_seconds"`
@@ -332,6 +340,79 @@ type SpecTemplateContainerProbe struct {
 	ThresholdFailure    int `json:"threshold_failure"`
 }
 
+const (
+	ProbeExec   = "exec"
+	ProbeSocket = "socket"
+	ProbeHTTP   = "http"
+
+	// DefaultProbePeriod - seconds between probe executions by default
+	DefaultProbePeriod = 10
+	// DefaultProbeTimeout - seconds after which the probe times out by default
+	DefaultProbeTimeout = 1
+	// DefaultProbeThresholdFailure - consecutive failures to mark probe as failed by default
+	DefaultProbeThresholdFailure = 3
+)
+
+// Type - returns probe handler type or empty string if probe is not set
+func (p SpecTemplateContainerProbe) Type() string {
+	switch true {
+	case len(strings.Join(p.Exec.Command, "")) != 0:
+		return ProbeExec
+	case p.HTTP.Port > 0:
+		return ProbeHTTP
+	case p.Socket.Port > 0:
+		return ProbeSocket
+	}
+	return EmptyString
+}
+
+func (p SpecTemplateContainerProbe) InitialDelay() time.Duration {
+	if p.InitialDelaySeconds < 0 {
+		return 0
+	}
+	return time.Duration(p.InitialDelaySeconds) * time.Second
+}
+
+func (p SpecTemplateContainerProbe) Period() time.Duration {
+	if p.PeriodSeconds <= 0 {
+		return DefaultProbePeriod * time.Second
+	}
+	return time.Duration(p.PeriodSeconds) * time.Second
+}
+
+func (p SpecTemplateContainerProbe) Timeout() time.Duration {
+	if p.TimeoutSeconds <= 0 {
+		return DefaultProbeTimeout * time.Second
+	}
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
+
+func (p SpecTemplateContainerProbe) SuccessThreshold() int {
+	if p.ThresholdSuccess <= 0 {
+		return 1
+	}
+	return p.ThresholdSuccess
+}
+
+func (p SpecTemplateContainerProbe) FailureThreshold() int {
+	if p.ThresholdFailure <= 0 {
+		return DefaultProbeThresholdFailure
+	}
+	return p.ThresholdFailure
+}
+
+// Equal - compare probes specs
+func (p SpecTemplateContainerProbe) Equal(o SpecTemplateContainerProbe) bool {
+	return strings.Join(p.Exec.Command, " ") == strings.Join(o.Exec.Command, " ") &&
+		p.Socket == o.Socket &&
+		p.HTTP == o.HTTP &&
+		p.InitialDelaySeconds == o.InitialDelaySeconds &&
+		p.TimeoutSeconds == o.TimeoutSeconds &&
+		p.PeriodSeconds == o.PeriodSeconds &&
+		p.ThresholdSuccess == o.ThresholdSuccess &&
+		p.ThresholdFailure == o.ThresholdFailure
+}
+
 // swagger:model types_spec_template_container_security
 type SpecTemplateContainerSecurity struct {
 	// Start container in priveleged mode
@@ -387,14 +468,67 @@ type SpecTemplateRestartPolicy struct {
 
 // swagger:model types_spec_strategy
 type SpecStrategy struct {
-	Type           string                     `json:"type"` // Rolling
+	Type           string                     `json:"type"` // Rolling, Canary, BlueGreen
 	RollingOptions SpecStrategyRollingOptions `json:"rollingOptions"`
+	CanaryOptions  SpecStrategyCanaryOptions  `json:"canaryOptions"`
 	Resources      SpecStrategyResources      `json:"resources"`
 	Deadline       int                        `json:"deadline"`
+	// Number of stored deployment revisions
+	Revisions int `json:"revisions"`
+	// Requested action for canary and blue-green rollout: promote or abort
+	Action string `json:"action"`
 	// Spec updated time
 	Updated time.Time `json:"updated"`
 }
 
+const (
+	// StrategyRolling - replace pods of old deployment step by step within surge and unavailability budgets
+	StrategyRolling = "rolling"
+	// StrategyCanary - run part of replicas on new deployment until rollout is promoted or aborted
+	StrategyCanary = "canary"
+	// StrategyBlueGreen - run new deployment next to old one and switch endpoint upstreams on promote
+	StrategyBlueGreen = "bluegreen"
+
+	StrategyActionPromote = "promote"
+	StrategyActionAbort   = "abort"
+
+	// DefaultCanaryPercent - percent of replicas running on canary deployment by default
+	DefaultCanaryPercent = 10
+)
+
+// Rolling returns true if rolling update strategy is selected
+func (s *SpecStrategy) Rolling() bool {
+	return strings.EqualFold(s.Type, StrategyRolling)
+}
+
+// Canary returns true if canary strategy is selected
+func (s *SpecStrategy) Canary() bool {
+	return strings.EqualFold(s.Type, StrategyCanary)
+}
+
+// BlueGreen returns true if blue-green strategy is selected
+func (s *SpecStrategy) BlueGreen() bool {
+	return strings.EqualFold(s.Type, StrategyBlueGreen)
+}
+
+// Manual returns true if rollout waits for promote or abort action
+func (s *SpecStrategy) Manual() bool {
+	return s.Canary() || s.BlueGreen()
+}
+
+// Staged returns true if new deployment is rolled out next to active deployment
+func (s *SpecStrategy) Staged() bool {
+	return s.Rolling() || s.Manual()
+}
+
+// RevisionsLimit returns count of deployment revisions stored for service
+func (s *SpecStrategy) RevisionsLimit() int {
+	if s.Revisions <= 0 {
+		return DefaultDeploymentRevisions
+	}
+	return s.Revisions
+}
+
 // swagger:model types_spec_strategy_resources
 type SpecStrategyResources struct {
 }
@@ -408,6 +542,32 @@ type SpecStrategyRollingOptions struct {
 	MaxSurge       int `json:"max_surge"`
 }
 
+// swagger:model types_spec_strategy_canary
+type SpecStrategyCanaryOptions struct {
+	// Percent of replicas running on new deployment
+	Percent int `json:"percent"`
+}
+
+// Replicas returns replicas count of canary deployment, at least one replica is used
+func (s *SpecStrategyCanaryOptions) Replicas(total int) int {
+
+	percent := s.Percent
+	if percent <= 0 {
+		percent = DefaultCanaryPercent
+	}
+
+	replicas := (total*percent + 99) / 100
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	if replicas > total {
+		replicas = total
+	}
+
+	return replicas
+}
+
 // SpecTriggers is a list of spec triggers
 // swagger:model types_spec_trigger_list
 type SpecTriggers []SpecTrigger
@@ -421,10 +581,67 @@ type SpecSelector struct {
 	Labels map[string]string `json:"labels"`
 
 	Node string `json:"node"`
+	// Allow scheduling on nodes with matching taints
+	Tolerations []SpecToleration `json:"tolerations"`
 	// Spec updated time
 	Updated time.Time `json:"updated"`
 }
 
+// swagger:model types_spec_toleration
+type SpecToleration struct {
+	Key string `json:"key"`
+	// Empty value tolerates taint with any value
+	Value string `json:"value"`
+}
+
+// Tolerates returns true if node taint is tolerated by selector
+func (s *SpecSelector) Tolerates(taint NodeTaint) bool {
+	for _, t := range s.Tolerations {
+		if t.Key == taint.Key && (t.Value == EmptyString || t.Value == taint.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// SpecAffinity describes pods placement rules relative to other pods
+// swagger:model types_spec_affinity
+type SpecAffinity struct {
+	// Place pods in topology domains with pods of selected services
+	Affinity []SpecAffinityRule `json:"affinity" yaml:"affinity"`
+	// Do not place pods in topology domains with pods of selected services
+	AntiAffinity []SpecAffinityRule `json:"anti_affinity" yaml:"anti_affinity"`
+	// Spread service pods evenly across topology domains
+	Spread *SpecTopologySpread `json:"spread" yaml:"spread"`
+	// Spec updated time
+	Updated time.Time `json:"updated" yaml:"updated"`
+}
+
+// swagger:model types_spec_affinity_rule
+type SpecAffinityRule struct {
+	// Service name in the same namespace, empty means service itself
+	Service string `json:"service" yaml:"service"`
+	// Node label used as topology domain, empty means node
+	Topology string `json:"topology" yaml:"topology"`
+	// Required rule filters nodes, preferred rule only affects nodes score
+	Required bool `json:"required" yaml:"required"`
+}
+
+// swagger:model types_spec_topology_spread
+type SpecTopologySpread struct {
+	// Node label used as topology domain, for example: zone
+	Topology string `json:"topology" yaml:"topology"`
+	// Max allowed difference of service pods count between domains
+	MaxSkew int `json:"max_skew" yaml:"max_skew"`
+	// Required constraint filters nodes, preferred constraint only affects nodes score
+	Required bool `json:"required" yaml:"required"`
+}
+
+// Empty returns true if no placement rules provided
+func (s *SpecAffinity) Empty() bool {
+	return len(s.Affinity) == 0 && len(s.AntiAffinity) == 0 && s.Spread == nil
+}
+
 func (s *SpecTemplateContainerEnvs) ToLinuxFormat() []string {
 	env := make([]string, 0)
 
@@ -21,6 +21,7 @@ package types
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestSpecTemplateContainerPort_Parse(t *testing.T) {
@@ -85,4 +86,49 @@ func TestSpecTemplateContainerPort_Parse(t *testing.T) {
 		})
 	}
 
-}
\ No newline at end of file
+}
+func TestSpecTemplateContainerProbe(t *testing.T) {
+
+	type suit struct {
+		name     string
+		probe    SpecTemplateContainerProbe
+		kind     string
+		period   time.Duration
+		timeout  time.Duration
+		failures int
+	}
+
+	exec := SpecTemplateContainerProbe{}
+	exec.Exec.Command = []string{"cat", "/tmp/healthy"}
+	exec.ThresholdFailure = 1
+
+	socket := SpecTemplateContainerProbe{}
+	socket.Socket.Port = 80
+	socket.PeriodSeconds = 3
+	socket.TimeoutSeconds = 2
+
+	http := SpecTemplateContainerProbe{}
+	http.HTTP.Port = 8080
+	http.HTTP.Path = "/healthz"
+
+	empty := SpecTemplateContainerProbe{}
+	empty.Exec.Command = make([]string, 0)
+
+	var tests = []suit{
+		{"exec probe", exec, ProbeExec, DefaultProbePeriod * time.Second, DefaultProbeTimeout * time.Second, 1},
+		{"socket probe", socket, ProbeSocket, 3 * time.Second, 2 * time.Second, DefaultProbeThresholdFailure},
+		{"http probe", http, ProbeHTTP, DefaultProbePeriod * time.Second, DefaultProbeTimeout * time.Second, DefaultProbeThresholdFailure},
+		{"probe not set", empty, EmptyString, DefaultProbePeriod * time.Second, DefaultProbeTimeout * time.Second, DefaultProbeThresholdFailure},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.kind, tc.probe.Type(), "probe type mismatch")
+			assert.Equal(t, tc.period, tc.probe.Period(), "probe period mismatch")
+			assert.Equal(t, tc.timeout, tc.probe.Timeout(), "probe timeout mismatch")
+			assert.Equal(t, tc.failures, tc.probe.FailureThreshold(), "probe failure threshold mismatch")
+			assert.Equal(t, 1, tc.probe.SuccessThreshold(), "probe success threshold mismatch")
+			assert.True(t, tc.probe.Equal(tc.probe), "probe should be equal to itself")
+		})
+	}
+}
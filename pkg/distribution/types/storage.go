@@ -25,4 +25,6 @@ type Runtime struct {
 type RuntimeSystem struct {
 	Revision int64  `json:"-"`
 	Key      string `json:"-"`
+	// Continue - token to continue list from, empty if list is complete
+	Continue string `json:"-"`
 }
@@ -33,4 +33,13 @@ const (
 	KindEndpoint  = "endpoint"
 	KindConfig    = "config"
 	KindVolume    = "volume"
+
+	KindCluster    = "cluster"
+	KindIngress    = "ingress"
+	KindDeployment = "deployment"
+	KindAutoscaler = "autoscaler"
+	KindUser       = "user"
+	KindRole       = "role"
+	KindAudit      = "audit"
+	KindBackup     = "backup"
 )
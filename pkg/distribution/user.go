@@ -0,0 +1,191 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package distribution
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
+)
+
+const (
+	logUserPrefix = "distribution:user"
+)
+
+type User struct {
+	context context.Context
+	storage storage.Storage
+}
+
+// Get - get user by name
+func (u *User) Get(name string) (*types.User, error) {
+
+	log.V(logLevel).Debugf("%s:get:> get user %s", logUserPrefix, name)
+
+	item := new(types.User)
+
+	err := u.storage.Get(u.context, u.storage.Collection().User(), u.storage.Key().User(name), &item, nil)
+	if err != nil {
+
+		if errors.Storage().IsErrEntityNotFound(err) {
+			return nil, nil
+		}
+
+		log.V(logLevel).Errorf("%s:get:> get user %s err: %v", logUserPrefix, name, err)
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// GetByToken - find user by access token
+func (u *User) GetByToken(token string) (*types.User, error) {
+
+	log.V(logLevel).Debugf("%s:getbytoken:> find user by token", logUserPrefix)
+
+	list, err := u.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range list.Items {
+		if item.CheckToken(token) {
+			return item, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// List - list of users
+func (u *User) List() (*types.UserList, error) {
+
+	log.V(logLevel).Debugf("%s:list:> get users list", logUserPrefix)
+
+	list := types.NewUserList()
+
+	err := u.storage.List(u.context, u.storage.Collection().User(), "", list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> get users list err: %v", logUserPrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Select - list of users matched by list options
+func (u *User) Select(opts *types.ListOptions) (*types.UserList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get users list", logUserPrefix)
+
+	list := types.NewUserList()
+
+	err := u.storage.List(u.context, u.storage.Collection().User(), "", list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get users list err: %v", logUserPrefix, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch users changes matched by watch options
+func (u *User) WatchSelect(ch chan types.UserEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch users", logUserPrefix)
+
+	o := watchOpts(opts)
+	return watch(u.context, u.storage, u.storage.Collection().User(), o, func(e *st.WatcherEvent) {
+
+		res := types.UserEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.User)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logUserPrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-u.context.Done():
+		}
+	})
+}
+
+// Create - create new user
+func (u *User) Create(user *types.User) (*types.User, error) {
+
+	log.V(logLevel).Debugf("%s:create:> create user %s", logUserPrefix, user.Meta.Name)
+
+	user.Meta.SetDefault()
+	user.SelfLink()
+
+	if err := u.storage.Put(u.context, u.storage.Collection().User(),
+		u.storage.Key().User(user.Meta.Name), user, nil); err != nil {
+		log.V(logLevel).Errorf("%s:create:> create user %s err: %v", logUserPrefix, user.Meta.Name, err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Update - update user
+func (u *User) Update(user *types.User) error {
+
+	log.V(logLevel).Debugf("%s:update:> update user %s", logUserPrefix, user.Meta.Name)
+
+	user.Meta.Updated = time.Now()
+
+	if err := u.storage.Set(u.context, u.storage.Collection().User(),
+		u.storage.Key().User(user.Meta.Name), user, updateOpts(user.Runtime)); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update user %s err: %v", logUserPrefix, user.Meta.Name, err)
+		return err
+	}
+
+	return nil
+}
+
+// Remove - remove user
+func (u *User) Remove(user *types.User) error {
+
+	log.V(logLevel).Debugf("%s:remove:> remove user %s", logUserPrefix, user.Meta.Name)
+
+	if err := u.storage.Del(u.context, u.storage.Collection().User(), u.storage.Key().User(user.Meta.Name)); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove user %s err: %v", logUserPrefix, user.Meta.Name, err)
+		return err
+	}
+
+	return nil
+}
+
+func NewUserModel(ctx context.Context, stg storage.Storage) *User {
+	return &User{ctx, stg}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
 	"regexp"
 )
 
@@ -85,6 +86,55 @@ func (v *Volume) ListByNamespace(namespace string) (*types.VolumeList, error) {
 	return list, nil
 }
 
+// Select - list of volumes in namespace matched by list options
+func (v *Volume) Select(namespace string, opts *types.ListOptions) (*types.VolumeList, error) {
+
+	log.V(logLevel).Debugf("%s:select:> get volumes list in namespace %s", logVolumePrefix, namespace)
+
+	q := v.storage.Filter().Volume().ByNamespace(namespace)
+	list := types.NewVolumeList()
+
+	err := v.storage.List(v.context, v.storage.Collection().Volume(), q, list, listOpts(opts))
+	if err != nil {
+		log.V(logLevel).Errorf("%s:select:> get volumes list in namespace %s err: %v", logVolumePrefix, namespace, err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// WatchSelect - watch volumes changes matched by watch options
+func (v *Volume) WatchSelect(namespace string, ch chan types.VolumeEvent, opts *types.WatchOptions) error {
+
+	log.V(logLevel).Debugf("%s:watchselect:> watch volumes in namespace %s", logVolumePrefix, namespace)
+
+	o := watchOpts(opts)
+	o.Selector = o.Selector.Field("meta.namespace", namespace)
+
+	return watch(v.context, v.storage, v.storage.Collection().Volume(), o, func(e *st.WatcherEvent) {
+
+		res := types.VolumeEvent{}
+		res.Action = e.Action
+		res.Name = e.Name
+		res.SelfLink = e.SelfLink
+		res.Revision = e.System.Revision
+
+		obj := new(types.Volume)
+		if err := json.Unmarshal(e.Data.([]byte), obj); err != nil {
+			log.Errorf("%s:watchselect:> parse data err: %v", logVolumePrefix, err)
+			return
+		}
+		obj.Runtime.System.Revision = e.System.Revision
+
+		res.Data = obj
+
+		select {
+		case ch <- res:
+		case <-v.context.Done():
+		}
+	})
+}
+
 func (v *Volume) Create(namespace *types.Namespace, vol *types.Volume) (*types.Volume, error) {
 	log.V(logLevel).Debugf("%s:crete:> create volume %s", logVolumePrefix, vol.SelfLink())
 
@@ -106,7 +156,7 @@ func (v *Volume) Update(volume *types.Volume) error {
 	log.V(logLevel).Debugf("%s:update:> update volume %s", logVolumePrefix, volume.Meta.Name)
 
 	if err := v.storage.Set(v.context, v.storage.Collection().Volume(),
-		v.storage.Key().Volume(volume.Meta.Namespace, volume.Meta.Name), volume, nil); err != nil {
+		v.storage.Key().Volume(volume.Meta.Namespace, volume.Meta.Name), volume, updateOpts(volume.Runtime)); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update volume err: %v", logVolumePrefix, err)
 		return err
 	}
@@ -176,6 +226,7 @@ func (v *Volume) Watch(ch chan types.VolumeEvent, rev *int64) error {
 					log.Errorf("%s:> parse data err: %v", logServicePrefix, err)
 					continue
 				}
+				volume.Runtime.System.Revision = e.System.Revision
 
 				res.Data = volume
 
@@ -19,12 +19,19 @@
 package pod
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/node/envs"
 	"github.com/lastbackend/lastbackend/pkg/node/runtime"
-	"net/http"
+	"github.com/lastbackend/lastbackend/pkg/util/http/ws"
 )
 
 const logLevel = 2
@@ -57,15 +64,139 @@ func PodLogsH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, ok := p.Containers[c]; !ok {
+	container, ok := p.Containers[c]
+	if !ok {
 		log.Errorf("node:http:pod:get:> container not found")
 		errors.New("pod").NotFound().Http(w)
 		return
 	}
 
-	if err := runtime.PodLogs(r.Context(), c, true, w, done); err != nil {
+	opts := v1.Request().Service().LogsOptions()
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.Errorf("node:http:pod:get:> validation query params err: %s", e.Err())
+		e.Http(w)
+		return
+	}
+
+	lo := opts.GetContainerLogs()
+
+	// previous logs are logs written by container instance before last restart
+	if opts.Previous {
+		previous := container.State.Restarted.Previous
+		if previous.IsZero() {
+			errors.New("container").BadRequest("previous container logs not found").Http(w)
+			return
+		}
+		if lo.Since.Before(previous) {
+			lo.Since = previous
+		}
+		lo.Until = container.State.Started.Timestamp
+	}
+
+	if err := runtime.PodLogs(r.Context(), c, lo, w, done); err != nil {
 		log.Errorf("node:http:pod:get:> get pod logs err: %s", err.Error())
 	}
 
 	return
 }
+
+// PodExecH handler runs command in pod container, command streams are attached over websocket
+func PodExecH(w http.ResponseWriter, r *http.Request) {
+
+	log.V(logLevel).Debug("node:http:pod:exec:> exec command in pod container")
+
+	var (
+		c    = mux.Vars(r)["container"]
+		p    = envs.Get().GetState().Pods().GetPod(mux.Vars(r)["pod"])
+		opts = v1.Request().Service().ExecOptions()
+	)
+
+	if p == nil {
+		log.Errorf("node:http:pod:exec:> pod not found")
+		errors.New("pod").NotFound().Http(w)
+		return
+	}
+
+	if _, ok := p.Containers[c]; !ok {
+		log.Errorf("node:http:pod:exec:> container not found")
+		errors.New("pod").NotFound().Http(w)
+		return
+	}
+
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.Errorf("node:http:pod:exec:> validation query params err: %s", e.Err())
+		e.Http(w)
+		return
+	}
+
+	conn, err := ws.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("node:http:pod:exec:> set websocket upgrade err: %s", err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := ws.NewExecStream(conn, opts.Stdin)
+
+	// stop command streaming when client is gone
+	go func() {
+		select {
+		case <-stream.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	code, err := runtime.PodExec(ctx, c, opts.GetContainerExec(), stream.Stream())
+	if err := stream.Close(code, err); err != nil {
+		log.V(logLevel).Debugf("node:http:pod:exec:> close exec stream err: %s", err.Error())
+	}
+}
+
+// PodPortForwardH handler forwards tcp connections multiplexed over websocket to pod port
+func PodPortForwardH(w http.ResponseWriter, r *http.Request) {
+
+	log.V(logLevel).Debug("node:http:pod:portforward:> forward connections to pod port")
+
+	var (
+		p    = envs.Get().GetState().Pods().GetPod(mux.Vars(r)["pod"])
+		opts = v1.Request().Service().PortForwardOptions()
+	)
+
+	if p == nil {
+		log.Errorf("node:http:pod:portforward:> pod not found")
+		errors.New("pod").NotFound().Http(w)
+		return
+	}
+
+	if p.Network.PodIP == "" {
+		log.Errorf("node:http:pod:portforward:> pod ip not found")
+		errors.New("pod").BadRequest("pod network is not ready").Http(w)
+		return
+	}
+
+	if e := opts.DecodeAndValidate(r.URL.Query()); e != nil {
+		log.Errorf("node:http:pod:portforward:> validation query params err: %s", e.Err())
+		e.Http(w)
+		return
+	}
+
+	conn, err := ws.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("node:http:pod:portforward:> set websocket upgrade err: %s", err.Error())
+		return
+	}
+
+	address := net.JoinHostPort(p.Network.PodIP, strconv.Itoa(int(opts.Port)))
+
+	err = ws.ServeForward(conn, func() (net.Conn, error) {
+		return net.DialTimeout("tcp", address, 10*time.Second)
+	})
+	if err != nil {
+		log.V(logLevel).Debugf("node:http:pod:portforward:> forward stream closed with err: %s", err.Error())
+	}
+
+	_ = conn.Close()
+}
@@ -26,4 +26,6 @@ import (
 var Routes = []http.Route{
 	{Path: "/pod/{pod}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: PodGetH},
 	{Path: "/pod/{pod}/{container}/logs", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: PodLogsH},
+	{Path: "/pod/{pod}/{container}/exec", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: PodExecH},
+	{Path: "/pod/{pod}/portforward", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: PodPortForwardH},
 }
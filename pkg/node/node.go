@@ -104,6 +104,7 @@ func Daemon() {
 	r.Restore(ctx)
 	r.Subscribe(ctx)
 	r.Loop(ctx)
+	r.Metrics(ctx)
 
 	if viper.IsSet("node.manifest.dir") || viper.IsSet("dir") {
 
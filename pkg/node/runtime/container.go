@@ -80,6 +80,7 @@ func containerSubscribe(ctx context.Context) error {
 
 		switch c.State {
 		case types.StateDestroyed:
+			ProbeStop(container.ID)
 			state.DelContainer(container)
 			break
 		case types.StateCreated:
@@ -92,13 +93,7 @@ func containerSubscribe(ctx context.Context) error {
 			if container.State.Started.Started {
 				continue
 			}
-			container.State = types.PodContainerState{
-				Started: types.PodContainerStateStarted{
-					Started:   true,
-					Timestamp: time.Now().UTC(),
-				},
-			}
-			container.State.Stopped.Stopped = false
+			container.State.SetStarted()
 		case types.StatusStopped:
 			if container.State.Stopped.Stopped {
 				continue
@@ -0,0 +1,97 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+	"github.com/spf13/viper"
+)
+
+const (
+	logMetricsPrefix = "node:runtime:metrics:>"
+
+	defaultMetricsInterval = 15
+)
+
+// Metrics - collect pods containers resources usage and report it with pod status
+func (r *Runtime) Metrics(ctx context.Context) {
+
+	interval := viper.GetInt("runtime.metrics.interval")
+	if interval <= 0 {
+		interval = defaultMetricsInterval
+	}
+
+	log.V(logLevel).Debugf("%s start metrics collection every %d seconds", logMetricsPrefix, interval)
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				metricsCollect(ctx)
+			}
+		}
+	}()
+}
+
+func metricsCollect(ctx context.Context) {
+
+	state := envs.Get().GetState().Pods()
+
+	for key := range state.GetPods() {
+
+		// usage is collected into pod status copy, which replaces shared status in state
+		pod := state.GetPodCopy(key)
+		if pod == nil || !pod.Running {
+			continue
+		}
+
+		var changed bool
+
+		for _, c := range pod.Containers {
+
+			if c.ID == "" || !c.State.Started.Started {
+				continue
+			}
+
+			stats, err := envs.Get().GetCRI().Stats(ctx, c.ID)
+			if err != nil {
+				log.V(logLevel).Debugf("%s container %s stats err: %s", logMetricsPrefix, c.ID, err.Error())
+				continue
+			}
+
+			c.Usage.CPU = stats.CPU
+			c.Usage.RAM = stats.RAM
+			c.Usage.Updated = time.Now()
+			changed = true
+		}
+
+		if changed {
+			state.SetPod(key, pod)
+		}
+	}
+}
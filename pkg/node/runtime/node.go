@@ -21,8 +21,10 @@ package runtime
 import (
 	"context"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/node/envs"
 	"github.com/lastbackend/lastbackend/pkg/util/system"
+	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/mem"
 	"github.com/spf13/viper"
 	"syscall"
@@ -79,9 +81,13 @@ func NodeStatus() types.NodeStatus {
 
 func NodeCapacity() types.NodeResources {
 
+	var m uint64
+
 	vmStat, err := mem.VirtualMemory()
 	if err != nil {
-		_ = fmt.Errorf("get memory err: %s", err)
+		log.Errorf("get memory err: %s", err.Error())
+	} else {
+		m = vmStat.Total / 1024 / 1024
 	}
 
 	var stat syscall.Statfs_t
@@ -93,11 +99,16 @@ func NodeCapacity() types.NodeResources {
 	// Available blocks * size per block = available space in bytes
 	storage := stat.Bfree * uint64(stat.Bsize)
 
-	m := vmStat.Total / 1024 / 1024
+	// cpu capacity is reported in nano cpus to match containers resources requests
+	cores, err := cpu.Counts(true)
+	if err != nil {
+		log.Errorf("get cpu err: %s", err.Error())
+	}
 
 	return types.NodeResources{
 		Storage:    int64(storage / 1024 / 1024),
 		Memory:     int64(m),
+		Cpu:        cores * 1e9,
 		Pods:       int(m / MinContainerMemory),
 		Containers: int(m / MinContainerMemory),
 	}
@@ -105,12 +116,15 @@ func NodeCapacity() types.NodeResources {
 
 func NodeAllocation() types.NodeResources {
 
+	var m uint64
+
 	vmStat, err := mem.VirtualMemory()
 	if err != nil {
-		_ = fmt.Errorf("get memory err: %s", err)
+		log.Errorf("get memory err: %s", err.Error())
+	} else {
+		m = vmStat.Free / 1024 / 1024
 	}
 
-	m := vmStat.Free / 1024 / 1024
 	s := envs.Get().GetState().Pods()
 
 	return types.NodeResources{
@@ -144,6 +144,7 @@ func PodManage(ctx context.Context, key string, manifest *types.PodManifest) err
 			}
 			return PodRestart(ctx, key)
 		default:
+			podProbesRestore(key, manifest, p)
 			return nil
 		}
 	}
@@ -380,13 +381,16 @@ func PodCreate(ctx context.Context, key string, manifest *types.PodManifest) (*t
 			return status, err
 		}
 
-		c.Ready = true
+		// container with readiness probe becomes ready after probe succeeded
+		c.Ready = s.Probes.ReadProbe.Type() == types.EmptyString
 		c.State.Started = types.PodContainerStateStarted{
 			Started:   true,
 			Timestamp: time.Now().UTC(),
 		}
 		status.Containers[c.ID] = c
 		envs.Get().GetState().Pods().SetPod(key, status)
+
+		ProbeStart(key, c.ID, s.Probes)
 	}
 
 	status.SetRunning()
@@ -402,6 +406,7 @@ func PodCreate(ctx context.Context, key string, manifest *types.PodManifest) (*t
 func PodClean(ctx context.Context, status *types.PodStatus) {
 
 	for _, c := range status.Containers {
+		ProbeStop(c.ID)
 		log.V(logLevel).Debugf("%s remove unnecessary container: %s", logPodPrefix, c.ID)
 		if err := envs.Get().GetCRI().Remove(ctx, c.ID, true, true); err != nil {
 			log.Warnf("%s can-not remove unnecessary container %s: %s", logPodPrefix, c.ID, err)
@@ -530,7 +535,7 @@ func PodRestore(ctx context.Context) error {
 	return nil
 }
 
-func PodLogs(ctx context.Context, id string, follow bool, s io.Writer, doneChan chan bool) error {
+func PodLogs(ctx context.Context, id string, opts *types.ContainerLogsOptions, s io.Writer, doneChan chan bool) error {
 
 	log.V(logLevel).Debugf("%s get container [%s] logs streaming", logPodPrefix, id)
 
@@ -540,7 +545,7 @@ func PodLogs(ctx context.Context, id string, follow bool, s io.Writer, doneChan
 		done   = make(chan bool, 1)
 	)
 
-	req, err := cri.Logs(ctx, id, true, true, follow)
+	req, err := cri.Logs(ctx, id, opts)
 	if err != nil {
 		log.Errorf("%s error get logs stream %s", logPodPrefix, err)
 		return err
@@ -1016,6 +1021,21 @@ func podVolumeClaimRestore(key string, manifest *types.PodManifest) {
 	}
 }
 
+// PodExec - run command in pod container with attached streams and return command exit code
+func PodExec(ctx context.Context, id string, exec *types.ContainerExec, stream *types.ContainerExecStream) (int, error) {
+
+	log.V(logLevel).Debugf("%s exec command in container [%s]", logPodPrefix, id)
+
+	code, err := envs.Get().GetCRI().Exec(ctx, id, exec, stream)
+	if err != nil {
+		log.Errorf("%s exec command in container [%s] err: %s", logPodPrefix, id, err)
+		return code, err
+	}
+
+	log.V(logLevel).Debugf("%s command in container [%s] exited with code %d", logPodPrefix, id, code)
+	return code, nil
+}
+
 func podVolumeKeyCreate(pod, volume string) string {
 	return fmt.Sprintf("%s-%s", strings.Replace(pod, ":", "-", -1), volume)
 }
@@ -0,0 +1,265 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+)
+
+const logProbePrefix = "node:runtime:probe:>"
+
+type probe struct {
+	pod       string
+	container string
+	spec      types.SpecTemplateContainerProbes
+}
+
+// ProbeStart - run container liveness and readiness probes in background
+func ProbeStart(pod, container string, spec types.SpecTemplateContainerProbes) {
+
+	if spec.LiveProbe.Type() == types.EmptyString && spec.ReadProbe.Type() == types.EmptyString {
+		return
+	}
+
+	ProbeStop(container)
+
+	log.V(logLevel).Debugf("%s start container probes: %s", logProbePrefix, container)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	envs.Get().GetState().Probes().AddProbe(container, &types.NodeTask{Cancel: cancel})
+
+	p := &probe{pod: pod, container: container, spec: spec}
+
+	if spec.LiveProbe.Type() != types.EmptyString {
+		go p.loop(ctx, spec.LiveProbe, p.live)
+	}
+
+	if spec.ReadProbe.Type() != types.EmptyString {
+		go p.loop(ctx, spec.ReadProbe, p.ready)
+	}
+}
+
+// ProbeStop - stop container probes
+func ProbeStop(container string) {
+	task := envs.Get().GetState().Probes().GetProbe(container)
+	if task == nil {
+		return
+	}
+
+	log.V(logLevel).Debugf("%s stop container probes: %s", logProbePrefix, container)
+	task.Cancel()
+	envs.Get().GetState().Probes().DelProbe(container)
+}
+
+// loop executes probe every period and calls handler when success or failure threshold is reached.
+// If handler returns true probe waits initial delay before the next check
+func (p *probe) loop(ctx context.Context, spec types.SpecTemplateContainerProbe, handler func(ctx context.Context, success bool) bool) {
+
+	var (
+		success int
+		failure int
+		timer   = time.NewTimer(spec.InitialDelay())
+	)
+
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if err := p.check(ctx, spec); err != nil {
+			log.V(logLevel).Debugf("%s container %s probe failed: %s", logProbePrefix, p.container, err.Error())
+			success = 0
+			failure++
+		} else {
+			failure = 0
+			success++
+		}
+
+		var reset bool
+
+		switch true {
+		case success == spec.SuccessThreshold():
+			reset = handler(ctx, true)
+		case failure == spec.FailureThreshold():
+			reset = handler(ctx, false)
+		}
+
+		if reset {
+			success, failure = 0, 0
+			timer.Reset(spec.InitialDelay())
+			continue
+		}
+
+		timer.Reset(spec.Period())
+	}
+}
+
+// live restarts container when liveness probe failed
+func (p *probe) live(ctx context.Context, success bool) bool {
+
+	if success {
+		return false
+	}
+
+	log.V(logLevel).Debugf("%s liveness probe failed: restart container: %s", logProbePrefix, p.container)
+
+	if err := envs.Get().GetCRI().Restart(ctx, p.container, nil); err != nil {
+		log.Errorf("%s can not restart container %s: %s", logProbePrefix, p.container, err.Error())
+		return false
+	}
+
+	envs.Get().GetState().Pods().UpdateContainer(p.pod, p.container, func(c *types.PodContainer) bool {
+		c.Restart.Attempt++
+		if p.spec.ReadProbe.Type() != types.EmptyString {
+			c.Ready = false
+		}
+		return true
+	})
+
+	return true
+}
+
+// ready sets container ready flag by readiness probe result
+func (p *probe) ready(ctx context.Context, success bool) bool {
+
+	envs.Get().GetState().Pods().UpdateContainer(p.pod, p.container, func(c *types.PodContainer) bool {
+		if c.Ready == success {
+			return false
+		}
+
+		log.V(logLevel).Debugf("%s container %s readiness changed: %t", logProbePrefix, p.container, success)
+
+		c.Ready = success
+		return true
+	})
+
+	return false
+}
+
+func (p *probe) check(ctx context.Context, spec types.SpecTemplateContainerProbe) error {
+
+	ctx, cancel := context.WithTimeout(ctx, spec.Timeout())
+	defer cancel()
+
+	switch spec.Type() {
+	case types.ProbeExec:
+
+		code, err := envs.Get().GetCRI().Exec(ctx, p.container, &types.ContainerExec{
+			Command: spec.Exec.Command,
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		if code != 0 {
+			return fmt.Errorf("command exited with code %d", code)
+		}
+
+	case types.ProbeSocket:
+
+		addr, err := p.address(spec.Socket.Port)
+		if err != nil {
+			return err
+		}
+
+		protocol := strings.ToLower(spec.Socket.Protocol)
+		if protocol == types.EmptyString {
+			protocol = "tcp"
+		}
+
+		conn, err := net.DialTimeout(protocol, addr, spec.Timeout())
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+
+	case types.ProbeHTTP:
+
+		addr, err := p.address(spec.HTTP.Port)
+		if err != nil {
+			return err
+		}
+
+		path := spec.HTTP.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s%s", addr, path), nil)
+		if err != nil {
+			return err
+		}
+
+		res, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+
+		if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("http status code %d", res.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+func (p *probe) address(port int) (string, error) {
+	pod := envs.Get().GetState().Pods().GetPod(p.pod)
+	if pod == nil || pod.Network.PodIP == types.EmptyString {
+		return types.EmptyString, errors.New("pod ip not found")
+	}
+
+	return net.JoinHostPort(pod.Network.PodIP, strconv.Itoa(port)), nil
+}
+
+func podProbesRestore(key string, manifest *types.PodManifest, status *types.PodStatus) {
+
+	name := strings.Split(key, ":")
+
+	for _, c := range status.Containers {
+
+		if envs.Get().GetState().Probes().GetProbe(c.ID) != nil {
+			continue
+		}
+
+		for _, s := range manifest.Template.Containers {
+			if c.Name == fmt.Sprintf("%s-%s", name[len(name)-1], s.Name) {
+				ProbeStart(key, c.ID, s.Probes)
+				break
+			}
+		}
+	}
+}
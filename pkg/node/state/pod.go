@@ -66,7 +66,13 @@ func (s *PodState) GetContainersCount() int {
 
 func (s *PodState) GetPods() map[string]*types.PodStatus {
 	log.V(logLevel).Debugf("%s: get pods", logPodPrefix)
-	return s.pods
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pods := make(map[string]*types.PodStatus, len(s.pods))
+	for key, pod := range s.pods {
+		pods[key] = pod
+	}
+	return pods
 }
 
 func (s *PodState) SetPods(pods map[string]*types.PodStatus) {
@@ -89,6 +95,18 @@ func (s *PodState) GetPod(key string) *types.PodStatus {
 	return pod
 }
 
+// GetPodCopy returns copy of pod status, changed copy should be saved with SetPod
+func (s *PodState) GetPodCopy(key string) *types.PodStatus {
+	log.V(logLevel).Debugf("%s: get pod copy: %s", logPodPrefix, key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pod, ok := s.pods[key]
+	if !ok {
+		return nil
+	}
+	return pod.Copy()
+}
+
 func (s *PodState) AddPod(key string, pod *types.PodStatus) {
 	log.V(logLevel).Debugf("%s: add pod: %s: %s ", logPodPrefix, key, pod.Status)
 	s.SetPod(key, pod)
@@ -135,6 +153,30 @@ func (s *PodState) SetPod(key string, pod *types.PodStatus) {
 	s.dispatch(key)
 }
 
+// UpdateContainer - change pod container under state lock, so concurrent pod updates are not lost.
+// Pod watchers are notified if update returns true
+func (s *PodState) UpdateContainer(key, id string, update func(c *types.PodContainer) bool) bool {
+	log.V(logLevel).Debugf("%s: update pod %s container: %s", logPodPrefix, key, id)
+
+	s.lock.Lock()
+	pod, ok := s.pods[key]
+	if !ok {
+		s.lock.Unlock()
+		return false
+	}
+
+	c, ok := pod.Containers[id]
+	if !ok || !update(c) {
+		s.lock.Unlock()
+		return false
+	}
+
+	state(pod)
+	s.lock.Unlock()
+	s.dispatch(key)
+	return true
+}
+
 func (s *PodState) DelPod(key string) {
 	log.V(logLevel).Debugf("%s: del pod: %s", logPodPrefix, key)
 	s.lock.Lock()
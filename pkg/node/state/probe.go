@@ -0,0 +1,58 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package state
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"sync"
+)
+
+const logProbePrefix = "state:probe:>"
+
+type ProbeState struct {
+	lock   sync.RWMutex
+	probes map[string]types.NodeTask
+}
+
+func (s *ProbeState) AddProbe(id string, task *types.NodeTask) {
+	log.V(logLevel).Debugf("%s add cancel func container probes: %s", logProbePrefix, id)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.probes[id] = *task
+}
+
+func (s *ProbeState) GetProbe(id string) *types.NodeTask {
+	log.V(logLevel).Debugf("%s get cancel func container probes: %s", logProbePrefix, id)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if t, ok := s.probes[id]; ok {
+		return &t
+	}
+
+	return nil
+}
+
+func (s *ProbeState) DelProbe(id string) {
+	log.V(logLevel).Debugf("%s del cancel func container probes: %s", logProbePrefix, id)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.probes, id)
+}
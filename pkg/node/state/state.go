@@ -33,6 +33,7 @@ type State struct {
 	secrets   *SecretsState
 	endpoints *EndpointState
 	task      *TaskState
+	probes    *ProbeState
 	configs   *ConfigState
 }
 
@@ -68,6 +69,10 @@ func (s *State) Tasks() *TaskState {
 	return s.task
 }
 
+func (s *State) Probes() *ProbeState {
+	return s.probes
+}
+
 func (s *State) Configs() *ConfigState {
 	return s.configs
 }
@@ -108,6 +113,9 @@ func New() *State {
 		task: &TaskState{
 			tasks: make(map[string]types.NodeTask, 0),
 		},
+		probes: &ProbeState{
+			probes: make(map[string]types.NodeTask, 0),
+		},
 		configs: &ConfigState{
 			configs: make(map[string]*types.ConfigManifest, 0),
 		},
@@ -30,7 +30,9 @@ import (
 	"net"
 	"os/exec"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	libipvs "github.com/docker/libnetwork/ipvs"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
@@ -46,6 +48,8 @@ const (
 	logLevel      = 3
 	ifaceName     = "lb-ipvs"
 	ifaceDocker   = "docker0"
+	// default seconds to finish in-flight connections before backend is removed
+	defaultDrainTimeout = 30
 )
 
 // Proxy balancer
@@ -58,6 +62,12 @@ type Proxy struct {
 		external net.IP
 		internal net.IP
 	}
+	// backends which are draining before removal
+	drain struct {
+		lock    sync.Mutex
+		timeout time.Duration
+		timers  map[string]*time.Timer
+	}
 }
 
 type Service struct {
@@ -161,7 +171,8 @@ func (p *Proxy) Destroy(ctx context.Context, state *types.EndpointState) error {
 		return err
 	}
 
-	for _, svc := range svcs {
+	for id, svc := range svcs {
+		p.drainStop(id)
 		if err = p.ipvs.DelService(svc.srvc); err != nil {
 			log.Errorf("%s can not delete service: %s", logIPVSPrefix, err.Error())
 		}
@@ -199,6 +210,7 @@ func (p *Proxy) Update(ctx context.Context, state *types.EndpointState, spec *ty
 		// remove service which not exists in new spec
 		if _, ok := psvc[id]; !ok {
 			log.Debugf("%s delete service: %s", logIPVSPrefix, id)
+			p.drainStop(id)
 			if err := p.ipvs.DelService(svc.srvc); err != nil {
 				log.Errorf("%s can not remove service: %s", logIPVSPrefix, err.Error())
 			}
@@ -219,10 +231,8 @@ func (p *Proxy) Update(ctx context.Context, state *types.EndpointState, spec *ty
 			for did, dest := range csvc[id].dest {
 				log.Debugf("%s check service %s old backend exists %s", logIPVSPrefix, id, did)
 				if _, ok := svc.dest[did]; !ok {
-					log.Debugf("%s service %s backend delete %s", logIPVSPrefix, id, did)
-					if err := p.ipvs.DelDestination(svc.srvc, dest); err != nil {
-						log.Errorf("%s can not remove backend: %s", logIPVSPrefix, err.Error())
-					}
+					log.Debugf("%s service %s backend drain %s", logIPVSPrefix, id, did)
+					p.drainStart(id, did, svc.srvc, dest)
 				}
 			}
 		}
@@ -237,6 +247,12 @@ func (p *Proxy) Update(ctx context.Context, state *types.EndpointState, spec *ty
 				}
 			} else {
 				if _, ok := csvc[id].dest[did]; !ok {
+
+					// backend became available again while draining
+					if p.drainCancel(id, did, svc.srvc, dest) {
+						continue
+					}
+
 					log.Debugf("%s service %s backend create %s", logIPVSPrefix, id, did)
 					if err := p.ipvs.NewDestination(svc.srvc, dest); err != nil {
 						log.Errorf("%s can not add backend: %s", logIPVSPrefix, err.Error())
@@ -336,6 +352,11 @@ func (p *Proxy) getState(ctx context.Context) (map[string]*types.EndpointState,
 				break
 			}
 
+			// skip draining backends: they do not receive new connections
+			if dest.Weight == 0 {
+				continue
+			}
+
 			for _, hst := range endpoint.Upstreams {
 				if dest.Address.String() == hst {
 					f = true
@@ -375,6 +396,92 @@ func (p *Proxy) getState(ctx context.Context) (map[string]*types.EndpointState,
 	return el, nil
 }
 
+// drainStart - stop sending new connections to backend and remove it after drain timeout,
+// so in-flight connections can be finished
+func (p *Proxy) drainStart(id, did string, srvc *libipvs.Service, dest *libipvs.Destination) {
+
+	p.drain.lock.Lock()
+	defer p.drain.lock.Unlock()
+
+	var key = fmt.Sprintf("%s/%s", id, did)
+
+	if _, ok := p.drain.timers[key]; ok {
+		return
+	}
+
+	d := *dest
+	d.Weight = 0
+
+	if p.drain.timeout == 0 {
+		if err := p.ipvs.DelDestination(srvc, dest); err != nil {
+			log.Errorf("%s can not remove backend: %s", logIPVSPrefix, err.Error())
+		}
+		return
+	}
+
+	if err := p.ipvs.UpdateDestination(srvc, &d); err != nil {
+		log.Errorf("%s can not drain backend: %s", logIPVSPrefix, err.Error())
+		if err := p.ipvs.DelDestination(srvc, dest); err != nil {
+			log.Errorf("%s can not remove backend: %s", logIPVSPrefix, err.Error())
+		}
+		return
+	}
+
+	p.drain.timers[key] = time.AfterFunc(p.drain.timeout, func() {
+		p.drain.lock.Lock()
+		defer p.drain.lock.Unlock()
+
+		if _, ok := p.drain.timers[key]; !ok {
+			return
+		}
+		delete(p.drain.timers, key)
+
+		log.Debugf("%s service %s drained backend delete %s", logIPVSPrefix, id, did)
+		if err := p.ipvs.DelDestination(srvc, dest); err != nil {
+			log.Errorf("%s can not remove backend: %s", logIPVSPrefix, err.Error())
+		}
+	})
+}
+
+// drainCancel - return draining backend back to service
+// returns false if backend is not draining
+func (p *Proxy) drainCancel(id, did string, srvc *libipvs.Service, dest *libipvs.Destination) bool {
+
+	p.drain.lock.Lock()
+	defer p.drain.lock.Unlock()
+
+	var key = fmt.Sprintf("%s/%s", id, did)
+
+	t, ok := p.drain.timers[key]
+	if !ok {
+		return false
+	}
+
+	t.Stop()
+	delete(p.drain.timers, key)
+
+	log.Debugf("%s service %s backend restore %s", logIPVSPrefix, id, did)
+	if err := p.ipvs.UpdateDestination(srvc, dest); err != nil {
+		log.Errorf("%s can not restore backend: %s", logIPVSPrefix, err.Error())
+	}
+
+	return true
+}
+
+// drainStop - stop draining service backends, used when service is removed
+func (p *Proxy) drainStop(id string) {
+
+	p.drain.lock.Lock()
+	defer p.drain.lock.Unlock()
+
+	for key, t := range p.drain.timers {
+		if strings.HasPrefix(key, fmt.Sprintf("%s/", id)) {
+			t.Stop()
+			delete(p.drain.timers, key)
+		}
+	}
+}
+
 func (p *Proxy) addIpBindToLink(ip string, dest net.IP) error {
 
 	ipn := net.ParseIP(ip)
@@ -469,6 +576,12 @@ func New() (*Proxy, error) {
 
 	prx.ipvs = handler
 
+	prx.drain.timers = make(map[string]*time.Timer)
+	prx.drain.timeout = defaultDrainTimeout * time.Second
+	if viper.IsSet("runtime.cpi.drain") {
+		prx.drain.timeout = time.Duration(viper.GetInt("runtime.cpi.drain")) * time.Second
+	}
+
 	links, err := netlink.LinkList()
 	if err != nil {
 		return nil, err
@@ -20,10 +20,13 @@ package docker
 
 import (
 	"context"
+	"encoding/json"
 	docker "github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"io"
+	"io/ioutil"
 	"strconv"
 	"strings"
 	"time"
@@ -100,14 +103,156 @@ func (r *Runtime) Remove(ctx context.Context, ID string, clean bool, force bool)
 	})
 }
 
-func (r *Runtime) Logs(ctx context.Context, ID string, stdout, stderr, follow bool) (io.ReadCloser, error) {
-	return r.client.ContainerLogs(ctx, ID, docker.ContainerLogsOptions{
-		ShowStdout: stdout,
-		ShowStderr: stderr,
-		Follow:     follow,
-		Timestamps: true,
-		Details:    true,
+func (r *Runtime) Exec(ctx context.Context, ID string, exec *types.ContainerExec, stream *types.ContainerExecStream) (int, error) {
+
+	attach := stream != nil
+
+	e, err := r.client.ContainerExecCreate(ctx, ID, docker.ExecConfig{
+		Cmd:          exec.Command,
+		Env:          exec.Envs,
+		Tty:          exec.Tty,
+		AttachStdin:  attach && stream.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := r.client.ContainerExecAttach(ctx, e.ID, docker.ExecStartCheck{Tty: exec.Tty})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	// attached connection is not bound to context, close it to stop command streaming
+	// or waiting for command without streams, so probe timeout is applied
+	go func() {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			resp.Close()
+		}
+	}()
+
+	if attach {
+
+		if stream.Stdin != nil {
+			go func() {
+				if _, err := io.Copy(resp.Conn, stream.Stdin); err != nil {
+					log.V(logLevel).Debugf("Docker: exec stdin copy err: %s", err)
+				}
+				resp.CloseWrite()
+			}()
+		}
+
+		if exec.Tty && stream.Resize != nil {
+			go func() {
+				for {
+					select {
+					case <-done:
+						return
+					case size, ok := <-stream.Resize:
+						if !ok {
+							return
+						}
+						if err := r.client.ContainerExecResize(ctx, e.ID, docker.ResizeOptions{
+							Height: uint(size.Height),
+							Width:  uint(size.Width),
+						}); err != nil {
+							log.V(logLevel).Debugf("Docker: exec resize err: %s", err)
+						}
+					}
+				}
+			}()
+		}
+	}
+
+	// wait until command is finished
+	switch true {
+	case !attach:
+		_, err = io.Copy(ioutil.Discard, resp.Reader)
+	case exec.Tty:
+		_, err = io.Copy(stream.Stdout, resp.Reader)
+	default:
+		_, err = stdcopy.StdCopy(stream.Stdout, stream.Stderr, resp.Reader)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := r.client.ContainerExecInspect(ctx, e.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.ExitCode, nil
+}
+
+func (r *Runtime) Stats(ctx context.Context, ID string) (*types.ContainerStats, error) {
+
+	resp, err := r.client.ContainerStats(ctx, ID, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var info docker.StatsJSON
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+
+	stats := new(types.ContainerStats)
+	// page cache is not counted as memory used by container
+	if cache := info.MemoryStats.Stats["cache"]; info.MemoryStats.Usage > cache {
+		stats.RAM = int64(info.MemoryStats.Usage - cache)
+	}
+
+	var (
+		cpuDelta    = float64(info.CPUStats.CPUUsage.TotalUsage) - float64(info.PreCPUStats.CPUUsage.TotalUsage)
+		systemDelta = float64(info.CPUStats.SystemUsage) - float64(info.PreCPUStats.SystemUsage)
+		cpus        = float64(info.CPUStats.OnlineCPUs)
+	)
+
+	if cpus == 0 {
+		cpus = float64(len(info.CPUStats.CPUUsage.PercpuUsage))
+	}
+
+	// cpu usage in nanocores is a share of host cpu time used by container
+	if cpuDelta > 0 && systemDelta > 0 {
+		stats.CPU = int64(cpuDelta / systemDelta * cpus * 1e9)
+	}
+
+	return stats, nil
+}
+
+func (r *Runtime) Logs(ctx context.Context, ID string, opts *types.ContainerLogsOptions) (io.ReadCloser, error) {
+
+	o := docker.ContainerLogsOptions{
+		ShowStdout: opts.Stdout,
+		ShowStderr: opts.Stderr,
+		Follow:     opts.Follow,
+		Timestamps: opts.Timestamps,
+		Details:    true,
+		Tail:       "all",
+	}
+
+	if opts.Tail > 0 {
+		o.Tail = strconv.Itoa(opts.Tail)
+	}
+
+	if !opts.Since.IsZero() {
+		o.Since = opts.Since.Format(time.RFC3339Nano)
+	}
+
+	if !opts.Until.IsZero() {
+		o.Until = opts.Until.Format(time.RFC3339Nano)
+	}
+
+	return r.client.ContainerLogs(ctx, ID, o)
 }
 
 func (r *Runtime) Inspect(ctx context.Context, ID string) (*types.Container, error) {
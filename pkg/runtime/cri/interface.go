@@ -36,7 +36,9 @@ type CRI interface {
 	Resume(ctx context.Context, ID string) error
 	Remove(ctx context.Context, ID string, clean bool, force bool) error
 	Inspect(ctx context.Context, ID string) (*types.Container, error)
-	Logs(ctx context.Context, ID string, stdout, stderr, follow bool) (io.ReadCloser, error)
+	Exec(ctx context.Context, ID string, exec *types.ContainerExec, stream *types.ContainerExecStream) (int, error)
+	Logs(ctx context.Context, ID string, opts *types.ContainerLogsOptions) (io.ReadCloser, error)
+	Stats(ctx context.Context, ID string) (*types.ContainerStats, error)
 	Copy(ctx context.Context, ID, path string, content io.Reader) error
 	Subscribe(ctx context.Context, container chan *types.Container) error
 }
@@ -0,0 +1,425 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	dt "github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+	"github.com/lastbackend/lastbackend/pkg/util/converter"
+	"github.com/spf13/viper"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	logLevel  = 6
+	logPrefix = "storage:bolt"
+
+	keySeparator = "/"
+	defaultPath  = "/var/lib/lastbackend/storage.db"
+)
+
+// Storage - embedded storage on single data file
+type Storage struct {
+	db *database
+}
+
+func New() (*Storage, error) {
+
+	log.V(logLevel).Debugf("%s:> define storage", logPrefix)
+
+	path := viper.GetString("storage.bolt.path")
+	if path == "" {
+		path = defaultPath
+	}
+
+	db, err := getDatabase(path)
+	if err != nil {
+		log.Errorf("%s:> open data file %s err: %v", logPrefix, path, err)
+		return nil, err
+	}
+
+	return &Storage{db: db}, nil
+}
+
+func (s Storage) Info(ctx context.Context, collection, name string) (*types.Runtime, error) {
+
+	r := new(types.Runtime)
+
+	err := s.db.view(func(tx *bolt.Tx) error {
+		r.System.Revision = revision(tx)
+		return nil
+	})
+
+	return r, err
+}
+
+func (s Storage) Get(ctx context.Context, collection, name string, obj interface{}, opts *types.Opts) error {
+
+	log.V(logLevel).Debugf("%s:get:> %s/%s", logPrefix, collection, name)
+
+	if reflect.ValueOf(obj).IsNil() {
+		return errors.New(types.ErrStructOutIsNil)
+	}
+
+	var r *record
+
+	s.db.view(func(tx *bolt.Tx) error {
+		r = fetch(tx, keyCreate(collection, name))
+		return nil
+	})
+
+	if r == nil {
+		return errors.New(types.ErrEntityNotFound)
+	}
+
+	if err := json.Unmarshal(r.data, obj); err != nil {
+		log.V(logLevel).Errorf("%s:get:> decode data err: %v", logPrefix, err)
+		return err
+	}
+
+	setRuntime(reflect.ValueOf(obj), r.rev, "")
+	return nil
+}
+
+func (s Storage) List(ctx context.Context, collection, q string, obj interface{}, opts *types.Opts) error {
+
+	log.V(logLevel).Debugf("%s:list:> %s/%s", logPrefix, collection, q)
+
+	if reflect.ValueOf(obj).IsNil() {
+		return errors.New(types.ErrStructOutIsNil)
+	}
+
+	v, err := converter.EnforcePtr(obj)
+	if err != nil {
+		return errors.New(types.ErrStructOutIsNotPointer)
+	}
+
+	f := v.FieldByName("Items")
+	if !f.IsValid() || f.Kind() != reflect.Slice {
+		return errors.New(types.ErrStructOutIsInvalid)
+	}
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	var (
+		prefix = keyCreate(collection, q)
+		last   string
+		cont   string
+		rev    int64
+		items  = make([]*record, 0)
+		keys   = make([]string, 0)
+	)
+
+	if opts.Continue != "" {
+		if last, err = types.DecodeContinue(opts.Continue, prefix); err != nil {
+			return err
+		}
+	}
+
+	s.db.view(func(tx *bolt.Tx) error {
+		rev = revision(tx)
+		return scan(tx, prefix, last, func(key string, r *record) bool {
+
+			if !opts.Selector.Match(r.data) {
+				return true
+			}
+
+			if opts.Limit > 0 && int64(len(items)) == opts.Limit {
+				cont = types.EncodeContinue(keys[len(keys)-1])
+				return false
+			}
+
+			keys = append(keys, key)
+			items = append(items, r)
+			return true
+		})
+	})
+
+	for _, r := range items {
+		item := reflect.New(f.Type().Elem())
+		if err := json.Unmarshal(r.data, item.Interface()); err != nil {
+			log.V(logLevel).Errorf("%s:list:> decode data err: %v", logPrefix, err)
+			return err
+		}
+		setRuntime(item, r.rev, "")
+		f.Set(reflect.Append(f, item.Elem()))
+	}
+
+	setRuntime(v, rev, cont)
+	return nil
+}
+
+func (s Storage) Map(ctx context.Context, collection, q string, obj interface{}, opts *types.Opts) error {
+
+	log.V(logLevel).Debugf("%s:map:> %s/%s", logPrefix, collection, q)
+
+	if reflect.ValueOf(obj).IsNil() {
+		return errors.New(types.ErrStructOutIsNil)
+	}
+
+	v, err := converter.EnforcePtr(obj)
+	if err != nil {
+		return errors.New(types.ErrStructOutIsNotPointer)
+	}
+
+	f := v.FieldByName("Items")
+	if !f.IsValid() || f.Kind() != reflect.Map {
+		return errors.New(types.ErrStructOutIsInvalid)
+	}
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	var (
+		rev   int64
+		items = make(map[string]*record)
+	)
+
+	s.db.view(func(tx *bolt.Tx) error {
+		rev = revision(tx)
+		return scan(tx, keyCreate(collection, q), "", func(key string, r *record) bool {
+			if opts.Selector.Match(r.data) {
+				items[key[strings.LastIndex(key, keySeparator)+1:]] = r
+			}
+			return true
+		})
+	})
+
+	if f.IsNil() {
+		f.Set(reflect.MakeMap(f.Type()))
+	}
+
+	for name, r := range items {
+		item := reflect.New(f.Type().Elem())
+		if err := json.Unmarshal(r.data, item.Interface()); err != nil {
+			log.V(logLevel).Errorf("%s:map:> decode data err: %v", logPrefix, err)
+			return err
+		}
+		setRuntime(item, r.rev, "")
+		f.SetMapIndex(reflect.ValueOf(name), item.Elem())
+	}
+
+	setRuntime(v, rev, "")
+	return nil
+}
+
+func (s Storage) Put(ctx context.Context, collection, name string, obj interface{}, opts *types.Opts) error {
+
+	log.V(logLevel).Debugf("%s:put:> %s/%s", logPrefix, collection, name)
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:put:> encode data err: %v", logPrefix, err)
+		return err
+	}
+
+	key := keyCreate(collection, name)
+
+	var rev int64
+
+	err = s.db.update(func(tx *bolt.Tx, events *[]*types.Event) error {
+
+		if fetch(tx, key) != nil {
+			return errors.New(types.ErrEntityExists)
+		}
+
+		rev, err = store(tx, key, data, opts.Ttl, events)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	setRuntime(reflect.ValueOf(obj), rev, "")
+	return nil
+}
+
+func (s Storage) Set(ctx context.Context, collection, name string, obj interface{}, opts *types.Opts) error {
+
+	log.V(logLevel).Debugf("%s:set:> %s/%s", logPrefix, collection, name)
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:set:> encode data err: %v", logPrefix, err)
+		return err
+	}
+
+	key := keyCreate(collection, name)
+
+	var rev int64
+
+	err = s.db.update(func(tx *bolt.Tx, events *[]*types.Event) error {
+
+		r := fetch(tx, key)
+		if r == nil && (!opts.Force || opts.Rev != nil) {
+			return errors.New(types.ErrEntityNotFound)
+		}
+
+		if opts.Rev != nil && r.rev != *opts.Rev {
+			return errors.New(types.ErrEntityConflict)
+		}
+
+		rev, err = store(tx, key, data, opts.Ttl, events)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	setRuntime(reflect.ValueOf(obj), rev, "")
+	return nil
+}
+
+func (s Storage) Del(ctx context.Context, collection, name string) error {
+
+	log.V(logLevel).Debugf("%s:del:> %s/%s", logPrefix, collection, name)
+
+	return s.db.update(func(tx *bolt.Tx, events *[]*types.Event) error {
+
+		if name != "" {
+			return remove(tx, keyCreate(collection, name), events)
+		}
+
+		keys := make([]string, 0)
+		scan(tx, keyCreate(collection, ""), "", func(key string, r *record) bool {
+			keys = append(keys, key)
+			return true
+		})
+
+		for _, key := range keys {
+			if err := remove(tx, key, events); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s Storage) Watch(ctx context.Context, collection string, event chan *types.WatcherEvent, opts *types.Opts) error {
+
+	log.V(logLevel).Debugf("%s:watch:> %s", logPrefix, collection)
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	w := newWatcher(collection)
+
+	if err := s.db.subscribe(w, keyCreate(collection, ""), opts.Rev); err != nil {
+		log.V(logLevel).Errorf("%s:watch:> subscribe err: %v", logPrefix, err)
+		return err
+	}
+	defer s.db.unsubscribe(w)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.V(logLevel).Debugf("%s:watch:> the user interrupted watch", logPrefix)
+			return nil
+		case <-w.signal:
+			events, overflow := w.pop()
+			for _, e := range events {
+
+				// deleted items are matched by previous state
+				if !opts.Selector.Match(e.Object.([]byte)) {
+					continue
+				}
+
+				link := e.Key[strings.LastIndex(e.Key, keySeparator)+1:]
+				match := strings.Split(link, ":")
+
+				res := new(types.WatcherEvent)
+				res.Action = e.Type
+				res.Name = match[len(match)-1]
+				res.SelfLink = link
+				res.System.Key = e.Key
+				res.System.Revision = e.Rev
+				res.Data = e.Object
+
+				select {
+				case event <- res:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+
+			if overflow {
+				log.V(logLevel).Errorf("%s:watch:> %s events queue overflow", logPrefix, collection)
+				return errors.New(types.ErrWatchOverflow)
+			}
+		}
+	}
+}
+
+func (s Storage) Filter() types.Filter {
+	return new(Filter)
+}
+
+func (s Storage) Key() types.Key {
+	return new(Key)
+}
+
+func (s Storage) Collection() types.Collection {
+	return new(Collection)
+}
+
+func keyCreate(val ...string) string {
+	return strings.Join(val, keySeparator)
+}
+
+// setRuntime - set storage revision and list continue token to entity runtime
+func setRuntime(v reflect.Value, rev int64, cont string) {
+
+	v = reflect.Indirect(v)
+	for v.Kind() == reflect.Ptr {
+		v = reflect.Indirect(v)
+	}
+
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	f := v.FieldByName("Runtime")
+	if !f.IsValid() || !f.CanSet() || f.Type() != reflect.TypeOf(dt.Runtime{}) {
+		return
+	}
+
+	r := dt.Runtime{}
+	r.System.Revision = rev
+	r.System.Continue = cont
+	f.Set(reflect.ValueOf(r))
+}
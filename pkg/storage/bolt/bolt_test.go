@@ -0,0 +1,201 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package bolt_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/storage/bolt"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		os.Exit(1)
+	}
+
+	viper.Set("storage.bolt.path", filepath.Join(dir, "storage.db"))
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestStorage(t *testing.T) {
+	stg, err := bolt.New()
+	if !assert.NoError(t, err, "storage initialize err") {
+		return
+	}
+	storage.StorageConformance(t, stg)
+}
+
+// Testing storages opened in one process share data file
+func TestStorage_Shared(t *testing.T) {
+
+	type obj struct {
+		Name string `json:"name"`
+	}
+
+	var ctx = context.Background()
+
+	stg, err := bolt.New()
+	if !assert.NoError(t, err, "storage initialize err") {
+		return
+	}
+
+	shared, err := bolt.New()
+	if !assert.NoError(t, err, "storage initialize err") {
+		return
+	}
+
+	if !assert.NoError(t, stg.Del(ctx, stg.Collection().Test(), "")) {
+		return
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	event := storage.NewWatcher()
+	go shared.Watch(wctx, stg.Collection().Test(), event, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	opts := storage.GetOpts()
+	opts.Ttl = 1
+
+	err = stg.Put(ctx, stg.Collection().Test(), "demo", &obj{Name: "demo"}, opts)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	out := new(obj)
+	if assert.NoError(t, shared.Get(ctx, stg.Collection().Test(), "demo", out, nil)) {
+		assert.Equal(t, "demo", out.Name, "object received error")
+	}
+
+	actions := make([]string, 0)
+	for len(actions) < 2 {
+		select {
+		case e := <-event:
+			actions = append(actions, e.Action)
+		case <-time.After(5 * time.Second):
+			t.Errorf("watch events timeout: received %d of %d", len(actions), 2)
+			return
+		}
+	}
+
+	assert.Equal(t, []string{types.STORAGECREATEEVENT, types.STORAGEDELETEEVENT}, actions, "expired item should be deleted")
+
+	err = stg.Get(ctx, stg.Collection().Test(), "demo", out, nil)
+	if assert.Error(t, err, "expected err") {
+		assert.Equal(t, errors.ErrEntityNotFound, err.Error(), "err message different")
+	}
+}
+
+// Testing watch is closed when events are not received and can be resumed from last received revision
+func TestStorage_WatchOverflow(t *testing.T) {
+
+	type obj struct {
+		Name string `json:"name"`
+	}
+
+	var ctx = context.Background()
+
+	stg, err := bolt.New()
+	if !assert.NoError(t, err, "storage initialize err") {
+		return
+	}
+
+	if !assert.NoError(t, stg.Del(ctx, stg.Collection().Test(), "")) {
+		return
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		event = make(chan *types.WatcherEvent)
+		errc  = make(chan error, 1)
+	)
+
+	go func() {
+		errc <- stg.Watch(wctx, stg.Collection().Test(), event, nil)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	const count = 1100
+
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("demo-%d", i)
+		if !assert.NoError(t, stg.Put(ctx, stg.Collection().Test(), name, &obj{Name: name}, nil)) {
+			return
+		}
+	}
+
+	var last int64
+	for {
+		select {
+		case e := <-event:
+			last = e.System.Revision
+			continue
+		case err := <-errc:
+			if assert.Error(t, err, "watch should be closed on queue overflow") {
+				assert.Equal(t, types.ErrWatchOverflow, err.Error())
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("watch is not closed on queue overflow")
+			return
+		}
+		break
+	}
+
+	info, err := stg.Info(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rev := last + 1
+	opts := storage.GetOpts()
+	opts.Rev = &rev
+
+	go stg.Watch(wctx, stg.Collection().Test(), event, opts)
+
+	for last < info.System.Revision {
+		select {
+		case e := <-event:
+			if !assert.Equal(t, last+1, e.System.Revision, "events should be resumed in revision order") {
+				return
+			}
+			last = e.System.Revision
+		case <-time.After(5 * time.Second):
+			t.Errorf("resumed watch events timeout: received revision %d of %d", last, info.System.Revision)
+			return
+		}
+	}
+}
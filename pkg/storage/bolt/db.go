@@ -0,0 +1,514 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// record header: modification revision and expiration unix time
+	headerSize = 16
+
+	sweepInterval = time.Second
+
+	// changeLogSize - count of last revisions kept in change log to resume watch from
+	changeLogSize = 10000
+)
+
+var (
+	dataBucket   = []byte("data")
+	expireBucket = []byte("expire")
+	metaBucket   = []byte("meta")
+	// changeBucket - change log indexed by revision, deleted items are stored with previous state
+	changeBucket = []byte("changes")
+
+	revisionKey = []byte("revision")
+)
+
+// databases - opened data files shared by all storages in process,
+// bolt holds exclusive file lock, so file can not be opened twice
+var databases = struct {
+	sync.Mutex
+	items map[string]*database
+}{items: make(map[string]*database)}
+
+type database struct {
+	db *bolt.DB
+
+	// lock - serializes writes with watchers notification to keep events in revision order
+	lock     sync.Mutex
+	watchers map[*watcher]string
+}
+
+type record struct {
+	rev    int64
+	expire int64
+	data   []byte
+}
+
+// getDatabase - open data file or get already opened one
+func getDatabase(path string) (*database, error) {
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	databases.Lock()
+	defer databases.Unlock()
+
+	if d, ok := databases.items[path]; ok {
+		return d, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{dataBucket, expireBucket, metaBucket, changeBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	d := &database{db: db, watchers: make(map[*watcher]string)}
+	databases.items[path] = d
+
+	go d.sweep()
+
+	return d, nil
+}
+
+// view - read data in read-only transaction
+func (d *database) view(fn func(tx *bolt.Tx) error) error {
+	return d.db.View(fn)
+}
+
+// update - change data in read-write transaction and notify watchers after commit
+func (d *database) update(fn func(tx *bolt.Tx, events *[]*types.Event) error) error {
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	events := make([]*types.Event, 0)
+
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		if err := fn(tx, &events); err != nil {
+			return err
+		}
+		return logChanges(tx, events)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		for w, prefix := range d.watchers {
+			if strings.HasPrefix(e.Key, prefix) {
+				w.push(e)
+			}
+		}
+	}
+
+	return nil
+}
+
+// subscribe - register watcher for keys with prefix,
+// changes since revision are sent first in revision order
+func (d *database) subscribe(w *watcher, prefix string, rev *int64) error {
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if rev != nil {
+		err := d.view(func(tx *bolt.Tx) error {
+			events, err := changes(tx, prefix, *rev)
+			if err != nil {
+				return err
+			}
+			w.replay(events)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	d.watchers[w] = prefix
+	return nil
+}
+
+func (d *database) unsubscribe(w *watcher) {
+	d.lock.Lock()
+	delete(d.watchers, w)
+	d.lock.Unlock()
+}
+
+// sweep - remove expired items
+func (d *database) sweep() {
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+
+		err := d.update(func(tx *bolt.Tx, events *[]*types.Event) error {
+
+			var (
+				now  = time.Now().Unix()
+				keys = make([]string, 0)
+				c    = tx.Bucket(expireBucket).Cursor()
+			)
+
+			for k, _ := c.First(); k != nil && int64(binary.BigEndian.Uint64(k[:8])) <= now; k, _ = c.Next() {
+				keys = append(keys, string(k[8:]))
+			}
+
+			for _, key := range keys {
+				if err := remove(tx, key, events); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			log.Errorf("%s:sweep:> remove expired items err: %v", logPrefix, err)
+		}
+	}
+}
+
+// revision - get current storage revision
+func revision(tx *bolt.Tx) int64 {
+	v := tx.Bucket(metaBucket).Get(revisionKey)
+	if v == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(v))
+}
+
+// next - bump storage revision
+func next(tx *bolt.Tx) (int64, error) {
+	rev := revision(tx) + 1
+	if err := tx.Bucket(metaBucket).Put(revisionKey, encodeInt(rev)); err != nil {
+		return 0, err
+	}
+	return rev, nil
+}
+
+// logChanges - add events into change log and remove revisions out of log size
+func logChanges(tx *bolt.Tx, events []*types.Event) error {
+
+	b := tx.Bucket(changeBucket)
+
+	for _, e := range events {
+		if err := b.Put(encodeInt(e.Rev), encodeChange(e)); err != nil {
+			return err
+		}
+	}
+
+	rev := revision(tx)
+	if rev <= changeLogSize {
+		return nil
+	}
+
+	var (
+		last = encodeInt(rev - changeLogSize)
+		keys = make([][]byte, 0)
+		c    = b.Cursor()
+	)
+
+	for k, _ := c.First(); k != nil && bytes.Compare(k, last) <= 0; k, _ = c.Next() {
+		keys = append(keys, k)
+	}
+
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changes - get changes of keys with prefix since revision,
+// error is returned if requested revision is removed from change log already
+func changes(tx *bolt.Tx, prefix string, rev int64) ([]*types.Event, error) {
+
+	if rev < 1 {
+		rev = 1
+	}
+
+	var (
+		events = make([]*types.Event, 0)
+		c      = tx.Bucket(changeBucket).Cursor()
+		first  = revision(tx) + 1
+	)
+
+	if k, _ := c.First(); k != nil {
+		first = int64(binary.BigEndian.Uint64(k))
+	}
+
+	if rev < first && rev <= revision(tx) {
+		return nil, errors.New(types.ErrRevisionCompacted)
+	}
+
+	for k, v := c.Seek(encodeInt(rev)); k != nil; k, v = c.Next() {
+		e := decodeChange(int64(binary.BigEndian.Uint64(k)), v)
+		if strings.HasPrefix(e.Key, prefix) {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
+
+// fetch - get not expired record by key
+func fetch(tx *bolt.Tx, key string) *record {
+	v := tx.Bucket(dataBucket).Get([]byte(key))
+	if v == nil {
+		return nil
+	}
+
+	r := decodeRecord(v)
+	if r.expired() {
+		return nil
+	}
+	return r
+}
+
+// scan - iterate over not expired records with key prefix after last key
+func scan(tx *bolt.Tx, prefix, last string, fn func(key string, r *record) bool) error {
+
+	var (
+		c = tx.Bucket(dataBucket).Cursor()
+		p = []byte(prefix)
+		k []byte
+		v []byte
+	)
+
+	if last != "" {
+		k, v = c.Seek([]byte(last))
+		if k != nil && bytes.Equal(k, []byte(last)) {
+			k, v = c.Next()
+		}
+	} else {
+		k, v = c.Seek(p)
+	}
+
+	for ; k != nil && bytes.HasPrefix(k, p); k, v = c.Next() {
+		r := decodeRecord(v)
+		if r.expired() {
+			continue
+		}
+		if !fn(string(k), r) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// store - save record with new revision
+func store(tx *bolt.Tx, key string, data []byte, ttl uint64, events *[]*types.Event) (int64, error) {
+
+	var (
+		b      = tx.Bucket(dataBucket)
+		action = types.STORAGECREATEEVENT
+	)
+
+	if v := b.Get([]byte(key)); v != nil {
+		prev := decodeRecord(v)
+		if !prev.expired() {
+			action = types.STORAGEUPDATEEVENT
+		}
+		if err := unexpire(tx, key, prev); err != nil {
+			return 0, err
+		}
+	}
+
+	rev, err := next(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	r := &record{rev: rev, data: data}
+	if ttl > 0 {
+		r.expire = time.Now().Unix() + int64(ttl)
+		if err := tx.Bucket(expireBucket).Put(expireKey(key, r.expire), []byte{}); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := b.Put([]byte(key), r.encode()); err != nil {
+		return 0, err
+	}
+
+	*events = append(*events, &types.Event{Type: action, Key: key, Rev: rev, Object: data})
+	return rev, nil
+}
+
+// remove - delete record, delete event is built from previous state
+func remove(tx *bolt.Tx, key string, events *[]*types.Event) error {
+
+	b := tx.Bucket(dataBucket)
+
+	v := b.Get([]byte(key))
+	if v == nil {
+		return nil
+	}
+
+	prev := decodeRecord(v)
+	if err := unexpire(tx, key, prev); err != nil {
+		return err
+	}
+
+	if err := b.Delete([]byte(key)); err != nil {
+		return err
+	}
+
+	rev, err := next(tx)
+	if err != nil {
+		return err
+	}
+
+	*events = append(*events, &types.Event{Type: types.STORAGEDELETEEVENT, Key: key, Rev: rev, Object: prev.data})
+	return nil
+}
+
+// unexpire - remove record from expiration index
+func unexpire(tx *bolt.Tx, key string, r *record) error {
+	if r.expire == 0 {
+		return nil
+	}
+	return tx.Bucket(expireBucket).Delete(expireKey(key, r.expire))
+}
+
+func (r *record) expired() bool {
+	return r.expire > 0 && r.expire <= time.Now().Unix()
+}
+
+func (r *record) encode() []byte {
+	buf := make([]byte, headerSize+len(r.data))
+	binary.BigEndian.PutUint64(buf[0:8], uint64(r.rev))
+	binary.BigEndian.PutUint64(buf[8:16], uint64(r.expire))
+	copy(buf[headerSize:], r.data)
+	return buf
+}
+
+// decodeRecord - parse stored value, data is copied as value is valid only inside transaction
+func decodeRecord(v []byte) *record {
+	r := new(record)
+	r.rev = int64(binary.BigEndian.Uint64(v[0:8]))
+	r.expire = int64(binary.BigEndian.Uint64(v[8:16]))
+	r.data = make([]byte, len(v)-headerSize)
+	copy(r.data, v[headerSize:])
+	return r
+}
+
+// encodeChange - event type, key length, key and object data
+func encodeChange(e *types.Event) []byte {
+
+	var (
+		data, _ = e.Object.([]byte)
+		buf     = make([]byte, 0, 5+len(e.Key)+len(data))
+		size    = make([]byte, 4)
+	)
+
+	binary.BigEndian.PutUint32(size, uint32(len(e.Key)))
+
+	buf = append(buf, changeType(e.Type))
+	buf = append(buf, size...)
+	buf = append(buf, e.Key...)
+	buf = append(buf, data...)
+	return buf
+}
+
+// decodeChange - parse change log value, data is copied as value is valid only inside transaction
+func decodeChange(rev int64, v []byte) *types.Event {
+
+	var (
+		size = int(binary.BigEndian.Uint32(v[1:5]))
+		data = make([]byte, len(v)-5-size)
+	)
+
+	copy(data, v[5+size:])
+
+	return &types.Event{
+		Type:   changeAction(v[0]),
+		Key:    string(v[5 : 5+size]),
+		Rev:    rev,
+		Object: data,
+	}
+}
+
+func changeType(action string) byte {
+	switch action {
+	case types.STORAGEUPDATEEVENT:
+		return 1
+	case types.STORAGEDELETEEVENT:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func changeAction(t byte) string {
+	switch t {
+	case 1:
+		return types.STORAGEUPDATEEVENT
+	case 2:
+		return types.STORAGEDELETEEVENT
+	default:
+		return types.STORAGECREATEEVENT
+	}
+}
+
+func expireKey(key string, expire int64) []byte {
+	return append(encodeInt(expire), []byte(key)...)
+}
+
+func encodeInt(i int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(i))
+	return b
+}
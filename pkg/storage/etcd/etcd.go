@@ -94,12 +94,7 @@ func (s Storage) List(ctx context.Context, collection string, query string, obj
 		return errors.New(types.ErrStructOutIsNil)
 	}
 
-	var rev *int64
-	if opts != nil {
-		rev = opts.Rev
-	}
-
-	return s.client.store.List(ctx, keyCreate(collection, query), "", obj, rev)
+	return s.client.store.List(ctx, keyCreate(collection, query), "", obj, opts)
 }
 
 func (s Storage) Map(ctx context.Context, collection string, query string, obj interface{}, opts *types.Opts) error {
@@ -146,13 +141,11 @@ func (s Storage) Set(ctx context.Context, collection string, name string, obj in
 
 func (s Storage) Del(ctx context.Context, collection string, name string) error {
 
-	key := keyCreate(collection, name)
-
 	if name == "" {
-		key = keyCreate(collection)
+		return s.client.store.Del(ctx, keyCreate(collection), true)
 	}
 
-	return s.client.store.Del(ctx, key)
+	return s.client.store.Del(ctx, keyCreate(collection, name), false)
 }
 
 func (s Storage) Watch(ctx context.Context, collection string, event chan *types.WatcherEvent, opts *types.Opts) error {
@@ -209,7 +202,7 @@ func (s Storage) Watch(ctx context.Context, collection string, event chan *types
 			e.System.Key = res.Key
 			e.System.Revision = res.Rev
 
-			match := strings.Split(res.Key, ":")
+			match := strings.Split(keys[1], ":")
 
 			if len(match) > 0 {
 				e.Name = match[len(match)-1]
@@ -217,15 +210,21 @@ func (s Storage) Watch(ctx context.Context, collection string, event chan *types
 				e.Name = keys[0]
 			}
 
-			if res.Type == types.STORAGEDELETEEVENT {
-				e.Data = res.Object
-				event <- e
-				continue
+			// deleted items are matched by previous state
+			if opts != nil {
+				if data, ok := res.Object.([]byte); ok && len(data) > 0 && !opts.Selector.Match(data) {
+					continue
+				}
 			}
 
 			e.Data = res.Object
 
-			event <- e
+			select {
+			case event <- e:
+			case <-ctx.Done():
+				watcher.Stop()
+				return nil
+			}
 		}
 	}
 
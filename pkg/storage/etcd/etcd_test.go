@@ -19,8 +19,15 @@
 package etcd_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
 	"testing"
+	"time"
 
+	"github.com/coreos/etcd/embed"
 	"github.com/lastbackend/lastbackend/pkg/storage"
 	"github.com/lastbackend/lastbackend/pkg/storage/etcd"
 	"github.com/lastbackend/lastbackend/pkg/storage/etcd/v3"
@@ -28,47 +35,85 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestStorage_Get(t *testing.T) {
+func TestStorage(t *testing.T) {
 	stg, err := etcd.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageGetAssets(t, stg)
+	if !assert.NoError(t, err, "storage initialize err") {
+		return
+	}
+	storage.StorageConformance(t, stg)
 }
 
-func TestStorage_List(t *testing.T) {
-	stg, err := etcd.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageListAssets(t, stg)
-}
+// TestMain - run storage tests against embedded etcd server
+func TestMain(m *testing.M) {
 
-func TestStorage_Map(t *testing.T) {
-	stg, err := etcd.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageMapAssets(t, stg)
-}
+	dir, err := ioutil.TempDir("", "etcd")
+	if err != nil {
+		fmt.Printf("create data dir err: %v\n", err)
+		os.Exit(1)
+	}
 
-func TestStorage_Put(t *testing.T) {
-	stg, err := etcd.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StoragePutAssets(t, stg)
-}
+	server, endpoint, err := startEtcd(dir)
+	if err != nil {
+		fmt.Printf("start etcd err: %v\n", err)
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
 
-func TestStorage_Set(t *testing.T) {
-	stg, err := etcd.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageSetAssets(t, stg)
-}
+	cfg := v3.Config{}
+	cfg.Prefix = "lstbknd"
+	cfg.Endpoints = []string{endpoint}
+	viper.Set("etcd", cfg)
 
-func TestStorage_Del(t *testing.T) {
-	stg, err := etcd.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageDelAssets(t, stg)
+	code := m.Run()
+
+	server.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
 }
 
-func init() {
+func startEtcd(dir string) (*embed.Etcd, string, error) {
 
-	cfg := v3.Config{}
-	cfg.Prefix = "lstbknd"
-	cfg.Endpoints = []string{"127.0.0.1:2379"}
-	viper.Set("etcd", cfg)
+	peer, err := localURL()
+	if err != nil {
+		return nil, "", err
+	}
+
+	client, err := localURL()
+	if err != nil {
+		return nil, "", err
+	}
+
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	cfg.LPUrls = []url.URL{*peer}
+	cfg.APUrls = []url.URL{*peer}
+	cfg.LCUrls = []url.URL{*client}
+	cfg.ACUrls = []url.URL{*client}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	server, err := embed.StartEtcd(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	select {
+	case <-server.Server.ReadyNotify():
+		return server, client.Host, nil
+	case err := <-server.Err():
+		server.Close()
+		return nil, "", err
+	case <-time.After(time.Minute):
+		server.Close()
+		return nil, "", fmt.Errorf("etcd start timeout")
+	}
+}
 
+// localURL - get url with free local port
+func localURL() (*url.URL, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+	return url.Parse(fmt.Sprintf("http://%s", l.Addr().String()))
 }
@@ -36,6 +36,14 @@ func (Key) Deployment(namespace, service, name string) string {
 	return fmt.Sprintf("%s:%s:%s", namespace, service, name)
 }
 
+func (Key) Revision(namespace, service string, version int) string {
+	return fmt.Sprintf("%s:%s:%d", namespace, service, version)
+}
+
+func (Key) Autoscaler(namespace, service string) string {
+	return fmt.Sprintf("%s:%s", namespace, service)
+}
+
 func (Key) Pod(namespace, service, deployment, name string) string {
 	return fmt.Sprintf("%s:%s:%s:%s", namespace, service, deployment, name)
 }
@@ -86,3 +94,15 @@ func (Key) Route(namespace, name string) string {
 func (Key) Subnet(name string) string {
 	return fmt.Sprintf("%s", name)
 }
+
+func (Key) User(name string) string {
+	return fmt.Sprintf("%s", name)
+}
+
+func (Key) Role(name string) string {
+	return fmt.Sprintf("%s", name)
+}
+
+func (Key) Audit(namespace, id string) string {
+	return fmt.Sprintf("%s:%s", namespace, id)
+}
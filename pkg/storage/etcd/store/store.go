@@ -30,10 +30,10 @@ type Store interface {
 	Count(ctx context.Context, key, keyRegexFilter string) (int, error)
 	Put(ctx context.Context, key string, obj, out interface{}, ttl uint64) error
 	Get(ctx context.Context, key string, objPtr interface{}, rev *int64) error
-	List(ctx context.Context, key, filter string, listObjPtr interface{}, rev *int64) error
+	List(ctx context.Context, key, filter string, listObjPtr interface{}, opts *types.Opts) error
 	Map(ctx context.Context, key, filter string, mapObj interface{}, rev *int64) error
 	Set(ctx context.Context, key string, obj, outPtr interface{}, ttl uint64, force bool, rev *int64) error
-	Del(ctx context.Context, key string) error
+	Del(ctx context.Context, key string, recursive bool) error
 	Watch(ctx context.Context, key, filter string, rev *int64) (types.Watcher, error)
 	Begin(ctx context.Context) TX
 	Decode(ctx context.Context, value []byte, out interface{}) error
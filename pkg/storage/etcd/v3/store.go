@@ -116,6 +116,8 @@ func (s *dbstore) Put(ctx context.Context, key string, obj, outPtr interface{},
 	if !txnResp.Succeeded {
 		return errors.New(types.ErrEntityExists)
 	}
+
+	setEntityRuntimeInfo(obj, getRuntimeFromResponse(txnResp.Header))
 	if validator.IsNil(outPtr) {
 		log.V(logLevel).Warn("%s:Create: output struct is nil")
 		return nil
@@ -154,7 +156,7 @@ func (s *dbstore) Get(ctx context.Context, key string, outPtr interface{}, rev *
 		return err
 	}
 
-	if err := setEntityRuntimeInfo(outPtr, getRuntimeFromResponse(res.Header)); err != nil {
+	if err := setEntityRuntimeInfo(outPtr, getRuntimeFromValue(res.Kvs[0])); err != nil {
 		log.V(logLevel).Errorf("%s:get:> can not set runtime info err: %v", logPrefix, err)
 		return err
 	}
@@ -162,31 +164,91 @@ func (s *dbstore) Get(ctx context.Context, key string, outPtr interface{}, rev *
 	return nil
 }
 
-func (s *dbstore) List(ctx context.Context, key, keyRegexFilter string, listOutPtr interface{}, rev *int64) error {
+func (s *dbstore) List(ctx context.Context, key, keyRegexFilter string, listOutPtr interface{}, opts *types.Opts) error {
 
 	key = path.Join(s.pathPrefix, key)
 
 	log.V(logLevel).Debugf("%s:list:> key: %s with filter: %s", logPrefix, key, keyRegexFilter)
 
-	getResp, err := s.client.KV.Get(ctx, key, clientv3.WithPrefix())
-	if err != nil {
-		log.V(logLevel).Errorf("%s:list:> request err: %v", logPrefix, err)
-		return err
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	var (
+		start = key
+		end   = clientv3.GetPrefixRangeEnd(key)
+		more  bool
+	)
+
+	if opts.Continue != "" {
+		last, err := types.DecodeContinue(opts.Continue, key)
+		if err != nil {
+			log.V(logLevel).Errorf("%s:list:> decode continue token err: %v", logPrefix, err)
+			return err
+		}
+		start = last + "\x00"
 	}
 
 	r, _ := regexp.Compile(keyRegexFilter)
-	items := make(map[string]*mvccpb.KeyValue)
+	items := make([]*mvccpb.KeyValue, 0)
+	index := make(map[string]int)
 
-	for _, kv := range getResp.Kvs {
+	var header *etcdserverpb.ResponseHeader
+
+	// items are read by key ranges in batches of limit size,
+	// key and selector filters are applied before items are decoded
+	for {
+
+		ops := []clientv3.OpOption{clientv3.WithRange(end), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend)}
+		if opts.Limit > 0 {
+			ops = append(ops, clientv3.WithLimit(opts.Limit))
+		}
+		if header != nil {
+			ops = append(ops, clientv3.WithRev(header.Revision))
+		}
 
-		keys := strings.Split(string(kv.Key), "/")
-		node := keys[len(keys)-1]
+		getResp, err := s.client.KV.Get(ctx, start, ops...)
+		if err != nil {
+			log.V(logLevel).Errorf("%s:list:> request err: %v", logPrefix, err)
+			return err
+		}
 
-		if (keyRegexFilter != "") && !r.MatchString(string(kv.Key)) {
-			continue
+		if header == nil {
+			header = getResp.Header
 		}
 
-		items[node] = kv
+		for _, kv := range getResp.Kvs {
+
+			keys := strings.Split(string(kv.Key), "/")
+			node := keys[len(keys)-1]
+
+			if (keyRegexFilter != "") && !r.MatchString(string(kv.Key)) {
+				continue
+			}
+
+			if !opts.Selector.Match(kv.Value) {
+				continue
+			}
+
+			if i, ok := index[node]; ok {
+				items[i] = kv
+				continue
+			}
+
+			if opts.Limit > 0 && int64(len(items)) == opts.Limit {
+				more = true
+				break
+			}
+
+			index[node] = len(items)
+			items = append(items, kv)
+		}
+
+		if more || opts.Limit == 0 || !getResp.More || len(getResp.Kvs) == 0 {
+			break
+		}
+
+		start = string(getResp.Kvs[len(getResp.Kvs)-1].Key) + "\x00"
 	}
 
 	if err := decodeList(s.codec, items, listOutPtr); err != nil {
@@ -194,7 +256,12 @@ func (s *dbstore) List(ctx context.Context, key, keyRegexFilter string, listOutP
 		return err
 	}
 
-	if err := setEntityRuntimeInfo(listOutPtr, getRuntimeFromResponse(getResp.Header)); err != nil {
+	runtime := getRuntimeFromResponse(header)
+	if more {
+		runtime.System.Continue = types.EncodeContinue(string(items[len(items)-1].Key))
+	}
+
+	if err := setEntityRuntimeInfo(listOutPtr, runtime); err != nil {
 		log.V(logLevel).Errorf("%s:get:> can not set runtime info err: %v", logPrefix, err)
 		return err
 	}
@@ -263,17 +330,19 @@ func (s *dbstore) Set(ctx context.Context, key string, obj, outPtr interface{},
 
 	txn := s.client.KV.Txn(ctx)
 
-	if !force {
-		rv := int64(0)
-		if rev != nil {
-			rv = *rev
-		}
-
-		txn = txn.If(clientv3.Compare(clientv3.ModRevision(key), "!=", rv))
+	switch {
+	case rev != nil:
+		txn = txn.If(
+			clientv3.Compare(clientv3.ModRevision(key), "!=", 0),
+			clientv3.Compare(clientv3.ModRevision(key), "=", *rev),
+		)
+	case !force:
+		txn = txn.If(clientv3.Compare(clientv3.ModRevision(key), "!=", 0))
 	}
 
 	txnResp, err := txn.
 		Then(clientv3.OpPut(key, string(data), opts...)).
+		Else(clientv3.OpGet(key, clientv3.WithCountOnly())).
 		Commit()
 
 	if err != nil {
@@ -281,8 +350,15 @@ func (s *dbstore) Set(ctx context.Context, key string, obj, outPtr interface{},
 		return err
 	}
 	if !txnResp.Succeeded {
+		if rev != nil && len(txnResp.Responses) > 0 {
+			if r := txnResp.Responses[0].GetResponseRange(); r != nil && r.Count > 0 {
+				return errors.New(types.ErrEntityConflict)
+			}
+		}
 		return errors.New(types.ErrEntityNotFound)
 	}
+
+	setEntityRuntimeInfo(obj, getRuntimeFromResponse(txnResp.Header))
 	if validator.IsNil(outPtr) {
 		log.V(logLevel).Warnf("%s:Update: output struct is nil", logPrefix)
 		return nil
@@ -302,14 +378,21 @@ func (s *dbstore) Set(ctx context.Context, key string, obj, outPtr interface{},
 	return nil
 }
 
-func (s *dbstore) Del(ctx context.Context, key string) error {
+func (s *dbstore) Del(ctx context.Context, key string, recursive bool) error {
 
 	key = path.Join(s.pathPrefix, key)
 
-	log.V(logLevel).Debugf("%s:delete:> key: %s", logPrefix, key)
+	log.V(logLevel).Debugf("%s:delete:> key: %s, recursive: %t", logPrefix, key, recursive)
+
+	ops := []clientv3.Op{clientv3.OpDelete(key)}
+
+	// keys with the same prefix are not removed unless they are stored under the key
+	if recursive {
+		ops = append(ops, clientv3.OpDelete(key+"/", clientv3.WithPrefix()))
+	}
 
 	_, err := s.client.KV.Txn(ctx).
-		Then(clientv3.OpGet(key), clientv3.OpDelete(key, clientv3.WithPrefix())).
+		Then(ops...).
 		Commit()
 	if err != nil {
 		log.V(logLevel).Errorf("%s:delete:> request err: %v", logPrefix, err)
@@ -361,6 +444,10 @@ func setEntityRuntimeInfo(out interface{}, runtime types.Runtime) error {
 
 func setValueRuntimeInfo(v reflect.Value, runtime types.Runtime) error {
 
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
 	if v.Kind() != reflect.Struct {
 		return nil
 	}
@@ -399,7 +486,7 @@ func decode(s serializer.Codec, value []byte, out interface{}) error {
 	return serializer.Decode(s, value, out)
 }
 
-func decodeList(codec serializer.Codec, items map[string]*mvccpb.KeyValue, listOut interface{}) error {
+func decodeList(codec serializer.Codec, items []*mvccpb.KeyValue, listOut interface{}) error {
 	v, err := converter.EnforcePtr(listOut)
 	if err != nil {
 		return errors.New(types.ErrStructOutIsInvalid)
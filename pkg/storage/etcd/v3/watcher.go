@@ -25,6 +25,8 @@ import (
 	"regexp"
 
 	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage/types"
 )
@@ -137,13 +139,9 @@ func (wc *watchChan) watching(watchClosedCh chan struct{}) {
 		clientv3.WithPrefix(),
 	}
 
+	// changes since requested revision are replayed by etcd in revision order, including deletes
 	if wc.rev != nil {
-		if err := wc.getState(); err != nil {
-			log.Errorf("%s:watching:> failed to getState with latest state: %v", logPrefix, err)
-			wc.sendError(err)
-			return
-		}
-		opts = append(opts, clientv3.WithRev(*wc.rev+1))
+		opts = append(opts, clientv3.WithRev(*wc.rev))
 	}
 
 	r, _ := regexp.Compile(wc.filter)
@@ -153,6 +151,10 @@ func (wc *watchChan) watching(watchClosedCh chan struct{}) {
 		if wres.Err() != nil {
 			err := wres.Err()
 			log.Errorf("%s:watching:> watch chan err: %v", logPrefix, err)
+			// requested revision is compacted, client should get state again
+			if err == rpctypes.ErrCompacted {
+				err = errors.New(types.ErrRevisionCompacted)
+			}
 			wc.sendError(err)
 			return
 		}
@@ -177,35 +179,6 @@ func (wc *watchChan) watching(watchClosedCh chan struct{}) {
 	close(watchClosedCh)
 }
 
-func (wc *watchChan) getState() error {
-
-	opts := []clientv3.OpOption{clientv3.WithPrefix()}
-
-	if wc.rev != nil {
-		opts = append(opts, clientv3.WithMinModRev(*wc.rev))
-	}
-
-	getResp, err := wc.watcher.client.Get(wc.ctx, wc.key, opts...)
-	if err != nil {
-		return err
-	}
-
-	wc.rev = &getResp.Header.Revision
-
-	for _, kv := range getResp.Kvs {
-		wc.sendEvent(&event{
-			key:       string(kv.Key),
-			value:     kv.Value,
-			prevValue: nil,
-			rev:       kv.ModRevision,
-			isDeleted: false,
-			isCreated: true,
-		})
-	}
-
-	return nil
-}
-
 func (wc *watchChan) handleEvent(wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -30,6 +30,11 @@ const (
 	endpointCollection   = "endpoint"
 	serviceCollection    = "service"
 	deploymentCollection = "deployment"
+	revisionCollection   = "revision"
+	autoscalerCollection = "autoscaler"
+	userCollection       = "user"
+	roleCollection       = "role"
+	auditCollection      = "audit"
 	podCollection        = "pod"
 	volumeCollection     = "volume"
 
@@ -85,6 +90,26 @@ func (Collection) Deployment() string {
 	return deploymentCollection
 }
 
+func (Collection) Revision() string {
+	return revisionCollection
+}
+
+func (Collection) Autoscaler() string {
+	return autoscalerCollection
+}
+
+func (Collection) User() string {
+	return userCollection
+}
+
+func (Collection) Role() string {
+	return roleCollection
+}
+
+func (Collection) Audit() string {
+	return auditCollection
+}
+
 func (Collection) Pod() string {
 	return podCollection
 }
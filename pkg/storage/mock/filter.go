@@ -38,6 +38,18 @@ func (Filter) Deployment() types.DeploymentFilter {
 	return new(DeploymentFilter)
 }
 
+func (Filter) Revision() types.RevisionFilter {
+	return new(RevisionFilter)
+}
+
+func (Filter) Autoscaler() types.AutoscalerFilter {
+	return new(AutoscalerFilter)
+}
+
+func (Filter) Audit() types.AuditFilter {
+	return new(AuditFilter)
+}
+
 func (Filter) Pod() types.PodFilter {
 	return new(PodFilter)
 }
@@ -92,6 +104,24 @@ func (DeploymentFilter) ByService(namespace, service string) string {
 	return byService(namespace, service)
 }
 
+type RevisionFilter struct{}
+
+func (RevisionFilter) ByService(namespace, service string) string {
+	return byService(namespace, service)
+}
+
+type AutoscalerFilter struct{}
+
+func (AutoscalerFilter) ByNamespace(namespace string) string {
+	return byNamespace(namespace)
+}
+
+type AuditFilter struct{}
+
+func (AuditFilter) ByNamespace(namespace string) string {
+	return byNamespace(namespace)
+}
+
 type PodFilter struct{}
 
 func (PodFilter) ByNamespace(namespace string) string {
@@ -20,8 +20,10 @@ package mock
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"reflect"
 
@@ -35,10 +37,27 @@ import (
 type Storage struct {
 	lock   sync.RWMutex
 	store map[string]map[string][]byte
+
+	rev      int64
+	revision map[string]map[string]int64
+	watchers map[*watcher]bool
+
+	// history - all changes in revision order to resume watch from revision
+	history []*change
+}
+
+type change struct {
+	collection string
+	event      *types.Event
 }
 
 func (s *Storage) Info(ctx context.Context, collection string, name string) (*types.Runtime, error) {
-	return new(types.Runtime), nil
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	r := new(types.Runtime)
+	r.System.Revision = s.rev
+	return r, nil
 }
 
 func (s *Storage) Get(ctx context.Context, collection string, name string, obj interface{}, opts *types.Opts) error {
@@ -47,18 +66,19 @@ func (s *Storage) Get(ctx context.Context, collection string, name string, obj i
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if _, ok := s.store[collection][name]; !ok {
-		return errors.New(types.ErrEntityNotFound)
-	}
-
 	if reflect.ValueOf(obj).IsNil() {
 		return errors.New(types.ErrStructOutIsNil)
 	}
 
+	if _, ok := s.store[collection][name]; !ok {
+		return errors.New(types.ErrEntityNotFound)
+	}
+
 	if err := json.Unmarshal(s.store[collection][name], obj); err != nil {
 		return err
 	}
 
+	setRevision(reflect.ValueOf(obj), s.revision[collection][name])
 	return nil
 }
 
@@ -77,23 +97,50 @@ func (s *Storage) List(ctx context.Context, collection string, q string, obj int
 		return errors.New(types.ErrStructOutIsNotPointer)
 	}
 
-	buffer := []byte("[")
-	current := 0
-	for k, item := range s.store[collection] {
-		if strings.HasPrefix(k, q) {
+	if opts == nil {
+		opts = new(types.Opts)
+	}
 
-			if current > 0 {
-				buffer = append(buffer, []byte(",")...)
-			}
+	var last string
+	if opts.Continue != "" {
+		if last, err = types.DecodeContinue(opts.Continue, q); err != nil {
+			return err
+		}
+	}
 
-			buffer = append(buffer, item...)
-			current++
+	keys := make([]string, 0)
+	for k, item := range s.store[collection] {
+		if strings.HasPrefix(k, q) && k > last && opts.Selector.Match(item) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var cont string
+	if opts.Limit > 0 && int64(len(keys)) > opts.Limit {
+		keys = keys[:opts.Limit]
+		cont = types.EncodeContinue(keys[len(keys)-1])
+	}
 
+	buffer := []byte("[")
+	for i, k := range keys {
+		if i > 0 {
+			buffer = append(buffer, []byte(",")...)
 		}
+		buffer = append(buffer, s.store[collection][k]...)
 	}
 
 	buffer = append(buffer, []byte("]")...)
 
+	if r := v.FieldByName("Runtime"); r.IsValid() {
+		if c := r.FieldByName("System").FieldByName("Continue"); c.IsValid() && c.CanSet() {
+			c.SetString(cont)
+		}
+		if c := r.FieldByName("System").FieldByName("Revision"); c.IsValid() && c.CanSet() {
+			c.SetInt(s.rev)
+		}
+	}
+
 	f := v.FieldByName("Items")
 	if f.Kind() != reflect.Slice {
 		return errors.New(types.ErrStructOutIsInvalid)
@@ -113,6 +160,10 @@ func (s *Storage) List(ctx context.Context, collection string, q string, obj int
 	}
 
 	f.Set(reflect.ValueOf(items).Elem())
+	for i, k := range keys {
+		setRevision(f.Index(i), s.revision[collection][k])
+	}
+
 	return nil
 }
 
@@ -183,14 +234,7 @@ func (s *Storage) Put(ctx context.Context, collection string, name string, obj i
 	defer s.lock.Unlock()
 
 	if _, ok := s.store[collection][name]; ok {
-
-		if opts == nil {
-			return errors.New(types.ErrEntityExists)
-		}
-
-		if !opts.Force {
-			return errors.New(types.ErrEntityExists)
-		}
+		return errors.New(types.ErrEntityExists)
 	}
 
 	b, err := json.Marshal(obj)
@@ -199,6 +243,13 @@ func (s *Storage) Put(ctx context.Context, collection string, name string, obj i
 	}
 
 	s.store[collection][name] = b
+	s.notify(collection, name, types.STORAGECREATEEVENT, b)
+	setRevision(reflect.ValueOf(obj), s.rev)
+
+	if opts != nil {
+		s.expire(collection, name, opts.Ttl)
+	}
+
 	return nil
 }
 
@@ -208,10 +259,16 @@ func (s *Storage) Set(ctx context.Context, collection string, name string, obj i
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if _, ok := s.store[collection][name]; !ok {
-		if opts != nil && !opts.Force {
-			return errors.New(types.ErrEntityNotFound)
-		}
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	if _, ok := s.store[collection][name]; !ok && (!opts.Force || opts.Rev != nil) {
+		return errors.New(types.ErrEntityNotFound)
+	}
+
+	if opts.Rev != nil && s.revision[collection][name] != *opts.Rev {
+		return errors.New(types.ErrEntityConflict)
 	}
 
 	b, err := json.Marshal(obj)
@@ -219,7 +276,15 @@ func (s *Storage) Set(ctx context.Context, collection string, name string, obj i
 		return err
 	}
 
+	action := types.STORAGECREATEEVENT
+	if _, ok := s.store[collection][name]; ok {
+		action = types.STORAGEUPDATEEVENT
+	}
+
 	s.store[collection][name] = b
+	s.notify(collection, name, action, b)
+	setRevision(reflect.ValueOf(obj), s.rev)
+	s.expire(collection, name, opts.Ttl)
 
 	return nil
 }
@@ -231,16 +296,86 @@ func (s *Storage) Del(ctx context.Context, collection string, name string) error
 	defer s.lock.Unlock()
 
 	if name == "" {
+		keys := make([]string, 0)
+		for k := range s.store[collection] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			s.notify(collection, k, types.STORAGEDELETEEVENT, s.store[collection][k])
+		}
+
 		s.store[collection] = make(map[string][]byte)
 		return nil
 	}
+
+	if item, ok := s.store[collection][name]; ok {
+		s.notify(collection, name, types.STORAGEDELETEEVENT, item)
+	}
+
 	delete(s.store[collection], name)
 	return nil
 }
 
 func (s *Storage) Watch(ctx context.Context, collection string, event chan *types.WatcherEvent, opts *types.Opts) error {
 	s.check(collection)
-	return nil
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	w := newWatcher(collection)
+
+	s.lock.Lock()
+
+	// send changes since requested revision before new events
+	if opts.Rev != nil {
+		for _, c := range s.history {
+			if c.collection == collection && c.event.Rev >= *opts.Rev {
+				w.push(c.event)
+			}
+		}
+	}
+
+	s.watchers[w] = true
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.watchers, w)
+		s.lock.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.signal:
+			for _, e := range w.pop() {
+
+				if !opts.Selector.Match(e.Object.([]byte)) {
+					continue
+				}
+
+				match := strings.Split(e.Key, ":")
+
+				res := new(types.WatcherEvent)
+				res.Action = e.Type
+				res.Name = match[len(match)-1]
+				res.SelfLink = e.Key
+				res.System.Key = e.Key
+				res.System.Revision = e.Rev
+				res.Data = e.Object
+
+				select {
+				case event <- res:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
 }
 
 func (s Storage) Filter() types.Filter {
@@ -261,10 +396,89 @@ func (s *Storage) check(kind string) {
 	if _, ok := s.store[kind]; !ok {
 		s.store[kind] = make(map[string][]byte)
 	}
+	if _, ok := s.revision[kind]; !ok {
+		s.revision[kind] = make(map[string]int64)
+	}
+}
+
+// expire - remove item after ttl seconds if it was not changed since,
+// should be called under storage lock
+func (s *Storage) expire(collection, name string, ttl uint64) {
+	if ttl == 0 {
+		return
+	}
+
+	rev := s.revision[collection][name]
+	time.AfterFunc(time.Duration(ttl)*time.Second, func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		if s.revision[collection][name] != rev {
+			return
+		}
+
+		if item, ok := s.store[collection][name]; ok {
+			s.notify(collection, name, types.STORAGEDELETEEVENT, item)
+			delete(s.store[collection], name)
+		}
+	})
+}
+
+// notify - bump storage revision and pass change to collection watchers,
+// should be called under storage lock
+func (s *Storage) notify(collection, name, action string, data []byte) {
+
+	s.rev++
+
+	if action == types.STORAGEDELETEEVENT {
+		delete(s.revision[collection], name)
+	} else {
+		s.revision[collection][name] = s.rev
+	}
+
+	e := &types.Event{
+		Type:   action,
+		Key:    name,
+		Rev:    s.rev,
+		Object: data,
+	}
+
+	s.history = append(s.history, &change{collection: collection, event: e})
+
+	for w := range s.watchers {
+		if w.collection != collection {
+			continue
+		}
+
+		w.push(e)
+	}
+}
+
+// setRevision - set item runtime revision if item has runtime
+func setRevision(v reflect.Value, rev int64) {
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	if r := v.FieldByName("Runtime"); r.IsValid() {
+		if c := r.FieldByName("System").FieldByName("Revision"); c.IsValid() && c.CanSet() {
+			c.SetInt(rev)
+		}
+	}
 }
 
 func New() (*Storage, error) {
 	db := new(Storage)
 	db.store = make(map[string]map[string][]byte)
+	db.revision = make(map[string]map[string]int64)
+	db.watchers = make(map[*watcher]bool)
 	return db, nil
 }
@@ -26,38 +26,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestStorage_Get(t *testing.T) {
+func TestStorage(t *testing.T) {
 	stg, err := mock.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageGetAssets(t, stg)
-}
-
-func TestStorage_List(t *testing.T) {
-	stg, err := mock.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageListAssets(t, stg)
-}
-
-func TestStorage_Map(t *testing.T) {
-	stg, err := mock.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageMapAssets(t, stg)
-}
-
-func TestStorage_Put(t *testing.T) {
-	stg, err := mock.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StoragePutAssets(t, stg)
-}
-
-func TestStorage_Set(t *testing.T) {
-	stg, err := mock.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageSetAssets(t, stg)
-}
-
-func TestStorage_Del(t *testing.T) {
-	stg, err := mock.New()
-	assert.NoError(t, err, "storage initialize err")
-	storage.StorageDelAssets(t, stg)
+	if !assert.NoError(t, err, "storage initialize err") {
+		return
+	}
+	storage.StorageConformance(t, stg)
 }
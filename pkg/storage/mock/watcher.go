@@ -0,0 +1,64 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package mock
+
+import (
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+)
+
+// watcher - queue of collection events for single watch
+type watcher struct {
+	collection string
+
+	lock   sync.Mutex
+	queue  []*types.Event
+	signal chan struct{}
+}
+
+func newWatcher(collection string) *watcher {
+	return &watcher{
+		collection: collection,
+		queue:      make([]*types.Event, 0),
+		signal:     make(chan struct{}, 1),
+	}
+}
+
+// push - add event to queue without blocking storage writers
+func (w *watcher) push(e *types.Event) {
+	w.lock.Lock()
+	w.queue = append(w.queue, e)
+	w.lock.Unlock()
+
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pop - get all queued events
+func (w *watcher) pop() []*types.Event {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	q := w.queue
+	w.queue = make([]*types.Event, 0)
+	return q
+}
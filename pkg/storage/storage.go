@@ -21,6 +21,7 @@ package storage
 import (
 	"context"
 
+	"github.com/lastbackend/lastbackend/pkg/storage/bolt"
 	"github.com/lastbackend/lastbackend/pkg/storage/etcd"
 	"github.com/lastbackend/lastbackend/pkg/storage/mock"
 	"github.com/lastbackend/lastbackend/pkg/storage/types"
@@ -65,6 +66,8 @@ func Get(driver string) (Storage, error) {
 	switch driver {
 	case "mock":
 		return mock.New()
+	case "bolt":
+		return bolt.New()
 	default:
 		return etcd.New()
 	}
@@ -21,15 +21,75 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"encoding/json"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	st "github.com/lastbackend/lastbackend/pkg/storage/types"
+	"github.com/lastbackend/lastbackend/pkg/util/selector"
 	"github.com/stretchr/testify/assert"
 )
 
+// StorageConformance - run all storage assets, every storage driver should pass it
+func StorageConformance(t *testing.T, stg Storage) {
+	t.Run("Info", func(t *testing.T) { StorageInfoAssets(t, stg) })
+	t.Run("Get", func(t *testing.T) { StorageGetAssets(t, stg) })
+	t.Run("List", func(t *testing.T) { StorageListAssets(t, stg) })
+	t.Run("ListSelect", func(t *testing.T) { StorageListSelectAssets(t, stg) })
+	t.Run("Map", func(t *testing.T) { StorageMapAssets(t, stg) })
+	t.Run("Put", func(t *testing.T) { StoragePutAssets(t, stg) })
+	t.Run("Set", func(t *testing.T) { StorageSetAssets(t, stg) })
+	t.Run("Force", func(t *testing.T) { StorageForceAssets(t, stg) })
+	t.Run("Conflict", func(t *testing.T) { StorageConflictAssets(t, stg) })
+	t.Run("Del", func(t *testing.T) { StorageDelAssets(t, stg) })
+	t.Run("Watch", func(t *testing.T) { StorageWatchAssets(t, stg) })
+	t.Run("Events", func(t *testing.T) { StorageEventsAssets(t, stg) })
+	t.Run("Ttl", func(t *testing.T) { StorageTtlAssets(t, stg) })
+}
+
+func StorageInfoAssets(t *testing.T, stg Storage) {
+
+	var ctx = context.Background()
+
+	type obj struct {
+		types.Runtime
+		Name string `json:"name"`
+	}
+
+	err := stg.Del(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	before, err := stg.Info(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = stg.Put(ctx, stg.Collection().Test(), "demo", &obj{Name: "demo"}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	after, err := stg.Info(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, after.System.Revision > before.System.Revision, "revision should grow after change")
+
+	out := new(obj)
+	if !assert.NoError(t, stg.Get(ctx, stg.Collection().Test(), "demo", out, nil)) {
+		return
+	}
+
+	assert.True(t, out.System.Revision > 0, "entity revision should be set")
+	assert.True(t, out.System.Revision <= after.System.Revision, "entity revision should not exceed storage revision")
+}
+
 func StorageGetAssets(t *testing.T, stg Storage) {
 
 	var ctx = context.Background()
@@ -74,6 +134,14 @@ func StorageGetAssets(t *testing.T, stg Storage) {
 			true,
 			errors.ErrStructOutIsNil,
 		},
+		{
+			"out struct is nil for not existing entity",
+			fields{stg},
+			args{ctx: ctx, key: "n", obj: &obj{Name: "demo"}, out: nil},
+			nil,
+			true,
+			errors.ErrStructOutIsNil,
+		},
 		{
 			"test successful get",
 			fields{stg},
@@ -244,6 +312,267 @@ func StorageListAssets(t *testing.T, stg Storage) {
 
 }
 
+func StorageListSelectAssets(t *testing.T, stg Storage) {
+
+	var ctx = context.Background()
+
+	type meta struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	type status struct {
+		State string `json:"state"`
+	}
+
+	type obj struct {
+		types.Runtime
+		Meta   meta   `json:"meta"`
+		Status status `json:"status"`
+	}
+
+	type objl struct {
+		types.Runtime
+		Items []*obj
+	}
+
+	items := []*obj{
+		{Meta: meta{Name: "a", Labels: map[string]string{"app": "web", "tier": "front"}}, Status: status{State: "ready"}},
+		{Meta: meta{Name: "b", Labels: map[string]string{"app": "web", "tier": "back"}}, Status: status{State: "error"}},
+		{Meta: meta{Name: "c", Labels: map[string]string{"app": "db"}}, Status: status{State: "ready"}},
+		{Meta: meta{Name: "d"}, Status: status{State: "ready"}},
+	}
+
+	err := stg.Del(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, o := range items {
+		err = stg.Put(ctx, stg.Collection().Test(), o.Meta.Name, o, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	names := func(l *objl) []string {
+		n := make([]string, 0)
+		for _, i := range l.Items {
+			n = append(n, i.Meta.Name)
+		}
+		return n
+	}
+
+	tests := []struct {
+		name   string
+		labels string
+		fields string
+		want   []string
+	}{
+		{"without selector", "", "", []string{"a", "b", "c", "d"}},
+		{"label equals", "app=web", "", []string{"a", "b"}},
+		{"label not equals", "app!=web", "", []string{"c", "d"}},
+		{"label in set", "tier in (front, back),app", "", []string{"a", "b"}},
+		{"label not exists", "!app", "", []string{"d"}},
+		{"field equals", "", "status.state=ready", []string{"a", "c", "d"}},
+		{"labels and fields", "app=web", "status.state!=ready", []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s, err := selector.New(tt.labels, tt.fields)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			opts := new(st.Opts)
+			opts.Selector = s
+
+			out := new(objl)
+			err = stg.List(ctx, stg.Collection().Test(), "", out, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.ElementsMatch(t, tt.want, names(out))
+			assert.Empty(t, out.System.Continue)
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+
+		var (
+			opts   = new(st.Opts)
+			result = make([]string, 0)
+			pages  int
+		)
+
+		opts.Limit = 2
+		opts.Selector, _ = selector.New("", "status.state=ready")
+
+		for {
+			out := new(objl)
+			err := stg.List(ctx, stg.Collection().Test(), "", out, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			pages++
+			assert.True(t, len(out.Items) <= 2, "page size exceeds limit")
+			result = append(result, names(out)...)
+
+			if out.System.Continue == "" || pages > 3 {
+				break
+			}
+			opts.Continue = out.System.Continue
+		}
+
+		assert.Equal(t, 2, pages)
+		assert.Equal(t, []string{"a", "c", "d"}, result)
+	})
+
+	t.Run("invalid continue", func(t *testing.T) {
+		opts := new(st.Opts)
+		opts.Continue = "%invalid%"
+
+		err := stg.List(ctx, stg.Collection().Test(), "", new(objl), opts)
+		if assert.Error(t, err) {
+			assert.Equal(t, errors.ErrListContinueInvalid, err.Error())
+		}
+	})
+}
+
+func StorageWatchAssets(t *testing.T, stg Storage) {
+
+	type meta struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	type obj struct {
+		types.Runtime
+		Meta meta `json:"meta"`
+	}
+
+	type objl struct {
+		types.Runtime
+		Items []*obj
+	}
+
+	var ctx = context.Background()
+
+	err := stg.Del(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// wait for expected count of events or fail by timeout
+	receive := func(event chan *st.WatcherEvent, count int) []*st.WatcherEvent {
+		items := make([]*st.WatcherEvent, 0)
+		for len(items) < count {
+			select {
+			case e := <-event:
+				items = append(items, e)
+			case <-time.After(5 * time.Second):
+				t.Errorf("watch events timeout: received %d of %d", len(items), count)
+				return items
+			}
+		}
+		return items
+	}
+
+	t.Run("watch with selector", func(t *testing.T) {
+
+		wctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		opts := GetOpts()
+		opts.Selector, _ = selector.New("app=web", "")
+
+		event := NewWatcher()
+		go stg.Watch(wctx, stg.Collection().Test(), event, opts)
+
+		// give watcher time to subscribe
+		time.Sleep(100 * time.Millisecond)
+
+		err := stg.Put(ctx, stg.Collection().Test(), "db", &obj{Meta: meta{Name: "db", Labels: map[string]string{"app": "db"}}}, nil)
+		assert.NoError(t, err)
+
+		err = stg.Put(ctx, stg.Collection().Test(), "web", &obj{Meta: meta{Name: "web", Labels: map[string]string{"app": "web"}}}, nil)
+		assert.NoError(t, err)
+
+		err = stg.Del(ctx, stg.Collection().Test(), "web")
+		assert.NoError(t, err)
+
+		items := receive(event, 2)
+		if !assert.Len(t, items, 2) {
+			return
+		}
+
+		assert.Equal(t, st.STORAGECREATEEVENT, items[0].Action)
+		assert.Equal(t, "web", items[0].Name)
+		assert.Equal(t, st.STORAGEDELETEEVENT, items[1].Action)
+		assert.Equal(t, "web", items[1].Name)
+		assert.True(t, items[1].System.Revision > items[0].System.Revision, "revision should grow")
+	})
+
+	t.Run("watch resume from revision", func(t *testing.T) {
+
+		wctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		list := new(objl)
+		if err := stg.List(ctx, stg.Collection().Test(), "", list, nil); !assert.NoError(t, err) {
+			return
+		}
+
+		item := func(name, version string) *obj {
+			return &obj{Meta: meta{Name: name, Labels: map[string]string{"app": "web", "version": version}}}
+		}
+
+		// changes made while watch is not running, deleted item should be replayed too
+		changes := []struct {
+			action string
+			name   string
+			change func() error
+		}{
+			{st.STORAGECREATEEVENT, "api", func() error { return stg.Put(ctx, stg.Collection().Test(), "api", item("api", "1"), nil) }},
+			{st.STORAGECREATEEVENT, "tmp", func() error { return stg.Put(ctx, stg.Collection().Test(), "tmp", item("tmp", "1"), nil) }},
+			{st.STORAGEUPDATEEVENT, "api", func() error { return stg.Set(ctx, stg.Collection().Test(), "api", item("api", "2"), nil) }},
+			{st.STORAGEDELETEEVENT, "tmp", func() error { return stg.Del(ctx, stg.Collection().Test(), "tmp") }},
+			{st.STORAGEUPDATEEVENT, "api", func() error { return stg.Set(ctx, stg.Collection().Test(), "api", item("api", "3"), nil) }},
+		}
+
+		for _, c := range changes {
+			if err := c.change(); !assert.NoError(t, err) {
+				return
+			}
+		}
+
+		rev := list.System.Revision + 1
+
+		opts := GetOpts()
+		opts.Rev = &rev
+
+		event := NewWatcher()
+		go stg.Watch(wctx, stg.Collection().Test(), event, opts)
+
+		items := receive(event, len(changes))
+		if !assert.Len(t, items, len(changes)) {
+			return
+		}
+
+		last := rev - 1
+		for i, c := range changes {
+			assert.Equal(t, c.action, items[i].Action, "event %d action mismatch", i)
+			assert.Equal(t, c.name, items[i].Name, "event %d name mismatch", i)
+			assert.True(t, items[i].System.Revision > last, "event %d revision should grow", i)
+			last = items[i].System.Revision
+		}
+	})
+}
+
 func StorageMapAssets(t *testing.T, stg Storage) {
 
 	var ctx = context.Background()
@@ -646,4 +975,369 @@ func StorageDelAssets(t *testing.T, stg Storage) {
 		})
 	}
 
+	t.Run("test del keeps items with the same name prefix", func(t *testing.T) {
+
+		err := stg.Del(ctx, stg.Collection().Test(), "")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, name := range []string{"demo", "demo2", "demo:test"} {
+			err := stg.Put(ctx, stg.Collection().Test(), name, &obj{name}, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+		}
+
+		if !assert.NoError(t, stg.Del(ctx, stg.Collection().Test(), "demo")) {
+			return
+		}
+
+		err = stg.Get(ctx, stg.Collection().Test(), "demo", new(obj), nil)
+		if assert.Error(t, err, "expected err") {
+			assert.Equal(t, errors.ErrEntityNotFound, err.Error(), "err message different")
+		}
+
+		assert.NoError(t, stg.Get(ctx, stg.Collection().Test(), "demo2", new(obj), nil), "item should not be removed")
+		assert.NoError(t, stg.Get(ctx, stg.Collection().Test(), "demo:test", new(obj), nil), "item should not be removed")
+	})
+
+	t.Run("test del collection", func(t *testing.T) {
+
+		for _, name := range []string{"a", "b"} {
+			err := stg.Set(ctx, stg.Collection().Test(), name, &obj{name}, &st.Opts{Force: true})
+			if !assert.NoError(t, err) {
+				return
+			}
+		}
+
+		if !assert.NoError(t, stg.Del(ctx, stg.Collection().Test(), "")) {
+			return
+		}
+
+		for _, name := range []string{"a", "b", "demo2"} {
+			err := stg.Get(ctx, stg.Collection().Test(), name, new(obj), nil)
+			if assert.Error(t, err, "expected err") {
+				assert.Equal(t, errors.ErrEntityNotFound, err.Error(), "err message different")
+			}
+		}
+	})
+}
+
+func StorageForceAssets(t *testing.T, stg Storage) {
+
+	var ctx = context.Background()
+
+	type obj struct {
+		Name string `json:"name"`
+		Desc string `json:"desc"`
+	}
+
+	tests := []struct {
+		name   string
+		exists bool
+		put    bool
+		opts   *st.Opts
+		err    string
+		want   string
+	}{
+		{"put exists", true, true, nil, errors.ErrEntityExists, "old"},
+		{"put exists with force", true, true, &st.Opts{Force: true}, errors.ErrEntityExists, "old"},
+		{"put not exists with force", false, true, &st.Opts{Force: true}, "", "new"},
+		{"set not exists without opts", false, false, nil, errors.ErrEntityNotFound, ""},
+		{"set not exists", false, false, &st.Opts{}, errors.ErrEntityNotFound, ""},
+		{"set not exists with force", false, false, &st.Opts{Force: true}, "", "new"},
+		{"set exists without opts", true, false, nil, "", "new"},
+		{"set exists with force", true, false, &st.Opts{Force: true}, "", "new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := stg.Del(ctx, stg.Collection().Test(), "")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if tt.exists {
+				err := stg.Put(ctx, stg.Collection().Test(), "demo", &obj{"demo", "old"}, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+
+			if tt.put {
+				err = stg.Put(ctx, stg.Collection().Test(), "demo", &obj{"demo", "new"}, tt.opts)
+			} else {
+				err = stg.Set(ctx, stg.Collection().Test(), "demo", &obj{"demo", "new"}, tt.opts)
+			}
+
+			if tt.err != "" {
+				if assert.Error(t, err, "expected err") {
+					assert.Equal(t, tt.err, err.Error(), "err message different")
+				}
+			} else if !assert.NoError(t, err) {
+				return
+			}
+
+			out := new(obj)
+			err = stg.Get(ctx, stg.Collection().Test(), "demo", out, nil)
+
+			if tt.want == "" {
+				if assert.Error(t, err, "expected err") {
+					assert.Equal(t, errors.ErrEntityNotFound, err.Error(), "err message different")
+				}
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, out.Desc, "object received error")
+		})
+	}
+}
+
+func StorageConflictAssets(t *testing.T, stg Storage) {
+
+	var ctx = context.Background()
+
+	type obj struct {
+		types.Runtime
+		Name string `json:"name"`
+		Desc string `json:"desc"`
+	}
+
+	type objList struct {
+		types.Runtime
+		Items []*obj
+	}
+
+	rev := func(r int64) *st.Opts {
+		return &st.Opts{Rev: &r}
+	}
+
+	err := stg.Del(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	item := &obj{Name: "demo", Desc: "created"}
+	if !assert.NoError(t, stg.Put(ctx, stg.Collection().Test(), "demo", item, nil)) {
+		return
+	}
+
+	created := new(obj)
+	if !assert.NoError(t, stg.Get(ctx, stg.Collection().Test(), "demo", created, nil)) {
+		return
+	}
+	assert.Equal(t, created.System.Revision, item.System.Revision, "put should set entity revision")
+
+	list := new(objList)
+	if assert.NoError(t, stg.List(ctx, stg.Collection().Test(), "", list, nil)) && assert.Len(t, list.Items, 1) {
+		assert.Equal(t, created.System.Revision, list.Items[0].System.Revision, "list should set entity revision")
+	}
+
+	t.Run("set with current revision", func(t *testing.T) {
+		item.Desc = "updated"
+		if !assert.NoError(t, stg.Set(ctx, stg.Collection().Test(), "demo", item, rev(created.System.Revision))) {
+			return
+		}
+		assert.True(t, item.System.Revision > created.System.Revision, "set should set new entity revision")
+
+		out := new(obj)
+		if assert.NoError(t, stg.Get(ctx, stg.Collection().Test(), "demo", out, nil)) {
+			assert.Equal(t, "updated", out.Desc, "object received error")
+			assert.Equal(t, item.System.Revision, out.System.Revision, "entity revision different")
+		}
+	})
+
+	t.Run("set with outdated revision", func(t *testing.T) {
+		err := stg.Set(ctx, stg.Collection().Test(), "demo", &obj{Name: "demo", Desc: "outdated"}, rev(created.System.Revision))
+		if assert.Error(t, err, "expected err") {
+			assert.Equal(t, errors.ErrEntityConflict, err.Error(), "err message different")
+		}
+
+		out := new(obj)
+		if assert.NoError(t, stg.Get(ctx, stg.Collection().Test(), "demo", out, nil)) {
+			assert.Equal(t, "updated", out.Desc, "object should not be changed")
+		}
+	})
+
+	t.Run("set with revision and force", func(t *testing.T) {
+		opts := rev(created.System.Revision)
+		opts.Force = true
+		err := stg.Set(ctx, stg.Collection().Test(), "demo", &obj{Name: "demo", Desc: "forced"}, opts)
+		if assert.Error(t, err, "expected err") {
+			assert.Equal(t, errors.ErrEntityConflict, err.Error(), "err message different")
+		}
+	})
+
+	t.Run("set not exists with revision", func(t *testing.T) {
+		opts := rev(created.System.Revision)
+		opts.Force = true
+		err := stg.Set(ctx, stg.Collection().Test(), "missing", &obj{Name: "missing"}, opts)
+		if assert.Error(t, err, "expected err") {
+			assert.Equal(t, errors.ErrEntityNotFound, err.Error(), "err message different")
+		}
+	})
+}
+
+func StorageEventsAssets(t *testing.T, stg Storage) {
+
+	var ctx = context.Background()
+
+	type obj struct {
+		Name string `json:"name"`
+		Desc string `json:"desc"`
+	}
+
+	err := stg.Del(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	event := NewWatcher()
+	go stg.Watch(wctx, stg.Collection().Test(), event, nil)
+
+	// give watcher time to subscribe
+	time.Sleep(100 * time.Millisecond)
+
+	force := GetOpts()
+	force.Force = true
+
+	steps := []func() error{
+		func() error { return stg.Put(ctx, stg.Collection().Test(), "a", &obj{"a", "created"}, nil) },
+		func() error { return stg.Set(ctx, stg.Collection().Test(), "a", &obj{"a", "updated"}, nil) },
+		func() error { return stg.Set(ctx, stg.Collection().Test(), "ns:b", &obj{"b", "created"}, force) },
+		func() error { return stg.Set(ctx, stg.Collection().Test(), "ns:b", &obj{"b", "updated"}, force) },
+		func() error { return stg.Del(ctx, stg.Collection().Test(), "a") },
+		func() error { return stg.Del(ctx, stg.Collection().Test(), "") },
+	}
+
+	for _, step := range steps {
+		if !assert.NoError(t, step()) {
+			return
+		}
+	}
+
+	want := []struct {
+		action string
+		name   string
+		link   string
+		desc   string
+	}{
+		{st.STORAGECREATEEVENT, "a", "a", "created"},
+		{st.STORAGEUPDATEEVENT, "a", "a", "updated"},
+		{st.STORAGECREATEEVENT, "b", "ns:b", "created"},
+		{st.STORAGEUPDATEEVENT, "b", "ns:b", "updated"},
+		{st.STORAGEDELETEEVENT, "a", "a", "updated"},
+		{st.STORAGEDELETEEVENT, "b", "ns:b", "updated"},
+	}
+
+	var rev int64
+
+	for i, w := range want {
+
+		var e *st.WatcherEvent
+
+		select {
+		case e = <-event:
+		case <-time.After(5 * time.Second):
+			t.Errorf("watch events timeout: received %d of %d", i, len(want))
+			return
+		}
+
+		assert.Equal(t, w.action, e.Action, "event %d action mismatch", i)
+		assert.Equal(t, w.name, e.Name, "event %d name mismatch", i)
+		assert.Equal(t, w.link, e.SelfLink, "event %d self link mismatch", i)
+		assert.True(t, e.System.Revision > rev, "event %d revision should grow", i)
+		rev = e.System.Revision
+
+		// deleted items are sent with previous state
+		data, ok := e.Data.([]byte)
+		if !assert.True(t, ok, "event %d data should be raw bytes", i) {
+			continue
+		}
+
+		o := new(obj)
+		if assert.NoError(t, json.Unmarshal(data, o), "event %d data decode err", i) {
+			assert.Equal(t, w.desc, o.Desc, "event %d data mismatch", i)
+		}
+	}
+
+	select {
+	case e := <-event:
+		t.Errorf("unexpected event %s %s", e.Action, e.Name)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func StorageTtlAssets(t *testing.T, stg Storage) {
+
+	var ctx = context.Background()
+
+	type obj struct {
+		Name string `json:"name"`
+	}
+
+	err := stg.Del(ctx, stg.Collection().Test(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	event := NewWatcher()
+	go stg.Watch(wctx, stg.Collection().Test(), event, nil)
+
+	// give watcher time to subscribe
+	time.Sleep(100 * time.Millisecond)
+
+	opts := GetOpts()
+	opts.Ttl = 1
+
+	if !assert.NoError(t, stg.Put(ctx, stg.Collection().Test(), "put", &obj{"put"}, opts)) {
+		return
+	}
+
+	opts.Force = true
+	if !assert.NoError(t, stg.Set(ctx, stg.Collection().Test(), "set", &obj{"set"}, opts)) {
+		return
+	}
+
+	if !assert.NoError(t, stg.Put(ctx, stg.Collection().Test(), "keep", &obj{"keep"}, nil)) {
+		return
+	}
+
+	assert.NoError(t, stg.Get(ctx, stg.Collection().Test(), "put", new(obj), nil), "item should exist before ttl expired")
+
+	deleted := make([]string, 0)
+	for len(deleted) < 2 {
+		select {
+		case e := <-event:
+			if e.Action == st.STORAGEDELETEEVENT {
+				deleted = append(deleted, e.Name)
+			}
+		case <-time.After(10 * time.Second):
+			t.Errorf("ttl expiry timeout: received %d of %d delete events", len(deleted), 2)
+			return
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"put", "set"}, deleted, "expired items mismatch")
+
+	for _, name := range []string{"put", "set"} {
+		err := stg.Get(ctx, stg.Collection().Test(), name, new(obj), nil)
+		if assert.Error(t, err, "expected err") {
+			assert.Equal(t, errors.ErrEntityNotFound, err.Error(), "err message different")
+		}
+	}
+
+	assert.NoError(t, stg.Get(ctx, stg.Collection().Test(), "keep", new(obj), nil), "item without ttl should not expire")
 }
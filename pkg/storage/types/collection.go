@@ -22,6 +22,11 @@ type Collection interface {
 	Namespace() string
 	Service() string
 	Deployment() string
+	Revision() string
+	Autoscaler() string
+	User() string
+	Role() string
+	Audit() string
 	Cluster() string
 	Pod() string
 	Ingress() IngressCollection
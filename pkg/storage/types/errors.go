@@ -24,9 +24,13 @@ const (
 	ErrEntityExists          = errors.ErrEntityExists
 	ErrOperationFailure      = errors.ErrOperationFailure
 	ErrEntityNotFound        = errors.ErrEntityNotFound
+	ErrEntityConflict        = errors.ErrEntityConflict
 	ErrStructArgIsNil        = errors.ErrStructArgIsNil
 	ErrStructOutIsNil        = errors.ErrStructOutIsNil
 	ErrStructArgIsInvalid    = errors.ErrStructArgIsInvalid
 	ErrStructOutIsInvalid    = errors.ErrStructOutIsInvalid
 	ErrStructOutIsNotPointer = errors.ErrStructOutIsNotPointer
+	ErrListContinueInvalid   = errors.ErrListContinueInvalid
+	ErrRevisionCompacted     = errors.ErrRevisionCompacted
+	ErrWatchOverflow         = errors.ErrWatchOverflow
 )
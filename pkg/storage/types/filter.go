@@ -23,6 +23,9 @@ type Filter interface {
 	Service() ServiceFilter
 	Config() ConfigFilter
 	Deployment() DeploymentFilter
+	Revision() RevisionFilter
+	Autoscaler() AutoscalerFilter
+	Audit() AuditFilter
 	Pod() PodFilter
 	Endpoint() EndpointFilter
 	Route() RouteFilter
@@ -42,6 +45,18 @@ type DeploymentFilter interface {
 	ByService(namespace, service string) string
 }
 
+type RevisionFilter interface {
+	ByService(namespace, service string) string
+}
+
+type AutoscalerFilter interface {
+	ByNamespace(namespace string) string
+}
+
+type AuditFilter interface {
+	ByNamespace(namespace string) string
+}
+
 type PodFilter interface {
 	ByNamespace(namespace string) string
 	ByService(namespace, service string) string
@@ -22,6 +22,8 @@ type Key interface {
 	Namespace(name string) string
 	Service(namespace, name string) string
 	Deployment(namespace, service, name string) string
+	Revision(namespace, service string, version int) string
+	Autoscaler(namespace, service string) string
 	Pod(namespace, service, deployment, name string) string
 	Endpoint(namespace, service string) string
 	Config(namespace, name string) string
@@ -34,4 +36,7 @@ type Key interface {
 	Node(name string) string
 	Route(namespace, name string) string
 	Subnet(name string) string
+	User(name string) string
+	Role(name string) string
+	Audit(namespace, id string) string
 }
@@ -18,7 +18,14 @@
 
 package types
 
-import "github.com/lastbackend/lastbackend/pkg/distribution/types"
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/selector"
+)
 
 const (
 	STORAGEDELETEEVENT = types.EventActionDelete
@@ -62,9 +69,32 @@ func (qf QueryFilter) String() string {
 type Opts struct {
 	Ttl   uint64
 	Force bool
-	Rev   *int64
+
+	// Rev - watch changes from revision, set item only if it has this revision
+	Rev *int64
+
+	// Selector - selects list items by labels and fields
+	Selector *selector.Selector
+	// Limit - max count of list items, zero means no limit
+	Limit int64
+	// Continue - token returned by previous list to continue from
+	Continue string
 }
 
 type Runtime struct {
 	types.Runtime
 }
+
+// EncodeContinue - build opaque list continue token from last listed key
+func EncodeContinue(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// DecodeContinue - get last listed key from continue token, key should be in list prefix
+func DecodeContinue(token, prefix string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(key), prefix) {
+		return "", errors.New(ErrListContinueInvalid)
+	}
+	return string(key), nil
+}
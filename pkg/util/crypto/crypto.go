@@ -0,0 +1,108 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// KeySize - aes-256 key size
+	KeySize = 32
+	// SaltSize - key derivation salt size
+	SaltSize = 16
+)
+
+var (
+	ErrKeyInvalid  = errors.New("encryption key must be 32 bytes")
+	ErrDataInvalid = errors.New("encrypted data is invalid or key is wrong")
+)
+
+// Seal - encrypt data with aes-gcm, random nonce is prepended to encrypted data.
+// Additional data is authenticated, but not encrypted: data is opened only with the same additional data
+func Seal(key, data, ad []byte) ([]byte, error) {
+
+	gcm, err := aead(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, data, ad), nil
+}
+
+// Open - decrypt and authenticate data encrypted by Seal with the same additional data
+func Open(key, data, ad []byte) ([]byte, error) {
+
+	gcm, err := aead(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrDataInvalid
+	}
+
+	nonce, data := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	res, err := gcm.Open(nil, nonce, data, ad)
+	if err != nil {
+		return nil, ErrDataInvalid
+	}
+
+	return res, nil
+}
+
+// DeriveKey - derive encryption key from passphrase with scrypt
+func DeriveKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, KeySize)
+}
+
+// Random - generate n cryptographically secure random bytes, used for keys and salts
+func Random(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func aead(key []byte) (cipher.AEAD, error) {
+
+	if len(key) != KeySize {
+		return nil, ErrKeyInvalid
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
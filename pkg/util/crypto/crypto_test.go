@@ -0,0 +1,117 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealOpen(t *testing.T) {
+
+	key, err := Random(KeySize)
+	assert.NoError(t, err)
+
+	other, err := Random(KeySize)
+	assert.NoError(t, err)
+
+	var (
+		data = []byte("registry password")
+		ad   = []byte("demo:registry")
+	)
+
+	sealed, err := Seal(key, data, ad)
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(sealed, data), "sealed data contains plain text")
+
+	tests := []struct {
+		name    string
+		key     []byte
+		data    []byte
+		ad      []byte
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "open with key",
+			key:  key,
+			data: sealed,
+			ad:   ad,
+			want: data,
+		},
+		{
+			name:    "open with other additional data",
+			key:     key,
+			data:    sealed,
+			ad:      []byte("demo:other"),
+			wantErr: ErrDataInvalid,
+		},
+		{
+			name:    "open with wrong key",
+			key:     other,
+			data:    sealed,
+			ad:      ad,
+			wantErr: ErrDataInvalid,
+		},
+		{
+			name:    "open truncated data",
+			key:     key,
+			data:    sealed[:4],
+			wantErr: ErrDataInvalid,
+		},
+		{
+			name:    "open with short key",
+			key:     key[:16],
+			data:    sealed,
+			wantErr: ErrKeyInvalid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Open(tc.key, tc.data, tc.ad)
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+
+	salt, err := Random(SaltSize)
+	assert.NoError(t, err)
+
+	a, err := DeriveKey("passphrase", salt)
+	assert.NoError(t, err)
+	assert.Len(t, a, KeySize)
+
+	b, err := DeriveKey("passphrase", salt)
+	assert.NoError(t, err)
+	assert.Equal(t, a, b, "same passphrase and salt derive different keys")
+
+	c, err := DeriveKey("other", salt)
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, c, "different passphrases derive same key")
+}
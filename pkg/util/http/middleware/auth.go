@@ -36,23 +36,8 @@ func Authenticate(h http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		var token string
-		var params = utils.Vars(r)
-		if _, ok := r.URL.Query()["x-lastbackend-token"]; ok {
-			token = r.URL.Query().Get("x-lastbackend-token")
-		} else if _, ok := params["x-lastbackend-token"]; ok {
-			token = params["x-lastbackend-token"]
-		} else if r.Header.Get("Authorization") != "" {
-			// Parse authorization header
-			var auth = strings.SplitN(r.Header.Get("Authorization"), " ", 2)
-
-			// Check authorization header parts length and authorization header format
-			if len(auth) != 2 || auth[0] != "Bearer" {
-				errors.HTTP.Unauthorized(w)
-				return
-			}
-			token = auth[1]
-		} else {
+		token, ok := Token(r)
+		if !ok {
 			errors.HTTP.Unauthorized(w)
 			return
 		}
@@ -65,3 +50,30 @@ func Authenticate(h http.HandlerFunc) http.HandlerFunc {
 		h.ServeHTTP(w, r)
 	}
 }
+
+// Token - fetch access token from request query, route vars or authorization header
+func Token(r *http.Request) (string, bool) {
+
+	var params = utils.Vars(r)
+
+	if _, ok := r.URL.Query()["x-lastbackend-token"]; ok {
+		return r.URL.Query().Get("x-lastbackend-token"), true
+	}
+
+	if _, ok := params["x-lastbackend-token"]; ok {
+		return params["x-lastbackend-token"], true
+	}
+
+	if r.Header.Get("Authorization") != "" {
+		// Parse authorization header
+		var auth = strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+
+		// Check authorization header parts length and authorization header format
+		if len(auth) != 2 || auth[0] != "Bearer" {
+			return "", false
+		}
+		return auth[1], true
+	}
+
+	return "", false
+}
@@ -0,0 +1,325 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+)
+
+// idle client buckets are removed not often than sweep interval
+const limiterSweepInterval = time.Minute
+
+// LimiterOpts - limits applied to each client identity, zero value disables limit
+type LimiterOpts struct {
+	// Rate - requests per second
+	Rate float64
+	// Burst - max requests served at once after idle period
+	Burst int
+	// Concurrent - max requests in progress, used to limit long-running streams
+	Concurrent int
+	// Body - max request body size in bytes
+	Body int64
+}
+
+// Limiter - token bucket rate limiter per client identity.
+// Client is identified by verified access token or by remote ip if token is not set or not valid
+type Limiter struct {
+	lock    sync.Mutex
+	opts    LimiterOpts
+	clients map[string]*limiterBucket
+	sweep   time.Time
+	now     func() time.Time
+	verify  func(r *http.Request, token string) bool
+}
+
+type limiterBucket struct {
+	tokens  float64
+	updated time.Time
+	active  int
+}
+
+// NewLimiter - create limiter with options, limits can be changed later with Configure
+func NewLimiter(opts LimiterOpts) *Limiter {
+	return &Limiter{
+		opts:    opts,
+		clients: make(map[string]*limiterBucket),
+		now:     time.Now,
+	}
+}
+
+// Configure - set limits and reset client buckets
+func (l *Limiter) Configure(opts LimiterOpts) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.opts = opts
+	l.clients = make(map[string]*limiterBucket)
+}
+
+// Verify - set access token verification, requests are limited by remote ip if verification is not set
+func (l *Limiter) Verify(verify func(r *http.Request, token string) bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.verify = verify
+}
+
+// Options - get current limits
+func (l *Limiter) Options() LimiterOpts {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.opts
+}
+
+// Limit - rate limiting middleware, responds 429 with Retry-After header if limit is exceeded
+// and 413 if request body is larger than allowed
+func (l *Limiter) Limit(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		key, token := l.identify(r)
+
+		ok, retry, body := l.acquire(key)
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+			errors.HTTP.TooManyRequests(w)
+			return
+		}
+		defer l.release(key)
+
+		// token is verified only after request is accepted by remote ip limits,
+		// so requests with invalid tokens can not bypass limits
+		if token != "" && l.verified(r, token) {
+			l.register(tokenKey(token))
+		}
+
+		if body > 0 && r.Body != nil {
+			if r.ContentLength > body {
+				errors.HTTP.RequestEntityTooLarge(w)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, body)
+
+			// body size is not known before it is read, so response is replaced with 413
+			// if handler failed to read body over limit
+			if r.ContentLength < 0 {
+				lb := &limitBody{ReadCloser: r.Body, left: body}
+				r.Body = lb
+				w = &limitWriter{ResponseWriter: w, body: lb}
+			}
+		}
+
+		h.ServeHTTP(w, r)
+	}
+}
+
+// identify - get client bucket key, access token is returned if it should be verified:
+// requests with token, which is not verified yet, are limited by remote ip
+func (l *Limiter) identify(r *http.Request) (string, string) {
+
+	token, ok := Token(r)
+	if !ok || token == "" {
+		return addressKey(r), ""
+	}
+
+	key := tokenKey(token)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.verify == nil {
+		return addressKey(r), ""
+	}
+
+	if _, ok := l.clients[key]; ok {
+		return key, ""
+	}
+
+	return addressKey(r), token
+}
+
+func (l *Limiter) verified(r *http.Request, token string) bool {
+
+	l.lock.Lock()
+	verify := l.verify
+	l.lock.Unlock()
+
+	return verify != nil && verify(r, token)
+}
+
+// register - create bucket for verified client, bucket is removed by cleanup when client is idle
+func (l *Limiter) register(key string) {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if _, ok := l.clients[key]; ok {
+		return
+	}
+
+	l.clients[key] = &limiterBucket{
+		tokens:  math.Max(float64(l.opts.Burst), 1),
+		updated: l.now(),
+	}
+}
+
+// acquire - take token from client bucket, returns time to wait for next token if there are no tokens left
+func (l *Limiter) acquire(key string) (bool, time.Duration, int64) {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var (
+		now  = l.now()
+		opts = l.opts
+	)
+
+	l.cleanup(now)
+
+	burst := math.Max(float64(opts.Burst), 1)
+
+	b, ok := l.clients[key]
+	if !ok {
+		b = &limiterBucket{tokens: burst, updated: now}
+		l.clients[key] = b
+	}
+
+	if opts.Concurrent > 0 && b.active >= opts.Concurrent {
+		return false, time.Second, opts.Body
+	}
+
+	if opts.Rate > 0 {
+
+		b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*opts.Rate)
+		b.updated = now
+
+		if b.tokens < 1 {
+			wait := time.Duration((1 - b.tokens) / opts.Rate * float64(time.Second))
+			return false, wait, opts.Body
+		}
+
+		b.tokens--
+	}
+
+	b.active++
+	return true, 0, opts.Body
+}
+
+func (l *Limiter) release(key string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if b, ok := l.clients[key]; ok && b.active > 0 {
+		b.active--
+	}
+}
+
+// cleanup - remove buckets of idle clients, which are refilled to burst already
+func (l *Limiter) cleanup(now time.Time) {
+
+	if now.Sub(l.sweep) < limiterSweepInterval {
+		return
+	}
+	l.sweep = now
+
+	refill := time.Duration(0)
+	if l.opts.Rate > 0 {
+		refill = time.Duration(math.Max(float64(l.opts.Burst), 1) / l.opts.Rate * float64(time.Second))
+	}
+
+	for key, b := range l.clients {
+		if b.active == 0 && now.Sub(b.updated) >= refill {
+			delete(l.clients, key)
+		}
+	}
+}
+
+func tokenKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(hash[:])
+}
+
+func addressKey(r *http.Request) string {
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// limitBody - request body with unknown size, which marks request if body is larger than allowed
+type limitBody struct {
+	io.ReadCloser
+	left     int64
+	exceeded bool
+}
+
+func (b *limitBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.left -= int64(n)
+	if err != nil && err != io.EOF && b.left <= 0 {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// limitWriter - replaces handler response with 413 if request body was larger than allowed
+type limitWriter struct {
+	http.ResponseWriter
+	body    *limitBody
+	written bool
+}
+
+func (w *limitWriter) WriteHeader(code int) {
+
+	if !w.body.exceeded {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	if !w.written {
+		w.written = true
+		errors.HTTP.RequestEntityTooLarge(w.ResponseWriter)
+	}
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+
+	if !w.body.exceeded {
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	return len(p), nil
+}
+
+func (w *limitWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
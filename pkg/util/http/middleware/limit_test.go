@@ -0,0 +1,191 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package middleware_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+// Testing Limiter middleware rate, concurrency and body size limits
+func TestLimiterMiddleware(t *testing.T) {
+
+	type request struct {
+		token   string
+		ip      string
+		body    string
+		chunked bool
+	}
+
+	tests := []struct {
+		description   string
+		opts          middleware.LimiterOpts
+		tokens        []string
+		requests      []request
+		expectedCodes []int
+	}{
+		{
+			description:   "requests are not limited with empty options",
+			opts:          middleware.LimiterOpts{},
+			requests:      []request{{ip: "10.0.0.1"}, {ip: "10.0.0.1"}, {ip: "10.0.0.1"}},
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+		{
+			description:   "requests over burst are limited",
+			opts:          middleware.LimiterOpts{Rate: 0.1, Burst: 2},
+			requests:      []request{{ip: "10.0.0.1"}, {ip: "10.0.0.1"}, {ip: "10.0.0.1"}},
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			description:   "clients with different ip have separate budgets",
+			opts:          middleware.LimiterOpts{Rate: 0.1, Burst: 1},
+			requests:      []request{{ip: "10.0.0.1"}, {ip: "10.0.0.2"}, {ip: "10.0.0.1"}},
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			description:   "clients with different verified token have separate budgets",
+			opts:          middleware.LimiterOpts{Rate: 0.1, Burst: 2},
+			tokens:        []string{"demo", "test"},
+			requests:      []request{{ip: "10.0.0.1", token: "demo"}, {ip: "10.0.0.1", token: "test"}, {ip: "10.0.0.1", token: "demo"}, {ip: "10.0.0.1", token: "demo"}, {ip: "10.0.0.2", token: "demo"}},
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			description:   "clients with invalid token are limited by ip",
+			opts:          middleware.LimiterOpts{Rate: 0.1, Burst: 1},
+			tokens:        []string{"demo"},
+			requests:      []request{{ip: "10.0.0.1", token: "fake"}, {ip: "10.0.0.1", token: "other"}, {ip: "10.0.0.2", token: "fake"}},
+			expectedCodes: []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK},
+		},
+		{
+			description:   "clients with token are limited by ip if verification is not set",
+			opts:          middleware.LimiterOpts{Rate: 0.1, Burst: 1},
+			requests:      []request{{ip: "10.0.0.1", token: "demo"}, {ip: "10.0.0.1", token: "test"}},
+			expectedCodes: []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			description:   "request body over limit is rejected",
+			opts:          middleware.LimiterOpts{Body: 4},
+			requests:      []request{{ip: "10.0.0.1", body: "demo"}, {ip: "10.0.0.1", body: "demo body"}},
+			expectedCodes: []int{http.StatusOK, http.StatusRequestEntityTooLarge},
+		},
+		{
+			description:   "request body of unknown size over limit is rejected",
+			opts:          middleware.LimiterOpts{Body: 4},
+			requests:      []request{{ip: "10.0.0.1", body: "demo", chunked: true}, {ip: "10.0.0.1", body: "demo body", chunked: true}},
+			expectedCodes: []int{http.StatusOK, http.StatusRequestEntityTooLarge},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+
+			l := middleware.NewLimiter(tc.opts)
+			if tc.tokens != nil {
+				l.Verify(func(r *http.Request, token string) bool {
+					for _, t := range tc.tokens {
+						if t == token {
+							return true
+						}
+					}
+					return false
+				})
+			}
+
+			// handler fails if body can not be read, as api handlers do
+			handler := l.Limit(func(w http.ResponseWriter, r *http.Request) {
+				if _, err := ioutil.ReadAll(r.Body); err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			for i, rq := range tc.requests {
+
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(rq.body))
+				req.RemoteAddr = rq.ip + ":12345"
+				if rq.chunked {
+					req.ContentLength = -1
+				}
+				if rq.token != "" {
+					req.Header.Set("Authorization", "Bearer "+rq.token)
+				}
+
+				res := httptest.NewRecorder()
+				handler.ServeHTTP(res, req)
+
+				if !assert.Equal(t, tc.expectedCodes[i], res.Code, "status code not equal") {
+					return
+				}
+
+				if res.Code == http.StatusTooManyRequests {
+					assert.Equal(t, "10", res.Header().Get("Retry-After"), "retry after header not equal")
+				}
+			}
+		})
+	}
+}
+
+// Testing Limiter middleware concurrent requests limit
+func TestLimiterMiddlewareConcurrent(t *testing.T) {
+
+	var (
+		wg      sync.WaitGroup
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	l := middleware.NewLimiter(middleware.LimiterOpts{Concurrent: 1})
+	handler := l.Limit(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	request := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.1:12345"
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		return res
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.Equal(t, http.StatusOK, request().Code, "long-running request should be served")
+	}()
+	<-started
+
+	res := request()
+	assert.Equal(t, http.StatusTooManyRequests, res.Code, "concurrent request should be limited")
+	assert.Equal(t, "1", res.Header().Get("Retry-After"), "retry after header not equal")
+
+	close(release)
+	wg.Wait()
+
+	go func() { <-started }()
+	assert.Equal(t, http.StatusOK, request().Code, "request should be served after stream is closed")
+}
@@ -0,0 +1,380 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	h "github.com/lastbackend/lastbackend/pkg/util/http"
+)
+
+// Version - OpenAPI specification version of built document
+const Version = "3.0.0"
+
+var pathParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	emptyType         = reflect.TypeOf(h.Empty{})
+	rawType           = reflect.TypeOf(h.Raw{})
+)
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem - operations of path by lower case http method
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Validate - check that all routes describe request and response body schema,
+// request schema is required for methods with body only
+func Validate(routes []h.Route) error {
+
+	missing := make([]string, 0)
+
+	for _, r := range routes {
+		if r.Response == nil || (hasBody(r.Method) && r.Request == nil) {
+			missing = append(missing, fmt.Sprintf("%s %s", r.Method, r.Path))
+		}
+	}
+
+	if len(missing) != 0 {
+		return fmt.Errorf("routes without schema: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// Build - create OpenAPI document from routes and their request and response schemas
+func Build(info Info, routes []h.Route) *Document {
+
+	b := &builder{
+		types: make(map[reflect.Type]string),
+		names: make(map[string]reflect.Type),
+		doc: &Document{
+			OpenAPI:    Version,
+			Info:       info,
+			Paths:      make(map[string]PathItem),
+			Components: Components{Schemas: make(map[string]*Schema)},
+		},
+	}
+
+	ids := make(map[string]bool)
+
+	for _, r := range routes {
+
+		tag, id := handlerName(r.Handler)
+
+		op := &Operation{
+			OperationID: id,
+			Responses:   make(map[string]*Response),
+		}
+
+		if tag != "" {
+			op.Tags = []string{tag}
+		}
+
+		params := pathParam.FindAllStringSubmatch(r.Path, -1)
+		for _, p := range params {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:     p[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+
+		// routes with shared handler get operation id suffix from path parameters
+		if ids[op.OperationID] {
+			for _, p := range params {
+				op.OperationID += "By" + strings.ToUpper(p[1][:1]) + p[1][1:]
+			}
+		}
+		for i := 2; ids[op.OperationID]; i++ {
+			op.OperationID = fmt.Sprintf("%s%d", id, i)
+		}
+		ids[op.OperationID] = true
+
+		if r.Request != nil && reflect.TypeOf(r.Request) != emptyType {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  b.content(r.Request),
+			}
+		}
+
+		op.Responses["200"] = &Response{
+			Description: http.StatusText(http.StatusOK),
+			Content:     b.content(r.Response),
+		}
+
+		item, ok := b.doc.Paths[r.Path]
+		if !ok {
+			item = make(PathItem)
+			b.doc.Paths[r.Path] = item
+		}
+		item[strings.ToLower(r.Method)] = op
+	}
+
+	return b.doc
+}
+
+type builder struct {
+	doc   *Document
+	types map[reflect.Type]string
+	names map[string]reflect.Type
+}
+
+// content - get body media types by schema, empty body has no content
+func (b *builder) content(v interface{}) map[string]*MediaType {
+
+	if v == nil {
+		return nil
+	}
+
+	switch reflect.TypeOf(v) {
+	case emptyType:
+		return nil
+	case rawType:
+		return map[string]*MediaType{
+			"application/octet-stream": {Schema: &Schema{Type: "string", Format: "binary"}},
+		}
+	}
+
+	return map[string]*MediaType{
+		"application/json": {Schema: b.schema(reflect.TypeOf(v))},
+	}
+}
+
+// schema - get schema of type, named structs are stored in components and referenced
+func (b *builder) schema(t reflect.Type) *Schema {
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == durationType:
+		return &Schema{Type: "integer", Format: "int64"}
+	case t == rawMessageType, t.Kind() == reflect.Interface:
+		return &Schema{}
+	case t.Implements(jsonMarshalerType), reflect.PtrTo(t).Implements(jsonMarshalerType):
+		return &Schema{}
+	case t.Implements(textMarshalerType), reflect.PtrTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + b.component(t)}
+	}
+
+	return &Schema{}
+}
+
+// component - register named type schema in components and get its name
+func (b *builder) component(t reflect.Type) string {
+
+	if name, ok := b.types[t]; ok {
+		return name
+	}
+
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	for i := 2; b.names[name] != nil; i++ {
+		name = fmt.Sprintf("%s.%s%d", path.Base(t.PkgPath()), t.Name(), i)
+	}
+
+	// register name before fields to support recursive types
+	b.types[t] = name
+	b.names[name] = t
+	b.doc.Components.Schemas[name] = b.object(t)
+
+	return name
+}
+
+// object - get struct schema by json encoding rules
+func (b *builder) object(t reflect.Type) *Schema {
+
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.fields(t, s)
+	return s
+}
+
+func (b *builder) fields(t reflect.Type, s *Schema) {
+
+	embedded := make([]reflect.Type, 0)
+
+	for i := 0; i < t.NumField(); i++ {
+
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		s.Properties[name] = b.schema(f.Type)
+	}
+
+	// embedded struct fields are promoted to parent object, parent fields take precedence
+	for _, et := range embedded {
+		es := &Schema{Properties: make(map[string]*Schema)}
+		b.fields(et, es)
+		for name, p := range es.Properties {
+			if _, ok := s.Properties[name]; !ok {
+				s.Properties[name] = p
+			}
+		}
+	}
+}
+
+// handlerName - get handler package name and function name without handler suffix
+func handlerName(fn func(w http.ResponseWriter, r *http.Request)) (string, string) {
+
+	if fn == nil {
+		return "", ""
+	}
+
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "", ""
+	}
+
+	name := path.Base(f.Name())
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) != 2 {
+		return "", name
+	}
+
+	return parts[0], strings.TrimSuffix(parts[1], "H")
+}
+
+func hasBody(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	h "github.com/lastbackend/lastbackend/pkg/util/http"
+	"github.com/lastbackend/lastbackend/pkg/util/http/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+type meta struct {
+	Name    int       `json:"name"`
+	Created time.Time `json:"created"`
+	secret  string
+}
+
+type item struct {
+	meta
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Ports  []int             `json:"ports"`
+	Data   []byte            `json:"data"`
+	Parent *item             `json:"parent"`
+	Skip   string            `json:"-"`
+}
+
+type itemList []*item
+
+type itemManifest struct {
+	Name *string `json:"name"`
+}
+
+func ItemListH(w http.ResponseWriter, r *http.Request) {}
+
+func ItemCreateH(w http.ResponseWriter, r *http.Request) {}
+
+func ItemRemoveH(w http.ResponseWriter, r *http.Request) {}
+
+func TestBuild(t *testing.T) {
+
+	routes := []h.Route{
+		{Path: "/item", Method: http.MethodGet, Handler: ItemListH, Response: itemList{}},
+		{Path: "/namespace/{namespace}/item", Method: http.MethodGet, Handler: ItemListH, Response: itemList{}},
+		{Path: "/item", Method: http.MethodPost, Handler: ItemCreateH, Request: itemManifest{}, Response: item{}},
+		{Path: "/item/{item}", Method: http.MethodDelete, Handler: ItemRemoveH, Response: h.Empty{}},
+		{Path: "/item/{item}/logs", Method: http.MethodGet, Handler: ItemListH, Response: h.Raw{}},
+	}
+
+	doc := openapi.Build(openapi.Info{Title: "test", Version: "v1"}, routes)
+
+	list := doc.Paths["/item"]["get"]
+	if assert.NotNil(t, list, "list operation not found") {
+		assert.Equal(t, "ItemList", list.OperationID, "operation id not equal")
+		assert.Equal(t, []string{"openapi_test"}, list.Tags, "operation tags not equal")
+		assert.Equal(t, "array", list.Responses["200"].Content["application/json"].Schema.Type, "list schema type not equal")
+	}
+
+	ns := doc.Paths["/namespace/{namespace}/item"]["get"]
+	if assert.NotNil(t, ns, "namespace list operation not found") {
+		assert.Equal(t, "ItemListByNamespace", ns.OperationID, "shared handler operation id should be unique")
+		assert.Len(t, ns.Parameters, 1, "path parameters count not equal")
+	}
+
+	create := doc.Paths["/item"]["post"]
+	if assert.NotNil(t, create, "create operation not found") && assert.NotNil(t, create.RequestBody, "request body not found") {
+		assert.Equal(t, "#/components/schemas/openapi_test.itemManifest",
+			create.RequestBody.Content["application/json"].Schema.Ref, "request schema ref not equal")
+	}
+
+	remove := doc.Paths["/item/{item}"]["delete"]
+	if assert.NotNil(t, remove, "remove operation not found") {
+		assert.Nil(t, remove.RequestBody, "request body should be empty")
+		assert.Empty(t, remove.Responses["200"].Content, "response content should be empty")
+	}
+
+	logs := doc.Paths["/item/{item}/logs"]["get"]
+	if assert.NotNil(t, logs, "logs operation not found") {
+		assert.Contains(t, logs.Responses["200"].Content, "application/octet-stream", "raw response content not found")
+	}
+
+	s, ok := doc.Components.Schemas["openapi_test.item"]
+	if !assert.True(t, ok, "item schema not found") {
+		return
+	}
+
+	assert.Equal(t, "string", s.Properties["name"].Type, "item field should take precedence over embedded one")
+	assert.Equal(t, "date-time", s.Properties["created"].Format, "embedded field should be promoted")
+	assert.Equal(t, "string", s.Properties["labels"].AdditionalProperties.Type, "map schema not equal")
+	assert.Equal(t, "integer", s.Properties["ports"].Items.Type, "array items schema not equal")
+	assert.Equal(t, "byte", s.Properties["data"].Format, "bytes schema not equal")
+	assert.Equal(t, "#/components/schemas/openapi_test.item", s.Properties["parent"].Ref, "recursive schema ref not equal")
+	assert.NotContains(t, s.Properties, "Skip", "skipped field should not be described")
+	assert.NotContains(t, s.Properties, "secret", "private field should not be described")
+}
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		route   h.Route
+		wantErr bool
+	}{
+		{"route with schema", h.Route{Path: "/item", Method: http.MethodPost, Request: itemManifest{}, Response: item{}}, false},
+		{"route without body", h.Route{Path: "/item", Method: http.MethodGet, Response: itemList{}}, false},
+		{"route without response schema", h.Route{Path: "/item", Method: http.MethodGet}, true},
+		{"route without request schema", h.Route{Path: "/item", Method: http.MethodPut, Response: item{}}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := openapi.Validate([]h.Route{tc.route})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/gorilla/websocket"
 	"golang.org/x/net/http2"
 	"io"
 	"io/ioutil"
@@ -227,6 +228,48 @@ func (r *Request) Stream() (io.ReadCloser, error) {
 	}
 }
 
+// Dial - open websocket connection to request url
+func (r *Request) Dial() (*websocket.Conn, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	u := r.URL()
+
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+
+	dialer := *websocket.DefaultDialer
+	if c, ok := r.client.(*http.Client); ok && c != nil {
+		if t, ok := c.Transport.(*http.Transport); ok && t != nil {
+			dialer.TLSClientConfig = t.TLSClientConfig
+		}
+	}
+
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	conn, res, err := dialer.DialContext(ctx, u.String(), r.headers)
+	if err != nil {
+		if res != nil && res.Body != nil {
+			defer res.Body.Close()
+			result := r.transformResponse(res, nil)
+			if result.Error() == nil && len(result.body) != 0 {
+				return nil, fmt.Errorf("%d while accessing %v: %s", result.statusCode, u, string(result.body))
+			}
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
 func (r *Request) Param(name, value string) *Request {
 	if r.params == nil {
 		r.params = make(url.Values)
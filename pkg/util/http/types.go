@@ -25,8 +25,19 @@ type Route struct {
 	Handler    func(w http.ResponseWriter, r *http.Request)
 	Middleware []Middleware
 	Method     string
+	// Request - request body schema, used to describe api
+	Request interface{}
+	// Response - response body schema, used to describe api
+	Response interface{}
 }
 
+// Empty - schema of request or response without body
+type Empty struct{}
+
+// Raw - schema of request or response body which is not json document:
+// yaml manifests, logs or websocket stream
+type Raw struct{}
+
 //type Middleware interface {
 //	Handler(func(w http.ResponseWriter, r *http.Request) error) func(w http.ResponseWriter, r *http.Request) error
 //}
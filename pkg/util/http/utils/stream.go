@@ -0,0 +1,56 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// StreamWriter - writes json objects into chunked response one by one
+type StreamWriter struct {
+	w       http.ResponseWriter
+	encoder *json.Encoder
+}
+
+// NewStreamWriter - start chunked json response
+func NewStreamWriter(w http.ResponseWriter) *StreamWriter {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	s := &StreamWriter{w: w, encoder: json.NewEncoder(w)}
+	s.flush()
+	return s
+}
+
+// Write - encode object and send it to client immediately
+func (s *StreamWriter) Write(obj interface{}) error {
+	if err := s.encoder.Encode(obj); err != nil {
+		return err
+	}
+	s.flush()
+	return nil
+}
+
+func (s *StreamWriter) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
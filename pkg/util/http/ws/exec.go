@@ -0,0 +1,224 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package ws
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+// Exec streams are multiplexed over websocket binary messages,
+// first byte of each message is a channel of stream
+const (
+	ChannelStdin byte = iota
+	ChannelStdout
+	ChannelStderr
+	// ChannelStatus - json encoded ExecStatus, sent before connection close
+	ChannelStatus
+	// ChannelResize - json encoded terminal size
+	ChannelResize
+)
+
+// stdinQueueSize - count of stdin messages buffered while command is not reading input
+const stdinQueueSize = 64
+
+// ExecStatus - result of executed command
+type ExecStatus struct {
+	ExitCode int    `json:"exit_code"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ExecStream - server side of command streams multiplexed over websocket connection
+type ExecStream struct {
+	lock sync.Mutex
+	conn *websocket.Conn
+
+	stdin  *io.PipeReader
+	input  *io.PipeWriter
+	queue  chan []byte
+	resize chan *types.TerminalSize
+	done   chan struct{}
+}
+
+// NewExecStream - create streams over connection, stdin is attached only if requested,
+// empty stdin message closes command input
+func NewExecStream(conn *websocket.Conn, stdin bool) *ExecStream {
+
+	s := &ExecStream{
+		conn:   conn,
+		resize: make(chan *types.TerminalSize, 1),
+		done:   make(chan struct{}),
+	}
+
+	if stdin {
+		s.stdin, s.input = io.Pipe()
+		s.queue = make(chan []byte, stdinQueueSize)
+		go s.pump()
+	}
+
+	go s.read()
+	return s
+}
+
+// pump - write queued input into command stdin, stdin is closed when queue is closed
+func (s *ExecStream) pump() {
+
+	defer s.input.Close()
+
+	for msg := range s.queue {
+		// input is not consumed anymore, drain queue until connection is closed
+		if _, err := s.input.Write(msg); err != nil {
+			for range s.queue {
+			}
+			return
+		}
+	}
+}
+
+func (s *ExecStream) read() {
+
+	var closed bool
+
+	defer func() {
+		if s.queue != nil && !closed {
+			close(s.queue)
+		}
+		close(s.done)
+	}()
+
+	for {
+		_, msg, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if len(msg) == 0 {
+			continue
+		}
+
+		switch msg[0] {
+		case ChannelStdin:
+			if s.queue == nil || closed {
+				continue
+			}
+			if len(msg) == 1 {
+				close(s.queue)
+				closed = true
+				continue
+			}
+			// stdin is written by pump, so slow command input does not block resize and close,
+			// input is dropped if command does not read it and queue is full
+			select {
+			case s.queue <- msg[1:]:
+			default:
+			}
+		case ChannelResize:
+			size := new(types.TerminalSize)
+			if err := json.Unmarshal(msg[1:], size); err != nil {
+				continue
+			}
+			// keep only the latest size if previous was not applied yet
+			select {
+			case <-s.resize:
+			default:
+			}
+			s.resize <- size
+		}
+	}
+}
+
+// Stream - command streams to pass into container runtime
+func (s *ExecStream) Stream() *types.ContainerExecStream {
+
+	stream := &types.ContainerExecStream{
+		Stdout: &channelWriter{s, ChannelStdout},
+		Stderr: &channelWriter{s, ChannelStderr},
+		Resize: s.resize,
+	}
+
+	if s.stdin != nil {
+		stream.Stdin = s.stdin
+	}
+
+	return stream
+}
+
+// Done - closed when connection is closed by client
+func (s *ExecStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close - send command status and close connection
+func (s *ExecStream) Close(code int, err error) error {
+
+	status := ExecStatus{ExitCode: code}
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	buf, e := json.Marshal(status)
+	if e != nil {
+		return e
+	}
+
+	if e := s.write(ChannelStatus, buf); e != nil {
+		return e
+	}
+
+	if s.stdin != nil {
+		_ = s.stdin.Close()
+	}
+
+	s.lock.Lock()
+	_ = s.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+	s.lock.Unlock()
+
+	return s.conn.Close()
+}
+
+func (s *ExecStream) write(channel byte, p []byte) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	msg := make([]byte, len(p)+1)
+	msg[0] = channel
+	copy(msg[1:], p)
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return s.conn.WriteMessage(websocket.BinaryMessage, msg)
+}
+
+type channelWriter struct {
+	stream  *ExecStream
+	channel byte
+}
+
+func (w *channelWriter) Write(p []byte) (int, error) {
+	if err := w.stream.write(w.channel, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
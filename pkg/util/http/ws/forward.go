@@ -0,0 +1,365 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package ws
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Port forward multiplexes tcp connections over one websocket connection.
+// Each binary message is a frame: frame type byte, big endian uint32 connection id and payload.
+const (
+	// FrameOpen - client opens new connection to target
+	FrameOpen byte = iota
+	// FrameData - connection data
+	FrameData
+	// FrameClose - connection is closed by other side
+	FrameClose
+	// FrameError - connection to target failed, payload is error message
+	FrameError
+)
+
+const (
+	frameHeaderSize = 5
+	forwardBuffer   = 32 * 1024
+	// forwardQueueSize - count of data frames buffered for connection, which is not written yet
+	forwardQueueSize = 64
+)
+
+// Forwarder - one side of multiplexed port forward stream
+type Forwarder struct {
+	lock  sync.Mutex
+	write sync.Mutex
+
+	conn  *websocket.Conn
+	dial  func() (net.Conn, error)
+	conns map[uint32]*stream
+	next  uint32
+
+	done chan struct{}
+	once sync.Once
+}
+
+// stream - forwarded connection, data frames are written into connection by own goroutine
+// so slow connection or dial does not block other connections
+type stream struct {
+	conn  net.Conn
+	queue chan []byte
+	done  chan struct{}
+}
+
+func newStream(conn net.Conn) *stream {
+	return &stream{
+		conn:  conn,
+		queue: make(chan []byte, forwardQueueSize),
+		done:  make(chan struct{}),
+	}
+}
+
+// ServeForward - serve port forward stream, connections opened by client are dialed with dial func.
+// Blocks until websocket connection is closed.
+func ServeForward(conn *websocket.Conn, dial func() (net.Conn, error)) error {
+	f := newForwarder(conn, dial)
+	return f.read()
+}
+
+// NewForwardClient - create client side of port forward stream
+func NewForwardClient(conn *websocket.Conn) *Forwarder {
+	f := newForwarder(conn, nil)
+	go func() {
+		_ = f.read()
+	}()
+	return f
+}
+
+func newForwarder(conn *websocket.Conn, dial func() (net.Conn, error)) *Forwarder {
+	return &Forwarder{
+		conn:  conn,
+		dial:  dial,
+		conns: make(map[uint32]*stream),
+		done:  make(chan struct{}),
+	}
+}
+
+// Forward - forward local connection through stream
+func (f *Forwarder) Forward(local net.Conn) error {
+
+	s := newStream(local)
+
+	f.lock.Lock()
+	f.next++
+	id := f.next
+	f.conns[id] = s
+	f.lock.Unlock()
+
+	if err := f.send(FrameOpen, id, nil); err != nil {
+		f.remove(id, s)
+		return err
+	}
+
+	go f.pump(id, s, local)
+	go f.flush(id, s, local)
+	return nil
+}
+
+// Listen - accept connections from listener and forward them until listener or stream is closed
+func (f *Forwarder) Listen(l net.Listener) error {
+
+	go func() {
+		<-f.done
+		_ = l.Close()
+	}()
+
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			select {
+			case <-f.done:
+				return nil
+			default:
+			}
+			_ = f.Close()
+			return err
+		}
+
+		if err := f.Forward(c); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+}
+
+// Done - closed when stream is closed
+func (f *Forwarder) Done() <-chan struct{} {
+	return f.done
+}
+
+// Close - close stream and all forwarded connections
+func (f *Forwarder) Close() error {
+
+	f.write.Lock()
+	_ = f.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+	f.write.Unlock()
+
+	f.shutdown()
+	return f.conn.Close()
+}
+
+func (f *Forwarder) shutdown() {
+	f.once.Do(func() {
+		f.lock.Lock()
+		for id, s := range f.conns {
+			s.close()
+			delete(f.conns, id)
+		}
+		f.lock.Unlock()
+		close(f.done)
+	})
+}
+
+func (f *Forwarder) read() error {
+
+	defer f.shutdown()
+
+	for {
+		_, msg, err := f.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return nil
+			}
+			return err
+		}
+
+		if len(msg) < frameHeaderSize {
+			continue
+		}
+
+		var (
+			kind    = msg[0]
+			id      = binary.BigEndian.Uint32(msg[1:frameHeaderSize])
+			payload = msg[frameHeaderSize:]
+		)
+
+		switch kind {
+		case FrameOpen:
+			if f.dial == nil {
+				continue
+			}
+
+			// connection id is already in use: close both connections,
+			// as data frames can not be matched to one of them
+			if s := f.get(id); s != nil {
+				f.remove(id, s)
+				_ = f.send(FrameError, id, []byte("connection id is already in use"))
+				continue
+			}
+
+			s := newStream(nil)
+
+			f.lock.Lock()
+			f.conns[id] = s
+			f.lock.Unlock()
+
+			go f.open(id, s)
+
+		case FrameData:
+			s := f.get(id)
+			if s == nil {
+				continue
+			}
+
+			// connection does not consume data fast enough, close it instead of blocking other connections
+			select {
+			case s.queue <- payload:
+			default:
+				if f.remove(id, s) {
+					_ = f.send(FrameClose, id, nil)
+				}
+			}
+
+		case FrameClose, FrameError:
+			if s := f.get(id); s != nil {
+				f.remove(id, s)
+			}
+		}
+	}
+}
+
+// open - dial connection to target and start forwarding
+func (f *Forwarder) open(id uint32, s *stream) {
+
+	c, err := f.dial()
+	if err != nil {
+		if f.remove(id, s) {
+			_ = f.send(FrameError, id, []byte(err.Error()))
+		}
+		return
+	}
+
+	f.lock.Lock()
+	if f.conns[id] != s {
+		// stream was closed while dialing
+		f.lock.Unlock()
+		_ = c.Close()
+		return
+	}
+	s.conn = c
+	f.lock.Unlock()
+
+	go f.pump(id, s, c)
+	f.flush(id, s, c)
+}
+
+// flush - write queued stream data into connection until stream is closed
+func (f *Forwarder) flush(id uint32, s *stream, c net.Conn) {
+	for {
+		select {
+		case <-s.done:
+			return
+		case p := <-s.queue:
+			if _, err := c.Write(p); err != nil {
+				if f.remove(id, s) {
+					_ = f.send(FrameClose, id, nil)
+				}
+				return
+			}
+		}
+	}
+}
+
+// pump - copy connection data into stream until connection is closed
+func (f *Forwarder) pump(id uint32, s *stream, c net.Conn) {
+
+	buf := make([]byte, forwardBuffer)
+
+	for {
+		n, err := c.Read(buf)
+		if n > 0 {
+			if err := f.send(FrameData, id, buf[:n]); err != nil {
+				f.remove(id, s)
+				return
+			}
+		}
+
+		if err != nil {
+			// notify other side only if connection was not closed by it
+			if f.remove(id, s) {
+				_ = f.send(FrameClose, id, nil)
+			}
+			return
+		}
+	}
+}
+
+func (f *Forwarder) send(kind byte, id uint32, payload []byte) error {
+
+	msg := make([]byte, frameHeaderSize+len(payload))
+	msg[0] = kind
+	binary.BigEndian.PutUint32(msg[1:frameHeaderSize], id)
+	copy(msg[frameHeaderSize:], payload)
+
+	f.write.Lock()
+	defer f.write.Unlock()
+
+	select {
+	case <-f.done:
+		return fmt.Errorf("stream is closed")
+	default:
+	}
+
+	_ = f.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return f.conn.WriteMessage(websocket.BinaryMessage, msg)
+}
+
+func (f *Forwarder) get(id uint32) *stream {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.conns[id]
+}
+
+// remove - close and forget stream, returns false if stream was already removed
+func (f *Forwarder) remove(id uint32, s *stream) bool {
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.conns[id] != s {
+		return false
+	}
+
+	delete(f.conns, id)
+	s.close()
+
+	return true
+}
+
+// close - stop stream writer and close connection, should be called under forwarder lock
+func (s *stream) close() {
+	close(s.done)
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+}
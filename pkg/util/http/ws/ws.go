@@ -0,0 +1,91 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package ws
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const writeWait = 10 * time.Second
+
+// Upgrader - upgrades http connections to websocket
+var Upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     CheckOrigin,
+}
+
+// CheckOrigin - allow requests without origin (cli and api to node connections)
+// and browser requests only from the same host to prevent cross-site websocket hijacking
+func CheckOrigin(r *http.Request) bool {
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// Proxy - pipe messages between two websocket connections until one of them is closed
+func Proxy(client, backend *websocket.Conn) error {
+
+	errc := make(chan error, 2)
+
+	pipe := func(dst, src *websocket.Conn) {
+		for {
+			t, msg, err := src.ReadMessage()
+			if err != nil {
+				errc <- err
+				return
+			}
+			if err := dst.WriteMessage(t, msg); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}
+
+	go pipe(client, backend)
+	go pipe(backend, client)
+
+	err := <-errc
+
+	for _, c := range []*websocket.Conn{client, backend} {
+		_ = c.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+		_ = c.Close()
+	}
+
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,395 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package ws_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/lastbackend/lastbackend/pkg/util/http/ws"
+	"github.com/stretchr/testify/assert"
+)
+
+// execServer - runs fake command, which copies stdin to stdout and reports terminal size to stderr
+func execServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		conn, err := ws.Upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		stream := ws.NewExecStream(conn, true)
+		s := stream.Stream()
+
+		size := <-s.Resize
+		fmt.Fprintf(s.Stderr, "%dx%d", size.Width, size.Height)
+
+		_, err = io.Copy(s.Stdout, s.Stdin)
+		assert.NoError(t, stream.Close(3, err))
+	}))
+}
+
+func wsURL(s *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func TestExecStream(t *testing.T) {
+
+	backend := execServer(t)
+	defer backend.Close()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		b, _, err := websocket.DefaultDialer.Dial(wsURL(backend), nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		c, err := ws.Upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, ws.Proxy(c, b))
+	}))
+	defer proxy.Close()
+
+	tests := []struct {
+		name       string
+		endpoint   string
+		stdinFirst bool
+	}{
+		{name: "exec stream", endpoint: wsURL(backend)},
+		{name: "exec stream through proxy", endpoint: wsURL(proxy)},
+		{name: "exec stream resize after unread stdin", endpoint: wsURL(backend), stdinFirst: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			conn, _, err := websocket.DefaultDialer.Dial(tc.endpoint, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer conn.Close()
+
+			send := func(channel byte, data string) {
+				err := conn.WriteMessage(websocket.BinaryMessage, append([]byte{channel}, data...))
+				assert.NoError(t, err)
+			}
+
+			if !tc.stdinFirst {
+				send(ws.ChannelResize, `{"width":80,"height":24}`)
+			}
+			send(ws.ChannelStdin, "hello ")
+			send(ws.ChannelStdin, "world")
+			send(ws.ChannelStdin, "")
+			if tc.stdinFirst {
+				send(ws.ChannelResize, `{"width":80,"height":24}`)
+			}
+
+			var (
+				stdout string
+				stderr string
+				status *ws.ExecStatus
+			)
+
+			for status == nil {
+				_, msg, err := conn.ReadMessage()
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				switch msg[0] {
+				case ws.ChannelStdout:
+					stdout += string(msg[1:])
+				case ws.ChannelStderr:
+					stderr += string(msg[1:])
+				case ws.ChannelStatus:
+					status = new(ws.ExecStatus)
+					assert.NoError(t, json.Unmarshal(msg[1:], status))
+				}
+			}
+
+			assert.Equal(t, "hello world", stdout)
+			assert.Equal(t, "80x24", stderr)
+			assert.Equal(t, 3, status.ExitCode)
+			assert.Empty(t, status.Error)
+
+			_, _, err = conn.ReadMessage()
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "connection should be closed")
+		})
+	}
+}
+
+// echoServer - runs tcp server, which writes back received data
+func echoServer(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				io.Copy(c, c)
+			}()
+		}
+	}()
+
+	return l
+}
+
+func TestForward(t *testing.T) {
+
+	echo := echoServer(t)
+	defer echo.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := ws.Upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		ws.ServeForward(conn, func() (net.Conn, error) {
+			return net.Dial("tcp", echo.Addr().String())
+		})
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		conns    int
+		dialFail bool
+	}{
+		{"forward single connection", 1, false},
+		{"forward multiple connections", 5, false},
+		{"close connection when target is not available", 1, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			target := srv
+			if tc.dialFail {
+				target = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					conn, err := ws.Upgrader.Upgrade(w, r, nil)
+					if !assert.NoError(t, err) {
+						return
+					}
+					ws.ServeForward(conn, func() (net.Conn, error) {
+						return nil, fmt.Errorf("connection refused")
+					})
+				}))
+				defer target.Close()
+			}
+
+			conn, _, err := websocket.DefaultDialer.Dial(wsURL(target), nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			f := ws.NewForwardClient(conn)
+			defer f.Close()
+			go f.Listen(l)
+
+			var wg sync.WaitGroup
+			for i := 0; i < tc.conns; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					c, err := net.Dial("tcp", l.Addr().String())
+					if !assert.NoError(t, err) {
+						return
+					}
+					defer c.Close()
+
+					msg := fmt.Sprintf("message from connection %d", i)
+					_, err = c.Write([]byte(msg))
+					assert.NoError(t, err)
+
+					buf := make([]byte, len(msg))
+					_, err = io.ReadFull(c, buf)
+					if tc.dialFail {
+						assert.Error(t, err, "connection should be closed")
+						return
+					}
+
+					assert.NoError(t, err)
+					assert.Equal(t, msg, string(buf), "echo data mismatch")
+				}(i)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+// dialer - dials in-memory echo connections, first dial waits until released by test case
+type dialer struct {
+	dials   int32
+	started chan struct{}
+	release chan struct{}
+	dialed  chan net.Conn
+}
+
+func (d *dialer) dial() (net.Conn, error) {
+
+	if atomic.AddInt32(&d.dials, 1) == 1 {
+		close(d.started)
+		<-d.release
+	}
+
+	local, remote := net.Pipe()
+	d.dialed <- remote
+	go func() {
+		defer remote.Close()
+		io.Copy(remote, remote)
+	}()
+
+	return local, nil
+}
+
+func TestForwardFrames(t *testing.T) {
+
+	frame := func(kind byte, id uint32, payload string) []byte {
+		msg := make([]byte, 5, 5+len(payload))
+		msg[0] = kind
+		binary.BigEndian.PutUint32(msg[1:5], id)
+		return append(msg, payload...)
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, conn *websocket.Conn, d *dialer)
+	}{
+		{
+			name: "slow dial does not block other connections",
+			run: func(t *testing.T, conn *websocket.Conn, d *dialer) {
+				defer close(d.release)
+
+				assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, frame(ws.FrameOpen, 1, "")))
+				<-d.started
+				assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, frame(ws.FrameOpen, 2, "")))
+				assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, frame(ws.FrameData, 2, "ping")))
+
+				_, msg, err := conn.ReadMessage()
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, frame(ws.FrameData, 2, "ping"), msg)
+			},
+		},
+		{
+			name: "duplicate connection id closes connection",
+			run: func(t *testing.T, conn *websocket.Conn, d *dialer) {
+				close(d.release)
+
+				assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, frame(ws.FrameOpen, 1, "")))
+				assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, frame(ws.FrameOpen, 1, "")))
+
+				_, msg, err := conn.ReadMessage()
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, frame(ws.FrameError, 1, "connection id is already in use"), msg)
+
+				// connection opened by first frame should be closed
+				c := <-d.dialed
+				_, err = c.Read(make([]byte, 1))
+				assert.Error(t, err, "connection should be closed")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			d := &dialer{
+				started: make(chan struct{}),
+				release: make(chan struct{}),
+				dialed:  make(chan net.Conn, 2),
+			}
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := ws.Upgrader.Upgrade(w, r, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+				ws.ServeForward(conn, d.dial)
+			}))
+			defer srv.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial(wsURL(srv), nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer conn.Close()
+
+			tc.run(t, conn, d)
+		})
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		host   string
+		origin string
+		want   bool
+	}{
+		{name: "request without origin", host: "api.lstbknd.net", want: true},
+		{name: "same origin", host: "api.lstbknd.net", origin: "https://api.lstbknd.net", want: true},
+		{name: "same origin with port", host: "api.lstbknd.net:2967", origin: "https://API.lstbknd.net:2967", want: true},
+		{name: "cross origin", host: "api.lstbknd.net", origin: "https://evil.example.com", want: false},
+		{name: "malformed origin", host: "api.lstbknd.net", origin: "://api.lstbknd.net", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Host = tc.host
+			if tc.origin != "" {
+				r.Header.Set("Origin", tc.origin)
+			}
+			assert.Equal(t, tc.want, ws.CheckOrigin(r))
+		})
+	}
+}
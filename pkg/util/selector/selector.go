@@ -0,0 +1,328 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	OperatorEquals    = "="
+	OperatorNotEquals = "!="
+	OperatorIn        = "in"
+	OperatorNotIn     = "notin"
+	OperatorExists    = "exists"
+	OperatorNotExists = "!"
+)
+
+// labelsPath - path of labels map in object
+var labelsPath = []string{"meta", "labels"}
+
+var (
+	keyRegexp   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	fieldRegexp = regexp.MustCompile(`^[a-z_]+(\.[a-z_]+)*$`)
+	setRegexp   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s+\((.*)\)$`)
+)
+
+// Requirement - single condition of selector
+type Requirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// Selector - selects objects by labels and fields
+type Selector struct {
+	Labels []Requirement
+	Fields []Requirement
+}
+
+// New - parse label and field selectors
+func New(labels, fields string) (*Selector, error) {
+
+	var (
+		s   = new(Selector)
+		err error
+	)
+
+	if s.Labels, err = ParseLabels(labels); err != nil {
+		return nil, err
+	}
+
+	if s.Fields, err = ParseFields(fields); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ParseLabels - parse label selector, supported requirements are:
+// `key=value`, `key==value`, `key!=value`, `key in (a,b)`, `key notin (a,b)`, `key` and `!key`
+func ParseLabels(s string) ([]Requirement, error) {
+
+	reqs := make([]Requirement, 0)
+
+	for _, part := range split(s) {
+
+		var req Requirement
+
+		switch true {
+		case setRegexp.MatchString(part):
+			m := setRegexp.FindStringSubmatch(part)
+			req = Requirement{Key: m[1], Operator: m[2], Values: make([]string, 0)}
+			for _, v := range strings.Split(m[3], ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					req.Values = append(req.Values, v)
+				}
+			}
+			if len(req.Values) == 0 {
+				return nil, fmt.Errorf("empty values set in requirement: %s", part)
+			}
+		case strings.HasPrefix(part, "!") && !strings.Contains(part, "="):
+			req = Requirement{Key: strings.TrimSpace(part[1:]), Operator: OperatorNotExists}
+		case !strings.Contains(part, "="):
+			req = Requirement{Key: part, Operator: OperatorExists}
+		default:
+			r, err := parseEquality(part)
+			if err != nil {
+				return nil, err
+			}
+			req = *r
+		}
+
+		if !keyRegexp.MatchString(req.Key) {
+			return nil, fmt.Errorf("invalid label key: %s", req.Key)
+		}
+
+		reqs = append(reqs, req)
+	}
+
+	return reqs, nil
+}
+
+// ParseFields - parse field selector, supported requirements are:
+// `path=value`, `path==value` and `path!=value`, where path is dot separated, like `status.state`
+func ParseFields(s string) ([]Requirement, error) {
+
+	reqs := make([]Requirement, 0)
+
+	for _, part := range split(s) {
+
+		req, err := parseEquality(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if !fieldRegexp.MatchString(req.Key) {
+			return nil, fmt.Errorf("invalid field path: %s", req.Key)
+		}
+
+		reqs = append(reqs, *req)
+	}
+
+	return reqs, nil
+}
+
+// Empty - selector without requirements matches everything
+func (s *Selector) Empty() bool {
+	return s == nil || (len(s.Labels) == 0 && len(s.Fields) == 0)
+}
+
+// Field - copy of selector narrowed by field equality requirement
+func (s *Selector) Field(key, value string) *Selector {
+
+	c := new(Selector)
+	if s != nil {
+		c.Labels = append(c.Labels, s.Labels...)
+		c.Fields = append(c.Fields, s.Fields...)
+	}
+
+	c.Fields = append(c.Fields, Requirement{Key: key, Operator: OperatorEquals, Values: []string{value}})
+	return c
+}
+
+// Match - check if json encoded object matches selector
+func (s *Selector) Match(data []byte) bool {
+
+	if s.Empty() {
+		return true
+	}
+
+	obj := make(map[string]interface{})
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return false
+	}
+
+	return s.MatchObject(obj)
+}
+
+// MatchObject - check if decoded object matches selector
+func (s *Selector) MatchObject(obj map[string]interface{}) bool {
+
+	if s.Empty() {
+		return true
+	}
+
+	labels := make(map[string]string)
+	if l, ok := lookup(obj, labelsPath).(map[string]interface{}); ok {
+		for k, v := range l {
+			labels[k] = toString(v)
+		}
+	}
+
+	for _, req := range s.Labels {
+		value, exists := labels[req.Key]
+		if !req.match(value, exists) {
+			return false
+		}
+	}
+
+	for _, req := range s.Fields {
+		v := lookup(obj, strings.Split(req.Key, "."))
+		if !req.match(toString(v), v != nil) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r Requirement) match(value string, exists bool) bool {
+	switch r.Operator {
+	case OperatorExists:
+		return exists
+	case OperatorNotExists:
+		return !exists
+	case OperatorEquals:
+		return value == r.Values[0]
+	case OperatorNotEquals:
+		return value != r.Values[0]
+	case OperatorIn:
+		return exists && contains(r.Values, value)
+	case OperatorNotIn:
+		return !exists || !contains(r.Values, value)
+	}
+	return false
+}
+
+func parseEquality(part string) (*Requirement, error) {
+
+	var (
+		op  = OperatorEquals
+		sep = "="
+	)
+
+	switch true {
+	case strings.Contains(part, "!="):
+		op, sep = OperatorNotEquals, "!="
+	case strings.Contains(part, "=="):
+		sep = "=="
+	}
+
+	kv := strings.SplitN(part, sep, 2)
+	if len(kv) != 2 {
+		return nil, fmt.Errorf("invalid requirement: %s", part)
+	}
+
+	key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+	if key == "" || strings.ContainsAny(value, "=!") {
+		return nil, fmt.Errorf("invalid requirement: %s", part)
+	}
+
+	return &Requirement{Key: key, Operator: op, Values: []string{value}}, nil
+}
+
+// split - split selector by commas which are not in values set
+func split(s string) []string {
+
+	var (
+		parts = make([]string, 0)
+		depth = 0
+		start = 0
+	)
+
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+
+	result := make([]string, 0)
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+func lookup(obj map[string]interface{}, path []string) interface{} {
+
+	var current interface{} = obj
+
+	for _, p := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if current, ok = m[p]; !ok {
+			return nil
+		}
+	}
+
+	return current
+}
+
+func toString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case bool:
+		return strconv.FormatBool(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(t)
+		return string(b)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
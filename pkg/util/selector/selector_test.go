@@ -0,0 +1,134 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  string
+		fields  string
+		want    *Selector
+		wantErr bool
+	}{
+		{
+			name: "empty selector",
+			want: &Selector{Labels: []Requirement{}, Fields: []Requirement{}},
+		},
+		{
+			name:   "equality and set based labels",
+			labels: "app=web, tier in (front,back), !debug",
+			fields: "status.state==ready",
+			want: &Selector{
+				Labels: []Requirement{
+					{Key: "app", Operator: OperatorEquals, Values: []string{"web"}},
+					{Key: "tier", Operator: OperatorIn, Values: []string{"front", "back"}},
+					{Key: "debug", Operator: OperatorNotExists},
+				},
+				Fields: []Requirement{
+					{Key: "status.state", Operator: OperatorEquals, Values: []string{"ready"}},
+				},
+			},
+		},
+		{
+			name:    "invalid label key",
+			labels:  "-app=web",
+			wantErr: true,
+		},
+		{
+			name:    "invalid field path",
+			fields:  "Status.State=ready",
+			wantErr: true,
+		},
+		{
+			name:    "set operator in field selector",
+			fields:  "status.state in (ready)",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			got, err := New(tc.labels, tc.fields)
+
+			if tc.wantErr {
+				assert.Error(t, err, "error should be not nil")
+				return
+			}
+
+			if !assert.NoError(t, err, "error should be nil") {
+				return
+			}
+
+			assert.ElementsMatch(t, tc.want.Labels, got.Labels, "labels mismatch")
+			assert.ElementsMatch(t, tc.want.Fields, got.Fields, "fields mismatch")
+		})
+	}
+}
+
+func TestSelector_Match(t *testing.T) {
+
+	data := []byte(`{"meta":{"name":"web","labels":{"app":"web","tier":"front"}},"spec":{"replicas":2},"status":{"state":"ready"}}`)
+
+	tests := []struct {
+		name   string
+		labels string
+		fields string
+		want   bool
+	}{
+		{name: "empty selector", want: true},
+		{name: "label equals", labels: "app=web", want: true},
+		{name: "label equals mismatch", labels: "app=db", want: false},
+		{name: "label not equals", labels: "app!=db", want: true},
+		{name: "label in", labels: "tier in (front,back)", want: true},
+		{name: "label notin", labels: "tier notin (front)", want: false},
+		{name: "label exists", labels: "tier", want: true},
+		{name: "label not exists", labels: "!tier", want: false},
+		{name: "field string", fields: "status.state=ready", want: true},
+		{name: "field number", fields: "spec.replicas=2", want: true},
+		{name: "field missing", fields: "status.message=error", want: false},
+		{name: "labels and fields", labels: "app=web", fields: "meta.name!=web", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			s, err := New(tc.labels, tc.fields)
+			if !assert.NoError(t, err, "error should be nil") {
+				return
+			}
+
+			assert.Equal(t, tc.want, s.Match(data), "match result mismatch")
+		})
+	}
+
+	var s *Selector
+	assert.True(t, s.Empty(), "nil selector should be empty")
+	assert.True(t, s.Match(data), "nil selector should match everything")
+
+	assert.True(t, s.Field("meta.name", "web").Match(data), "field narrowed selector should match")
+	assert.False(t, s.Field("meta.name", "db").Match(data), "field narrowed selector should not match")
+	assert.True(t, s.Empty(), "field should not change origin selector")
+}